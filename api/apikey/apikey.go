@@ -0,0 +1,412 @@
+package apikey
+
+import (
+	"BackendAPI/api/seller"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// Scopes that API keys can be given
+const (
+	ProductsWriteScope = "products:write"
+	OrdersReadScope    = "orders:read"
+)
+
+const (
+	keyPrefix         = "aucto"
+	defaultRateLimit  = 60
+	maxRateLimit      = 600
+	maxSellerApiKeys  = 20
+	selectApiKeyQuery = `SELECT key_id, seller_id, name, key_prefix, scopes, rate_limit,
+			CASE WHEN revoked_date IS NULL THEN 'active' ELSE 'revoked' END, COALESCE(last_used_date::TEXT, ''),
+			COALESCE(rotated_date::TEXT, ''), COALESCE(revoked_date::TEXT, ''), created_date::TEXT
+		FROM seller_api_keys`
+)
+
+var scopes = []string{ProductsWriteScope, OrdersReadScope}
+
+/*
+Creates an API key for a seller with some scopes and a limit of requests per minute, 60 if none is given. The key is
+only returned here, only a hash of its secret is kept.
+*/
+func CreateApiKey(db *sql.DB, sellerId string, request data.CreateApiKeyRequestData) (data.ApiKeyData, *utils.ErrorHandler) {
+	var apiKey data.ApiKeyData
+
+	if !seller.DoesSellerExist(db, sellerId) {
+		return apiKey, utils.NotFoundError("Seller with given id does not exist")
+	}
+
+	keyScopes, validateErr := validateCreateApiKey(db, sellerId, &request)
+
+	if validateErr != nil {
+		return apiKey, validateErr
+	}
+
+	prefix, secret, err := generateKey()
+
+	if err != nil {
+		return apiKey, err
+	}
+
+	var keyId string
+	query := `INSERT INTO seller_api_keys(seller_id, name, key_prefix, secret_hash, scopes, rate_limit)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING key_id;`
+	scanErr := db.QueryRowContext(context.Background(), query, sellerId, request.Name, prefix, hashSecret(secret),
+		pq.Array(keyScopes), request.RateLimit).Scan(&keyId)
+
+	if scanErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(scanErr, "Error in inserting Seller Api Key rows")
+		return apiKey, errResp
+	}
+
+	apiKey, err = getApiKey(db, sellerId, keyId)
+
+	if err != nil {
+		return apiKey, err
+	}
+
+	apiKey.Key = formatKey(prefix, secret)
+	return apiKey, nil
+}
+
+/*
+Gets the API keys of a seller from the newest key, with when they were last used. The keys themselves are not
+returned.
+*/
+func GetApiKeys(db *sql.DB, sellerId string) (data.GetApiKeysResponseData, *utils.ErrorHandler) {
+	response := data.GetApiKeysResponseData{ApiKeys: []data.ApiKeyData{}}
+
+	if !seller.DoesSellerExist(db, sellerId) {
+		return response, utils.NotFoundError("Seller with given id does not exist")
+	}
+
+	query := selectApiKeyQuery + ` WHERE seller_id = $1 ORDER BY created_date DESC;`
+	rows, err := db.QueryContext(context.Background(), query, sellerId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Seller Api Key rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var apiKey data.ApiKeyData
+		err = scanApiKey(rows, &apiKey)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Seller Api Key rows")
+			return response, errResp
+		}
+
+		response.ApiKeys = append(response.ApiKeys, apiKey)
+	}
+
+	return response, nil
+}
+
+/*
+Rotates the secret of an API key of a seller. The key keeps its prefix, scopes and rate limit, the previous key
+stops working right away and the new key is only returned here.
+*/
+func RotateApiKey(db *sql.DB, sellerId string, keyId string) (data.ApiKeyData, *utils.ErrorHandler) {
+	apiKey, err := getApiKey(db, sellerId, keyId)
+
+	if err != nil {
+		return apiKey, err
+	}
+
+	if apiKey.Status == "revoked" {
+		return apiKey, utils.BadRequestError("API key has been revoked")
+	}
+
+	secret, err := generateSecret()
+
+	if err != nil {
+		return apiKey, err
+	}
+
+	query := `UPDATE seller_api_keys SET secret_hash = $2, rotated_date = NOW() WHERE key_id = $1 AND revoked_date IS NULL;`
+	result, execErr := db.ExecContext(context.Background(), query, keyId, hashSecret(secret))
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in updating Seller Api Key rows")
+		return apiKey, errResp
+	}
+
+	if updated, _ := result.RowsAffected(); updated == 0 {
+		return apiKey, utils.BadRequestError("API key has been revoked")
+	}
+
+	apiKey, err = getApiKey(db, sellerId, keyId)
+
+	if err != nil {
+		return apiKey, err
+	}
+
+	apiKey.Key = formatKey(apiKey.Prefix, secret)
+	return apiKey, nil
+}
+
+/*
+Revokes an API key of a seller, requests with the key are no longer accepted. Revoking a revoked key does nothing.
+*/
+func RevokeApiKey(db *sql.DB, sellerId string, keyId string) (data.ApiKeyData, *utils.ErrorHandler) {
+	apiKey, err := getApiKey(db, sellerId, keyId)
+
+	if err != nil {
+		return apiKey, err
+	}
+
+	query := `UPDATE seller_api_keys SET revoked_date = NOW() WHERE key_id = $1 AND revoked_date IS NULL;`
+	_, execErr := db.ExecContext(context.Background(), query, keyId)
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in updating Seller Api Key rows")
+		return apiKey, errResp
+	}
+
+	return getApiKey(db, sellerId, keyId)
+}
+
+/*
+Authenticates a request made with an API key that needs a scope and counts it towards the rate limit of the key. An
+empty scope accepts a key with any scope. Returns a 401 Unauthorized error if the key is not valid, a 429 Too many
+requests error if the key has used up its requests for the minute and a 403 Forbidden error if the key does not have
+the scope.
+*/
+func Authenticate(db *sql.DB, key string, scope string) (data.ApiKeyData, *utils.ErrorHandler) {
+	var apiKey data.ApiKeyData
+
+	prefix, secret, isValid := parseKey(key)
+
+	if !isValid {
+		return apiKey, utils.UnauthorizedError("Invalid API key")
+	}
+
+	var keyId, secretHash string
+	query := `SELECT key_id, secret_hash FROM seller_api_keys WHERE key_prefix = $1 AND revoked_date IS NULL;`
+	err := db.QueryRowContext(context.Background(), query, prefix).Scan(&keyId, &secretHash)
+
+	if err == sql.ErrNoRows {
+		return apiKey, utils.UnauthorizedError("Invalid API key")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Seller Api Key rows")
+		return apiKey, errResp
+	}
+
+	if subtle.ConstantTimeCompare([]byte(secretHash), []byte(hashSecret(secret))) != 1 {
+		return apiKey, utils.UnauthorizedError("Invalid API key")
+	}
+
+	// Requests are counted in a fixed window of a minute that starts with the first request after the previous window
+	var windowCount int
+	query = `UPDATE seller_api_keys SET last_used_date = NOW(),
+			window_count = CASE WHEN window_start > NOW() - INTERVAL '1 minute' THEN window_count + 1 ELSE 1 END,
+			window_start = CASE WHEN window_start > NOW() - INTERVAL '1 minute' THEN window_start ELSE NOW() END
+		WHERE key_id = $1 RETURNING window_count;`
+	err = db.QueryRowContext(context.Background(), query, keyId).Scan(&windowCount)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Seller Api Key rows")
+		return apiKey, errResp
+	}
+
+	query = selectApiKeyQuery + ` WHERE key_id = $1;`
+	err = scanApiKey(db.QueryRowContext(context.Background(), query, keyId), &apiKey)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Seller Api Key rows")
+		return apiKey, errResp
+	}
+
+	if windowCount > apiKey.RateLimit {
+		return apiKey, utils.TooManyRequestsError("API key rate limit exceeded")
+	}
+
+	if scope != "" && !hasScope(apiKey.Scopes, scope) {
+		return apiKey, utils.ForbiddenError("API key does not have the " + scope + " scope")
+	}
+
+	return apiKey, nil
+}
+
+/*
+Gets an API key of a seller, returns a 404 Not found error if the seller has no key with the given id
+*/
+func getApiKey(db *sql.DB, sellerId string, keyId string) (data.ApiKeyData, *utils.ErrorHandler) {
+	var apiKey data.ApiKeyData
+
+	query := selectApiKeyQuery + ` WHERE key_id::TEXT = $1 AND seller_id::TEXT = $2;`
+	err := scanApiKey(db.QueryRowContext(context.Background(), query, keyId, sellerId), &apiKey)
+
+	if err == sql.ErrNoRows {
+		utils.LogMessage("API key with given id does not exist")
+		return apiKey, utils.NotFoundError("API key with given id does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Seller Api Key rows")
+		return apiKey, errResp
+	}
+
+	return apiKey, nil
+}
+
+/*
+Scans a row selected with the API key select query
+*/
+func scanApiKey(row interface{ Scan(...interface{}) error }, apiKey *data.ApiKeyData) error {
+	return row.Scan(&apiKey.KeyId, &apiKey.SellerId, &apiKey.Name, &apiKey.Prefix, pq.Array(&apiKey.Scopes),
+		&apiKey.RateLimit, &apiKey.Status, &apiKey.LastUsedDate, &apiKey.RotatedDate, &apiKey.RevokedDate, &apiKey.CreatedDate)
+}
+
+/*
+Validates an API key and returns its scopes without duplicates. The key needs a name and at least one scope, the
+rate limit defaults to 60 requests a minute and can be at most 600, and a seller can only have a few active keys.
+*/
+func validateCreateApiKey(db *sql.DB, sellerId string, request *data.CreateApiKeyRequestData) ([]string, *utils.ErrorHandler) {
+	if strings.TrimSpace(request.Name) == "" {
+		return nil, utils.BadRequestError("Bad name data")
+	}
+
+	var keyScopes []string
+
+	for i := 0; i < len(request.Scopes); i++ {
+		if !hasScope(scopes, request.Scopes[i]) {
+			utils.LogMessage("API key scope " + request.Scopes[i] + " does not exist")
+			return nil, utils.BadRequestError("Bad scopes data")
+		}
+
+		if !hasScope(keyScopes, request.Scopes[i]) {
+			keyScopes = append(keyScopes, request.Scopes[i])
+		}
+	}
+
+	if len(keyScopes) == 0 {
+		return nil, utils.BadRequestError("Bad scopes data")
+	}
+
+	if request.RateLimit == 0 {
+		request.RateLimit = defaultRateLimit
+	}
+
+	if request.RateLimit < 0 || request.RateLimit > maxRateLimit {
+		return nil, utils.BadRequestError("Bad rate_limit data")
+	}
+
+	var count int
+	query := `SELECT COUNT(*) FROM seller_api_keys WHERE seller_id = $1 AND revoked_date IS NULL;`
+	err := db.QueryRowContext(context.Background(), query, sellerId).Scan(&count)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Seller Api Key rows")
+		return nil, errResp
+	}
+
+	if count >= maxSellerApiKeys {
+		return nil, utils.BadRequestError("Seller already has the most API keys allowed")
+	}
+
+	return keyScopes, nil
+}
+
+/*
+Generates the prefix and secret of a new API key
+*/
+func generateKey() (string, string, *utils.ErrorHandler) {
+	prefixBytes := make([]byte, 6)
+	_, err := rand.Read(prefixBytes)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in generating Api Key")
+		return "", "", errResp
+	}
+
+	secret, secretErr := generateSecret()
+
+	if secretErr != nil {
+		return "", "", secretErr
+	}
+
+	return hex.EncodeToString(prefixBytes), secret, nil
+}
+
+/*
+Generates the secret of an API key, used for new keys and when a key is rotated
+*/
+func generateSecret() (string, *utils.ErrorHandler) {
+	secretBytes := make([]byte, 32)
+	_, err := rand.Read(secretBytes)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in generating Api Key secret")
+		return "", errResp
+	}
+
+	return hex.EncodeToString(secretBytes), nil
+}
+
+/*
+Formats the key that sellers send from its prefix and secret, such as aucto_1a2b3c4d5e6f_<secret>
+*/
+func formatKey(prefix string, secret string) string {
+	return keyPrefix + "_" + prefix + "_" + secret
+}
+
+/*
+Parses a key into its prefix and secret, returns false if it is not a key
+*/
+func parseKey(key string) (string, string, bool) {
+	parts := strings.Split(key, "_")
+
+	if len(parts) != 3 || parts[0] != keyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", "", false
+	}
+
+	return parts[1], parts[2], true
+}
+
+/*
+Hashes the secret of a key. Secrets are long and random so a single SHA-256 is enough, unlike passwords.
+*/
+func hashSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+/*
+Checks wether a list of scopes has a scope
+*/
+func hasScope(keyScopes []string, scope string) bool {
+	for i := 0; i < len(keyScopes); i++ {
+		if keyScopes[i] == scope {
+			return true
+		}
+	}
+
+	return false
+}
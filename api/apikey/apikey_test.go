@@ -0,0 +1,248 @@
+package apikey
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateApiKey(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerId := createDummySeller(t, db)
+
+	//Test 1: API key is created with its key, the default rate limit and without duplicate scopes
+	request := data.CreateApiKeyRequestData{Name: "Inventory sync",
+		Scopes: []string{ProductsWriteScope, OrdersReadScope, ProductsWriteScope}}
+	res, resErr := CreateApiKey(db, sellerId, request)
+	assert.Empty(t, resErr)
+	assert.Equal(t, sellerId, res.SellerId)
+	assert.Equal(t, []string{ProductsWriteScope, OrdersReadScope}, res.Scopes)
+	assert.Equal(t, defaultRateLimit, res.RateLimit)
+	assert.Equal(t, "active", res.Status)
+	assert.True(t, strings.HasPrefix(res.Key, "aucto_"+res.Prefix+"_"))
+
+	//Test 2: Key is not returned when getting the API keys
+	apiKeys, resErr := GetApiKeys(db, sellerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(apiKeys.ApiKeys))
+	assert.Equal(t, res.KeyId, apiKeys.ApiKeys[0].KeyId)
+	assert.Empty(t, apiKeys.ApiKeys[0].Key)
+
+	//Test 3: Scope does not exist
+	request = data.CreateApiKeyRequestData{Name: "Inventory sync", Scopes: []string{"products:read"}}
+	_, resErr = CreateApiKey(db, sellerId, request)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad scopes data", resErr.Error())
+
+	//Test 4: Rate limit is too high
+	request = data.CreateApiKeyRequestData{Name: "Inventory sync", Scopes: []string{OrdersReadScope},
+		RateLimit: maxRateLimit + 1}
+	_, resErr = CreateApiKey(db, sellerId, request)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad rate_limit data", resErr.Error())
+
+	//Test 5: Name is blank
+	request = data.CreateApiKeyRequestData{Name: " ", Scopes: []string{OrdersReadScope}}
+	_, resErr = CreateApiKey(db, sellerId, request)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad name data", resErr.Error())
+
+	//Test 6: Seller can only have a few active API keys
+	request = data.CreateApiKeyRequestData{Name: "Inventory sync", Scopes: []string{OrdersReadScope}}
+	for i := 1; i < maxSellerApiKeys; i++ {
+		_, resErr = CreateApiKey(db, sellerId, request)
+		assert.Empty(t, resErr)
+	}
+	_, resErr = CreateApiKey(db, sellerId, request)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Seller already has the most API keys allowed", resErr.Error())
+
+	//Test 7: Seller does not exist
+	_, resErr = CreateApiKey(db, "wrong id", request)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Seller with given id does not exist", resErr.Error())
+
+	store.CloseDB(db)
+}
+
+func TestRotateApiKey(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerId := createDummySeller(t, db)
+	otherSellerId := createOtherSeller(t, db)
+	apiKey := createTestApiKey(t, db, sellerId, 10, OrdersReadScope)
+
+	//Test 1: API key of another seller cannot be rotated
+	_, resErr := RotateApiKey(db, otherSellerId, apiKey.KeyId)
+	assert.Error(t, resErr)
+	assert.Equal(t, "API key with given id does not exist", resErr.Error())
+
+	//Test 2: Rotated key keeps its prefix and the old key stops working
+	res, resErr := RotateApiKey(db, sellerId, apiKey.KeyId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, apiKey.Prefix, res.Prefix)
+	assert.NotEqual(t, apiKey.Key, res.Key)
+	assert.NotEmpty(t, res.RotatedDate)
+
+	_, resErr = Authenticate(db, apiKey.Key, OrdersReadScope)
+	assert.Error(t, resErr)
+	assert.Equal(t, 401, resErr.ErrorCode())
+
+	_, resErr = Authenticate(db, res.Key, OrdersReadScope)
+	assert.Empty(t, resErr)
+
+	//Test 3: Key is authenticated without a scope, as on the key management routes
+	_, resErr = Authenticate(db, res.Key, "")
+	assert.Empty(t, resErr)
+
+	//Test 4: Revoked key cannot be rotated
+	_, resErr = RevokeApiKey(db, sellerId, apiKey.KeyId)
+	assert.Empty(t, resErr)
+	_, resErr = RotateApiKey(db, sellerId, apiKey.KeyId)
+	assert.Error(t, resErr)
+	assert.Equal(t, "API key has been revoked", resErr.Error())
+
+	store.CloseDB(db)
+}
+
+func TestRevokeApiKey(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerId := createDummySeller(t, db)
+	otherSellerId := createOtherSeller(t, db)
+	apiKey := createTestApiKey(t, db, sellerId, 10, ProductsWriteScope)
+
+	//Test 1: API key of another seller cannot be revoked
+	_, resErr := RevokeApiKey(db, otherSellerId, apiKey.KeyId)
+	assert.Error(t, resErr)
+	assert.Equal(t, "API key with given id does not exist", resErr.Error())
+
+	//Test 2: Revoked key is kept in the list and stops working
+	res, resErr := RevokeApiKey(db, sellerId, apiKey.KeyId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, "revoked", res.Status)
+	assert.NotEmpty(t, res.RevokedDate)
+
+	apiKeys, resErr := GetApiKeys(db, sellerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(apiKeys.ApiKeys))
+	assert.Equal(t, "revoked", apiKeys.ApiKeys[0].Status)
+
+	_, resErr = Authenticate(db, apiKey.Key, ProductsWriteScope)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Invalid API key", resErr.Error())
+
+	store.CloseDB(db)
+}
+
+func TestAuthenticate(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerId := createDummySeller(t, db)
+	apiKey := createTestApiKey(t, db, sellerId, 2, OrdersReadScope)
+
+	//Test 1: Key with the scope is authenticated and its last use is recorded
+	res, resErr := Authenticate(db, apiKey.Key, OrdersReadScope)
+	assert.Empty(t, resErr)
+	assert.Equal(t, sellerId, res.SellerId)
+	assert.NotEmpty(t, res.LastUsedDate)
+
+	//Test 2: Key without the scope is forbidden
+	_, resErr = Authenticate(db, apiKey.Key, ProductsWriteScope)
+	assert.Error(t, resErr)
+	assert.Equal(t, 403, resErr.ErrorCode())
+
+	//Test 3: Key that has used up its requests for the minute is rate limited
+	_, resErr = Authenticate(db, apiKey.Key, OrdersReadScope)
+	assert.Error(t, resErr)
+	assert.Equal(t, 429, resErr.ErrorCode())
+
+	//Test 4: Requests are counted again in a new window
+	_, err = db.ExecContext(context.Background(),
+		`UPDATE seller_api_keys SET window_start = NOW() - INTERVAL '2 minutes' WHERE key_id = $1;`, apiKey.KeyId)
+	assert.NoError(t, err)
+	_, resErr = Authenticate(db, apiKey.Key, OrdersReadScope)
+	assert.Empty(t, resErr)
+
+	//Test 5: Key with a wrong secret is not valid
+	_, resErr = Authenticate(db, formatKey(apiKey.Prefix, strings.Repeat("0", 64)), OrdersReadScope)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Invalid API key", resErr.Error())
+
+	//Test 6: Malformed key is not valid
+	_, resErr = Authenticate(db, "not a key", OrdersReadScope)
+	assert.Error(t, resErr)
+	assert.Equal(t, 401, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func TestParseKey(t *testing.T) {
+	//Test 1: Formatted key is parsed into its prefix and secret
+	prefix, secret, isValid := parseKey(formatKey("1a2b3c4d5e6f", "secret"))
+	assert.True(t, isValid)
+	assert.Equal(t, "1a2b3c4d5e6f", prefix)
+	assert.Equal(t, "secret", secret)
+
+	//Test 2: Keys with another prefix or missing parts are not valid
+	_, _, isValid = parseKey("other_1a2b3c4d5e6f_secret")
+	assert.False(t, isValid)
+	_, _, isValid = parseKey("aucto_1a2b3c4d5e6f")
+	assert.False(t, isValid)
+	_, _, isValid = parseKey("aucto__secret")
+	assert.False(t, isValid)
+}
+
+func TestHashSecret(t *testing.T) {
+	//Test 1: Secret is hashed with SHA-256
+	assert.Equal(t, "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b", hashSecret("secret"))
+
+	//Test 2: Different secrets have different hashes
+	assert.NotEqual(t, hashSecret("secret"), hashSecret("secret2"))
+}
+
+func TestGenerateSecret(t *testing.T) {
+	//Test 1: Secret is 32 random bytes in hex
+	secret, err := generateSecret()
+	assert.Empty(t, err)
+	assert.Equal(t, 64, len(secret))
+
+	//Test 2: Every secret is different
+	otherSecret, err := generateSecret()
+	assert.Empty(t, err)
+	assert.NotEqual(t, secret, otherSecret)
+}
+
+func createDummySeller(t *testing.T, db *sql.DB) string {
+	var sellerId string
+	query := `INSERT INTO sellers(email, seller_name, password) VALUES ('test@aucto.io','test','test') RETURNING seller_id`
+	err := db.QueryRowContext(context.Background(), query).Scan(&sellerId)
+	assert.NoError(t, err)
+	return sellerId
+}
+
+func createOtherSeller(t *testing.T, db *sql.DB) string {
+	var sellerId string
+	query := `INSERT INTO sellers(email, seller_name, password) VALUES ('test2@aucto.io','test2','test') RETURNING seller_id`
+	err := db.QueryRowContext(context.Background(), query).Scan(&sellerId)
+	assert.NoError(t, err)
+	return sellerId
+}
+
+func createTestApiKey(t *testing.T, db *sql.DB, sellerId string, rateLimit int, keyScopes ...string) data.ApiKeyData {
+	request := data.CreateApiKeyRequestData{Name: "Test key", Scopes: keyScopes, RateLimit: rateLimit}
+	apiKey, resErr := CreateApiKey(db, sellerId, request)
+	assert.Empty(t, resErr)
+	return apiKey
+}
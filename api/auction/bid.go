@@ -0,0 +1,259 @@
+package auction
+
+import (
+	"BackendAPI/api/buyer"
+	"BackendAPI/api/order"
+	"BackendAPI/api/outbox"
+	"BackendAPI/api/product"
+	"BackendAPI/api/telegram"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+)
+
+/*
+Places a bid on an auction. The auction row is locked for the whole transaction so that concurrent bids
+are placed one after the other, each bid is checked against the price left by the previous bid. Bids
+placed close to the end of the auction extend the auction so that other bidders can respond.
+*/
+func PlaceBid(db *sql.DB, productId string, request data.PlaceBidRequestData) (data.PlaceBidResponseData, *utils.ErrorHandler) {
+	var response data.PlaceBidResponseData
+
+	validateErr := validatePlaceBid(db, productId, request)
+
+	if validateErr != nil {
+		return response, validateErr
+	}
+
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Bid transaction")
+		return response, errResp
+	}
+
+	defer tx.Rollback()
+
+	var startPrice, currentPrice, bidCount, extensionSeconds int
+	var startsAt, endsAt, now time.Time
+	var status, highBidderId string
+
+	query := `SELECT start_price, products.price, bid_count, starts_at, ends_at, extension_seconds, status,
+			COALESCE(high_bidder_id::TEXT, ''), clock_timestamp()
+		FROM auction_information INNER JOIN products ON products.product_id = auction_information.product_id
+		WHERE auction_information.product_id = $1
+		FOR UPDATE OF auction_information;`
+
+	err = tx.QueryRowContext(context.Background(), query, productId).Scan(&startPrice, &currentPrice, &bidCount,
+		&startsAt, &endsAt, &extensionSeconds, &status, &highBidderId, &now)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Auction Information rows")
+		return response, errResp
+	}
+
+	if status != "open" || !now.Before(endsAt) {
+		return response, utils.BadRequestError("Auction has ended")
+	}
+
+	if now.Before(startsAt) {
+		return response, utils.BadRequestError("Auction has not started")
+	}
+
+	if highBidderId == request.BuyerId {
+		return response, utils.BadRequestError("Buyer is already the highest bidder")
+	}
+
+	minimumBid := product.GetMinimumBid(startPrice, currentPrice, bidCount)
+	if request.Amount < minimumBid {
+		return response, utils.BadRequestError("Bid is below the minimum bid of " + strconv.Itoa(minimumBid))
+	}
+
+	if request.Checkout != nil {
+		registerErr := registerBidder(tx, productId, request.BuyerId, *request.Checkout)
+
+		if registerErr != nil {
+			return response, registerErr
+		}
+	}
+
+	// Anti-sniping, a bid in the last moments of the auction pushes the end back
+	extension := time.Duration(extensionSeconds) * time.Second
+	if endsAt.Sub(now) < extension {
+		endsAt = now.Add(extension)
+	}
+
+	query = `INSERT INTO auction_bids(product_id, buyer_id, amount, bid_date) VALUES ($1,$2,$3,$4) RETURNING bid_id, bid_date::TEXT;`
+	err = tx.QueryRowContext(context.Background(), query, productId, request.BuyerId, request.Amount, now).Scan(
+		&response.BidId, &response.BidDate)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Auction Bid rows")
+		return response, errResp
+	}
+
+	query = `UPDATE auction_information SET bid_count = bid_count + 1, high_bidder_id = $2, ends_at = $3 WHERE product_id = $1;`
+	_, err = tx.ExecContext(context.Background(), query, productId, request.BuyerId, endsAt)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Auction Information rows")
+		return response, errResp
+	}
+
+	query = `UPDATE products SET price = $2 WHERE product_id = $1;`
+	_, err = tx.ExecContext(context.Background(), query, productId, request.Amount)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Product rows")
+		return response, errResp
+	}
+
+	messageIds, outboxErr := telegram.AddBidMessages(tx, productId, highBidderId, request.Amount)
+
+	if outboxErr != nil {
+		return response, outboxErr
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Bid transaction")
+		return response, errResp
+	}
+
+	outbox.DeliverMessages(db, messageIds)
+
+	response.ProductId = productId
+	response.Amount = request.Amount
+	response.MinimumBid = product.GetMinimumBid(startPrice, request.Amount, bidCount+1)
+	response.EndsAt = endsAt.Format(time.RFC3339)
+
+	return response, nil
+}
+
+/*
+Gets the bid history of an auction from the highest bid. Bidders are numbered in the order they first
+bid so that the history does not show who placed the bids.
+*/
+func GetBids(db *sql.DB, productId string) (data.GetBidsResponseData, *utils.ErrorHandler) {
+	response := data.GetBidsResponseData{ProductId: productId, Bids: []data.BidData{}}
+
+	if !product.IsAuctionProduct(db, productId) {
+		return response, utils.NotFoundError("Auction with given id does not exist")
+	}
+
+	query := `SELECT auction_bids.bid_id, bidders.bidder_no, auction_bids.amount, auction_bids.bid_date::TEXT
+		FROM auction_bids INNER JOIN (
+			SELECT buyer_id, ROW_NUMBER() OVER (ORDER BY MIN(bid_date)) AS bidder_no 
+			FROM auction_bids WHERE product_id = $1 GROUP BY buyer_id) bidders 
+		ON bidders.buyer_id = auction_bids.buyer_id
+		WHERE auction_bids.product_id = $1
+		ORDER BY auction_bids.amount DESC, auction_bids.bid_date ASC;`
+
+	rows, err := db.QueryContext(context.Background(), query, productId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Auction Bid rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var bid data.BidData
+		err = rows.Scan(&bid.BidId, &bid.BidderNo, &bid.Amount, &bid.BidDate)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Auction Bid rows")
+			return response, errResp
+		}
+
+		response.Bids = append(response.Bids, bid)
+	}
+
+	return response, nil
+}
+
+/*
+Saves the checkout details of a bidder, replacing the details they registered before
+*/
+func registerBidder(tx *sql.Tx, productId string, buyerId string, checkout data.AuctionCheckoutData) *utils.ErrorHandler {
+	query := `INSERT INTO auction_bidders(
+		product_id, buyer_id, phone_number, address_line_1, address_line_2, postal_code, telegram_handle, 
+		delivery_type, payment_type) 
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
+		ON CONFLICT (product_id, buyer_id) DO UPDATE SET 
+			phone_number = EXCLUDED.phone_number, address_line_1 = EXCLUDED.address_line_1, 
+			address_line_2 = EXCLUDED.address_line_2, postal_code = EXCLUDED.postal_code, 
+			telegram_handle = EXCLUDED.telegram_handle, delivery_type = EXCLUDED.delivery_type, 
+			payment_type = EXCLUDED.payment_type;`
+
+	_, err := tx.ExecContext(context.Background(), query, productId, buyerId, checkout.PhoneNumber, checkout.AddressLine1,
+		utils.NewNullableString(checkout.AddressLine2), checkout.PostalCode, utils.NewNullableString(checkout.TelegramHandle),
+		checkout.DeliveryType, checkout.PaymentType)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Auction Bidder rows")
+		return errResp
+	}
+
+	return nil
+}
+
+/*
+Checks wether a buyer has registered their checkout details for an auction
+*/
+func isBidderRegistered(db *sql.DB, productId string, buyerId string) bool {
+	var isRegistered bool
+	query := `SELECT EXISTS(SELECT * FROM auction_bidders WHERE product_id = $1 AND buyer_id = $2);`
+	err := db.QueryRowContext(context.Background(), query, productId, buyerId).Scan(&isRegistered)
+
+	if err != nil {
+		return false
+	}
+
+	return isRegistered
+}
+
+/*
+Validates a bid request. A buyer has to give their checkout details with their first bid on an auction
+so that an order can be created if they win.
+*/
+func validatePlaceBid(db *sql.DB, productId string, request data.PlaceBidRequestData) *utils.ErrorHandler {
+	if !product.IsAuctionProduct(db, productId) {
+		return utils.NotFoundError("Auction with given id does not exist")
+	}
+
+	if !buyer.DoesBuyerExist(db, request.BuyerId) {
+		utils.LogMessage("Buyer with given id does not exist")
+		return utils.BadRequestError("Bad buyer_id data")
+	}
+
+	if request.Amount <= 0 {
+		utils.LogMessage("Bid amount is not positive")
+		return utils.BadRequestError("Bad amount data")
+	}
+
+	if request.Checkout != nil {
+		return order.ValidateAuctionCheckout(*request.Checkout)
+	}
+
+	if !isBidderRegistered(db, productId, request.BuyerId) {
+		utils.LogMessage("Buyer has not given checkout details for the auction")
+		return utils.BadRequestError("Bad checkout data, checkout details are needed for the first bid")
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package auction
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+var dummyCheckout = data.AuctionCheckoutData{PhoneNumber: "91234567", AddressLine1: "1 Test Road",
+	PostalCode: "123456", DeliveryType: "standard_delivery", PaymentType: "paynow_online"}
+
+func TestPlaceBid(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerIds := createDummyBuyers(db)
+	productId, err := createDummyAuction(db, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	//Test 1: First bid needs checkout details
+	_, resErr := PlaceBid(db, productId, data.PlaceBidRequestData{BuyerId: buyerIds[0], Amount: 1000})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad checkout data, checkout details are needed for the first bid", resErr.Error())
+
+	//Test 2: Bid below the start price
+	_, resErr = PlaceBid(db, productId, data.PlaceBidRequestData{BuyerId: buyerIds[0], Amount: 900, Checkout: &dummyCheckout})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bid is below the minimum bid of 1000", resErr.Error())
+
+	//Test 3: First bid at the start price
+	res, resErr := PlaceBid(db, productId, data.PlaceBidRequestData{BuyerId: buyerIds[0], Amount: 1000, Checkout: &dummyCheckout})
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1100, res.MinimumBid)
+
+	//Test 4: Highest bidder cannot outbid themselves
+	_, resErr = PlaceBid(db, productId, data.PlaceBidRequestData{BuyerId: buyerIds[0], Amount: 2000})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Buyer is already the highest bidder", resErr.Error())
+
+	//Test 5: Bid must raise the price by the increment
+	_, resErr = PlaceBid(db, productId, data.PlaceBidRequestData{BuyerId: buyerIds[1], Amount: 1050, Checkout: &dummyCheckout})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bid is below the minimum bid of 1100", resErr.Error())
+
+	//Test 6: Auction that does not exist
+	_, resErr = PlaceBid(db, buyerIds[0], data.PlaceBidRequestData{BuyerId: buyerIds[1], Amount: 1100, Checkout: &dummyCheckout})
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	//Test 7: Concurrent bids at the same amount, only one is accepted
+	var wg sync.WaitGroup
+	results := make([]bool, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, bidErr := PlaceBid(db, productId, data.PlaceBidRequestData{BuyerId: buyerIds[i+1], Amount: 1100, Checkout: &dummyCheckout})
+			results[i] = bidErr == nil
+		}(i)
+	}
+	wg.Wait()
+	assert.NotEqual(t, results[0], results[1])
+
+	//Test 8: Bid history from the highest bid
+	bids, resErr := GetBids(db, productId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, len(bids.Bids))
+	assert.Equal(t, 1100, bids.Bids[0].Amount)
+	assert.Equal(t, 2, bids.Bids[0].BidderNo)
+
+	store.CloseDB(db)
+}
+
+func TestPlaceBidExtension(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerIds := createDummyBuyers(db)
+	endsAt := time.Now().Add(30 * time.Second)
+	productId, err := createDummyAuction(db, endsAt)
+	assert.NoError(t, err)
+
+	//Test 1: Bid close to the end extends the auction
+	res, resErr := PlaceBid(db, productId, data.PlaceBidRequestData{BuyerId: buyerIds[0], Amount: 1000, Checkout: &dummyCheckout})
+	assert.Empty(t, resErr)
+	newEndsAt, _ := time.Parse(time.RFC3339, res.EndsAt)
+	assert.Equal(t, true, newEndsAt.After(endsAt.Add(60*time.Second)))
+
+	store.CloseDB(db)
+}
+
+func createDummyBuyers(db *sql.DB) []string {
+	var buyerIds []string
+	emails := []string{"test@aucto.io", "test2@aucto.io", "test3@aucto.io"}
+
+	for i := 0; i < len(emails); i++ {
+		var buyerId string
+		query := `INSERT INTO buyers(email, password) VALUES ($1,'test') RETURNING buyer_id;`
+		db.QueryRowContext(context.Background(), query, emails[i]).Scan(&buyerId)
+		buyerIds = append(buyerIds, buyerId)
+	}
+
+	return buyerIds
+}
+
+func createDummyAuction(db *sql.DB, endsAt time.Time) (string, error) {
+	var sellerId, productId string
+	query := `INSERT INTO sellers(email, seller_name, password) VALUES ('test@aucto.io','test','test') 
+		ON CONFLICT (email) DO UPDATE SET email = EXCLUDED.email RETURNING seller_id`
+	err := db.QueryRowContext(context.Background(), query).Scan(&sellerId)
+
+	if err != nil {
+		return productId, err
+	}
+
+	query = `INSERT INTO products(
+		title, seller_id, description, product_type, language, expansion, posted_date, price, condition, product_quantity) 
+		VALUES ('Test', $1, 'This is a test description', 'Auction', 'Eng', 'Test', NOW(), 1000, 5, 1) RETURNING product_id;`
+	err = db.QueryRowContext(context.Background(), query, sellerId).Scan(&productId)
+
+	if err != nil {
+		return productId, err
+	}
+
+	query = `INSERT INTO auction_information(product_id, start_price, reserve_price, starts_at, ends_at, extension_seconds) 
+		VALUES ($1, 1000, 1500, NOW(), $2, 120);`
+	_, err = db.ExecContext(context.Background(), query, productId, endsAt)
+
+	return productId, err
+}
@@ -0,0 +1,224 @@
+package auction
+
+import (
+	"BackendAPI/api/order"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+)
+
+/*
+Closes every open auction that has ended. An auction with a bid that meets the reserve is sold and an
+order is created for the highest bidder at the hammer price, otherwise the auction is unsold.
+Auctions that are being closed by another call are skipped so this can safely run on a schedule.
+*/
+func CloseEndedAuctions(db *sql.DB) (data.CloseAuctionsResponseData, *utils.ErrorHandler) {
+	response := data.CloseAuctionsResponseData{Sold: []string{}, Unsold: []string{}}
+	var productIds []string
+
+	query := `SELECT product_id FROM auction_information WHERE status = 'open' AND ends_at <= NOW();`
+	rows, err := db.QueryContext(context.Background(), query)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Auction Information rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var productId string
+		err = rows.Scan(&productId)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Auction Information rows")
+			return response, errResp
+		}
+
+		productIds = append(productIds, productId)
+	}
+
+	for i := 0; i < len(productIds); i++ {
+		status, closeErr := closeAuction(db, productIds[i])
+
+		if closeErr != nil {
+			return response, closeErr
+		}
+
+		if status == "sold" {
+			response.Sold = append(response.Sold, productIds[i])
+		} else if status == "unsold" {
+			response.Unsold = append(response.Unsold, productIds[i])
+		}
+	}
+
+	return response, nil
+}
+
+/*
+Closes a single auction and returns its new status, or an empty status if the auction could not be closed
+because it is locked, already closed or was extended by a late bid.
+*/
+func closeAuction(db *sql.DB, productId string) (string, *utils.ErrorHandler) {
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Auction transaction")
+		return "", errResp
+	}
+
+	defer tx.Rollback()
+
+	var hammerPrice, reservePrice, bidCount int
+	var highBidderId string
+
+	query := `SELECT products.price, reserve_price, bid_count, COALESCE(high_bidder_id::TEXT, '')
+		FROM auction_information INNER JOIN products ON products.product_id = auction_information.product_id
+		WHERE auction_information.product_id = $1 AND status = 'open' AND ends_at <= clock_timestamp()
+		FOR UPDATE OF auction_information SKIP LOCKED;`
+
+	err = tx.QueryRowContext(context.Background(), query, productId).Scan(&hammerPrice, &reservePrice, &bidCount, &highBidderId)
+
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Auction Information rows")
+		return "", errResp
+	}
+
+	if bidCount == 0 || hammerPrice < reservePrice {
+		query = `UPDATE auction_information SET status = 'unsold' WHERE product_id = $1;`
+		_, err = tx.ExecContext(context.Background(), query, productId)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in updating Auction Information rows")
+			return "", errResp
+		}
+
+		err = tx.Commit()
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in committing Auction transaction")
+			return "", errResp
+		}
+
+		return "unsold", nil
+	}
+
+	checkout, checkoutErr := getBidderCheckout(tx, productId, highBidderId)
+
+	if checkoutErr != nil {
+		return "", checkoutErr
+	}
+
+	orderId, fees, orderErr := order.CreateAuctionOrder(tx, productId, highBidderId, hammerPrice, checkout)
+
+	if orderErr != nil {
+		return "", orderErr
+	}
+
+	query = `UPDATE auction_information SET status = 'sold', order_id = $2 WHERE product_id = $1;`
+	_, err = tx.ExecContext(context.Background(), query, productId, orderId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Auction Information rows")
+		return "", errResp
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Auction transaction")
+		return "", errResp
+	}
+
+	// The auction stays sold if the payment request fails, the order can still be paid for later
+	paymentResponse, paymentErr := order.CreatePaymentRequest(float64(fees.TotalPaid)/100, orderId, fees.PaymentType, false)
+
+	if paymentErr != nil {
+		utils.LogMessage("Payment request could not be created for auction order " + orderId)
+		return "sold", nil
+	}
+
+	query = `UPDATE auction_information SET payment_url = $2 WHERE product_id = $1;`
+	_, err = db.ExecContext(context.Background(), query, productId, paymentResponse.Url)
+
+	if err != nil {
+		utils.LogError(err, "Error in updating Auction Information rows")
+	}
+
+	return "sold", nil
+}
+
+/*
+Gets the result of an auction for a buyer. Only the winner of the auction gets the order and payment url.
+*/
+func GetAuctionResult(db *sql.DB, productId string, buyerId string) (data.AuctionResultData, *utils.ErrorHandler) {
+	response := data.AuctionResultData{ProductId: productId}
+	var highBidderId string
+	var hammerPrice int
+
+	query := `SELECT status, COALESCE(high_bidder_id::TEXT, ''), products.price, COALESCE(order_id::TEXT, ''), 
+			COALESCE(payment_url, '')
+		FROM auction_information INNER JOIN products ON products.product_id = auction_information.product_id
+		WHERE auction_information.product_id = $1;`
+
+	err := db.QueryRowContext(context.Background(), query, productId).Scan(&response.Status, &highBidderId,
+		&hammerPrice, &response.OrderId, &response.PaymentUrl)
+
+	if err == sql.ErrNoRows {
+		return response, utils.NotFoundError("Auction with given id does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Auction Information rows")
+		return response, errResp
+	}
+
+	if response.Status == "sold" {
+		response.HammerPrice = hammerPrice
+	}
+
+	response.Won = response.Status == "sold" && highBidderId == buyerId
+	if !response.Won {
+		response.OrderId = ""
+		response.PaymentUrl = ""
+	}
+
+	return response, nil
+}
+
+/*
+Gets the checkout details a bidder registered for an auction
+*/
+func getBidderCheckout(tx *sql.Tx, productId string, buyerId string) (data.AuctionCheckoutData, *utils.ErrorHandler) {
+	var checkout data.AuctionCheckoutData
+
+	query := `SELECT phone_number, address_line_1, COALESCE(address_line_2, ''), postal_code, COALESCE(telegram_handle, ''),
+			delivery_type, payment_type
+		FROM auction_bidders WHERE product_id = $1 AND buyer_id = $2;`
+
+	err := tx.QueryRowContext(context.Background(), query, productId, buyerId).Scan(&checkout.PhoneNumber,
+		&checkout.AddressLine1, &checkout.AddressLine2, &checkout.PostalCode, &checkout.TelegramHandle,
+		&checkout.DeliveryType, &checkout.PaymentType)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Auction Bidder rows")
+		return checkout, errResp
+	}
+
+	return checkout, nil
+}
@@ -0,0 +1,75 @@
+package auction
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCloseEndedAuctions(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerIds := createDummyBuyers(db)
+	soldId, err := createDummyAuction(db, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	reserveNotMetId, err := createDummyAuction(db, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	noBidsId, err := createDummyAuction(db, time.Now().Add(-time.Minute))
+	assert.NoError(t, err)
+	openId, err := createDummyAuction(db, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	_, bidErr := PlaceBid(db, soldId, data.PlaceBidRequestData{BuyerId: buyerIds[0], Amount: 1000, Checkout: &dummyCheckout})
+	assert.Empty(t, bidErr)
+	_, bidErr = PlaceBid(db, soldId, data.PlaceBidRequestData{BuyerId: buyerIds[1], Amount: 2000, Checkout: &dummyCheckout})
+	assert.Empty(t, bidErr)
+	_, bidErr = PlaceBid(db, reserveNotMetId, data.PlaceBidRequestData{BuyerId: buyerIds[0], Amount: 1000, Checkout: &dummyCheckout})
+	assert.Empty(t, bidErr)
+
+	query := `UPDATE auction_information SET ends_at = NOW() - INTERVAL '1 minute' WHERE product_id IN ($1, $2);`
+	_, err = db.ExecContext(context.Background(), query, soldId, reserveNotMetId)
+	assert.NoError(t, err)
+
+	//Test 1: Ended auctions are closed, open auctions are left
+	res, resErr := CloseEndedAuctions(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, []string{soldId}, res.Sold)
+	assert.ElementsMatch(t, []string{reserveNotMetId, noBidsId}, res.Unsold)
+	assert.NotContains(t, res.Unsold, openId)
+
+	//Test 2: Winner gets the order at the hammer price
+	result, resErr := GetAuctionResult(db, soldId, buyerIds[1])
+	assert.Empty(t, resErr)
+	assert.Equal(t, true, result.Won)
+	assert.Equal(t, 2000, result.HammerPrice)
+	assert.NotEqual(t, "", result.OrderId)
+
+	var totalPaid int
+	err = db.QueryRowContext(context.Background(), `SELECT total_paid FROM orders WHERE order_id = $1;`, result.OrderId).Scan(&totalPaid)
+	assert.NoError(t, err)
+	assert.Equal(t, 2500, totalPaid)
+
+	//Test 3: Other bidders do not get the order
+	result, resErr = GetAuctionResult(db, soldId, buyerIds[0])
+	assert.Empty(t, resErr)
+	assert.Equal(t, false, result.Won)
+	assert.Equal(t, "", result.OrderId)
+
+	//Test 4: Closing again does not close the auctions twice
+	res, resErr = CloseEndedAuctions(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(res.Sold)+len(res.Unsold))
+
+	//Test 5: Bids on a closed auction are rejected
+	_, bidErr = PlaceBid(db, soldId, data.PlaceBidRequestData{BuyerId: buyerIds[0], Amount: 5000})
+	assert.Error(t, bidErr)
+	assert.Equal(t, "Auction has ended", bidErr.Error())
+
+	store.CloseDB(db)
+}
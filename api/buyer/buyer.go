@@ -1,7 +1,9 @@
 package buyer
 
 import (
+	"BackendAPI/api/outbox"
 	"BackendAPI/data"
+	"BackendAPI/mailing"
 	"BackendAPI/utils"
 	"context"
 	"database/sql"
@@ -59,9 +61,20 @@ func BuyerSignUp(db *sql.DB, signupData data.BuyerSignUpData) (data.BuyerLoginRe
 	}
 
 	otp := utils.GetOtp(6)
+	locale := mailing.GetLocale(signupData.Locale)
 
-	query := `INSERT INTO buyers(email, password) VALUES ($1,$2) RETURNING email, buyer_id, verification;`
-	err = db.QueryRowContext(context.Background(), query, signupData.Email, hashPassword).Scan(
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Buyer transaction")
+		return response, errResp
+	}
+
+	defer tx.Rollback()
+
+	query := `INSERT INTO buyers(email, password, locale) VALUES ($1,$2,$3) RETURNING email, buyer_id, verification;`
+	err = tx.QueryRowContext(context.Background(), query, signupData.Email, hashPassword, locale).Scan(
 		&response.Email, &response.BuyerId, &response.Verification)
 
 	if err != nil {
@@ -72,7 +85,7 @@ func BuyerSignUp(db *sql.DB, signupData data.BuyerSignUpData) (data.BuyerLoginRe
 
 	query = `INSERT INTO buyer_otps(buyer_id, email_otp) VALUES ($1,$2);`
 
-	_, err = db.ExecContext(context.Background(), query, response.BuyerId, otp)
+	_, err = tx.ExecContext(context.Background(), query, response.BuyerId, otp)
 
 	if err != nil {
 		errResp := utils.InternalServerError(err)
@@ -80,14 +93,28 @@ func BuyerSignUp(db *sql.DB, signupData data.BuyerSignUpData) (data.BuyerLoginRe
 		return response, errResp
 	}
 
-	err = utils.SendOtpMail(signupData.Email, otp)
+	mailId, outboxErr := addOtpMail(tx, signupData.Email, locale, otp)
+
+	if outboxErr != nil {
+		return response, outboxErr
+	}
+
+	_, outboxErr = outbox.AddMessage(tx, outbox.BuyerSignedUpTopic, data.BuyerEventData{BuyerId: response.BuyerId})
+
+	if outboxErr != nil {
+		return response, outboxErr
+	}
+
+	err = tx.Commit()
 
 	if err != nil {
 		errResp := utils.InternalServerError(nil)
-		utils.LogError(err, "Error in sending otp mail")
+		utils.LogError(err, "Error in committing Buyer transaction")
 		return response, errResp
 	}
 
+	outbox.DeliverMessages(db, []string{mailId})
+
 	return response, nil
 }
 
@@ -99,9 +126,20 @@ func ResendOtp(db *sql.DB, resendOtpReq data.BuyerResendOtpData) (data.Message,
 	}
 
 	newOtp := utils.GetOtp(6)
+
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Buyer transaction")
+		return response, errResp
+	}
+
+	defer tx.Rollback()
+
 	query := `UPDATE buyer_otps SET email_otp = $1 WHERE buyer_id = $2;`
 
-	_, err := db.ExecContext(context.Background(), query, newOtp, resendOtpReq.BuyerId)
+	_, err = tx.ExecContext(context.Background(), query, newOtp, resendOtpReq.BuyerId)
 
 	if err != nil {
 		errResp := utils.InternalServerError(nil)
@@ -109,9 +147,9 @@ func ResendOtp(db *sql.DB, resendOtpReq data.BuyerResendOtpData) (data.Message,
 		return response, errResp
 	}
 
-	var email string
-	query = `SELECT email FROM buyers WHERE buyer_id = $1;`
-	err = db.QueryRowContext(context.Background(), query, resendOtpReq.BuyerId).Scan(&email)
+	var email, locale string
+	query = `SELECT email, locale FROM buyers WHERE buyer_id = $1;`
+	err = tx.QueryRowContext(context.Background(), query, resendOtpReq.BuyerId).Scan(&email, &locale)
 
 	if err != nil {
 		errResp := utils.InternalServerError(nil)
@@ -119,14 +157,22 @@ func ResendOtp(db *sql.DB, resendOtpReq data.BuyerResendOtpData) (data.Message,
 		return response, errResp
 	}
 
-	err = utils.SendOtpMail(email, newOtp)
+	mailId, outboxErr := addOtpMail(tx, email, locale, newOtp)
+
+	if outboxErr != nil {
+		return response, outboxErr
+	}
+
+	err = tx.Commit()
 
 	if err != nil {
 		errResp := utils.InternalServerError(nil)
-		utils.LogError(err, "Error in sending otp mail")
+		utils.LogError(err, "Error in committing Buyer transaction")
 		return response, errResp
 	}
 
+	outbox.DeliverMessages(db, []string{mailId})
+
 	response.Message = "Resent otp to provided email address"
 	return response, nil
 }
@@ -163,6 +209,21 @@ func ValidateOtp(db *sql.DB, validateOtpReq data.BuyerValidateOtpData) (data.Buy
 	return response, nil
 }
 
+/*
+Adds the mail with the OTP of a buyer to the outbox, so that it is only sent if the OTP is saved
+*/
+func addOtpMail(tx *sql.Tx, email string, locale string, otp string) (string, *utils.ErrorHandler) {
+	message, err := mailing.ComposeOtpMail(email, locale, otp)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in composing otp mail")
+		return "", errResp
+	}
+
+	return outbox.AddMail(tx, message)
+}
+
 /*
 Checks wether a Buyer with a given email address already exists in the database
 and returns true if it does false otherwise.
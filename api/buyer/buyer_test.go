@@ -2,10 +2,12 @@ package buyer
 
 import (
 	"BackendAPI/data"
+	"BackendAPI/mailing"
 	"BackendAPI/store"
 	"BackendAPI/utils"
 	"context"
 	"database/sql"
+	"errors"
 	"testing"
 
 	_ "github.com/lib/pq"
@@ -111,6 +113,19 @@ func TestBuyerSignUp(t *testing.T) {
 	res, err = BuyerSignUp(db, testSignup3)
 	assert.Error(t, err)
 
+	//Test 4: Signup is successful when the otp mail fails to send and the mail is left in the outbox
+	mailing.UseMailer(failingMailer{})
+	defer mailing.UseMailer(nil)
+	testSignup4 := data.BuyerSignUpData{Email: "test4@aucto.io", Password: "Test1234"}
+	res, err = BuyerSignUp(db, testSignup4)
+	assert.Empty(t, err)
+	assert.NotEmpty(t, res.BuyerId)
+	var attempts int
+	query := `SELECT attempts FROM outbox_messages WHERE topic = 'mail' AND status = 'pending' AND payload->>'ToEmail' = $1;`
+	dbErr := db.QueryRowContext(context.Background(), query, testSignup4.Email).Scan(&attempts)
+	assert.NoError(t, dbErr)
+	assert.Equal(t, 1, attempts)
+
 	store.CloseDB(db)
 }
 
@@ -167,6 +182,12 @@ func TestValidateOtp(t *testing.T) {
 	store.CloseDB(db)
 }
 
+type failingMailer struct{}
+
+func (failingMailer) Send(message mailing.Message) error {
+	return errors.New("Mail provider is down")
+}
+
 func createDummyBuyers(db *sql.DB) []string {
 	var dummyAccounts []data.BuyerSignUpData = []data.BuyerSignUpData{{Email: "test@aucto.io", Password: "Test1234"},
 		{Email: "test2@aucto.io", Password: "Test1234"}, {Email: "test3@aucto.io", Password: "Test1234"}}
@@ -0,0 +1,543 @@
+package cart
+
+import (
+	"BackendAPI/api/buyer"
+	"BackendAPI/api/offer"
+	"BackendAPI/api/order"
+	"BackendAPI/api/product"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+)
+
+const cartTokenBytes = 32
+
+/*
+Gets the id of the cart of a buyer, the cart is created the first time it is used. If the buyer does not
+exist, a 404 Not found error is returned.
+*/
+func GetBuyerCartId(db *sql.DB, buyerId string) (string, *utils.ErrorHandler) {
+	var cartId string
+
+	if !buyer.DoesBuyerExist(db, buyerId) {
+		return cartId, utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	query := `INSERT INTO carts(buyer_id, created_date, updated_date) VALUES ($1, NOW(), NOW())
+		ON CONFLICT (buyer_id) DO UPDATE SET buyer_id = EXCLUDED.buyer_id RETURNING cart_id;`
+	err := db.QueryRowContext(context.Background(), query, buyerId).Scan(&cartId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Cart rows")
+		return cartId, errResp
+	}
+
+	return cartId, nil
+}
+
+/*
+Gets the id of a guest cart from its token. If no cart has the token, a 404 Not found error is returned.
+*/
+func GetGuestCartId(db *sql.DB, cartToken string) (string, *utils.ErrorHandler) {
+	var cartId string
+
+	query := `SELECT cart_id FROM carts WHERE guest_token = $1;`
+	err := db.QueryRowContext(context.Background(), query, cartToken).Scan(&cartId)
+
+	if err == sql.ErrNoRows {
+		return cartId, utils.NotFoundError("Cart with given token does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Cart rows")
+		return cartId, errResp
+	}
+
+	return cartId, nil
+}
+
+/*
+Creates an empty cart for a guest. The token of the cart is only returned here and identifies the cart
+in later requests.
+*/
+func CreateGuestCart(db *sql.DB) (data.CartData, *utils.ErrorHandler) {
+	var response data.CartData
+
+	tokenBytes := make([]byte, cartTokenBytes)
+	_, randErr := rand.Read(tokenBytes)
+
+	if randErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(randErr, "Error in generating cart token")
+		return response, errResp
+	}
+
+	cartToken := hex.EncodeToString(tokenBytes)
+	var cartId string
+
+	query := `INSERT INTO carts(guest_token, created_date, updated_date) VALUES ($1, NOW(), NOW()) RETURNING cart_id;`
+	err := db.QueryRowContext(context.Background(), query, cartToken).Scan(&cartId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Cart rows")
+		return response, errResp
+	}
+
+	response, cartErr := GetCart(db, cartId)
+	response.CartToken = cartToken
+
+	return response, cartErr
+}
+
+/*
+Gets the products in a cart, each checked against the current price and stock of the product. Products that
+can no longer be bought as they are have an issue: 'unavailable' when the product can no longer be ordered,
+'sold_out' or 'insufficient_stock' when there is not enough stock left and 'price_changed' when the price
+differs from the price when the product was added. A product with a changed price is not available until the
+buyer saves it again at its current price, but it is still counted in the subtotal at its current price.
+*/
+func GetCart(db *sql.DB, cartId string) (data.CartData, *utils.ErrorHandler) {
+	response := data.CartData{CartId: cartId, Products: []data.CartProductData{}}
+	var buyerId sql.NullString
+
+	query := `SELECT buyer_id FROM carts WHERE cart_id = $1;`
+	err := db.QueryRowContext(context.Background(), query, cartId).Scan(&buyerId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Cart rows")
+		return response, errResp
+	}
+
+	response.BuyerId = buyerId.String
+
+	query = `SELECT cart_products.product_id, products.title, products.product_type, cart_products.quantity,
+			products.price - COALESCE(product_effective_discounts.discount, 0), cart_products.added_price,
+			products.product_quantity - products.sold_quantity,
+			products.product_type = 'Pre-Order' AND COALESCE(
+				preorder_information.status <> 'open' OR preorder_information.order_by < NOW(), FALSE)
+		FROM ((cart_products INNER JOIN products ON products.product_id = cart_products.product_id)
+			LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id)
+				LEFT OUTER JOIN preorder_information ON preorder_information.product_id = products.product_id
+		WHERE cart_products.cart_id = $1
+		ORDER BY cart_products.added_date ASC;`
+	rows, err := db.QueryContext(context.Background(), query, cartId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Cart Product rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	var closed []bool
+	var productIds []string
+
+	for rows.Next() {
+		var cartProduct data.CartProductData
+		var isClosed bool
+		err = rows.Scan(&cartProduct.ProductId, &cartProduct.Title, &cartProduct.ProductType, &cartProduct.Quantity,
+			&cartProduct.Price, &cartProduct.AddedPrice, &cartProduct.RemainingQuantity, &isClosed)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Cart Product rows")
+			return response, errResp
+		}
+
+		response.Products = append(response.Products, cartProduct)
+		closed = append(closed, isClosed)
+		productIds = append(productIds, cartProduct.ProductId)
+	}
+
+	//Stock reserved by the accepted offers of other buyers cannot be bought from the cart
+	reserved, reservedErr := offer.GetReservedQuantities(db, productIds, response.BuyerId)
+	if reservedErr != nil {
+		return response, reservedErr
+	}
+
+	for i := 0; i < len(response.Products); i++ {
+		cartProduct := &response.Products[i]
+		cartProduct.RemainingQuantity -= reserved[cartProduct.ProductId]
+		cartProduct.Issue = getCartProductIssue(*cartProduct, closed[i])
+		cartProduct.Available = cartProduct.Issue == ""
+
+		if cartProduct.Available || cartProduct.Issue == "price_changed" {
+			response.Subtotal += cartProduct.Price * cartProduct.Quantity
+		}
+	}
+
+	return response, nil
+}
+
+/*
+Adds a product to a cart, or adds to its quantity if it is already in the cart. Returns a 400 bad request if
+the product cannot be ordered or there is not enough stock for the new quantity.
+*/
+func AddCartProduct(db *sql.DB, cartId string, request data.AddCartProductRequestData) (data.CartData, *utils.ErrorHandler) {
+	var currentQuantity int
+
+	if !product.DoesProductExist(db, request.ProductId) {
+		utils.LogMessage("Product with given id does not exist")
+		return data.CartData{}, utils.BadRequestError("Bad product_id data")
+	}
+
+	query := `SELECT COALESCE(SUM(quantity), 0) FROM cart_products WHERE cart_id = $1 AND product_id::TEXT = $2;`
+	err := db.QueryRowContext(context.Background(), query, cartId, request.ProductId).Scan(&currentQuantity)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Cart Product rows")
+		return data.CartData{}, errResp
+	}
+
+	if request.Quantity <= 0 {
+		utils.LogMessage("Invalid cart quantity")
+		return data.CartData{}, utils.BadRequestError("Bad quantity data")
+	}
+
+	price, validErr := validateCartProduct(db, cartId, request.ProductId, currentQuantity+request.Quantity)
+
+	if validErr != nil {
+		return data.CartData{}, validErr
+	}
+
+	query = `INSERT INTO cart_products(cart_id, product_id, quantity, added_price, added_date) VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (cart_id, product_id) DO UPDATE SET quantity = cart_products.quantity + EXCLUDED.quantity,
+			added_price = EXCLUDED.added_price;`
+	_, err = db.ExecContext(context.Background(), query, cartId, request.ProductId, request.Quantity, price)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Cart Product rows")
+		return data.CartData{}, errResp
+	}
+
+	touchCart(db, cartId)
+	return GetCart(db, cartId)
+}
+
+/*
+Changes the quantity of a product in a cart. The product is now expected at its current price. Returns a 404
+Not found error if the product is not in the cart and a 400 bad request if there is not enough stock.
+*/
+func UpdateCartProduct(db *sql.DB, cartId string, productId string, request data.UpdateCartProductRequestData) (data.CartData, *utils.ErrorHandler) {
+	if !isProductInCart(db, cartId, productId) {
+		return data.CartData{}, utils.NotFoundError("Product is not in the cart")
+	}
+
+	price, validErr := validateCartProduct(db, cartId, productId, request.Quantity)
+
+	if validErr != nil {
+		return data.CartData{}, validErr
+	}
+
+	query := `UPDATE cart_products SET quantity = $3, added_price = $4 WHERE cart_id = $1 AND product_id = $2;`
+	_, err := db.ExecContext(context.Background(), query, cartId, productId, request.Quantity, price)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Cart Product rows")
+		return data.CartData{}, errResp
+	}
+
+	touchCart(db, cartId)
+	return GetCart(db, cartId)
+}
+
+/*
+Removes a product from a cart. Returns a 404 Not found error if the product is not in the cart.
+*/
+func RemoveCartProduct(db *sql.DB, cartId string, productId string) (data.CartData, *utils.ErrorHandler) {
+	if !isProductInCart(db, cartId, productId) {
+		return data.CartData{}, utils.NotFoundError("Product is not in the cart")
+	}
+
+	query := `DELETE FROM cart_products WHERE cart_id = $1 AND product_id = $2;`
+	_, err := db.ExecContext(context.Background(), query, cartId, productId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in deleting Cart Product rows")
+		return data.CartData{}, errResp
+	}
+
+	touchCart(db, cartId)
+	return GetCart(db, cartId)
+}
+
+/*
+Moves the products of a guest cart into the cart of a buyer, used when a guest logs in or signs up. Quantities
+of products that are in both carts are added together and the guest cart is removed.
+*/
+func MergeGuestCart(db *sql.DB, buyerId string, cartToken string) (data.CartData, *utils.ErrorHandler) {
+	guestCartId, err := GetGuestCartId(db, cartToken)
+
+	if err != nil {
+		return data.CartData{}, err
+	}
+
+	cartId, err := GetBuyerCartId(db, buyerId)
+
+	if err != nil {
+		return data.CartData{}, err
+	}
+
+	tx, txErr := db.BeginTx(context.Background(), nil)
+
+	if txErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(txErr, "Error in starting Cart transaction")
+		return data.CartData{}, errResp
+	}
+
+	defer tx.Rollback()
+
+	query := `INSERT INTO cart_products(cart_id, product_id, quantity, added_price, added_date)
+		SELECT $1, product_id, quantity, added_price, added_date FROM cart_products WHERE cart_id = $2
+		ON CONFLICT (cart_id, product_id) DO UPDATE SET quantity = cart_products.quantity + EXCLUDED.quantity;`
+	_, execErr := tx.ExecContext(context.Background(), query, cartId, guestCartId)
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in merging Cart Product rows")
+		return data.CartData{}, errResp
+	}
+
+	query = `DELETE FROM carts WHERE cart_id = $1;`
+	_, execErr = tx.ExecContext(context.Background(), query, guestCartId)
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in deleting Cart rows")
+		return data.CartData{}, errResp
+	}
+
+	execErr = tx.Commit()
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in committing Cart transaction")
+		return data.CartData{}, errResp
+	}
+
+	touchCart(db, cartId)
+	return GetCart(db, cartId)
+}
+
+/*
+Creates an order for a buyer from the products in their cart and empties the cart. Returns a 400 bad request
+if the cart is empty or has products that are no longer available.
+*/
+func CheckoutBuyerCart(db *sql.DB, buyerId string, request data.CheckoutCartRequestData) (data.CreateOrderResponseData, *utils.ErrorHandler) {
+	var response data.CreateOrderResponseData
+
+	cartId, err := GetBuyerCartId(db, buyerId)
+
+	if err != nil {
+		return response, err
+	}
+
+	products, err := getCheckoutProducts(db, cartId)
+
+	if err != nil {
+		return response, err
+	}
+
+	response, err = order.CreateOrder(db, data.CreateOrderRequestData{
+		Products: products, BuyerId: buyerId, PhoneNumber: request.PhoneNumber, AddressLine1: request.AddressLine1,
+		AddressLine2: request.AddressLine2, PostalCode: request.PostalCode, TelegramHandle: request.TelegramHandle,
+		PromoCode: request.PromoCode, Fees: request.Fees})
+
+	if err != nil {
+		return response, err
+	}
+
+	clearCart(db, cartId)
+	return response, nil
+}
+
+/*
+Creates a guest order from the products in a guest cart and empties the cart. Returns a 400 bad request if
+the cart is empty or has products that are no longer available.
+*/
+func CheckoutGuestCart(db *sql.DB, cartToken string, request data.CheckoutGuestCartRequestData) (data.CreateGuestOrderResponseData, *utils.ErrorHandler) {
+	var response data.CreateGuestOrderResponseData
+
+	cartId, err := GetGuestCartId(db, cartToken)
+
+	if err != nil {
+		return response, err
+	}
+
+	products, err := getCheckoutProducts(db, cartId)
+
+	if err != nil {
+		return response, err
+	}
+
+	response, err = order.CreateGuestOrder(db, data.CreateGuestOrderRequestData{
+		Products: products, Email: request.Email, PhoneNumber: request.PhoneNumber, AddressLine1: request.AddressLine1,
+		AddressLine2: request.AddressLine2, PostalCode: request.PostalCode, TelegramHandle: request.TelegramHandle,
+		PromoCode: request.PromoCode, Fees: request.Fees})
+
+	if err != nil {
+		return response, err
+	}
+
+	clearCart(db, cartId)
+	return response, nil
+}
+
+/*
+Gets the products of a cart as the products of an order. Products with a changed price have to be saved again with
+UpdateCartProduct before the cart can be checked out, so the buyer never pays a price they have not seen.
+*/
+func getCheckoutProducts(db *sql.DB, cartId string) ([]data.ProductOrder, *utils.ErrorHandler) {
+	var products []data.ProductOrder
+
+	cart, err := GetCart(db, cartId)
+
+	if err != nil {
+		return products, err
+	}
+
+	if len(cart.Products) == 0 {
+		return products, utils.BadRequestError("Cart is empty")
+	}
+
+	for i := 0; i < len(cart.Products); i++ {
+		if cart.Products[i].Issue == "price_changed" {
+			utils.LogMessage("Cart has products whose price has changed")
+			return products, utils.BadRequestError("Cart has products whose price has changed")
+		}
+
+		if !cart.Products[i].Available {
+			utils.LogMessage("Cart has products that are no longer available")
+			return products, utils.BadRequestError("Cart has products that are no longer available")
+		}
+
+		products = append(products, data.ProductOrder{ProductId: cart.Products[i].ProductId, OrderQuantity: cart.Products[i].Quantity})
+	}
+
+	return products, nil
+}
+
+/*
+Gets the issue that keeps a product in a cart from being bought as it is, or an empty string if there is none
+*/
+func getCartProductIssue(cartProduct data.CartProductData, isClosed bool) string {
+	if cartProduct.ProductType == "Auction" || isClosed {
+		return "unavailable"
+	}
+
+	if cartProduct.RemainingQuantity <= 0 {
+		return "sold_out"
+	}
+
+	if cartProduct.Quantity > cartProduct.RemainingQuantity {
+		return "insufficient_stock"
+	}
+
+	if cartProduct.Price != cartProduct.AddedPrice {
+		return "price_changed"
+	}
+
+	return ""
+}
+
+/*
+Validates that a product can be put in a cart with the given quantity and returns its current price
+*/
+func validateCartProduct(db *sql.DB, cartId string, productId string, quantity int) (int, *utils.ErrorHandler) {
+	var price, remaining int
+	var buyerId sql.NullString
+
+	if quantity <= 0 {
+		utils.LogMessage("Invalid cart quantity")
+		return 0, utils.BadRequestError("Bad quantity data")
+	}
+
+	if !product.DoesProductExist(db, productId) || product.IsAuctionProduct(db, productId) {
+		utils.LogMessage("Product does not exist or is an auction")
+		return 0, utils.BadRequestError("Bad product_id data")
+	}
+
+	if product.IsPreOrderClosed(db, productId) {
+		utils.LogMessage("Pre-order is past its order by date or has been released")
+		return 0, utils.BadRequestError("Pre-order has closed")
+	}
+
+	query := `SELECT products.price - COALESCE(product_effective_discounts.discount, 0),
+			products.product_quantity - products.sold_quantity, carts.buyer_id
+		FROM (products LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id),
+			carts
+		WHERE products.product_id = $1 AND carts.cart_id = $2;`
+	err := db.QueryRowContext(context.Background(), query, productId, cartId).Scan(&price, &remaining, &buyerId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Product rows")
+		return 0, errResp
+	}
+
+	reserved, reservedErr := offer.GetReservedQuantities(db, []string{productId}, buyerId.String)
+	if reservedErr != nil {
+		return 0, reservedErr
+	}
+
+	if quantity > remaining-reserved[productId] {
+		utils.LogMessage("Cart quantity is greater than available stock")
+		return 0, utils.BadRequestError("Bad quantity data")
+	}
+
+	return price, nil
+}
+
+/*
+Checks whether a product is in a cart
+*/
+func isProductInCart(db *sql.DB, cartId string, productId string) bool {
+	var inCart bool
+	query := `SELECT EXISTS(SELECT * FROM cart_products WHERE cart_id = $1 AND product_id::TEXT = $2);`
+	err := db.QueryRowContext(context.Background(), query, cartId, productId).Scan(&inCart)
+
+	if err != nil {
+		return false
+	}
+
+	return inCart
+}
+
+/*
+Marks a cart as updated
+*/
+func touchCart(db *sql.DB, cartId string) {
+	query := `UPDATE carts SET updated_date = NOW() WHERE cart_id = $1;`
+	_, err := db.ExecContext(context.Background(), query, cartId)
+
+	if err != nil {
+		utils.LogError(err, "Error in updating Cart rows")
+	}
+}
+
+/*
+Removes every product from a cart once it has been checked out
+*/
+func clearCart(db *sql.DB, cartId string) {
+	query := `DELETE FROM cart_products WHERE cart_id = $1;`
+	_, err := db.ExecContext(context.Background(), query, cartId)
+
+	if err != nil {
+		utils.LogError(err, "Error in deleting Cart Product rows")
+	}
+
+	touchCart(db, cartId)
+}
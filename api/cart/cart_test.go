@@ -0,0 +1,228 @@
+package cart
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCartProductIssue(t *testing.T) {
+	cartProduct := data.CartProductData{ProductType: "Buy-Now", Quantity: 2, Price: 1000, AddedPrice: 1000, RemainingQuantity: 3}
+
+	//Test 1: Product can be bought as it is
+	assert.Equal(t, "", getCartProductIssue(cartProduct, false))
+
+	//Test 2: Price has changed since the product was added
+	changed := cartProduct
+	changed.Price = 900
+	assert.Equal(t, "price_changed", getCartProductIssue(changed, false))
+
+	//Test 3: Not enough stock for the quantity in the cart
+	insufficient := cartProduct
+	insufficient.RemainingQuantity = 1
+	assert.Equal(t, "insufficient_stock", getCartProductIssue(insufficient, false))
+
+	//Test 4: Product is sold out
+	soldOut := cartProduct
+	soldOut.RemainingQuantity = 0
+	assert.Equal(t, "sold_out", getCartProductIssue(soldOut, false))
+
+	//Test 5: Closed pre-order can no longer be ordered
+	assert.Equal(t, "unavailable", getCartProductIssue(cartProduct, true))
+}
+
+func TestAddCartProduct(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerIds, productIds := createDummyData(t, db)
+
+	cartId, resErr := GetBuyerCartId(db, buyerIds[0])
+	assert.Empty(t, resErr)
+
+	//Test 1: Buyer cart is only created once
+	sameCartId, resErr := GetBuyerCartId(db, buyerIds[0])
+	assert.Empty(t, resErr)
+	assert.Equal(t, cartId, sameCartId)
+
+	//Test 2: Product is added to the cart
+	res, resErr := AddCartProduct(db, cartId, data.AddCartProductRequestData{ProductId: productIds[0], Quantity: 2})
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(res.Products))
+	assert.Equal(t, 20000, res.Subtotal)
+	assert.Equal(t, true, res.Products[0].Available)
+
+	//Test 3: Adding more than the stock
+	_, resErr = AddCartProduct(db, cartId, data.AddCartProductRequestData{ProductId: productIds[0], Quantity: 2})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad quantity data", resErr.Error())
+
+	//Test 4: Auctions cannot be added
+	_, resErr = AddCartProduct(db, cartId, data.AddCartProductRequestData{ProductId: productIds[1], Quantity: 1})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad product_id data", resErr.Error())
+
+	//Test 5: Price change is shown in the cart
+	_, execErr := db.ExecContext(context.Background(), `UPDATE products SET price = 9000 WHERE product_id = $1;`, productIds[0])
+	assert.NoError(t, execErr)
+	res, resErr = GetCart(db, cartId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, "price_changed", res.Products[0].Issue)
+	assert.Equal(t, false, res.Products[0].Available)
+	assert.Equal(t, 18000, res.Subtotal)
+
+	//Test 6: Buyer does not exist
+	_, resErr = GetBuyerCartId(db, productIds[0])
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func TestUpdateCartProduct(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerIds, productIds := createDummyData(t, db)
+
+	cartId, resErr := GetBuyerCartId(db, buyerIds[0])
+	assert.Empty(t, resErr)
+	_, resErr = AddCartProduct(db, cartId, data.AddCartProductRequestData{ProductId: productIds[0], Quantity: 1})
+	assert.Empty(t, resErr)
+
+	//Test 1: Quantity is changed
+	res, resErr := UpdateCartProduct(db, cartId, productIds[0], data.UpdateCartProductRequestData{Quantity: 3})
+	assert.Empty(t, resErr)
+	assert.Equal(t, 3, res.Products[0].Quantity)
+
+	//Test 2: Quantity above the stock
+	_, resErr = UpdateCartProduct(db, cartId, productIds[0], data.UpdateCartProductRequestData{Quantity: 4})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad quantity data", resErr.Error())
+
+	//Test 3: Product is removed
+	res, resErr = RemoveCartProduct(db, cartId, productIds[0])
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(res.Products))
+
+	//Test 4: Product is not in the cart
+	_, resErr = RemoveCartProduct(db, cartId, productIds[0])
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func TestMergeGuestCart(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerIds, productIds := createDummyData(t, db)
+
+	guestCart, resErr := CreateGuestCart(db)
+	assert.Empty(t, resErr)
+	assert.NotEmpty(t, guestCart.CartToken)
+	_, resErr = AddCartProduct(db, guestCart.CartId, data.AddCartProductRequestData{ProductId: productIds[0], Quantity: 1})
+	assert.Empty(t, resErr)
+
+	cartId, resErr := GetBuyerCartId(db, buyerIds[0])
+	assert.Empty(t, resErr)
+	_, resErr = AddCartProduct(db, cartId, data.AddCartProductRequestData{ProductId: productIds[0], Quantity: 1})
+	assert.Empty(t, resErr)
+
+	//Test 1: Quantities of the same product are added together
+	res, resErr := MergeGuestCart(db, buyerIds[0], guestCart.CartToken)
+	assert.Empty(t, resErr)
+	assert.Equal(t, cartId, res.CartId)
+	assert.Equal(t, 2, res.Products[0].Quantity)
+
+	//Test 2: Guest cart no longer exists after the merge
+	_, resErr = GetGuestCartId(db, guestCart.CartToken)
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func TestCheckoutBuyerCart(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerIds, productIds := createDummyData(t, db)
+	request := data.CheckoutCartRequestData{PhoneNumber: "91234567", AddressLine1: "Test", PostalCode: "123456",
+		Fees: data.OrderFees{PaymentType: "paynow_online", DeliveryType: "self_collection", TotalPaid: 10000}}
+
+	//Test 1: Empty cart cannot be checked out
+	_, resErr := CheckoutBuyerCart(db, buyerIds[0], request)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Cart is empty", resErr.Error())
+
+	//Test 2: Sold out products block the checkout
+	cartId, resErr := GetBuyerCartId(db, buyerIds[0])
+	assert.Empty(t, resErr)
+	_, resErr = AddCartProduct(db, cartId, data.AddCartProductRequestData{ProductId: productIds[0], Quantity: 1})
+	assert.Empty(t, resErr)
+	_, execErr := db.ExecContext(context.Background(), `UPDATE products SET sold_quantity = 3 WHERE product_id = $1;`, productIds[0])
+	assert.NoError(t, execErr)
+
+	_, resErr = CheckoutBuyerCart(db, buyerIds[0], request)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Cart has products that are no longer available", resErr.Error())
+
+	//Test 3: Products with a changed price block the checkout until they are saved again
+	_, execErr = db.ExecContext(context.Background(), `UPDATE products SET sold_quantity = 0, price = 9000 WHERE product_id = $1;`, productIds[0])
+	assert.NoError(t, execErr)
+
+	_, resErr = CheckoutBuyerCart(db, buyerIds[0], request)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Cart has products whose price has changed", resErr.Error())
+
+	res, resErr := UpdateCartProduct(db, cartId, productIds[0], data.UpdateCartProductRequestData{Quantity: 1})
+	assert.Empty(t, resErr)
+	assert.Empty(t, res.Products[0].Issue)
+	assert.Equal(t, true, res.Products[0].Available)
+
+	store.CloseDB(db)
+}
+
+func createDummyData(t *testing.T, db *sql.DB) ([]string, []string) {
+	var sellerId string
+	var buyerIds, productIds []string
+
+	query := `INSERT INTO sellers(email, seller_name, password) VALUES ('test@aucto.io','test','test') RETURNING seller_id`
+	err := db.QueryRowContext(context.Background(), query).Scan(&sellerId)
+	assert.NoError(t, err)
+
+	emails := []string{"test@aucto.io", "test2@aucto.io"}
+	for i := 0; i < len(emails); i++ {
+		var buyerId string
+		query = `INSERT INTO buyers(email, password) VALUES ($1,'test') RETURNING buyer_id;`
+		err = db.QueryRowContext(context.Background(), query, emails[i]).Scan(&buyerId)
+		assert.NoError(t, err)
+		buyerIds = append(buyerIds, buyerId)
+	}
+
+	productTypes := []string{"Buy-Now", "Auction"}
+	for i := 0; i < len(productTypes); i++ {
+		var productId string
+		query = `INSERT INTO products(
+			title, seller_id, description, product_type, language, expansion, posted_date, price, condition, product_quantity)
+			VALUES ('Test', $1, 'This is a test description', $2, 'Eng', 'Test', $3, 10000, 5, 3) RETURNING product_id;`
+		err = db.QueryRowContext(context.Background(), query, sellerId, productTypes[i], time.Now()).Scan(&productId)
+		assert.NoError(t, err)
+		productIds = append(productIds, productId)
+	}
+
+	query = `INSERT INTO auction_information(product_id, start_price, starts_at, ends_at, extension_seconds)
+		VALUES ($1, 10000, NOW(), NOW() + INTERVAL '1 day', 0);`
+	_, err = db.ExecContext(context.Background(), query, productIds[1])
+	assert.NoError(t, err)
+
+	return buyerIds, productIds
+}
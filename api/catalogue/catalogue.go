@@ -0,0 +1,263 @@
+package catalogue
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+)
+
+/*
+Gets all the games in the catalogue
+*/
+func GetGames(db *sql.DB) ([]data.GameData, *utils.ErrorHandler) {
+	response := []data.GameData{}
+
+	query := `SELECT game_id, code, name FROM games ORDER BY name ASC;`
+	rows, err := db.QueryContext(context.Background(), query)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting game rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var game data.GameData
+		err = rows.Scan(&game.GameId, &game.Code, &game.Name)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting game rows")
+			return response, errResp
+		}
+
+		response = append(response, game)
+	}
+
+	return response, nil
+}
+
+/*
+Creates a game in the catalogue. If a game with the same code already exists returns a 400 bad request.
+*/
+func CreateGame(db *sql.DB, request data.CreateGameData) (data.GameData, *utils.ErrorHandler) {
+	var response data.GameData
+
+	if DoesGameExist(db, request.Code) {
+		return response, utils.BadRequestError("Game code is already in use")
+	}
+
+	query := `INSERT INTO games(code, name) VALUES ($1,$2) RETURNING game_id, code, name;`
+	err := db.QueryRowContext(context.Background(), query, request.Code, request.Name).Scan(
+		&response.GameId, &response.Code, &response.Name)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting game rows")
+		return response, errResp
+	}
+
+	return response, nil
+}
+
+/*
+Updates the name of a game. If the game does not exist returns a 404 not found.
+*/
+func UpdateGame(db *sql.DB, code string, request data.UpdateGameData) (data.GameData, *utils.ErrorHandler) {
+	var response data.GameData
+
+	if !DoesGameExist(db, code) {
+		return response, utils.NotFoundError("Game with given code does not exist")
+	}
+
+	query := `UPDATE games SET name = $2 WHERE code = $1 RETURNING game_id, code, name;`
+	err := db.QueryRowContext(context.Background(), query, code, request.Name).Scan(
+		&response.GameId, &response.Code, &response.Name)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating game rows")
+		return response, errResp
+	}
+
+	return response, nil
+}
+
+/*
+Deletes a game from the catalogue. Games that still have expansions cannot be deleted.
+*/
+func DeleteGame(db *sql.DB, code string) *utils.ErrorHandler {
+	if !DoesGameExist(db, code) {
+		return utils.NotFoundError("Game with given code does not exist")
+	}
+
+	var hasExpansions bool
+	query := `SELECT EXISTS(SELECT * FROM expansions INNER JOIN games ON games.game_id = expansions.game_id WHERE games.code = $1);`
+	err := db.QueryRowContext(context.Background(), query, code).Scan(&hasExpansions)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting expansion rows")
+		return errResp
+	}
+
+	if hasExpansions {
+		return utils.BadRequestError("Game still has expansions")
+	}
+
+	query = `DELETE FROM games WHERE code = $1;`
+	_, err = db.ExecContext(context.Background(), query, code)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in deleting game rows")
+		return errResp
+	}
+
+	return nil
+}
+
+/*
+Gets all the languages in the catalogue
+*/
+func GetLanguages(db *sql.DB) ([]data.LanguageData, *utils.ErrorHandler) {
+	response := []data.LanguageData{}
+
+	query := `SELECT language_code, name FROM languages ORDER BY language_code ASC;`
+	rows, err := db.QueryContext(context.Background(), query)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting language rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var language data.LanguageData
+		err = rows.Scan(&language.Code, &language.Name)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting language rows")
+			return response, errResp
+		}
+
+		response = append(response, language)
+	}
+
+	return response, nil
+}
+
+/*
+Creates a language in the catalogue. If a language with the same code already exists returns a 400 bad request.
+*/
+func CreateLanguage(db *sql.DB, request data.LanguageData) (data.LanguageData, *utils.ErrorHandler) {
+	var response data.LanguageData
+
+	if DoesLanguageExist(db, request.Code) {
+		return response, utils.BadRequestError("Language code is already in use")
+	}
+
+	query := `INSERT INTO languages(language_code, name) VALUES ($1,$2) RETURNING language_code, name;`
+	err := db.QueryRowContext(context.Background(), query, request.Code, request.Name).Scan(&response.Code, &response.Name)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting language rows")
+		return response, errResp
+	}
+
+	return response, nil
+}
+
+/*
+Updates the name of a language. If the language does not exist returns a 404 not found.
+*/
+func UpdateLanguage(db *sql.DB, code string, request data.UpdateLanguageData) (data.LanguageData, *utils.ErrorHandler) {
+	var response data.LanguageData
+
+	if !DoesLanguageExist(db, code) {
+		return response, utils.NotFoundError("Language with given code does not exist")
+	}
+
+	query := `UPDATE languages SET name = $2 WHERE language_code = $1 RETURNING language_code, name;`
+	err := db.QueryRowContext(context.Background(), query, code, request.Name).Scan(&response.Code, &response.Name)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating language rows")
+		return response, errResp
+	}
+
+	return response, nil
+}
+
+/*
+Deletes a language from the catalogue. Languages that are used by products or expansions cannot be deleted.
+*/
+func DeleteLanguage(db *sql.DB, code string) *utils.ErrorHandler {
+	if !DoesLanguageExist(db, code) {
+		return utils.NotFoundError("Language with given code does not exist")
+	}
+
+	var isUsed bool
+	query := `SELECT EXISTS(SELECT * FROM products WHERE language = $1) 
+		OR EXISTS(SELECT * FROM expansion_languages WHERE language_code = $1);`
+	err := db.QueryRowContext(context.Background(), query, code).Scan(&isUsed)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting language rows")
+		return errResp
+	}
+
+	if isUsed {
+		return utils.BadRequestError("Language is used by products or expansions")
+	}
+
+	query = `DELETE FROM languages WHERE language_code = $1;`
+	_, err = db.ExecContext(context.Background(), query, code)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in deleting language rows")
+		return errResp
+	}
+
+	return nil
+}
+
+/*
+Checks wether a game with the given code exists in the catalogue
+*/
+func DoesGameExist(db *sql.DB, code string) bool {
+	var gameExists bool
+	query := `SELECT EXISTS(SELECT * FROM games WHERE code = $1);`
+	err := db.QueryRowContext(context.Background(), query, code).Scan(&gameExists)
+
+	if err != nil {
+		return false
+	}
+
+	return gameExists
+}
+
+/*
+Checks wether a language with the given code exists in the catalogue
+*/
+func DoesLanguageExist(db *sql.DB, code string) bool {
+	var languageExists bool
+	query := `SELECT EXISTS(SELECT * FROM languages WHERE language_code = $1);`
+	err := db.QueryRowContext(context.Background(), query, code).Scan(&languageExists)
+
+	if err != nil {
+		return false
+	}
+
+	return languageExists
+}
@@ -0,0 +1,103 @@
+package catalogue
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGameCatalogue(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	//Test 1: Game is created
+	game, resErr := CreateGame(db, data.CreateGameData{Code: "pokemon", Name: "Pokemon"})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "pokemon", game.Code)
+	assert.Equal(t, true, DoesGameExist(db, "pokemon"))
+
+	//Test 2: Game code is already in use
+	_, resErr = CreateGame(db, data.CreateGameData{Code: "pokemon", Name: "Pokemon"})
+	assert.Error(t, resErr)
+	assert.Equal(t, 400, resErr.ErrorCode())
+
+	//Test 3: Game is updated
+	game, resErr = UpdateGame(db, "pokemon", data.UpdateGameData{Name: "Pokemon TCG"})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "Pokemon TCG", game.Name)
+
+	//Test 4: Updating a game that does not exist
+	_, resErr = UpdateGame(db, "wrong", data.UpdateGameData{Name: "Wrong"})
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	//Test 5: Games are listed
+	games, resErr := GetGames(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(games))
+
+	//Test 6: Game with expansions cannot be deleted
+	createDummyExpansion(db, "pokemon", "SV01")
+	resErr = DeleteGame(db, "pokemon")
+	assert.Error(t, resErr)
+	assert.Equal(t, "Game still has expansions", resErr.Error())
+
+	//Test 7: Game is deleted
+	DeleteExpansion(db, "SV01")
+	resErr = DeleteGame(db, "pokemon")
+	assert.Empty(t, resErr)
+	assert.Equal(t, false, DoesGameExist(db, "pokemon"))
+
+	store.CloseDB(db)
+}
+
+func TestLanguageCatalogue(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	//Test 1: Existing languages are in the catalogue
+	assert.Equal(t, true, DoesLanguageExist(db, "Eng"))
+	assert.Equal(t, true, DoesLanguageExist(db, "Jap"))
+	assert.Equal(t, false, DoesLanguageExist(db, "Wrong"))
+
+	//Test 2: Language is created
+	language, resErr := CreateLanguage(db, data.LanguageData{Code: "Kor", Name: "Korean"})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "Kor", language.Code)
+
+	//Test 3: Language code is already in use
+	_, resErr = CreateLanguage(db, data.LanguageData{Code: "Kor", Name: "Korean"})
+	assert.Error(t, resErr)
+	assert.Equal(t, 400, resErr.ErrorCode())
+
+	//Test 4: Language is updated
+	language, resErr = UpdateLanguage(db, "Kor", data.UpdateLanguageData{Name: "Korean (Hangul)"})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "Korean (Hangul)", language.Name)
+
+	//Test 5: Language used by an expansion cannot be deleted
+	CreateGame(db, data.CreateGameData{Code: "pokemon", Name: "Pokemon"})
+	CreateExpansion(db, data.CreateExpansionData{GameCode: "pokemon", Code: "SV01", Name: "Scarlet & Violet", Languages: []string{"Kor"}})
+	resErr = DeleteLanguage(db, "Kor")
+	assert.Error(t, resErr)
+	assert.Equal(t, "Language is used by products or expansions", resErr.Error())
+
+	//Test 6: Language is deleted
+	DeleteExpansion(db, "SV01")
+	resErr = DeleteLanguage(db, "Kor")
+	assert.Empty(t, resErr)
+	assert.Equal(t, false, DoesLanguageExist(db, "Kor"))
+
+	store.CloseDB(db)
+}
+
+func createDummyExpansion(db *sql.DB, gameCode string, code string) error {
+	query := `INSERT INTO expansions(game_id, code, name) SELECT game_id, $2, $2 FROM games WHERE code = $1;`
+	_, err := db.ExecContext(context.Background(), query, gameCode, code)
+	return err
+}
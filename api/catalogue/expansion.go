@@ -0,0 +1,337 @@
+package catalogue
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+/*
+Gets the expansions in the catalogue, optionally only the expansions of a game or the expansions released in a language.
+The newest expansions are returned first.
+*/
+func GetExpansions(db *sql.DB, request data.GetExpansionsRequestData) ([]data.ExpansionData, *utils.ErrorHandler) {
+	response := []data.ExpansionData{}
+
+	query := `SELECT expansions.expansion_id, games.code, expansions.code, expansions.name, 
+			COALESCE(expansions.release_date::TEXT, ''),
+			COALESCE(array_agg(expansion_languages.language_code ORDER BY expansion_languages.language_code) 
+				FILTER (WHERE expansion_languages.language_code IS NOT NULL), '{}')
+		FROM (expansions INNER JOIN games ON games.game_id = expansions.game_id)
+			LEFT OUTER JOIN expansion_languages ON expansion_languages.expansion_id = expansions.expansion_id
+		WHERE ($1 = '' OR games.code = $1) 
+			AND ($2 = '' OR EXISTS(SELECT * FROM expansion_languages AS languages 
+				WHERE languages.expansion_id = expansions.expansion_id AND languages.language_code = $2))
+		GROUP BY expansions.expansion_id, games.code
+		ORDER BY expansions.release_date DESC NULLS LAST, expansions.name ASC;`
+
+	rows, err := db.QueryContext(context.Background(), query, request.GameCode, request.Language)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting expansion rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var expansion data.ExpansionData
+		err = rows.Scan(&expansion.ExpansionId, &expansion.GameCode, &expansion.Code, &expansion.Name,
+			&expansion.ReleaseDate, pq.Array(&expansion.Languages))
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting expansion rows")
+			return response, errResp
+		}
+
+		response = append(response, expansion)
+	}
+
+	return response, nil
+}
+
+/*
+Gets a single expansion by its code. If the expansion does not exist returns a 404 not found.
+*/
+func GetExpansion(db *sql.DB, code string) (data.ExpansionData, *utils.ErrorHandler) {
+	var response data.ExpansionData
+
+	query := `SELECT expansions.expansion_id, games.code, expansions.code, expansions.name, 
+			COALESCE(expansions.release_date::TEXT, ''),
+			COALESCE(array_agg(expansion_languages.language_code ORDER BY expansion_languages.language_code) 
+				FILTER (WHERE expansion_languages.language_code IS NOT NULL), '{}')
+		FROM (expansions INNER JOIN games ON games.game_id = expansions.game_id)
+			LEFT OUTER JOIN expansion_languages ON expansion_languages.expansion_id = expansions.expansion_id
+		WHERE expansions.code = $1
+		GROUP BY expansions.expansion_id, games.code;`
+
+	err := db.QueryRowContext(context.Background(), query, code).Scan(&response.ExpansionId, &response.GameCode,
+		&response.Code, &response.Name, &response.ReleaseDate, pq.Array(&response.Languages))
+
+	if err == sql.ErrNoRows {
+		return response, utils.NotFoundError("Expansion with given code does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting expansion rows")
+		return response, errResp
+	}
+
+	return response, nil
+}
+
+/*
+Creates an expansion in the catalogue along with the languages it was released in
+*/
+func CreateExpansion(db *sql.DB, request data.CreateExpansionData) (data.ExpansionData, *utils.ErrorHandler) {
+	var response data.ExpansionData
+
+	if DoesExpansionExist(db, request.Code) {
+		return response, utils.BadRequestError("Expansion code is already in use")
+	}
+
+	validateErr := validateExpansion(db, request.GameCode, request.ReleaseDate, request.Languages)
+
+	if validateErr != nil {
+		return response, validateErr
+	}
+
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting expansion transaction")
+		return response, errResp
+	}
+
+	defer tx.Rollback()
+
+	var expansionId string
+	query := `INSERT INTO expansions(game_id, code, name, release_date) 
+		SELECT game_id, $2, $3, NULLIF($4, '')::DATE FROM games WHERE code = $1 
+		RETURNING expansion_id;`
+	err = tx.QueryRowContext(context.Background(), query,
+		request.GameCode, request.Code, request.Name, request.ReleaseDate).Scan(&expansionId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting expansion rows")
+		return response, errResp
+	}
+
+	insertErr := insertExpansionLanguages(tx, expansionId, request.Languages)
+
+	if insertErr != nil {
+		return response, insertErr
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing expansion transaction")
+		return response, errResp
+	}
+
+	return GetExpansion(db, request.Code)
+}
+
+/*
+Updates an expansion and replaces the languages it was released in. Languages that are still used by
+products of the expansion cannot be removed.
+*/
+func UpdateExpansion(db *sql.DB, code string, request data.UpdateExpansionData) (data.ExpansionData, *utils.ErrorHandler) {
+	var response data.ExpansionData
+
+	existing, getErr := GetExpansion(db, code)
+
+	if getErr != nil {
+		return response, getErr
+	}
+
+	validateErr := validateExpansion(db, request.GameCode, request.ReleaseDate, request.Languages)
+
+	if validateErr != nil {
+		return response, validateErr
+	}
+
+	var languageInUse bool
+	query := `SELECT EXISTS(SELECT * FROM products WHERE expansion = $1 AND NOT (language = ANY($2)));`
+	err := db.QueryRowContext(context.Background(), query, code, pq.Array(request.Languages)).Scan(&languageInUse)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting product rows")
+		return response, errResp
+	}
+
+	if languageInUse {
+		return response, utils.BadRequestError("Removed language is used by products of the expansion")
+	}
+
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting expansion transaction")
+		return response, errResp
+	}
+
+	defer tx.Rollback()
+
+	query = `UPDATE expansions SET game_id = games.game_id, name = $3, release_date = NULLIF($4, '')::DATE 
+		FROM games WHERE games.code = $2 AND expansions.code = $1;`
+	_, err = tx.ExecContext(context.Background(), query, code, request.GameCode, request.Name, request.ReleaseDate)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating expansion rows")
+		return response, errResp
+	}
+
+	query = `DELETE FROM expansion_languages WHERE expansion_id = $1;`
+	_, err = tx.ExecContext(context.Background(), query, existing.ExpansionId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in deleting expansion language rows")
+		return response, errResp
+	}
+
+	insertErr := insertExpansionLanguages(tx, existing.ExpansionId, request.Languages)
+
+	if insertErr != nil {
+		return response, insertErr
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing expansion transaction")
+		return response, errResp
+	}
+
+	return GetExpansion(db, code)
+}
+
+/*
+Deletes an expansion from the catalogue. Expansions that still have products cannot be deleted.
+*/
+func DeleteExpansion(db *sql.DB, code string) *utils.ErrorHandler {
+	if !DoesExpansionExist(db, code) {
+		return utils.NotFoundError("Expansion with given code does not exist")
+	}
+
+	var hasProducts bool
+	query := `SELECT EXISTS(SELECT * FROM products WHERE expansion = $1);`
+	err := db.QueryRowContext(context.Background(), query, code).Scan(&hasProducts)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting product rows")
+		return errResp
+	}
+
+	if hasProducts {
+		return utils.BadRequestError("Expansion still has products")
+	}
+
+	query = `DELETE FROM expansions WHERE code = $1;`
+	_, err = db.ExecContext(context.Background(), query, code)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in deleting expansion rows")
+		return errResp
+	}
+
+	return nil
+}
+
+/*
+Checks wether an expansion with the given code exists in the catalogue
+*/
+func DoesExpansionExist(db *sql.DB, code string) bool {
+	var expansionExists bool
+	query := `SELECT EXISTS(SELECT * FROM expansions WHERE code = $1);`
+	err := db.QueryRowContext(context.Background(), query, code).Scan(&expansionExists)
+
+	if err != nil {
+		return false
+	}
+
+	return expansionExists
+}
+
+/*
+Checks wether an expansion was released in the given language
+*/
+func IsExpansionInLanguage(db *sql.DB, code string, language string) bool {
+	var inLanguage bool
+	query := `SELECT EXISTS(SELECT * FROM expansions 
+		INNER JOIN expansion_languages ON expansion_languages.expansion_id = expansions.expansion_id 
+		WHERE expansions.code = $1 AND expansion_languages.language_code = $2);`
+	err := db.QueryRowContext(context.Background(), query, code, language).Scan(&inLanguage)
+
+	if err != nil {
+		return false
+	}
+
+	return inLanguage
+}
+
+/*
+Adds the languages an expansion was released in
+*/
+func insertExpansionLanguages(tx *sql.Tx, expansionId string, languages []string) *utils.ErrorHandler {
+	for i := 0; i < len(languages); i++ {
+		query := `INSERT INTO expansion_languages(expansion_id, language_code) VALUES ($1,$2) ON CONFLICT DO NOTHING;`
+		_, err := tx.ExecContext(context.Background(), query, expansionId, languages[i])
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in inserting expansion language rows")
+			return errResp
+		}
+	}
+
+	return nil
+}
+
+/*
+Validates the game, release date and languages of an expansion
+*/
+func validateExpansion(db *sql.DB, gameCode string, releaseDate string, languages []string) *utils.ErrorHandler {
+	if !DoesGameExist(db, gameCode) {
+		return utils.BadRequestError("Bad game_code data")
+	}
+
+	if releaseDate != "" {
+		_, err := time.Parse("2006-01-02", releaseDate)
+
+		if err != nil {
+			utils.LogMessage("Expansion release date is not a date")
+			return utils.BadRequestError("Bad release_date data")
+		}
+	}
+
+	if len(languages) == 0 {
+		return utils.BadRequestError("Bad languages data")
+	}
+
+	for i := 0; i < len(languages); i++ {
+		if !DoesLanguageExist(db, languages[i]) {
+			return utils.BadRequestError("Bad languages data")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package catalogue
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateExpansion(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	CreateGame(db, data.CreateGameData{Code: "pokemon", Name: "Pokemon TCG"})
+
+	//Test 1: Expansion is created
+	expansion, resErr := CreateExpansion(db, data.CreateExpansionData{
+		GameCode: "pokemon", Code: "SV03", Name: "Obsidian Flames", ReleaseDate: "2023-08-11", Languages: []string{"Jap", "Eng"}})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "pokemon", expansion.GameCode)
+	assert.Equal(t, "2023-08-11", expansion.ReleaseDate)
+	assert.Equal(t, []string{"Eng", "Jap"}, expansion.Languages)
+
+	//Test 2: Expansion code is already in use
+	_, resErr = CreateExpansion(db, data.CreateExpansionData{
+		GameCode: "pokemon", Code: "SV03", Name: "Obsidian Flames", Languages: []string{"Eng"}})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Expansion code is already in use", resErr.Error())
+
+	//Test 3: Game does not exist
+	_, resErr = CreateExpansion(db, data.CreateExpansionData{
+		GameCode: "wrong", Code: "SV04", Name: "Paradox Rift", Languages: []string{"Eng"}})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad game_code data", resErr.Error())
+
+	//Test 4: Release date is not a date
+	_, resErr = CreateExpansion(db, data.CreateExpansionData{
+		GameCode: "pokemon", Code: "SV04", Name: "Paradox Rift", ReleaseDate: "soon", Languages: []string{"Eng"}})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad release_date data", resErr.Error())
+
+	//Test 5: Language does not exist
+	_, resErr = CreateExpansion(db, data.CreateExpansionData{
+		GameCode: "pokemon", Code: "SV04", Name: "Paradox Rift", Languages: []string{"Wrong"}})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad languages data", resErr.Error())
+
+	//Test 6: Expansion availability by language
+	assert.Equal(t, true, IsExpansionInLanguage(db, "SV03", "Jap"))
+	assert.Equal(t, false, IsExpansionInLanguage(db, "SV03", "Wrong"))
+
+	store.CloseDB(db)
+}
+
+func TestGetExpansions(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	CreateGame(db, data.CreateGameData{Code: "pokemon", Name: "Pokemon TCG"})
+	CreateGame(db, data.CreateGameData{Code: "onepiece", Name: "One Piece Card Game"})
+	CreateExpansion(db, data.CreateExpansionData{
+		GameCode: "pokemon", Code: "SV03", Name: "Obsidian Flames", ReleaseDate: "2023-08-11", Languages: []string{"Eng", "Jap"}})
+	CreateExpansion(db, data.CreateExpansionData{
+		GameCode: "pokemon", Code: "SV04", Name: "Paradox Rift", ReleaseDate: "2023-11-03", Languages: []string{"Eng"}})
+	CreateExpansion(db, data.CreateExpansionData{
+		GameCode: "onepiece", Code: "OP01", Name: "Romance Dawn", Languages: []string{"Jap"}})
+
+	//Test 1: All expansions, newest first
+	expansions, resErr := GetExpansions(db, data.GetExpansionsRequestData{})
+	assert.Empty(t, resErr)
+	assert.Equal(t, 3, len(expansions))
+	assert.Equal(t, "SV04", expansions[0].Code)
+
+	//Test 2: Expansions of a game
+	expansions, resErr = GetExpansions(db, data.GetExpansionsRequestData{GameCode: "pokemon"})
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, len(expansions))
+
+	//Test 3: Expansions in a language keep all their languages
+	expansions, resErr = GetExpansions(db, data.GetExpansionsRequestData{Language: "Jap"})
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, len(expansions))
+	assert.Equal(t, []string{"Eng", "Jap"}, expansions[0].Languages)
+
+	//Test 4: Unknown game has no expansions
+	expansions, resErr = GetExpansions(db, data.GetExpansionsRequestData{GameCode: "wrong"})
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(expansions))
+
+	store.CloseDB(db)
+}
+
+func TestUpdateAndDeleteExpansion(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	CreateGame(db, data.CreateGameData{Code: "pokemon", Name: "Pokemon TCG"})
+	CreateExpansion(db, data.CreateExpansionData{
+		GameCode: "pokemon", Code: "SV03", Name: "Obsidian Flames", Languages: []string{"Eng", "Jap"}})
+
+	//Test 1: Expansion is updated
+	expansion, resErr := UpdateExpansion(db, "SV03", data.UpdateExpansionData{
+		GameCode: "pokemon", Name: "Obsidian Flames", ReleaseDate: "2023-08-11", Languages: []string{"Eng"}})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "2023-08-11", expansion.ReleaseDate)
+	assert.Equal(t, []string{"Eng"}, expansion.Languages)
+
+	//Test 2: Updating an expansion that does not exist
+	_, resErr = UpdateExpansion(db, "wrong", data.UpdateExpansionData{
+		GameCode: "pokemon", Name: "Wrong", Languages: []string{"Eng"}})
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	//Test 3: Deleting an expansion that does not exist
+	resErr = DeleteExpansion(db, "wrong")
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	//Test 4: Expansion is deleted
+	resErr = DeleteExpansion(db, "SV03")
+	assert.Empty(t, resErr)
+	assert.Equal(t, false, DoesExpansionExist(db, "SV03"))
+
+	store.CloseDB(db)
+}
@@ -0,0 +1,209 @@
+package discount
+
+import (
+	"BackendAPI/api/product"
+	"BackendAPI/api/seller"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"time"
+)
+
+const selectDiscountQuery = `SELECT discount_id, seller_id, COALESCE(product_id::TEXT, ''), discount_type, amount,
+		starts_at::TEXT, COALESCE(ends_at::TEXT, ''),
+		CASE WHEN status = 'active' AND ends_at <= NOW() THEN 'ended' ELSE status END, created_date::TEXT
+	FROM discounts`
+
+/*
+Creates a sale for a seller. The sale applies to a single product of the seller, or to every product of the
+seller when no product is given. A sale without a start date starts immediately and a sale without an end
+date runs until it is cancelled. If there is an issue with the inputed data, it returns a 400 bad request.
+*/
+func CreateDiscount(db *sql.DB, sellerId string, request data.CreateDiscountRequestData) (data.DiscountData, *utils.ErrorHandler) {
+	var discount data.DiscountData
+
+	if !seller.DoesSellerExist(db, sellerId) {
+		return discount, utils.NotFoundError("Seller with given id does not exist")
+	}
+
+	startsAt, endsAt, err := validateCreateDiscount(db, sellerId, request)
+
+	if err != nil {
+		return discount, err
+	}
+
+	var productId interface{}
+	if request.ProductId != "" {
+		productId = request.ProductId
+	}
+
+	var discountId string
+	query := `INSERT INTO discounts(seller_id, product_id, discount_type, amount, starts_at, ends_at, created_date)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW()) RETURNING discount_id;`
+	scanErr := db.QueryRowContext(context.Background(), query, sellerId, productId, request.DiscountType,
+		request.Amount, startsAt, endsAt).Scan(&discountId)
+
+	if scanErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(scanErr, "Error in inserting Discount rows")
+		return discount, errResp
+	}
+
+	_, err = product.RecordPriceHistory(db, nil)
+
+	if err != nil {
+		return discount, err
+	}
+
+	return getDiscount(db, discountId)
+}
+
+/*
+Gets the sales of a seller, from the newest sale. Sales that have passed their end date have the status ended.
+*/
+func GetSellerDiscounts(db *sql.DB, sellerId string) (data.GetDiscountsResponseData, *utils.ErrorHandler) {
+	response := data.GetDiscountsResponseData{Discounts: []data.DiscountData{}}
+
+	if !seller.DoesSellerExist(db, sellerId) {
+		return response, utils.NotFoundError("Seller with given id does not exist")
+	}
+
+	query := selectDiscountQuery + ` WHERE seller_id = $1 ORDER BY created_date DESC;`
+	rows, err := db.QueryContext(context.Background(), query, sellerId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Discount rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var discount data.DiscountData
+		err = scanDiscount(rows, &discount)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Discount rows")
+			return response, errResp
+		}
+
+		response.Discounts = append(response.Discounts, discount)
+	}
+
+	return response, nil
+}
+
+/*
+Cancels a sale of a seller so that it no longer applies. Returns a 404 Not found error if the seller
+has no active sale with the given id.
+*/
+func CancelDiscount(db *sql.DB, sellerId string, discountId string) (data.DiscountData, *utils.ErrorHandler) {
+	discount, err := getDiscount(db, discountId)
+
+	if err != nil || discount.SellerId != sellerId {
+		return discount, utils.NotFoundError("Discount with given id does not exist")
+	}
+
+	if discount.Status != "active" {
+		return discount, utils.BadRequestError("Discount has already ended")
+	}
+
+	query := `UPDATE discounts SET status = 'cancelled' WHERE discount_id = $1;`
+	_, execErr := db.ExecContext(context.Background(), query, discountId)
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in updating Discount rows")
+		return discount, errResp
+	}
+
+	_, err = product.RecordPriceHistory(db, nil)
+
+	if err != nil {
+		return discount, err
+	}
+
+	return getDiscount(db, discountId)
+}
+
+/*
+Gets a sale by its id
+*/
+func getDiscount(db *sql.DB, discountId string) (data.DiscountData, *utils.ErrorHandler) {
+	var discount data.DiscountData
+
+	query := selectDiscountQuery + ` WHERE discount_id = $1;`
+	err := scanDiscount(db.QueryRowContext(context.Background(), query, discountId), &discount)
+
+	if err != nil {
+		utils.LogMessage("Discount with given id does not exist")
+		return discount, utils.NotFoundError("Discount with given id does not exist")
+	}
+
+	return discount, nil
+}
+
+/*
+Scans a row selected with the discount select query
+*/
+func scanDiscount(row interface{ Scan(...interface{}) error }, discount *data.DiscountData) error {
+	return row.Scan(&discount.DiscountId, &discount.SellerId, &discount.ProductId, &discount.DiscountType, &discount.Amount,
+		&discount.StartsAt, &discount.EndsAt, &discount.Status, &discount.CreatedDate)
+}
+
+/*
+Validates the sale of a seller and returns its start and end dates, the end date is nil for a sale that runs
+until it is cancelled. A percentage is between 1 and 100 and a product sale must be on a product of the seller
+that is not an auction.
+*/
+func validateCreateDiscount(db *sql.DB, sellerId string, request data.CreateDiscountRequestData) (time.Time, *time.Time, *utils.ErrorHandler) {
+	startsAt := time.Now()
+
+	if request.DiscountType != "fixed" && request.DiscountType != "percentage" {
+		utils.LogMessage("Discount type is not fixed or percentage")
+		return startsAt, nil, utils.BadRequestError("Bad discount_type data")
+	}
+
+	if request.Amount <= 0 || (request.DiscountType == "percentage" && request.Amount > 100) {
+		utils.LogMessage("Discount amount is out of range")
+		return startsAt, nil, utils.BadRequestError("Bad amount data")
+	}
+
+	if request.ProductId != "" {
+		var productSellerId, productType string
+		query := `SELECT seller_id, product_type FROM products WHERE product_id = $1;`
+		err := db.QueryRowContext(context.Background(), query, request.ProductId).Scan(&productSellerId, &productType)
+
+		if err != nil || productSellerId != sellerId || productType == "Auction" {
+			utils.LogMessage("Discounted product does not exist, is not the seller's or is an auction")
+			return startsAt, nil, utils.BadRequestError("Bad product_id data")
+		}
+	}
+
+	if request.StartsAt != "" {
+		parsed, err := time.Parse(time.RFC3339, request.StartsAt)
+
+		if err != nil {
+			utils.LogMessage("Discount start date is not in RFC3339 format")
+			return startsAt, nil, utils.BadRequestError("Bad starts_at data")
+		}
+
+		startsAt = parsed
+	}
+
+	if request.EndsAt == "" {
+		return startsAt, nil, nil
+	}
+
+	endsAt, err := time.Parse(time.RFC3339, request.EndsAt)
+
+	if err != nil || !endsAt.After(startsAt) || !endsAt.After(time.Now()) {
+		utils.LogMessage("Discount end date is invalid, in the past or before the start date")
+		return startsAt, nil, utils.BadRequestError("Bad ends_at data")
+	}
+
+	return startsAt, &endsAt, nil
+}
@@ -0,0 +1,142 @@
+package discount
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDiscount(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerIds, productIds := createDummyData(t, db)
+	endsAt := time.Now().Add(24 * time.Hour).Format(time.RFC3339)
+
+	//Test 1: Valid percentage sale on a product
+	res, resErr := CreateDiscount(db, sellerIds[0], data.CreateDiscountRequestData{ProductId: productIds[0],
+		DiscountType: "percentage", Amount: 20, EndsAt: endsAt})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "active", res.Status)
+	assert.Equal(t, productIds[0], res.ProductId)
+
+	assert.Equal(t, 2000, getEffectiveDiscount(t, db, productIds[0]))
+
+	//Test 2: Valid storewide sale starting in the future does not apply yet
+	res, resErr = CreateDiscount(db, sellerIds[0], data.CreateDiscountRequestData{DiscountType: "fixed", Amount: 5000,
+		StartsAt: time.Now().Add(time.Hour).Format(time.RFC3339)})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "", res.ProductId)
+
+	assert.Equal(t, 2000, getEffectiveDiscount(t, db, productIds[0]))
+
+	//Test 3: Percentage above 100
+	_, resErr = CreateDiscount(db, sellerIds[0], data.CreateDiscountRequestData{DiscountType: "percentage", Amount: 101})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad amount data", resErr.Error())
+
+	//Test 4: Unknown discount type
+	_, resErr = CreateDiscount(db, sellerIds[0], data.CreateDiscountRequestData{DiscountType: "bogo", Amount: 10})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad discount_type data", resErr.Error())
+
+	//Test 5: Product of another seller
+	_, resErr = CreateDiscount(db, sellerIds[1], data.CreateDiscountRequestData{ProductId: productIds[0],
+		DiscountType: "fixed", Amount: 10})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad product_id data", resErr.Error())
+
+	//Test 6: Auctions cannot be discounted
+	_, resErr = CreateDiscount(db, sellerIds[0], data.CreateDiscountRequestData{ProductId: productIds[1],
+		DiscountType: "fixed", Amount: 10})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad product_id data", resErr.Error())
+
+	//Test 7: End date in the past
+	_, resErr = CreateDiscount(db, sellerIds[0], data.CreateDiscountRequestData{DiscountType: "fixed", Amount: 10,
+		EndsAt: time.Now().Add(-time.Hour).Format(time.RFC3339)})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad ends_at data", resErr.Error())
+
+	//Test 8: Seller does not exist
+	_, resErr = CreateDiscount(db, productIds[0], data.CreateDiscountRequestData{DiscountType: "fixed", Amount: 10})
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func TestCancelDiscount(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerIds, productIds := createDummyData(t, db)
+
+	discount, resErr := CreateDiscount(db, sellerIds[0], data.CreateDiscountRequestData{DiscountType: "fixed", Amount: 3000})
+	assert.Empty(t, resErr)
+
+	//Test 1: Another seller cannot cancel the sale
+	_, resErr = CancelDiscount(db, sellerIds[1], discount.DiscountId)
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	//Test 2: Cancelled sale no longer applies
+	res, resErr := CancelDiscount(db, sellerIds[0], discount.DiscountId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, "cancelled", res.Status)
+
+	assert.Equal(t, 0, getEffectiveDiscount(t, db, productIds[0]))
+
+	//Test 3: Sale cannot be cancelled twice
+	_, resErr = CancelDiscount(db, sellerIds[0], discount.DiscountId)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Discount has already ended", resErr.Error())
+
+	//Test 4: Sales of the seller are listed
+	list, resErr := GetSellerDiscounts(db, sellerIds[0])
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(list.Discounts))
+
+	store.CloseDB(db)
+}
+
+func createDummyData(t *testing.T, db *sql.DB) ([]string, []string) {
+	var sellerIds, productIds []string
+
+	names := []string{"test", "test2"}
+	for i := 0; i < len(names); i++ {
+		var sellerId string
+		query := `INSERT INTO sellers(email, seller_name, password) VALUES ($1, $2, 'test') RETURNING seller_id`
+		err := db.QueryRowContext(context.Background(), query, names[i]+"@aucto.io", names[i]).Scan(&sellerId)
+		assert.NoError(t, err)
+		sellerIds = append(sellerIds, sellerId)
+	}
+
+	productTypes := []string{"Buy-Now", "Auction"}
+	for i := 0; i < len(productTypes); i++ {
+		var productId string
+		query := `INSERT INTO products(
+			title, seller_id, description, product_type, language, expansion, posted_date, price, condition, product_quantity)
+			VALUES ('Test', $1, 'This is a test description', $2, 'Eng', 'Test', $3, 10000, 5, 1) RETURNING product_id;`
+		err := db.QueryRowContext(context.Background(), query, sellerIds[0], productTypes[i], time.Now()).Scan(&productId)
+		assert.NoError(t, err)
+		productIds = append(productIds, productId)
+	}
+
+	return sellerIds, productIds
+}
+
+func getEffectiveDiscount(t *testing.T, db *sql.DB, productId string) int {
+	var discount int
+	query := `SELECT discount FROM product_effective_discounts WHERE product_id = $1;`
+	err := db.QueryRowContext(context.Background(), query, productId).Scan(&discount)
+	assert.NoError(t, err)
+
+	return discount
+}
@@ -0,0 +1,236 @@
+package follow
+
+import (
+	"BackendAPI/api/buyer"
+	"BackendAPI/api/outbox"
+	"BackendAPI/api/product"
+	"BackendAPI/data"
+	"BackendAPI/mailing"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"time"
+)
+
+const maxFollowDigestListings = 20
+
+/*
+Gets the most recent listings of the sellers a buyer follows, from the newest listing. If there are more listings,
+next_cursor can be passed as the cursor to get the next page.
+*/
+func GetBuyerFeed(db *sql.DB, buyerId string, cursor string, limit int) (data.GetBuyerFeedResponseData, *utils.ErrorHandler) {
+	response := data.GetBuyerFeedResponseData{BuyerId: buyerId, Products: []data.GetProductResponseData{}}
+
+	if !buyer.DoesBuyerExist(db, buyerId) {
+		return response, utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	sellerIds, sellerErr := getFollowedSellerIds(db, buyerId)
+
+	if sellerErr != nil || len(sellerIds) == 0 {
+		return response, sellerErr
+	}
+
+	products, productErr := product.GetProductList(db, data.GetProductListRequestData{
+		SortBy:    "None",
+		SellerIds: sellerIds,
+		Cursor:    cursor,
+		Limit:     limit})
+
+	if productErr != nil {
+		return response, productErr
+	}
+
+	response.Products = append(response.Products, products.Products...)
+	response.NextCursor = products.NextCursor
+
+	return response, nil
+}
+
+/*
+Sends every buyer who has turned on the digest a mail of the listings of the sellers they follow since their last
+digest, at most once a day. The mail is added to the outbox along with the date of the digest, so a mail that fails
+to send is retried by the outbox rather than lost or sent twice. Returns the buyers that were sent a digest.
+*/
+func SendFollowDigests(db *sql.DB) (data.SendFollowDigestsResponseData, *utils.ErrorHandler) {
+	response := data.SendFollowDigestsResponseData{Sent: []string{}}
+	digestDate := time.Now()
+
+	query := `SELECT buyer_id, email, locale, COALESCE(follow_digest_sent_date, $1::TIMESTAMPTZ - INTERVAL '1 day')
+		FROM buyers
+		WHERE follow_digest = TRUE AND (follow_digest_sent_date IS NULL OR follow_digest_sent_date <= $1::TIMESTAMPTZ - INTERVAL '1 day');`
+	rows, err := db.QueryContext(context.Background(), query, digestDate)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Buyer rows")
+		return response, errResp
+	}
+
+	var buyerIds, emails, locales []string
+	var sinceDates []time.Time
+
+	for rows.Next() {
+		var buyerId, email, locale string
+		var since time.Time
+		err = rows.Scan(&buyerId, &email, &locale, &since)
+
+		if err != nil {
+			rows.Close()
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Buyer rows")
+			return response, errResp
+		}
+
+		buyerIds = append(buyerIds, buyerId)
+		emails = append(emails, email)
+		locales = append(locales, locale)
+		sinceDates = append(sinceDates, since)
+	}
+
+	rows.Close()
+
+	for i := 0; i < len(buyerIds); i++ {
+		titles, titleErr := getFollowDigestTitles(db, buyerIds[i], sinceDates[i], digestDate)
+
+		if titleErr != nil {
+			return response, titleErr
+		}
+
+		isSent, digestErr := addFollowDigest(db, buyerIds[i], emails[i], locales[i], titles, digestDate)
+
+		if digestErr != nil {
+			return response, digestErr
+		}
+
+		if isSent {
+			response.Sent = append(response.Sent, buyerIds[i])
+		}
+	}
+
+	return response, nil
+}
+
+/*
+Adds the digest mail of a buyer to the outbox, if there are new listings, and records the date of the digest in the
+same transaction. Returns whether a mail was added.
+*/
+func addFollowDigest(db *sql.DB, buyerId string, email string, locale string, titles []string, digestDate time.Time) (bool, *utils.ErrorHandler) {
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Buyer transaction")
+		return false, errResp
+	}
+
+	defer tx.Rollback()
+
+	var isSent bool
+
+	if len(titles) > 0 {
+		message, composeErr := mailing.ComposeFollowDigestMail(email, locale, titles)
+
+		if composeErr != nil {
+			utils.LogError(composeErr, "Error in composing follow digest mail for buyer "+buyerId)
+			return false, nil
+		}
+
+		_, outboxErr := outbox.AddMail(tx, message)
+
+		if outboxErr != nil {
+			return false, outboxErr
+		}
+
+		isSent = true
+	}
+
+	query := `UPDATE buyers SET follow_digest_sent_date = $2 WHERE buyer_id = $1;`
+	_, err = tx.ExecContext(context.Background(), query, buyerId, digestDate)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Buyer rows")
+		return false, errResp
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Buyer transaction")
+		return false, errResp
+	}
+
+	return isSent, nil
+}
+
+/*
+Gets the titles of the listings of the sellers a buyer follows that were posted in a period, from the newest listing
+*/
+func getFollowDigestTitles(db *sql.DB, buyerId string, since time.Time, until time.Time) ([]string, *utils.ErrorHandler) {
+	var titles []string
+
+	query := `SELECT products.title
+		FROM products INNER JOIN seller_follows ON seller_follows.seller_id = products.seller_id
+		WHERE seller_follows.buyer_id = $1 AND products.posted_date > $2 AND products.posted_date <= $3
+		ORDER BY products.posted_date DESC
+		LIMIT $4;`
+	rows, err := db.QueryContext(context.Background(), query, buyerId, since, until, maxFollowDigestListings)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Product rows")
+		return titles, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var title string
+		err = rows.Scan(&title)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Product rows")
+			return titles, errResp
+		}
+
+		titles = append(titles, title)
+	}
+
+	return titles, nil
+}
+
+/*
+Gets the ids of the sellers a buyer follows
+*/
+func getFollowedSellerIds(db *sql.DB, buyerId string) ([]string, *utils.ErrorHandler) {
+	var sellerIds []string
+
+	query := `SELECT seller_id FROM seller_follows WHERE buyer_id = $1;`
+	rows, err := db.QueryContext(context.Background(), query, buyerId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Seller Follow rows")
+		return sellerIds, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var sellerId string
+		err = rows.Scan(&sellerId)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Seller Follow rows")
+			return sellerIds, errResp
+		}
+
+		sellerIds = append(sellerIds, sellerId)
+	}
+
+	return sellerIds, nil
+}
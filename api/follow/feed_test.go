@@ -0,0 +1,97 @@
+package follow
+
+import (
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBuyerFeed(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerIds, sellerIds := createDummyFollowData(t, db)
+	followedProductId := createDummyListing(t, db, sellerIds[0], "Followed", time.Now())
+	createDummyListing(t, db, sellerIds[1], "Not followed", time.Now())
+
+	//Test 1: Feed is empty without follows
+	res, resErr := GetBuyerFeed(db, buyerIds[0], "", 0)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(res.Products))
+
+	//Test 2: Feed only has listings of followed sellers
+	FollowSeller(db, buyerIds[0], sellerIds[0])
+	res, resErr = GetBuyerFeed(db, buyerIds[0], "", 0)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(res.Products))
+	assert.Equal(t, followedProductId, res.Products[0].ProductId)
+
+	//Test 3: Buyer does not exist
+	_, resErr = GetBuyerFeed(db, "wrong id", "", 0)
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func TestSendFollowDigests(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerIds, sellerIds := createDummyFollowData(t, db)
+	FollowSeller(db, buyerIds[0], sellerIds[0])
+	FollowSeller(db, buyerIds[1], sellerIds[0])
+	createDummyListing(t, db, sellerIds[0], "New", time.Now().Add(-time.Hour))
+	createDummyListing(t, db, sellerIds[0], "Old", time.Now().Add(-48*time.Hour))
+
+	//Test 1: Only buyers with the digest turned on get a mail of the new listings
+	_, err = db.ExecContext(context.Background(), `UPDATE buyers SET follow_digest = TRUE WHERE buyer_id = $1;`, buyerIds[0])
+	assert.NoError(t, err)
+	res, resErr := SendFollowDigests(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, []string{buyerIds[0]}, res.Sent)
+	assert.Equal(t, 1, countDigestMails(t, db, "test1@aucto.io"))
+	assert.Equal(t, 0, countDigestMails(t, db, "test2@aucto.io"))
+
+	//Test 2: Digest is only sent once a day
+	res, resErr = SendFollowDigests(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(res.Sent))
+	assert.Equal(t, 1, countDigestMails(t, db, "test1@aucto.io"))
+
+	//Test 3: Digest of every buyer with the digest turned on is added to the outbox
+	_, err = db.ExecContext(context.Background(), `UPDATE buyers SET follow_digest = TRUE, follow_digest_sent_date = NULL;`)
+	assert.NoError(t, err)
+	res, resErr = SendFollowDigests(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, len(res.Sent))
+	assert.Equal(t, 1, countDigestMails(t, db, "test2@aucto.io"))
+
+	store.CloseDB(db)
+}
+
+func countDigestMails(t *testing.T, db *sql.DB, email string) int {
+	var count int
+	query := `SELECT COUNT(*) FROM outbox_messages WHERE topic = 'mail' AND payload->>'ToEmail' = $1;`
+	assert.NoError(t, db.QueryRowContext(context.Background(), query, email).Scan(&count))
+	return count
+}
+
+func createDummyListing(t *testing.T, db *sql.DB, sellerId string, title string, postedDate time.Time) string {
+	var productId string
+
+	query := `INSERT INTO products(title, seller_id, description, product_type, language, expansion, posted_date, price, condition, product_quantity)
+		VALUES ($1, $2, 'Test', 'Buy-Now', 'Eng', 'Test', $3, 1000, 5, 1) RETURNING product_id;`
+	err := db.QueryRowContext(context.Background(), query, title, sellerId, postedDate).Scan(&productId)
+	assert.NoError(t, err)
+
+	_, err = db.ExecContext(context.Background(), `INSERT INTO product_images(product_id, image_no) VALUES ($1, 1);`, productId)
+	assert.NoError(t, err)
+
+	return productId
+}
@@ -0,0 +1,185 @@
+package follow
+
+import (
+	"BackendAPI/api/buyer"
+	"BackendAPI/api/seller"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"time"
+)
+
+/*
+Makes a buyer follow a seller and adds them to the followers of the seller. Following a seller that is already
+followed does nothing. Returns a 404 Not found error if the buyer or seller does not exist.
+*/
+func FollowSeller(db *sql.DB, buyerId string, sellerId string) (data.FollowSellerResponseData, *utils.ErrorHandler) {
+	validateErr := validateFollow(db, buyerId, sellerId)
+
+	if validateErr != nil {
+		return data.FollowSellerResponseData{}, validateErr
+	}
+
+	query := `INSERT INTO seller_follows(buyer_id, seller_id, created_date) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING;`
+
+	return updateFollow(db, buyerId, sellerId, true, query, buyerId, sellerId, time.Now())
+}
+
+/*
+Makes a buyer stop following a seller and removes them from the followers of the seller. Unfollowing a seller that
+is not followed does nothing. Returns a 404 Not found error if the buyer or seller does not exist.
+*/
+func UnfollowSeller(db *sql.DB, buyerId string, sellerId string) (data.FollowSellerResponseData, *utils.ErrorHandler) {
+	validateErr := validateFollow(db, buyerId, sellerId)
+
+	if validateErr != nil {
+		return data.FollowSellerResponseData{}, validateErr
+	}
+
+	query := `DELETE FROM seller_follows WHERE buyer_id = $1 AND seller_id = $2;`
+
+	return updateFollow(db, buyerId, sellerId, false, query, buyerId, sellerId)
+}
+
+/*
+Gets the sellers a buyer follows from the most recently followed seller and whether the buyer gets the new listing
+digest. Returns a 404 Not found error if the buyer does not exist.
+*/
+func GetBuyerFollows(db *sql.DB, buyerId string) (data.GetBuyerFollowsResponseData, *utils.ErrorHandler) {
+	response := data.GetBuyerFollowsResponseData{BuyerId: buyerId, Sellers: []data.GetSellerByIdResponseData{}}
+
+	query := `SELECT follow_digest FROM buyers WHERE buyer_id::TEXT = $1;`
+	err := db.QueryRowContext(context.Background(), query, buyerId).Scan(&response.FollowDigest)
+
+	if err == sql.ErrNoRows {
+		return response, utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Buyer rows")
+		return response, errResp
+	}
+
+	query = `SELECT sellers.seller_id, sellers.seller_name, sellers.followers,
+			COALESCE(seller_ratings.rating, 0), COALESCE(seller_ratings.review_count, 0)
+		FROM (seller_follows INNER JOIN sellers ON sellers.seller_id = seller_follows.seller_id)
+			LEFT OUTER JOIN seller_ratings ON seller_ratings.seller_id = sellers.seller_id
+		WHERE seller_follows.buyer_id = $1
+		ORDER BY seller_follows.created_date DESC;`
+	rows, err := db.QueryContext(context.Background(), query, buyerId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Seller Follow rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var followed data.GetSellerByIdResponseData
+		err = rows.Scan(&followed.SellerId, &followed.SellerName, &followed.Followers, &followed.Rating, &followed.ReviewCount)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Seller Follow rows")
+			return response, errResp
+		}
+
+		response.Sellers = append(response.Sellers, followed)
+	}
+
+	return response, nil
+}
+
+/*
+Turns the daily mail of new listings from followed sellers on or off for a buyer
+*/
+func UpdateFollowDigest(db *sql.DB, buyerId string, request data.UpdateFollowDigestRequestData) (data.GetBuyerFollowsResponseData, *utils.ErrorHandler) {
+	query := `UPDATE buyers SET follow_digest = $2 WHERE buyer_id::TEXT = $1;`
+	res, err := db.ExecContext(context.Background(), query, buyerId, request.Enabled)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Buyer rows")
+		return data.GetBuyerFollowsResponseData{}, errResp
+	}
+
+	if count, _ := res.RowsAffected(); count == 0 {
+		return data.GetBuyerFollowsResponseData{}, utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	return GetBuyerFollows(db, buyerId)
+}
+
+/*
+Runs the query that adds or removes a follow and keeps the followers of the seller in step with it. The count is
+only changed if the query added or removed a follow.
+*/
+func updateFollow(db *sql.DB, buyerId string, sellerId string, following bool, query string,
+	args ...interface{}) (data.FollowSellerResponseData, *utils.ErrorHandler) {
+	response := data.FollowSellerResponseData{BuyerId: buyerId, SellerId: sellerId, Following: following}
+
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Seller Follow transaction")
+		return response, errResp
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(context.Background(), query, args...)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Seller Follow rows")
+		return response, errResp
+	}
+
+	count, _ := res.RowsAffected()
+	change := 0
+	if count > 0 && following {
+		change = 1
+	}
+	if count > 0 && !following {
+		change = -1
+	}
+
+	query = `UPDATE sellers SET followers = GREATEST(followers + $2, 0) WHERE seller_id = $1 RETURNING followers;`
+	err = tx.QueryRowContext(context.Background(), query, sellerId, change).Scan(&response.Followers)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Seller rows")
+		return response, errResp
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Seller Follow transaction")
+		return response, errResp
+	}
+
+	return response, nil
+}
+
+/*
+Validates that the buyer and seller of a follow exist
+*/
+func validateFollow(db *sql.DB, buyerId string, sellerId string) *utils.ErrorHandler {
+	if !buyer.DoesBuyerExist(db, buyerId) {
+		return utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	if !seller.DoesSellerExist(db, sellerId) {
+		return utils.NotFoundError("Seller with given id does not exist")
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package follow
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFollowSeller(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerIds, sellerIds := createDummyFollowData(t, db)
+
+	//Test 1: Buyer follows a seller
+	res, resErr := FollowSeller(db, buyerIds[0], sellerIds[0])
+	assert.Empty(t, resErr)
+	assert.Equal(t, true, res.Following)
+	assert.Equal(t, 1, res.Followers)
+
+	//Test 2: Following again does not change the followers
+	res, resErr = FollowSeller(db, buyerIds[0], sellerIds[0])
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, res.Followers)
+
+	//Test 3: Followers are counted per buyer
+	res, resErr = FollowSeller(db, buyerIds[1], sellerIds[0])
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, res.Followers)
+
+	//Test 4: Buyer unfollows the seller
+	res, resErr = UnfollowSeller(db, buyerIds[0], sellerIds[0])
+	assert.Empty(t, resErr)
+	assert.Equal(t, false, res.Following)
+	assert.Equal(t, 1, res.Followers)
+
+	//Test 5: Unfollowing again does not change the followers
+	res, resErr = UnfollowSeller(db, buyerIds[0], sellerIds[0])
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, res.Followers)
+
+	//Test 6: Seller does not exist
+	_, resErr = FollowSeller(db, buyerIds[0], "wrong id")
+	assert.Error(t, resErr)
+	assert.Equal(t, "Seller with given id does not exist", resErr.Error())
+
+	//Test 7: Buyer does not exist
+	_, resErr = FollowSeller(db, "wrong id", sellerIds[0])
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func TestGetBuyerFollows(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerIds, sellerIds := createDummyFollowData(t, db)
+
+	//Test 1: Buyer does not follow anyone
+	res, resErr := GetBuyerFollows(db, buyerIds[0])
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(res.Sellers))
+	assert.Equal(t, false, res.FollowDigest)
+
+	//Test 2: Followed sellers from the most recently followed
+	FollowSeller(db, buyerIds[0], sellerIds[0])
+	FollowSeller(db, buyerIds[0], sellerIds[1])
+	res, resErr = GetBuyerFollows(db, buyerIds[0])
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, len(res.Sellers))
+	assert.Equal(t, sellerIds[1], res.Sellers[0].SellerId)
+	assert.Equal(t, 1, res.Sellers[0].Followers)
+
+	//Test 3: Digest is turned on
+	res, resErr = UpdateFollowDigest(db, buyerIds[0], data.UpdateFollowDigestRequestData{Enabled: true})
+	assert.Empty(t, resErr)
+	assert.Equal(t, true, res.FollowDigest)
+
+	//Test 4: Buyer does not exist
+	_, resErr = UpdateFollowDigest(db, "wrong id", data.UpdateFollowDigestRequestData{Enabled: true})
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func createDummyFollowData(t *testing.T, db *sql.DB) ([]string, []string) {
+	var buyerIds, sellerIds []string
+
+	for _, name := range []string{"test1", "test2"} {
+		var buyerId, sellerId string
+
+		query := `INSERT INTO buyers(email, password) VALUES ($1,'test') RETURNING buyer_id;`
+		err := db.QueryRowContext(context.Background(), query, name+"@aucto.io").Scan(&buyerId)
+		assert.NoError(t, err)
+
+		query = `INSERT INTO sellers(email, seller_name, password) VALUES ($1, $2, 'test') RETURNING seller_id`
+		err = db.QueryRowContext(context.Background(), query, name+"@aucto.io", name).Scan(&sellerId)
+		assert.NoError(t, err)
+
+		buyerIds = append(buyerIds, buyerId)
+		sellerIds = append(sellerIds, sellerId)
+	}
+
+	return buyerIds, sellerIds
+}
@@ -0,0 +1,153 @@
+package jobs
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"time"
+)
+
+const jobListLimit = 200
+
+const jobColumns = `job_id, job_type, payload::TEXT, status, attempts, max_attempts, last_error, run_date::TEXT,
+	COALESCE(locked_until::TEXT, ''), created_date::TEXT, COALESCE(completed_date::TEXT, '')`
+
+/*
+Adds a job to the queue for an admin. The job type must have a handler, the run date is optional and the job gets
+five attempts unless given otherwise.
+*/
+func (queue *Queue) EnqueueJob(request data.EnqueueJobRequestData) (data.JobData, *utils.ErrorHandler) {
+	if !queue.HasHandler(request.Type) {
+		return data.JobData{}, utils.BadRequestError("Bad type data")
+	}
+
+	var runDate time.Time
+	if request.RunDate != "" {
+		parsed, err := time.Parse(time.RFC3339, request.RunDate)
+
+		if err != nil {
+			return data.JobData{}, utils.BadRequestError("Bad run date data")
+		}
+
+		runDate = parsed
+	}
+
+	maxAttempts := defaultMaxAttempts
+	if request.MaxAttempts != 0 {
+		maxAttempts = request.MaxAttempts
+	}
+
+	if maxAttempts < 1 || maxAttempts > 20 {
+		return data.JobData{}, utils.BadRequestError("Bad max attempts data")
+	}
+
+	var payload interface{}
+	if request.Payload != nil {
+		payload = request.Payload
+	}
+
+	jobId, err := insertJob(queue.db, request.Type, payload, runDate, maxAttempts)
+
+	if err != nil {
+		return data.JobData{}, err
+	}
+
+	return getJob(queue.db, jobId)
+}
+
+/*
+Gets the jobs that are failing, from the newest job. Failing jobs are the failed jobs and the pending jobs that have
+failed at least once. Jobs can also be filtered by status instead.
+*/
+func GetJobs(db *sql.DB, status string) (data.GetJobsResponseData, *utils.ErrorHandler) {
+	response := data.GetJobsResponseData{Jobs: []data.JobData{}}
+
+	if status != "" && status != "pending" && status != "running" && status != "completed" && status != "failed" {
+		return response, utils.BadRequestError("Bad status data")
+	}
+
+	query := `SELECT ` + jobColumns + ` FROM jobs
+		WHERE ($1 = '' AND (status = 'failed' OR (status = 'pending' AND attempts > 0))) OR status = $1
+		ORDER BY created_date DESC LIMIT $2;`
+	rows, err := db.QueryContext(context.Background(), query, status, jobListLimit)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Job rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		job, scanErr := scanJob(rows)
+
+		if scanErr != nil {
+			return response, scanErr
+		}
+
+		response.Jobs = append(response.Jobs, job)
+	}
+
+	return response, nil
+}
+
+/*
+Puts a failed job back in the queue to run as soon as possible with all of its attempts. Returns a 404 Not found error
+if the job does not exist and a 400 bad request if it has not failed.
+*/
+func RetryJob(db *sql.DB, jobId string) (data.JobData, *utils.ErrorHandler) {
+	job, err := getJob(db, jobId)
+
+	if err != nil {
+		return job, err
+	}
+
+	if job.Status != "failed" {
+		return job, utils.BadRequestError("Only failed jobs can be retried")
+	}
+
+	query := `UPDATE jobs SET status = 'pending', attempts = 0, last_error = '', run_date = NOW()
+		WHERE job_id = $1 AND status = 'failed';`
+	_, execErr := db.ExecContext(context.Background(), query, jobId)
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in updating Job rows")
+		return job, errResp
+	}
+
+	return getJob(db, jobId)
+}
+
+/*
+Gets a job, returns a 404 Not found error if it does not exist
+*/
+func getJob(db *sql.DB, jobId string) (data.JobData, *utils.ErrorHandler) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE job_id::TEXT = $1;`
+
+	return scanJob(db.QueryRowContext(context.Background(), query, jobId))
+}
+
+/*
+Scans a row of job columns
+*/
+func scanJob(row interface{ Scan(...interface{}) error }) (data.JobData, *utils.ErrorHandler) {
+	var job data.JobData
+
+	err := row.Scan(&job.JobId, &job.Type, &job.Payload, &job.Status, &job.Attempts, &job.MaxAttempts, &job.LastError,
+		&job.RunDate, &job.LockedUntil, &job.CreatedDate, &job.CompletedDate)
+
+	if err == sql.ErrNoRows {
+		return job, utils.NotFoundError("Job with given id does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Job rows")
+		return job, errResp
+	}
+
+	return job, nil
+}
@@ -0,0 +1,100 @@
+package jobs
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"context"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnqueueJob(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	queue := NewQueue(db)
+	Handle(queue, "test", func(ctx context.Context, payload testJobData) error {
+		return nil
+	})
+
+	//Test 1: Job is added with its payload, run date and attempts
+	res, resErr := queue.EnqueueJob(data.EnqueueJobRequestData{Type: "test", Payload: map[string]interface{}{"name": "first"},
+		RunDate: "2030-01-02T15:04:05Z", MaxAttempts: 3})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "pending", res.Status)
+	assert.Equal(t, 3, res.MaxAttempts)
+	assert.JSONEq(t, `{"name": "first"}`, res.Payload)
+	runDate, _ := time.Parse("2006-01-02 15:04:05-07", res.RunDate)
+	assert.True(t, runDate.Equal(time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)))
+
+	//Test 2: Job is added to run now with the default attempts
+	res, resErr = queue.EnqueueJob(data.EnqueueJobRequestData{Type: "test"})
+	assert.Empty(t, resErr)
+	assert.Equal(t, defaultMaxAttempts, res.MaxAttempts)
+	assert.JSONEq(t, `{}`, res.Payload)
+
+	//Test 3: Job type without a handler
+	_, resErr = queue.EnqueueJob(data.EnqueueJobRequestData{Type: "unknown"})
+	assert.NotEmpty(t, resErr)
+	assert.Equal(t, 400, resErr.ErrorCode())
+
+	//Test 4: Bad run date and max attempts
+	_, resErr = queue.EnqueueJob(data.EnqueueJobRequestData{Type: "test", RunDate: "tomorrow"})
+	assert.NotEmpty(t, resErr)
+	assert.Equal(t, 400, resErr.ErrorCode())
+	_, resErr = queue.EnqueueJob(data.EnqueueJobRequestData{Type: "test", MaxAttempts: -1})
+	assert.NotEmpty(t, resErr)
+	assert.Equal(t, 400, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func TestGetJobsAndRetryJob(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	failedId, _ := Enqueue(db, "test", nil, time.Time{})
+	retryingId, _ := Enqueue(db, "test", nil, time.Time{})
+	Enqueue(db, "test", nil, time.Time{})
+	_, err = db.ExecContext(context.Background(), `UPDATE jobs SET status = 'failed', attempts = 5 WHERE job_id = $1;`, failedId)
+	assert.NoError(t, err)
+	_, err = db.ExecContext(context.Background(), `UPDATE jobs SET attempts = 2 WHERE job_id = $1;`, retryingId)
+	assert.NoError(t, err)
+
+	//Test 1: Failing jobs are listed by default
+	res, resErr := GetJobs(db, "")
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, len(res.Jobs))
+
+	//Test 2: Jobs are filtered by status
+	res, resErr = GetJobs(db, "failed")
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(res.Jobs))
+	assert.Equal(t, failedId, res.Jobs[0].JobId)
+
+	//Test 3: Bad status
+	_, resErr = GetJobs(db, "wrong")
+	assert.NotEmpty(t, resErr)
+	assert.Equal(t, 400, resErr.ErrorCode())
+
+	//Test 4: Failed job is retried with all of its attempts
+	job, resErr := RetryJob(db, failedId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, "pending", job.Status)
+	assert.Equal(t, 0, job.Attempts)
+
+	//Test 5: Job that has not failed cannot be retried
+	_, resErr = RetryJob(db, retryingId)
+	assert.NotEmpty(t, resErr)
+	assert.Equal(t, 400, resErr.ErrorCode())
+
+	//Test 6: Job that does not exist cannot be retried
+	_, resErr = RetryJob(db, "wrong")
+	assert.NotEmpty(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
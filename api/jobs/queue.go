@@ -0,0 +1,380 @@
+package jobs
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxAttempts       = 5
+	defaultVisibilityTimeout = 5 * time.Minute
+	jobBaseBackoff           = 30 * time.Second
+	jobMaxBackoff            = time.Hour
+)
+
+/*
+Runs a job with its JSON payload
+*/
+type Handler func(ctx context.Context, payload []byte) error
+
+type recurringJob struct {
+	jobType  string
+	interval time.Duration
+}
+
+type claimedJob struct {
+	jobId       string
+	jobType     string
+	payload     string
+	attempts    int
+	maxAttempts int
+}
+
+/*
+A queue of background jobs kept in Postgres. Any number of workers can run the jobs of a queue, every job is claimed
+by one worker at a time.
+*/
+type Queue struct {
+	db                *sql.DB
+	mutex             sync.Mutex
+	handlers          map[string]Handler
+	recurring         []recurringJob
+	visibilityTimeout time.Duration
+}
+
+/*
+Creates a queue without any handlers. A claimed job is hidden from other workers for five minutes, if it has not
+finished by then it is cancelled and can be claimed again.
+*/
+func NewQueue(db *sql.DB) *Queue {
+	return &Queue{db: db, handlers: make(map[string]Handler), visibilityTimeout: defaultVisibilityTimeout}
+}
+
+/*
+Sets how long a claimed job is hidden from other workers and how long it can run for
+*/
+func (queue *Queue) SetVisibilityTimeout(timeout time.Duration) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	queue.visibilityTimeout = timeout
+}
+
+/*
+Sets the handler that runs the jobs of a type. The payload of the job is decoded into the type the handler takes,
+a job with a payload that cannot be decoded fails like any other error.
+*/
+func Handle[T any](queue *Queue, jobType string, handler func(ctx context.Context, payload T) error) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	queue.handlers[jobType] = func(ctx context.Context, payload []byte) error {
+		var decoded T
+		err := json.Unmarshal(payload, &decoded)
+
+		if err != nil {
+			return err
+		}
+
+		return handler(ctx, decoded)
+	}
+}
+
+/*
+Schedules a job of a type to run at every interval, such as every minute. Jobs are scheduled for the start of each
+interval and only once for each interval however many workers are running.
+*/
+func (queue *Queue) Every(jobType string, interval time.Duration) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	queue.recurring = append(queue.recurring, recurringJob{jobType: jobType, interval: interval})
+}
+
+/*
+Checks wether the queue has a handler for a type of job
+*/
+func (queue *Queue) HasHandler(jobType string) bool {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	_, hasHandler := queue.handlers[jobType]
+	return hasHandler
+}
+
+/*
+Adds a job to the queue that runs once the run date has passed, a zero run date runs the job as soon as possible.
+Jobs can be added as part of a transaction so that they only run if the transaction commits. Returns the id of the job.
+*/
+func Enqueue(db interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}, jobType string, payload interface{}, runDate time.Time) (string, *utils.ErrorHandler) {
+	return insertJob(db, jobType, payload, runDate, defaultMaxAttempts)
+}
+
+/*
+Runs the jobs that are due until there are none left or the context is done, after scheduling the recurring jobs.
+Failed jobs are retried with exponential backoff until they run out of attempts. Used by workers and by scheduled
+invocations of the API.
+*/
+func (queue *Queue) RunDueJobs(ctx context.Context) (data.RunJobsResponseData, *utils.ErrorHandler) {
+	var response data.RunJobsResponseData
+
+	scheduled, err := queue.scheduleRecurringJobs(time.Now())
+	response.Scheduled = scheduled
+
+	if err != nil {
+		return response, err
+	}
+
+	query := `UPDATE jobs SET status = 'failed', locked_until = NULL, last_error = 'Job timed out'
+		WHERE status = 'running' AND locked_until < NOW() AND attempts >= max_attempts;`
+	result, execErr := queue.db.ExecContext(context.Background(), query)
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in updating Job rows")
+		return response, errResp
+	}
+
+	timedOut, _ := result.RowsAffected()
+	response.Failed += int(timedOut)
+
+	query = `DELETE FROM jobs WHERE status = 'completed' AND completed_date < NOW() - INTERVAL '7 days';`
+	_, execErr = queue.db.ExecContext(context.Background(), query)
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in deleting Job rows")
+		return response, errResp
+	}
+
+	for ctx.Err() == nil {
+		job, found, claimErr := queue.claimJob()
+
+		if claimErr != nil {
+			return response, claimErr
+		}
+
+		if !found {
+			break
+		}
+
+		status, finishErr := queue.runJob(ctx, job)
+
+		if finishErr != nil {
+			return response, finishErr
+		}
+
+		switch status {
+		case "completed":
+			response.Completed++
+		case "pending":
+			response.Retried++
+		case "failed":
+			response.Failed++
+		}
+	}
+
+	return response, nil
+}
+
+/*
+Runs due jobs every poll interval until the context is done. Used by the worker process.
+*/
+func (queue *Queue) Work(ctx context.Context, pollInterval time.Duration) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		response, err := queue.RunDueJobs(ctx)
+
+		if err != nil {
+			utils.LogMessage("Running of jobs failed: " + err.Error())
+		} else if response.Completed+response.Retried+response.Failed > 0 {
+			utils.LogMessage(fmt.Sprintf("Ran jobs, %d completed, %d retried and %d failed",
+				response.Completed, response.Retried, response.Failed))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+/*
+Adds a job for the current interval of every recurring job, unless it has already been added. Returns how many jobs
+were added.
+*/
+func (queue *Queue) scheduleRecurringJobs(now time.Time) (int, *utils.ErrorHandler) {
+	queue.mutex.Lock()
+	recurring := append([]recurringJob{}, queue.recurring...)
+	queue.mutex.Unlock()
+
+	scheduled := 0
+	query := `INSERT INTO jobs(job_type, run_date, unique_key) VALUES ($1, $2, $3) ON CONFLICT (unique_key) DO NOTHING;`
+
+	for i := 0; i < len(recurring); i++ {
+		runDate := now.UTC().Truncate(recurring[i].interval)
+		uniqueKey := recurring[i].jobType + "@" + runDate.Format(time.RFC3339)
+		result, err := queue.db.ExecContext(context.Background(), query, recurring[i].jobType, runDate, uniqueKey)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in inserting Job rows")
+			return scheduled, errResp
+		}
+
+		added, _ := result.RowsAffected()
+		scheduled += int(added)
+	}
+
+	return scheduled, nil
+}
+
+/*
+Claims the next job that is due, or a running job whose visibility timeout has run out because its worker stopped.
+Claiming a job counts as an attempt.
+*/
+func (queue *Queue) claimJob() (claimedJob, bool, *utils.ErrorHandler) {
+	var job claimedJob
+
+	query := `UPDATE jobs SET status = 'running', attempts = attempts + 1, locked_until = NOW() + $1 * INTERVAL '1 second'
+		WHERE job_id = (SELECT job_id FROM jobs
+			WHERE (status = 'pending' AND run_date <= NOW())
+				OR (status = 'running' AND locked_until < NOW() AND attempts < max_attempts)
+			ORDER BY run_date LIMIT 1 FOR UPDATE SKIP LOCKED)
+		RETURNING job_id, job_type, payload::TEXT, attempts, max_attempts;`
+	err := queue.db.QueryRowContext(context.Background(), query, int(queue.getVisibilityTimeout().Seconds())).Scan(
+		&job.jobId, &job.jobType, &job.payload, &job.attempts, &job.maxAttempts)
+
+	if err == sql.ErrNoRows {
+		return job, false, nil
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in claiming Job rows")
+		return job, false, errResp
+	}
+
+	return job, true, nil
+}
+
+/*
+Runs a claimed job and records how it went. A job that fails is pending again after a backoff, or failed if it has
+no attempts left. Returns the status of the job afterwards.
+*/
+func (queue *Queue) runJob(ctx context.Context, job claimedJob) (string, *utils.ErrorHandler) {
+	jobCtx, cancel := context.WithTimeout(ctx, queue.getVisibilityTimeout())
+	runErr := queue.callHandler(jobCtx, job)
+	cancel()
+
+	status := "completed"
+	query := `UPDATE jobs SET status = 'completed', locked_until = NULL, last_error = '', completed_date = NOW()
+		WHERE job_id = $1 AND status = 'running' AND attempts = $2;`
+	args := []interface{}{job.jobId, job.attempts}
+
+	if runErr != nil && job.attempts >= job.maxAttempts {
+		status = "failed"
+		query = `UPDATE jobs SET status = 'failed', locked_until = NULL, last_error = $3
+			WHERE job_id = $1 AND status = 'running' AND attempts = $2;`
+		args = append(args, runErr.Error())
+		utils.LogMessage("Job " + job.jobId + " of type " + job.jobType + " failed: " + runErr.Error())
+	} else if runErr != nil {
+		status = "pending"
+		query = `UPDATE jobs SET status = 'pending', locked_until = NULL, last_error = $3,
+				run_date = NOW() + $4 * INTERVAL '1 second'
+			WHERE job_id = $1 AND status = 'running' AND attempts = $2;`
+		args = append(args, runErr.Error(), int(utils.GetBackoff(job.attempts, jobBaseBackoff, jobMaxBackoff).Seconds()))
+	}
+
+	//The job is only updated if no other worker has claimed it since, which happens when it runs past its timeout
+	_, err := queue.db.ExecContext(context.Background(), query, args...)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Job rows")
+		return status, errResp
+	}
+
+	return status, nil
+}
+
+/*
+Calls the handler of a job, a handler that panics fails the job instead of stopping the worker
+*/
+func (queue *Queue) callHandler(ctx context.Context, job claimedJob) (err error) {
+	queue.mutex.Lock()
+	handler, hasHandler := queue.handlers[job.jobType]
+	queue.mutex.Unlock()
+
+	if !hasHandler {
+		return errors.New("No handler for job type " + job.jobType)
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("Job panicked: %v", recovered)
+		}
+	}()
+
+	return handler(ctx, []byte(job.payload))
+}
+
+/*
+Gets how long a claimed job is hidden from other workers
+*/
+func (queue *Queue) getVisibilityTimeout() time.Duration {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	return queue.visibilityTimeout
+}
+
+/*
+Inserts a job that runs once the run date has passed, jobs with a zero run date run as soon as possible
+*/
+func insertJob(db interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}, jobType string, payload interface{}, runDate time.Time, maxAttempts int) (string, *utils.ErrorHandler) {
+	var jobId string
+
+	if payload == nil {
+		payload = struct{}{}
+	}
+
+	content, err := json.Marshal(payload)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in encoding job payload")
+		return jobId, errResp
+	}
+
+	var runAt interface{}
+	if !runDate.IsZero() {
+		runAt = runDate
+	}
+
+	query := `INSERT INTO jobs(job_type, payload, run_date, max_attempts) VALUES ($1, $2, COALESCE($3::TIMESTAMPTZ, NOW()), $4)
+		RETURNING job_id;`
+	err = db.QueryRowContext(context.Background(), query, jobType, string(content), runAt, maxAttempts).Scan(&jobId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Job rows")
+		return jobId, errResp
+	}
+
+	return jobId, nil
+}
@@ -0,0 +1,197 @@
+package jobs
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+type testJobData struct {
+	Name string `json:"name"`
+}
+
+func TestRunDueJobs(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	queue := NewQueue(db)
+	var ran []string
+	failing := true
+	Handle(queue, "test", func(ctx context.Context, payload testJobData) error {
+		ran = append(ran, payload.Name)
+		return nil
+	})
+	Handle(queue, "failing", func(ctx context.Context, payload testJobData) error {
+		if failing {
+			return errors.New("Endpoint is down")
+		}
+		return nil
+	})
+	Handle(queue, "panicking", func(ctx context.Context, payload testJobData) error {
+		panic("nil map")
+	})
+
+	//Test 1: Due job is run with its typed payload
+	jobId, resErr := Enqueue(db, "test", testJobData{Name: "first"}, time.Time{})
+	assert.Empty(t, resErr)
+	res, resErr := queue.RunDueJobs(context.Background())
+	assert.Empty(t, resErr)
+	assert.Equal(t, data.RunJobsResponseData{Completed: 1}, res)
+	assert.Equal(t, []string{"first"}, ran)
+	job, _ := getJob(db, jobId)
+	assert.Equal(t, "completed", job.Status)
+	assert.Equal(t, 1, job.Attempts)
+	assert.NotEmpty(t, job.CompletedDate)
+
+	//Test 2: Scheduled job is not run before its run date
+	_, resErr = Enqueue(db, "test", testJobData{Name: "later"}, time.Now().Add(time.Hour))
+	assert.Empty(t, resErr)
+	res, resErr = queue.RunDueJobs(context.Background())
+	assert.Empty(t, resErr)
+	assert.Equal(t, data.RunJobsResponseData{}, res)
+	assert.Equal(t, 1, len(ran))
+
+	//Test 3: Job that fails is retried after a backoff
+	failingId, resErr := Enqueue(db, "failing", testJobData{}, time.Time{})
+	assert.Empty(t, resErr)
+	res, resErr = queue.RunDueJobs(context.Background())
+	assert.Empty(t, resErr)
+	assert.Equal(t, data.RunJobsResponseData{Retried: 1}, res)
+	job, _ = getJob(db, failingId)
+	assert.Equal(t, "pending", job.Status)
+	assert.Equal(t, "Endpoint is down", job.LastError)
+	res, resErr = queue.RunDueJobs(context.Background())
+	assert.Empty(t, resErr)
+	assert.Equal(t, data.RunJobsResponseData{}, res)
+
+	//Test 4: Job that runs out of attempts fails
+	_, err = db.ExecContext(context.Background(), `UPDATE jobs SET attempts = 4, run_date = NOW() WHERE job_id = $1;`, failingId)
+	assert.NoError(t, err)
+	res, resErr = queue.RunDueJobs(context.Background())
+	assert.Empty(t, resErr)
+	assert.Equal(t, data.RunJobsResponseData{Failed: 1}, res)
+	job, _ = getJob(db, failingId)
+	assert.Equal(t, "failed", job.Status)
+	assert.Equal(t, 5, job.Attempts)
+
+	//Test 5: Job that panics or has no handler fails without stopping the worker
+	panickingId, _ := Enqueue(db, "panicking", testJobData{}, time.Time{})
+	unknownId, _ := Enqueue(db, "unknown", testJobData{}, time.Time{})
+	res, resErr = queue.RunDueJobs(context.Background())
+	assert.Empty(t, resErr)
+	assert.Equal(t, data.RunJobsResponseData{Retried: 2}, res)
+	job, _ = getJob(db, panickingId)
+	assert.Equal(t, "Job panicked: nil map", job.LastError)
+	job, _ = getJob(db, unknownId)
+	assert.Equal(t, "No handler for job type unknown", job.LastError)
+
+	//Test 6: Job with a payload that does not match its handler fails
+	badId, _ := Enqueue(db, "test", []string{"wrong"}, time.Time{})
+	res, resErr = queue.RunDueJobs(context.Background())
+	assert.Empty(t, resErr)
+	assert.Equal(t, data.RunJobsResponseData{Retried: 1}, res)
+	job, _ = getJob(db, badId)
+	assert.NotEmpty(t, job.LastError)
+
+	store.CloseDB(db)
+}
+
+func TestVisibilityTimeout(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	queue := NewQueue(db)
+	runs := 0
+	Handle(queue, "test", func(ctx context.Context, payload testJobData) error {
+		runs++
+		return nil
+	})
+
+	jobId, _ := Enqueue(db, "test", testJobData{}, time.Time{})
+
+	//Test 1: Claimed job is hidden from other workers
+	job, found, resErr := queue.claimJob()
+	assert.Empty(t, resErr)
+	assert.True(t, found)
+	assert.Equal(t, jobId, job.jobId)
+	_, found, resErr = queue.claimJob()
+	assert.Empty(t, resErr)
+	assert.False(t, found)
+
+	//Test 2: Job is claimed again once its visibility timeout runs out
+	_, err = db.ExecContext(context.Background(), `UPDATE jobs SET locked_until = NOW() - INTERVAL '1 second';`)
+	assert.NoError(t, err)
+	res, resErr := queue.RunDueJobs(context.Background())
+	assert.Empty(t, resErr)
+	assert.Equal(t, data.RunJobsResponseData{Completed: 1}, res)
+	assert.Equal(t, 1, runs)
+
+	//Test 3: Worker whose job was claimed again does not overwrite it
+	status, resErr := queue.runJob(context.Background(), job)
+	assert.Empty(t, resErr)
+	assert.Equal(t, "completed", status)
+	stored, _ := getJob(db, jobId)
+	assert.Equal(t, 2, stored.Attempts)
+
+	//Test 4: Job that times out on its last attempt fails
+	timedOutId, _ := Enqueue(db, "test", testJobData{}, time.Time{})
+	_, err = db.ExecContext(context.Background(), `UPDATE jobs SET status = 'running', attempts = max_attempts,
+		locked_until = NOW() - INTERVAL '1 second' WHERE job_id = $1;`, timedOutId)
+	assert.NoError(t, err)
+	res, resErr = queue.RunDueJobs(context.Background())
+	assert.Empty(t, resErr)
+	assert.Equal(t, data.RunJobsResponseData{Failed: 1}, res)
+	stored, _ = getJob(db, timedOutId)
+	assert.Equal(t, "failed", stored.Status)
+	assert.Equal(t, "Job timed out", stored.LastError)
+
+	store.CloseDB(db)
+}
+
+func TestScheduleRecurringJobs(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	queue := NewQueue(db)
+	otherQueue := NewQueue(db)
+	queue.Every("test", time.Minute)
+	otherQueue.Every("test", time.Minute)
+	now := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	//Test 1: Recurring job is scheduled for the start of the interval
+	scheduled, resErr := queue.scheduleRecurringJobs(now)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, scheduled)
+	var runDate time.Time
+	err = db.QueryRowContext(context.Background(), `SELECT run_date FROM jobs WHERE job_type = 'test';`).Scan(&runDate)
+	assert.NoError(t, err)
+	assert.True(t, runDate.Equal(time.Date(2026, 1, 2, 15, 4, 0, 0, time.UTC)))
+
+	//Test 2: Recurring job is only scheduled once for each interval by every worker
+	scheduled, resErr = otherQueue.scheduleRecurringJobs(now.Add(30 * time.Second))
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, scheduled)
+
+	//Test 3: Recurring job is scheduled again for the next interval
+	scheduled, resErr = queue.scheduleRecurringJobs(now.Add(time.Minute))
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, scheduled)
+	assert.Equal(t, 2, countJobs(t, db))
+
+	store.CloseDB(db)
+}
+
+func countJobs(t *testing.T, db *sql.DB) int {
+	var count int
+	err := db.QueryRowContext(context.Background(), `SELECT COUNT(*) FROM jobs;`).Scan(&count)
+	assert.NoError(t, err)
+
+	return count
+}
@@ -0,0 +1,400 @@
+package ledger
+
+import (
+	"BackendAPI/api/seller"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"time"
+)
+
+const (
+	cashAccount            = "platform_cash"
+	commissionAccount      = "platform_commission"
+	sellerPendingAccount   = "seller_pending"
+	sellerAvailableAccount = "seller_available"
+)
+
+const defaultCommissionPercent = 10
+const defaultHoldDays = 7
+
+type ledgerEntry struct {
+	account  string
+	sellerId string
+	amount   int
+}
+
+/*
+Records the payment of a sub-order. The platform receives the total of the sub-order, keeps its commission on the
+products and holds the rest for the seller until the sub-order is delivered and the dispute window has passed. The
+commission is PLATFORM_COMMISSION_PERCENT of the products after discounts, the delivery fee goes to the seller. A
+sub-order is only recorded as paid once.
+*/
+func RecordSubOrderPayment(tx *sql.Tx, subOrderId string) *utils.ErrorHandler {
+	var sellerId string
+	var productsCost, total int
+
+	query := `SELECT seller_id, products_cost - discount, products_cost - discount + delivery_fee FROM sub_orders
+		WHERE sub_order_id = $1;`
+	err := tx.QueryRowContext(context.Background(), query, subOrderId).Scan(&sellerId, &productsCost, &total)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Sub Order rows")
+		return errResp
+	}
+
+	commission := calculateCommission(productsCost, getCommissionPercent())
+
+	return postTransaction(tx, "payment", subOrderId, "", "", []ledgerEntry{
+		{account: cashAccount, amount: total},
+		{account: sellerPendingAccount, sellerId: sellerId, amount: -(total - commission)},
+		{account: commissionAccount, amount: -commission}})
+}
+
+/*
+Records a refund of a sub-order. The commission on the refunded part is returned by the platform and the rest is
+taken from the seller, from the held funds or from the available balance if the funds have been released. The
+refunded amount of the sub-order has to include the refund, so the last refund returns whatever commission is left.
+*/
+func RecordSubOrderRefund(tx *sql.Tx, subOrderId string, refundId string, amount int) *utils.ErrorHandler {
+	var sellerId string
+	var total, refundedAmount, paidCommission, netCommission int
+	var isReleased bool
+
+	query := `SELECT sub_orders.seller_id, sub_orders.products_cost - sub_orders.discount + sub_orders.delivery_fee,
+			sub_orders.refunded_amount,
+			COALESCE(-SUM(ledger_entries.amount) FILTER (WHERE ledger_transactions.transaction_type = 'payment'), 0),
+			COALESCE(-SUM(ledger_entries.amount), 0),
+			EXISTS(SELECT * FROM ledger_transactions WHERE sub_order_id = $1 AND transaction_type = 'release')
+		FROM (sub_orders LEFT OUTER JOIN ledger_transactions ON ledger_transactions.sub_order_id = sub_orders.sub_order_id)
+			LEFT OUTER JOIN ledger_entries ON ledger_entries.transaction_id = ledger_transactions.transaction_id
+				AND ledger_entries.account = '` + commissionAccount + `'
+		WHERE sub_orders.sub_order_id = $1
+		GROUP BY sub_orders.sub_order_id;`
+	err := tx.QueryRowContext(context.Background(), query, subOrderId).Scan(&sellerId, &total, &refundedAmount,
+		&paidCommission, &netCommission, &isReleased)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Sub Order rows")
+		return errResp
+	}
+
+	returnedCommission := netCommission
+	if refundedAmount < total && total > 0 {
+		returnedCommission = amount * paidCommission / total
+	}
+
+	sellerAccount := sellerPendingAccount
+	if isReleased {
+		sellerAccount = sellerAvailableAccount
+	}
+
+	return postTransaction(tx, "refund", subOrderId, refundId, "", []ledgerEntry{
+		{account: cashAccount, amount: -amount},
+		{account: commissionAccount, amount: returnedCommission},
+		{account: sellerAccount, sellerId: sellerId, amount: amount - returnedCommission}})
+}
+
+/*
+Releases the held funds of sub-orders delivered more than PAYOUT_HOLD_DAYS ago, so that they are paid out in the
+next payout batch. Returns the ids of the sub-orders whose funds were released.
+*/
+func ReleaseSellerFunds(db *sql.DB) (data.ReleaseFundsResponseData, *utils.ErrorHandler) {
+	response := data.ReleaseFundsResponseData{Released: []string{}}
+
+	query := `SELECT sub_order_id FROM sub_orders
+		WHERE status = 'delivered' AND delivered_date <= NOW() - make_interval(days => $1)
+			AND EXISTS(SELECT * FROM ledger_transactions
+				WHERE ledger_transactions.sub_order_id = sub_orders.sub_order_id AND transaction_type = 'payment')
+			AND NOT EXISTS(SELECT * FROM ledger_transactions
+				WHERE ledger_transactions.sub_order_id = sub_orders.sub_order_id AND transaction_type = 'release');`
+	rows, err := db.QueryContext(context.Background(), query, getPayoutHoldDays())
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Sub Order rows")
+		return response, errResp
+	}
+
+	var subOrderIds []string
+
+	for rows.Next() {
+		var subOrderId string
+		err = rows.Scan(&subOrderId)
+
+		if err != nil {
+			rows.Close()
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Sub Order rows")
+			return response, errResp
+		}
+
+		subOrderIds = append(subOrderIds, subOrderId)
+	}
+
+	rows.Close()
+
+	for i := 0; i < len(subOrderIds); i++ {
+		releaseErr := releaseSubOrderFunds(db, subOrderIds[i])
+
+		if releaseErr != nil {
+			return response, releaseErr
+		}
+
+		response.Released = append(response.Released, subOrderIds[i])
+	}
+
+	return response, nil
+}
+
+/*
+Gets the balance of a seller: the funds held until their sub-orders are delivered and the dispute window has
+passed, the funds available for the next payout and the total paid out. If the seller does not exist, a 404 Not
+found error is returned.
+*/
+func GetSellerBalance(db *sql.DB, sellerId string) (data.SellerBalanceData, *utils.ErrorHandler) {
+	response := data.SellerBalanceData{SellerId: sellerId}
+
+	if !seller.DoesSellerExist(db, sellerId) {
+		return response, utils.NotFoundError("Seller with given id does not exist")
+	}
+
+	query := `SELECT
+			COALESCE(-SUM(ledger_entries.amount) FILTER (WHERE account = '` + sellerPendingAccount + `'), 0),
+			COALESCE(-SUM(ledger_entries.amount) FILTER (WHERE account = '` + sellerAvailableAccount + `'), 0),
+			COALESCE(SUM(ledger_entries.amount) FILTER (WHERE ledger_transactions.transaction_type = 'payout'), 0)
+		FROM ledger_entries INNER JOIN ledger_transactions ON ledger_transactions.transaction_id = ledger_entries.transaction_id
+		WHERE ledger_entries.seller_id = $1;`
+	err := db.QueryRowContext(context.Background(), query, sellerId).Scan(&response.Pending, &response.Available, &response.PaidOut)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Ledger Entry rows")
+		return response, errResp
+	}
+
+	return response, nil
+}
+
+/*
+Gets the statement of a seller, every ledger entry of their accounts from the oldest entry. Amounts are positive
+when money is owed to the seller and negative when it is taken off. The statement can be limited to entries on or
+after from and before to, both in RFC3339 format. Returns a 404 Not found error if the seller does not exist and a
+400 bad request if a date is invalid.
+*/
+func GetSellerStatement(db *sql.DB, sellerId string, from string, to string) (data.GetSellerStatementResponseData, *utils.ErrorHandler) {
+	response := data.GetSellerStatementResponseData{SellerId: sellerId, From: from, To: to, Entries: []data.StatementEntryData{}}
+
+	if !seller.DoesSellerExist(db, sellerId) {
+		return response, utils.NotFoundError("Seller with given id does not exist")
+	}
+
+	fromDate, dateErr := parseStatementDate(from, "from")
+	if dateErr != nil {
+		return response, dateErr
+	}
+
+	toDate, dateErr := parseStatementDate(to, "to")
+	if dateErr != nil {
+		return response, dateErr
+	}
+
+	query := `SELECT ledger_transactions.transaction_id, ledger_transactions.transaction_type,
+			COALESCE(ledger_transactions.sub_order_id::TEXT, ''), COALESCE(ledger_transactions.payout_id::TEXT, ''),
+			ledger_entries.account, -ledger_entries.amount, ledger_transactions.created_date::TEXT
+		FROM ledger_entries INNER JOIN ledger_transactions ON ledger_transactions.transaction_id = ledger_entries.transaction_id
+		WHERE ledger_entries.seller_id = $1 AND ($2::TIMESTAMPTZ IS NULL OR ledger_transactions.created_date >= $2)
+			AND ($3::TIMESTAMPTZ IS NULL OR ledger_transactions.created_date < $3)
+		ORDER BY ledger_transactions.created_date ASC, ledger_entries.entry_id ASC;`
+	rows, err := db.QueryContext(context.Background(), query, sellerId, fromDate, toDate)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Ledger Entry rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry data.StatementEntryData
+		err = rows.Scan(&entry.TransactionId, &entry.TransactionType, &entry.SubOrderId, &entry.PayoutId,
+			&entry.Account, &entry.Amount, &entry.CreatedDate)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Ledger Entry rows")
+			return response, errResp
+		}
+
+		response.Entries = append(response.Entries, entry)
+	}
+
+	return response, nil
+}
+
+/*
+Moves the held funds of a delivered sub-order to the available balance of the seller
+*/
+func releaseSubOrderFunds(db *sql.DB, subOrderId string) *utils.ErrorHandler {
+	var sellerId string
+	var held int
+
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Ledger transaction")
+		return errResp
+	}
+
+	defer tx.Rollback()
+
+	query := `SELECT sub_orders.seller_id, COALESCE(-SUM(ledger_entries.amount), 0)
+		FROM (sub_orders LEFT OUTER JOIN ledger_transactions ON ledger_transactions.sub_order_id = sub_orders.sub_order_id)
+			LEFT OUTER JOIN ledger_entries ON ledger_entries.transaction_id = ledger_transactions.transaction_id
+				AND ledger_entries.account = '` + sellerPendingAccount + `'
+		WHERE sub_orders.sub_order_id = $1
+		GROUP BY sub_orders.sub_order_id;`
+	err = tx.QueryRowContext(context.Background(), query, subOrderId).Scan(&sellerId, &held)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Ledger Entry rows")
+		return errResp
+	}
+
+	postErr := postTransaction(tx, "release", subOrderId, "", "", []ledgerEntry{
+		{account: sellerPendingAccount, sellerId: sellerId, amount: held},
+		{account: sellerAvailableAccount, sellerId: sellerId, amount: -held}})
+
+	if postErr != nil {
+		return postErr
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Ledger transaction")
+		return errResp
+	}
+
+	return nil
+}
+
+/*
+Records a ledger transaction and its entries. Entries with no amount are left out and a payment or release that
+has already been recorded for the sub-order is not recorded again.
+*/
+func postTransaction(tx *sql.Tx, transactionType string, subOrderId string, refundId string, payoutId string,
+	entries []ledgerEntry) *utils.ErrorHandler {
+	var transactionId string
+	var balance int
+
+	for i := 0; i < len(entries); i++ {
+		balance += entries[i].amount
+	}
+
+	if balance != 0 {
+		utils.LogMessage("Ledger transaction " + transactionType + " does not balance")
+		return utils.InternalServerError(nil)
+	}
+
+	query := `INSERT INTO ledger_transactions(transaction_type, sub_order_id, refund_id, payout_id, created_date)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (sub_order_id, transaction_type) WHERE transaction_type IN ('payment', 'release') DO NOTHING
+		RETURNING transaction_id;`
+	err := tx.QueryRowContext(context.Background(), query, transactionType, utils.NewNullableString(subOrderId),
+		utils.NewNullableString(refundId), utils.NewNullableString(payoutId)).Scan(&transactionId)
+
+	if err == sql.ErrNoRows {
+		return nil
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Ledger Transaction rows")
+		return errResp
+	}
+
+	for i := 0; i < len(entries); i++ {
+		if entries[i].amount == 0 {
+			continue
+		}
+
+		query = `INSERT INTO ledger_entries(transaction_id, account, seller_id, amount) VALUES ($1, $2, $3, $4);`
+		_, err = tx.ExecContext(context.Background(), query, transactionId, entries[i].account,
+			utils.NewNullableString(entries[i].sellerId), entries[i].amount)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in inserting Ledger Entry rows")
+			return errResp
+		}
+	}
+
+	return nil
+}
+
+/*
+Calculates the commission of the platform on the products of a sub-order, rounded down to the cent
+*/
+func calculateCommission(productsCost int, commissionPercent int) int {
+	if productsCost <= 0 || commissionPercent <= 0 {
+		return 0
+	}
+
+	if commissionPercent > 100 {
+		commissionPercent = 100
+	}
+
+	return productsCost * commissionPercent / 100
+}
+
+/*
+Parses an optional date of a statement, an empty date is not used to limit the statement
+*/
+func parseStatementDate(date string, name string) (*time.Time, *utils.ErrorHandler) {
+	if date == "" {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, date)
+
+	if err != nil {
+		utils.LogMessage("Statement date is not in RFC3339 format")
+		return nil, utils.BadRequestError("Bad " + name + " data")
+	}
+
+	return &parsed, nil
+}
+
+/*
+Gets the percent of the products cost the platform keeps, set by PLATFORM_COMMISSION_PERCENT
+*/
+func getCommissionPercent() int {
+	percent, err := utils.GetDotEnvInt("PLATFORM_COMMISSION_PERCENT")
+
+	if err != nil || percent < 0 {
+		percent = defaultCommissionPercent
+	}
+
+	return percent
+}
+
+/*
+Gets how many days after delivery the seller funds are held for, set by PAYOUT_HOLD_DAYS
+*/
+func getPayoutHoldDays() int {
+	days, err := utils.GetDotEnvInt("PAYOUT_HOLD_DAYS")
+
+	if err != nil || days < 0 {
+		days = defaultHoldDays
+	}
+
+	return days
+}
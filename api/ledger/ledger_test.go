@@ -0,0 +1,195 @@
+package ledger
+
+import (
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateCommission(t *testing.T) {
+	//Test 1: Commission is rounded down to the cent
+	assert.Equal(t, 999, calculateCommission(9999, 10))
+
+	//Test 2: No commission on free products or with no commission set
+	assert.Equal(t, 0, calculateCommission(0, 10))
+	assert.Equal(t, 0, calculateCommission(10000, 0))
+
+	//Test 3: Commission is never more than the products
+	assert.Equal(t, 10000, calculateCommission(10000, 150))
+}
+
+func TestGetCommissionPercent(t *testing.T) {
+	//Test 1: Setting is not set
+	os.Unsetenv("PLATFORM_COMMISSION_PERCENT")
+	assert.Equal(t, defaultCommissionPercent, getCommissionPercent())
+
+	//Test 2: Setting is set
+	os.Setenv("PLATFORM_COMMISSION_PERCENT", "3")
+	assert.Equal(t, 3, getCommissionPercent())
+
+	//Test 3: Setting is not a number
+	os.Setenv("PLATFORM_COMMISSION_PERCENT", "three")
+	assert.Equal(t, defaultCommissionPercent, getCommissionPercent())
+
+	os.Unsetenv("PLATFORM_COMMISSION_PERCENT")
+}
+
+func TestRecordSubOrderPayment(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+	os.Setenv("PLATFORM_COMMISSION_PERCENT", "10")
+
+	sellerId, subOrderId := createDummySubOrder(t, db)
+
+	//Test 1: Seller is owed the sub-order less the commission on the products
+	recordPayment(t, db, subOrderId)
+	balance, resErr := GetSellerBalance(db, sellerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 9400, balance.Pending)
+	assert.Equal(t, 0, balance.Available)
+
+	//Test 2: Payment is only recorded once
+	recordPayment(t, db, subOrderId)
+	balance, resErr = GetSellerBalance(db, sellerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 9400, balance.Pending)
+
+	//Test 3: Refund returns the commission on the refunded part
+	recordRefund(t, db, subOrderId, 5200)
+	balance, resErr = GetSellerBalance(db, sellerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 4700, balance.Pending)
+
+	//Test 4: Every transaction balances
+	var total int
+	err = db.QueryRowContext(context.Background(), `SELECT COALESCE(SUM(amount), 0) FROM ledger_entries;`).Scan(&total)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+
+	//Test 5: Statement lists the entries of the seller
+	statement, resErr := GetSellerStatement(db, sellerId, "", "")
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, len(statement.Entries))
+	assert.Equal(t, 9400, statement.Entries[0].Amount)
+	assert.Equal(t, -4700, statement.Entries[1].Amount)
+
+	//Test 6: Invalid statement date
+	_, resErr = GetSellerStatement(db, sellerId, "yesterday", "")
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad from data", resErr.Error())
+
+	//Test 7: Seller does not exist
+	_, resErr = GetSellerBalance(db, subOrderId)
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	os.Unsetenv("PLATFORM_COMMISSION_PERCENT")
+	store.CloseDB(db)
+}
+
+func TestReleaseSellerFunds(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+	os.Setenv("PLATFORM_COMMISSION_PERCENT", "10")
+	os.Setenv("PAYOUT_HOLD_DAYS", "7")
+
+	sellerId, subOrderId := createDummySubOrder(t, db)
+	recordPayment(t, db, subOrderId)
+
+	//Test 1: Funds are held until the sub-order is delivered
+	res, resErr := ReleaseSellerFunds(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(res.Released))
+
+	//Test 2: Funds are held during the dispute window
+	_, err = db.ExecContext(context.Background(), `UPDATE sub_orders SET status = 'delivered', delivered_date = NOW() - INTERVAL '6 days';`)
+	assert.NoError(t, err)
+	res, resErr = ReleaseSellerFunds(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(res.Released))
+
+	//Test 3: Funds are available once the dispute window has passed
+	_, err = db.ExecContext(context.Background(), `UPDATE sub_orders SET delivered_date = NOW() - INTERVAL '8 days';`)
+	assert.NoError(t, err)
+	res, resErr = ReleaseSellerFunds(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, []string{subOrderId}, res.Released)
+
+	balance, resErr := GetSellerBalance(db, sellerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, balance.Pending)
+	assert.Equal(t, 9400, balance.Available)
+
+	//Test 4: Funds are only released once
+	res, resErr = ReleaseSellerFunds(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(res.Released))
+
+	//Test 5: Refund after the release is taken off the available balance
+	recordRefund(t, db, subOrderId, 1040)
+	balance, resErr = GetSellerBalance(db, sellerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 8460, balance.Available)
+
+	os.Unsetenv("PLATFORM_COMMISSION_PERCENT")
+	os.Unsetenv("PAYOUT_HOLD_DAYS")
+	store.CloseDB(db)
+}
+
+func createDummySubOrder(t *testing.T, db *sql.DB) (string, string) {
+	var sellerId, buyerId, orderId, subOrderId string
+
+	query := `INSERT INTO sellers(email, seller_name, password) VALUES ('test@aucto.io','test','test') RETURNING seller_id`
+	err := db.QueryRowContext(context.Background(), query).Scan(&sellerId)
+	assert.NoError(t, err)
+
+	query = `INSERT INTO buyers(email, password) VALUES ('test@aucto.io','test') RETURNING buyer_id;`
+	err = db.QueryRowContext(context.Background(), query).Scan(&buyerId)
+	assert.NoError(t, err)
+
+	query = `INSERT INTO orders(buyer_id, delivery_type, delivery_fee, payment_type, payment_fee, small_order_fee, total_paid,
+			phone_number, order_date, address_line_1, postal_code, payment_status)
+		VALUES ($1, 'standard_delivery', 400, 'paynow_online', 0, 0, 10400, '91234567', NOW(), 'Test', '123456', 'completed')
+		RETURNING order_id;`
+	err = db.QueryRowContext(context.Background(), query, buyerId).Scan(&orderId)
+	assert.NoError(t, err)
+
+	query = `INSERT INTO sub_orders(order_id, seller_id, products_cost, discount, delivery_fee, status, created_date, updated_date)
+		VALUES ($1, $2, 10000, 0, 400, 'paid', NOW(), NOW()) RETURNING sub_order_id;`
+	err = db.QueryRowContext(context.Background(), query, orderId, sellerId).Scan(&subOrderId)
+	assert.NoError(t, err)
+
+	return sellerId, subOrderId
+}
+
+func recordPayment(t *testing.T, db *sql.DB, subOrderId string) {
+	tx, err := db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+
+	resErr := RecordSubOrderPayment(tx, subOrderId)
+	assert.Empty(t, resErr)
+	assert.NoError(t, tx.Commit())
+}
+
+func recordRefund(t *testing.T, db *sql.DB, subOrderId string, amount int) {
+	var refundId string
+
+	tx, err := db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+
+	query := `INSERT INTO sub_order_refunds(sub_order_id, amount, created_date) VALUES ($1, $2, NOW()) RETURNING refund_id;`
+	err = tx.QueryRowContext(context.Background(), query, subOrderId, amount).Scan(&refundId)
+	assert.NoError(t, err)
+	_, err = tx.ExecContext(context.Background(), `UPDATE sub_orders SET refunded_amount = refunded_amount + $2 WHERE sub_order_id = $1;`,
+		subOrderId, amount)
+	assert.NoError(t, err)
+
+	resErr := RecordSubOrderRefund(tx, subOrderId, refundId, amount)
+	assert.Empty(t, resErr)
+	assert.NoError(t, tx.Commit())
+}
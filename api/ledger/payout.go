@@ -0,0 +1,272 @@
+package ledger
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"time"
+)
+
+const payoutQuery = `SELECT payouts.payout_id, payouts.batch_id, payouts.seller_id, sellers.seller_name, payouts.amount,
+		payouts.status, payouts.created_date::TEXT, COALESCE(payouts.paid_date::TEXT, '')
+	FROM payouts INNER JOIN sellers ON sellers.seller_id = payouts.seller_id`
+
+/*
+Creates a payout batch that pays out the available balance of every seller that is owed money. Each payout takes the
+amount off the available balance of the seller, the transfer to the seller is marked as done with MarkPayoutPaid.
+Returns an empty batch if no seller has an available balance.
+*/
+func CreatePayoutBatch(db *sql.DB) (data.PayoutBatchData, *utils.ErrorHandler) {
+	response := data.PayoutBatchData{Payouts: []data.PayoutData{}}
+
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Payout transaction")
+		return response, errResp
+	}
+
+	defer tx.Rollback()
+
+	//Only one batch is created at a time so that a balance is never paid out twice
+	_, err = tx.ExecContext(context.Background(), `LOCK TABLE payout_batches IN EXCLUSIVE MODE;`)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in locking Payout Batch rows")
+		return response, errResp
+	}
+
+	query := `SELECT seller_id, -SUM(amount) FROM ledger_entries WHERE account = '` + sellerAvailableAccount + `'
+		GROUP BY seller_id HAVING -SUM(amount) > 0 ORDER BY seller_id;`
+	rows, err := tx.QueryContext(context.Background(), query)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Ledger Entry rows")
+		return response, errResp
+	}
+
+	balances := make(map[string]int)
+	var sellerIds []string
+
+	for rows.Next() {
+		var sellerId string
+		var available int
+		err = rows.Scan(&sellerId, &available)
+
+		if err != nil {
+			rows.Close()
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Ledger Entry rows")
+			return response, errResp
+		}
+
+		balances[sellerId] = available
+		sellerIds = append(sellerIds, sellerId)
+		response.Total += available
+	}
+
+	rows.Close()
+
+	if len(sellerIds) == 0 {
+		return response, nil
+	}
+
+	createdDate := time.Now()
+
+	query = `INSERT INTO payout_batches(total, created_date) VALUES ($1, $2) RETURNING batch_id;`
+	err = tx.QueryRowContext(context.Background(), query, response.Total, createdDate).Scan(&response.BatchId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Payout Batch rows")
+		return response, errResp
+	}
+
+	for i := 0; i < len(sellerIds); i++ {
+		var payoutId string
+		amount := balances[sellerIds[i]]
+
+		query = `INSERT INTO payouts(batch_id, seller_id, amount, created_date) VALUES ($1, $2, $3, $4) RETURNING payout_id;`
+		err = tx.QueryRowContext(context.Background(), query, response.BatchId, sellerIds[i], amount, createdDate).Scan(&payoutId)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in inserting Payout rows")
+			return response, errResp
+		}
+
+		postErr := postTransaction(tx, "payout", "", "", payoutId, []ledgerEntry{
+			{account: sellerAvailableAccount, sellerId: sellerIds[i], amount: amount},
+			{account: cashAccount, amount: -amount}})
+
+		if postErr != nil {
+			return response, postErr
+		}
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Payout transaction")
+		return response, errResp
+	}
+
+	batches, batchErr := getPayoutBatches(db, response.BatchId)
+
+	if batchErr != nil || len(batches) == 0 {
+		return response, batchErr
+	}
+
+	return batches[0], nil
+}
+
+/*
+Marks a payout as paid once the money has been transferred to the seller. Returns a 404 Not found error if the
+payout does not exist and a 400 bad request if it has already been paid.
+*/
+func MarkPayoutPaid(db *sql.DB, payoutId string) (data.PayoutData, *utils.ErrorHandler) {
+	var status string
+
+	query := `SELECT status FROM payouts WHERE payout_id::TEXT = $1;`
+	err := db.QueryRowContext(context.Background(), query, payoutId).Scan(&status)
+
+	if err == sql.ErrNoRows {
+		return data.PayoutData{}, utils.NotFoundError("Payout with given id does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Payout rows")
+		return data.PayoutData{}, errResp
+	}
+
+	if status == "paid" {
+		return data.PayoutData{}, utils.BadRequestError("Payout has already been paid")
+	}
+
+	query = `UPDATE payouts SET status = 'paid', paid_date = NOW() WHERE payout_id = $1;`
+	_, err = db.ExecContext(context.Background(), query, payoutId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Payout rows")
+		return data.PayoutData{}, errResp
+	}
+
+	query = payoutQuery + ` WHERE payouts.payout_id = $1;`
+	var payout data.PayoutData
+	err = scanPayout(db.QueryRowContext(context.Background(), query, payoutId), &payout)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Payout rows")
+		return payout, errResp
+	}
+
+	return payout, nil
+}
+
+/*
+Gets the payout report of the platform: the funds held for sellers, the funds available for the next payout, the
+total paid out, the commission earned and every payout batch from the newest batch.
+*/
+func GetPayoutReport(db *sql.DB) (data.PayoutReportData, *utils.ErrorHandler) {
+	response := data.PayoutReportData{Batches: []data.PayoutBatchData{}}
+
+	query := `SELECT
+			COALESCE(-SUM(amount) FILTER (WHERE account = '` + sellerPendingAccount + `'), 0),
+			COALESCE(-SUM(amount) FILTER (WHERE account = '` + sellerAvailableAccount + `'), 0),
+			COALESCE(-SUM(amount) FILTER (WHERE account = '` + commissionAccount + `'), 0),
+			(SELECT COALESCE(SUM(amount), 0) FROM payouts)
+		FROM ledger_entries;`
+	err := db.QueryRowContext(context.Background(), query).Scan(&response.Pending, &response.Available,
+		&response.Commission, &response.PaidOut)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Ledger Entry rows")
+		return response, errResp
+	}
+
+	batches, batchErr := getPayoutBatches(db, "")
+	response.Batches = append(response.Batches, batches...)
+
+	return response, batchErr
+}
+
+/*
+Gets the payout batches with their payouts from the newest batch, or only the batch with the given id
+*/
+func getPayoutBatches(db *sql.DB, batchId string) ([]data.PayoutBatchData, *utils.ErrorHandler) {
+	var batches []data.PayoutBatchData
+
+	query := `SELECT batch_id, total, created_date::TEXT FROM payout_batches
+		WHERE $1 = '' OR batch_id::TEXT = $1 ORDER BY created_date DESC;`
+	rows, err := db.QueryContext(context.Background(), query, batchId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Payout Batch rows")
+		return batches, errResp
+	}
+
+	batchIndex := make(map[string]int)
+
+	for rows.Next() {
+		batch := data.PayoutBatchData{Payouts: []data.PayoutData{}}
+		err = rows.Scan(&batch.BatchId, &batch.Total, &batch.CreatedDate)
+
+		if err != nil {
+			rows.Close()
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Payout Batch rows")
+			return batches, errResp
+		}
+
+		batchIndex[batch.BatchId] = len(batches)
+		batches = append(batches, batch)
+	}
+
+	rows.Close()
+
+	query = payoutQuery + ` WHERE $1 = '' OR payouts.batch_id::TEXT = $1 ORDER BY sellers.seller_name ASC;`
+	rows, err = db.QueryContext(context.Background(), query, batchId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Payout rows")
+		return batches, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var payout data.PayoutData
+		err = scanPayout(rows, &payout)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Payout rows")
+			return batches, errResp
+		}
+
+		if index, hasBatch := batchIndex[payout.BatchId]; hasBatch {
+			batches[index].Payouts = append(batches[index].Payouts, payout)
+		}
+	}
+
+	return batches, nil
+}
+
+/*
+Scans a row of payout columns into a payout
+*/
+func scanPayout(row interface{ Scan(...interface{}) error }, payout *data.PayoutData) error {
+	return row.Scan(&payout.PayoutId, &payout.BatchId, &payout.SellerId, &payout.SellerName, &payout.Amount,
+		&payout.Status, &payout.CreatedDate, &payout.PaidDate)
+}
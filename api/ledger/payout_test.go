@@ -0,0 +1,73 @@
+package ledger
+
+import (
+	"BackendAPI/store"
+	"context"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePayoutBatch(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+	os.Setenv("PLATFORM_COMMISSION_PERCENT", "10")
+
+	sellerId, subOrderId := createDummySubOrder(t, db)
+	recordPayment(t, db, subOrderId)
+
+	//Test 1: Held funds are not paid out
+	batch, resErr := CreatePayoutBatch(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, "", batch.BatchId)
+	assert.Equal(t, 0, len(batch.Payouts))
+
+	//Test 2: Available balance is paid out
+	_, err = db.ExecContext(context.Background(), `UPDATE sub_orders SET status = 'delivered', delivered_date = NOW() - INTERVAL '30 days';`)
+	assert.NoError(t, err)
+	_, resErr = ReleaseSellerFunds(db)
+	assert.Empty(t, resErr)
+
+	batch, resErr = CreatePayoutBatch(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 9400, batch.Total)
+	assert.Equal(t, 1, len(batch.Payouts))
+	assert.Equal(t, sellerId, batch.Payouts[0].SellerId)
+	assert.Equal(t, "pending", batch.Payouts[0].Status)
+
+	balance, resErr := GetSellerBalance(db, sellerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, balance.Available)
+	assert.Equal(t, 9400, balance.PaidOut)
+
+	//Test 3: Balance is not paid out twice
+	emptyBatch, resErr := CreatePayoutBatch(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(emptyBatch.Payouts))
+
+	//Test 4: Payout is marked as paid once
+	payout, resErr := MarkPayoutPaid(db, batch.Payouts[0].PayoutId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, "paid", payout.Status)
+	_, resErr = MarkPayoutPaid(db, batch.Payouts[0].PayoutId)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Payout has already been paid", resErr.Error())
+
+	//Test 5: Report has the commission and the batch
+	report, resErr := GetPayoutReport(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1000, report.Commission)
+	assert.Equal(t, 9400, report.PaidOut)
+	assert.Equal(t, 0, report.Pending)
+	assert.Equal(t, 1, len(report.Batches))
+
+	//Test 6: Payout does not exist
+	_, resErr = MarkPayoutPaid(db, "wrong id")
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	os.Unsetenv("PLATFORM_COMMISSION_PERCENT")
+	store.CloseDB(db)
+}
@@ -0,0 +1,280 @@
+package notification
+
+import (
+	"BackendAPI/api/buyer"
+	"BackendAPI/api/outbox"
+	"BackendAPI/data"
+	"BackendAPI/mailing"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"time"
+)
+
+const (
+	PriceDropNotification     = "price_drop"
+	RestockNotification       = "restock"
+	AuctionEndingNotification = "auction_ending"
+	SavedSearchNotification   = "saved_search"
+	maxNotifications          = 50
+)
+
+// Every type of notification a buyer can turn off, in the order they are shown
+var notificationTypes = []string{PriceDropNotification, RestockNotification, AuctionEndingNotification,
+	SavedSearchNotification}
+
+/*
+Notifies a buyer in the app and by mail, unless they have turned off notifications of this type. Returns whether
+the buyer was notified. The mail is sent through the outbox, so a mail that fails to send is retried.
+*/
+func Notify(db *sql.DB, buyerId string, notificationType string, title string, message string, productId string) (bool, *utils.ErrorHandler) {
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Notification transaction")
+		return false, errResp
+	}
+
+	defer tx.Rollback()
+
+	notified, messageId, notifyErr := AddNotification(tx, buyerId, notificationType, title, message, productId)
+
+	if notifyErr != nil {
+		return false, notifyErr
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Notification transaction")
+		return false, errResp
+	}
+
+	if messageId != "" {
+		outbox.DeliverMessages(db, []string{messageId})
+	}
+
+	return notified, nil
+}
+
+/*
+Adds a notification of a buyer as part of a transaction, along with its mail in the outbox, unless they have turned
+off notifications of this type. Returns whether the buyer was notified and the id of the outbox message with the
+mail, which is empty if there is no mail to send.
+*/
+func AddNotification(tx *sql.Tx, buyerId string, notificationType string, title string, message string,
+	productId string) (bool, string, *utils.ErrorHandler) {
+	var email, locale string
+	var enabled bool
+
+	query := `SELECT buyers.email, buyers.locale, COALESCE(notification_preferences.enabled, TRUE)
+		FROM buyers LEFT OUTER JOIN notification_preferences
+			ON notification_preferences.buyer_id = buyers.buyer_id AND notification_preferences.notification_type = $2
+		WHERE buyers.buyer_id = $1;`
+	err := tx.QueryRowContext(context.Background(), query, buyerId, notificationType).Scan(&email, &locale, &enabled)
+
+	if err == sql.ErrNoRows {
+		return false, "", utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Notification Preference rows")
+		return false, "", errResp
+	}
+
+	if !enabled {
+		return false, "", nil
+	}
+
+	var product interface{}
+	if productId != "" {
+		product = productId
+	}
+
+	query = `INSERT INTO notifications(buyer_id, notification_type, title, message, product_id, created_date)
+		VALUES ($1, $2, $3, $4, $5, $6);`
+	_, err = tx.ExecContext(context.Background(), query, buyerId, notificationType, title, message, product, time.Now())
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Notification rows")
+		return false, "", errResp
+	}
+
+	mail, err := mailing.ComposeNotificationMail(email, locale, title, message)
+
+	if err != nil {
+		utils.LogError(err, "Error in composing notification mail for buyer "+buyerId)
+		return true, "", nil
+	}
+
+	messageId, outboxErr := outbox.AddMail(tx, mail)
+
+	if outboxErr != nil {
+		return false, "", outboxErr
+	}
+
+	return true, messageId, nil
+}
+
+/*
+Gets the most recent notifications of a buyer from the newest notification along with the number of unread
+notifications. Returns a 404 Not found error if the buyer does not exist.
+*/
+func GetNotifications(db *sql.DB, buyerId string) (data.GetNotificationsResponseData, *utils.ErrorHandler) {
+	response := data.GetNotificationsResponseData{BuyerId: buyerId, Notifications: []data.NotificationData{}}
+
+	if !buyer.DoesBuyerExist(db, buyerId) {
+		return response, utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	query := `SELECT COUNT(*) FROM notifications WHERE buyer_id = $1 AND NOT is_read;`
+	err := db.QueryRowContext(context.Background(), query, buyerId).Scan(&response.UnreadCount)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Notification rows")
+		return response, errResp
+	}
+
+	query = `SELECT notification_id, notification_type, title, message, COALESCE(product_id::TEXT, ''), is_read, created_date::TEXT
+		FROM notifications WHERE buyer_id = $1 ORDER BY created_date DESC LIMIT $2;`
+	rows, err := db.QueryContext(context.Background(), query, buyerId, maxNotifications)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Notification rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var notification data.NotificationData
+		err = rows.Scan(&notification.NotificationId, &notification.NotificationType, &notification.Title,
+			&notification.Message, &notification.ProductId, &notification.IsRead, &notification.CreatedDate)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Notification rows")
+			return response, errResp
+		}
+
+		response.Notifications = append(response.Notifications, notification)
+	}
+
+	return response, nil
+}
+
+/*
+Marks every notification of a buyer as read
+*/
+func MarkNotificationsRead(db *sql.DB, buyerId string) (data.GetNotificationsResponseData, *utils.ErrorHandler) {
+	if !buyer.DoesBuyerExist(db, buyerId) {
+		return data.GetNotificationsResponseData{}, utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	query := `UPDATE notifications SET is_read = TRUE WHERE buyer_id = $1 AND NOT is_read;`
+	_, err := db.ExecContext(context.Background(), query, buyerId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Notification rows")
+		return data.GetNotificationsResponseData{}, errResp
+	}
+
+	return GetNotifications(db, buyerId)
+}
+
+/*
+Gets whether a buyer gets each type of notification. Returns a 404 Not found error if the buyer does not exist.
+*/
+func GetNotificationPreferences(db *sql.DB, buyerId string) (data.NotificationPreferencesData, *utils.ErrorHandler) {
+	response := data.NotificationPreferencesData{BuyerId: buyerId, Preferences: []data.NotificationPreferenceData{}}
+
+	if !buyer.DoesBuyerExist(db, buyerId) {
+		return response, utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	query := `SELECT notification_type, enabled FROM notification_preferences WHERE buyer_id = $1;`
+	rows, err := db.QueryContext(context.Background(), query, buyerId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Notification Preference rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	enabled := make(map[string]bool)
+
+	for rows.Next() {
+		var notificationType string
+		var isEnabled bool
+		err = rows.Scan(&notificationType, &isEnabled)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Notification Preference rows")
+			return response, errResp
+		}
+
+		enabled[notificationType] = isEnabled
+	}
+
+	for i := 0; i < len(notificationTypes); i++ {
+		isEnabled, hasPreference := enabled[notificationTypes[i]]
+		response.Preferences = append(response.Preferences, data.NotificationPreferenceData{
+			NotificationType: notificationTypes[i],
+			Enabled:          isEnabled || !hasPreference})
+	}
+
+	return response, nil
+}
+
+/*
+Turns types of notifications on or off for a buyer, types that are left out keep their current setting
+*/
+func UpdateNotificationPreferences(db *sql.DB, buyerId string, request data.NotificationPreferencesData) (data.NotificationPreferencesData, *utils.ErrorHandler) {
+	if !buyer.DoesBuyerExist(db, buyerId) {
+		return data.NotificationPreferencesData{}, utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	for i := 0; i < len(request.Preferences); i++ {
+		if !isNotificationType(request.Preferences[i].NotificationType) {
+			return data.NotificationPreferencesData{}, utils.BadRequestError("Bad notification_type data")
+		}
+	}
+
+	for i := 0; i < len(request.Preferences); i++ {
+		query := `INSERT INTO notification_preferences(buyer_id, notification_type, enabled) VALUES ($1, $2, $3)
+			ON CONFLICT (buyer_id, notification_type) DO UPDATE SET enabled = EXCLUDED.enabled;`
+		_, err := db.ExecContext(context.Background(), query, buyerId, request.Preferences[i].NotificationType,
+			request.Preferences[i].Enabled)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in inserting Notification Preference rows")
+			return data.NotificationPreferencesData{}, errResp
+		}
+	}
+
+	return GetNotificationPreferences(db, buyerId)
+}
+
+/*
+Checks wether a notification type is one that buyers can turn off
+*/
+func isNotificationType(notificationType string) bool {
+	for i := 0; i < len(notificationTypes); i++ {
+		if notificationTypes[i] == notificationType {
+			return true
+		}
+	}
+
+	return false
+}
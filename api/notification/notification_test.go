@@ -0,0 +1,131 @@
+package notification
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/mailing"
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotify(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	mailer := mailing.NewMemoryMailer()
+	mailing.UseMailer(mailer)
+	defer mailing.UseMailer(nil)
+
+	buyerId := createDummyBuyer(t, db)
+
+	//Test 1: Buyer is notified in the app and by mail
+	notified, resErr := Notify(db, buyerId, RestockNotification, "Back in stock", "Test has been restocked.", "")
+	assert.Empty(t, resErr)
+	assert.Equal(t, true, notified)
+	messages := mailer.Messages()
+	assert.Equal(t, 1, len(messages))
+	assert.Equal(t, "test@aucto.io", messages[0].ToEmail)
+
+	res, resErr := GetNotifications(db, buyerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, res.UnreadCount)
+	assert.Equal(t, 1, len(res.Notifications))
+	assert.Equal(t, RestockNotification, res.Notifications[0].NotificationType)
+	assert.Equal(t, "", res.Notifications[0].ProductId)
+
+	//Test 2: Failed mails still leave the notification in the app and the mail in the outbox to be retried
+	mailing.UseMailer(failingMailer{})
+	notified, resErr = Notify(db, buyerId, RestockNotification, "Back in stock", "Test has been restocked.", "")
+	assert.Empty(t, resErr)
+	assert.Equal(t, true, notified)
+	var pending int
+	query := `SELECT COUNT(*) FROM outbox_messages WHERE topic = 'mail' AND status = 'pending' AND payload->>'ToEmail' = $1;`
+	assert.NoError(t, db.QueryRowContext(context.Background(), query, "test@aucto.io").Scan(&pending))
+	assert.Equal(t, 1, pending)
+	mailing.UseMailer(mailer)
+
+	//Test 3: Buyer is not notified of types they turned off
+	_, resErr = UpdateNotificationPreferences(db, buyerId, data.NotificationPreferencesData{
+		Preferences: []data.NotificationPreferenceData{{NotificationType: RestockNotification, Enabled: false}}})
+	assert.Empty(t, resErr)
+	notified, resErr = Notify(db, buyerId, RestockNotification, "Back in stock", "Test has been restocked.", "")
+	assert.Empty(t, resErr)
+	assert.Equal(t, false, notified)
+	assert.Equal(t, 1, len(mailer.Messages()))
+
+	//Test 4: Notifications are marked as read
+	res, resErr = MarkNotificationsRead(db, buyerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, res.UnreadCount)
+	assert.Equal(t, 2, len(res.Notifications))
+
+	//Test 5: Buyer does not exist
+	_, resErr = Notify(db, "00000000-0000-0000-0000-000000000000", RestockNotification, "Back in stock", "", "")
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func TestUpdateNotificationPreferences(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerId := createDummyBuyer(t, db)
+
+	//Test 1: Every type is turned on by default
+	res, resErr := GetNotificationPreferences(db, buyerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 4, len(res.Preferences))
+	for i := 0; i < len(res.Preferences); i++ {
+		assert.Equal(t, true, res.Preferences[i].Enabled)
+	}
+
+	//Test 2: Turn off a type
+	res, resErr = UpdateNotificationPreferences(db, buyerId, data.NotificationPreferencesData{
+		Preferences: []data.NotificationPreferenceData{{NotificationType: PriceDropNotification, Enabled: false}}})
+	assert.Empty(t, resErr)
+	assert.Equal(t, PriceDropNotification, res.Preferences[0].NotificationType)
+	assert.Equal(t, false, res.Preferences[0].Enabled)
+	assert.Equal(t, true, res.Preferences[1].Enabled)
+
+	//Test 3: Turn the type back on
+	res, resErr = UpdateNotificationPreferences(db, buyerId, data.NotificationPreferencesData{
+		Preferences: []data.NotificationPreferenceData{{NotificationType: PriceDropNotification, Enabled: true}}})
+	assert.Empty(t, resErr)
+	assert.Equal(t, true, res.Preferences[0].Enabled)
+
+	//Test 4: Unknown type
+	_, resErr = UpdateNotificationPreferences(db, buyerId, data.NotificationPreferencesData{
+		Preferences: []data.NotificationPreferenceData{{NotificationType: "wrong", Enabled: false}}})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad notification_type data", resErr.Error())
+
+	//Test 5: Buyer does not exist
+	_, resErr = GetNotificationPreferences(db, "wrong id")
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func createDummyBuyer(t *testing.T, db *sql.DB) string {
+	var buyerId string
+
+	query := `INSERT INTO buyers(email, password) VALUES ('test@aucto.io','test') RETURNING buyer_id;`
+	err := db.QueryRowContext(context.Background(), query).Scan(&buyerId)
+	assert.NoError(t, err)
+
+	return buyerId
+}
+
+type failingMailer struct{}
+
+func (failingMailer) Send(message mailing.Message) error {
+	return errors.New("failed")
+}
@@ -0,0 +1,636 @@
+package offer
+
+import (
+	"BackendAPI/api/buyer"
+	"BackendAPI/api/outbox"
+	"BackendAPI/api/telegram"
+	"BackendAPI/api/webhook"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	defaultOfferExpiryHours      = 48
+	defaultOfferReservationHours = 24
+	expiredOfferCondition        = `((offers.status IN ('pending', 'countered') AND offers.expires_at <= NOW()) OR (offers.status = 'accepted' AND offers.reserved_until <= NOW()))`
+	selectOfferQuery             = `SELECT offers.offer_id, offers.product_id, offers.buyer_id, products.seller_id, offers.quantity, offers.amount,
+			COALESCE(offers.counter_amount, 0),
+			CASE WHEN offers.status IN ('accepted', 'completed') THEN COALESCE(offers.counter_amount, offers.amount) ELSE 0 END,
+			CASE WHEN ` + expiredOfferCondition + ` THEN 'expired' ELSE offers.status END,
+			offers.created_date::TEXT, offers.expires_at::TEXT, COALESCE(offers.reserved_until::TEXT, ''), COALESCE(offers.order_id::TEXT, '')
+		FROM offers INNER JOIN products ON products.product_id = offers.product_id`
+)
+
+/*
+Makes an offer on a Buy-Now product for a buyer. The offer stays open for the seller to respond to until it expires.
+*/
+func CreateOffer(db *sql.DB, productId string, request data.CreateOfferRequestData) (data.OfferData, *utils.ErrorHandler) {
+	var response data.OfferData
+
+	if request.Quantity == 0 {
+		request.Quantity = 1
+	}
+
+	validateErr := validateCreateOffer(db, productId, request)
+
+	if validateErr != nil {
+		return response, validateErr
+	}
+
+	// Offers that have run out of time still hold the buyer's open offer until they are marked as expired
+	query := `UPDATE offers SET status = 'expired' WHERE product_id = $1 AND buyer_id = $2 AND ` + expiredOfferCondition + `;`
+	_, err := db.ExecContext(context.Background(), query, productId, request.BuyerId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Offer rows")
+		return response, errResp
+	}
+
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Offer transaction")
+		return response, errResp
+	}
+
+	defer tx.Rollback()
+
+	var offerId string
+	query = `INSERT INTO offers(product_id, buyer_id, quantity, amount, created_date, expires_at)
+		VALUES ($1,$2,$3,$4,NOW(),$5) ON CONFLICT DO NOTHING RETURNING offer_id;`
+	err = tx.QueryRowContext(context.Background(), query, productId, request.BuyerId, request.Quantity, request.Amount,
+		time.Now().Add(getOfferExpiry())).Scan(&offerId)
+
+	if err == sql.ErrNoRows {
+		return response, utils.BadRequestError("Buyer already has an open offer on this product")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Offer rows")
+		return response, errResp
+	}
+
+	messageIds, webhookErr := webhook.AddOfferReceivedEvent(tx, offerId)
+
+	if webhookErr != nil {
+		return response, webhookErr
+	}
+
+	commitErr := commitOffer(db, tx, offerId, telegram.SellerAccount, messageIds...)
+
+	if commitErr != nil {
+		return response, commitErr
+	}
+
+	return GetOfferById(db, offerId)
+}
+
+/*
+Responds to an open offer as the seller of the product. The seller can accept or reject the offer, or counter
+it with a higher price that the buyer then has to respond to.
+*/
+func RespondToOfferAsSeller(db *sql.DB, offerId string, request data.SellerOfferResponseRequestData) (data.OfferData, *utils.ErrorHandler) {
+	offer, err := GetOfferById(db, offerId)
+
+	if err != nil {
+		return offer, err
+	}
+
+	if offer.SellerId != request.SellerId {
+		utils.LogMessage("Seller does not own the product of the offer")
+		return offer, utils.BadRequestError("Bad seller_id data")
+	}
+
+	if offer.Status != "pending" {
+		return offer, utils.BadRequestError("Offer is no longer open")
+	}
+
+	switch request.Action {
+	case "accept":
+		return acceptOffer(db, offer, "pending", telegram.BuyerAccount)
+	case "reject":
+		return updateOfferStatus(db, offer, "pending", "rejected", telegram.BuyerAccount)
+	case "counter":
+		listedPrice, priceErr := getListedPrice(db, offer.ProductId)
+
+		if priceErr != nil {
+			return offer, priceErr
+		}
+
+		if request.CounterAmount <= offer.Amount || request.CounterAmount >= listedPrice {
+			utils.LogMessage("Counter offer is not between the offer and the listed price")
+			return offer, utils.BadRequestError("Bad counter_amount data")
+		}
+
+		tx, execErr := db.BeginTx(context.Background(), nil)
+
+		if execErr != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(execErr, "Error in starting Offer transaction")
+			return offer, errResp
+		}
+
+		defer tx.Rollback()
+
+		query := `UPDATE offers SET status = 'countered', counter_amount = $2, expires_at = $3
+			WHERE offer_id = $1 AND status = 'pending' AND NOT ` + expiredOfferCondition + `;`
+		result, execErr := tx.ExecContext(context.Background(), query, offerId, request.CounterAmount, time.Now().Add(getOfferExpiry()))
+
+		if execErr != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(execErr, "Error in updating Offer rows")
+			return offer, errResp
+		}
+
+		if rows, _ := result.RowsAffected(); rows == 0 {
+			return offer, utils.BadRequestError("Offer is no longer open")
+		}
+
+		commitErr := commitOffer(db, tx, offerId, telegram.BuyerAccount)
+
+		if commitErr != nil {
+			return offer, commitErr
+		}
+
+		return GetOfferById(db, offerId)
+	}
+
+	utils.LogMessage("Seller offer action is invalid")
+	return offer, utils.BadRequestError("Bad action data")
+}
+
+/*
+Responds to a counter offer as the buyer that made the offer, the buyer can accept or reject the counter offer
+*/
+func RespondToOfferAsBuyer(db *sql.DB, offerId string, request data.BuyerOfferResponseRequestData) (data.OfferData, *utils.ErrorHandler) {
+	offer, err := GetOfferById(db, offerId)
+
+	if err != nil {
+		return offer, err
+	}
+
+	if offer.BuyerId != request.BuyerId {
+		utils.LogMessage("Buyer did not make the offer")
+		return offer, utils.BadRequestError("Bad buyer_id data")
+	}
+
+	if offer.Status != "countered" {
+		return offer, utils.BadRequestError("Offer is no longer open")
+	}
+
+	switch request.Action {
+	case "accept":
+		return acceptOffer(db, offer, "countered", telegram.SellerAccount)
+	case "reject":
+		return updateOfferStatus(db, offer, "countered", "rejected", telegram.SellerAccount)
+	}
+
+	utils.LogMessage("Buyer offer action is invalid")
+	return offer, utils.BadRequestError("Bad action data")
+}
+
+/*
+Gets an offer by its id, if the offer does not exist returns a 404 Error
+*/
+func GetOfferById(db *sql.DB, offerId string) (data.OfferData, *utils.ErrorHandler) {
+	var offer data.OfferData
+
+	query := selectOfferQuery + ` WHERE offers.offer_id = $1;`
+	err := scanOffer(db.QueryRowContext(context.Background(), query, offerId), &offer)
+
+	if err == sql.ErrNoRows {
+		return offer, utils.NotFoundError("Offer with given id does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Offer rows")
+		return offer, errResp
+	}
+
+	return offer, nil
+}
+
+/*
+Gets the offers made on the products of a seller, from the newest offer
+*/
+func GetSellerOffers(db *sql.DB, sellerId string) (data.GetOffersResponseData, *utils.ErrorHandler) {
+	return getOffers(db, `products.seller_id = $1`, sellerId)
+}
+
+/*
+Gets the offers made by a buyer, from the newest offer
+*/
+func GetBuyerOffers(db *sql.DB, buyerId string) (data.GetOffersResponseData, *utils.ErrorHandler) {
+	return getOffers(db, `offers.buyer_id = $1`, buyerId)
+}
+
+/*
+Marks the offers that have run out of time as expired. Open offers expire when the seller or buyer does not
+respond in time and accepted offers expire when the buyer does not check out before the reservation ends.
+*/
+func ExpireOffers(db *sql.DB) (data.ExpireOffersResponseData, *utils.ErrorHandler) {
+	response := data.ExpireOffersResponseData{Expired: []string{}}
+
+	query := `UPDATE offers SET status = 'expired' WHERE ` + expiredOfferCondition + ` RETURNING offer_id;`
+	rows, err := db.QueryContext(context.Background(), query)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Offer rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var offerId string
+		err = rows.Scan(&offerId)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in updating Offer rows")
+			return response, errResp
+		}
+
+		response.Expired = append(response.Expired, offerId)
+	}
+
+	return response, nil
+}
+
+/*
+Gets the accepted offer that a buyer is checking out with. Returns a 400 bad request if the offer
+is not the buyer's or its reservation has ended.
+*/
+func GetCheckoutOffer(db *sql.DB, offerId string, buyerId string) (data.OfferData, *utils.ErrorHandler) {
+	offer, err := GetOfferById(db, offerId)
+
+	if err != nil {
+		utils.LogMessage("Offer with given id does not exist")
+		return offer, utils.BadRequestError("Bad offer_id data")
+	}
+
+	if offer.BuyerId != buyerId {
+		utils.LogMessage("Offer was made by a different buyer")
+		return offer, utils.BadRequestError("Bad offer_id data")
+	}
+
+	if offer.Status != "accepted" {
+		return offer, utils.BadRequestError("Offer is not accepted or its reservation has ended")
+	}
+
+	return offer, nil
+}
+
+/*
+Attaches an accepted offer to the order that checks it out, as part of the transaction that creates the order. The
+offer stays accepted, and its stock reserved, until the payment of the order completes or fails. A buyer who leaves
+the payment page can check the offer out again with a new order while the reservation lasts.
+*/
+func AttachOffer(tx *sql.Tx, offerId string, orderId string) *utils.ErrorHandler {
+	query := `UPDATE offers SET order_id = $2 WHERE offer_id = $1 AND status = 'accepted' AND reserved_until > NOW();`
+	result, err := tx.ExecContext(context.Background(), query, offerId, orderId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Offer rows")
+		return errResp
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return utils.BadRequestError("Offer is not accepted or its reservation has ended")
+	}
+
+	return nil
+}
+
+/*
+Updates the offer attached to an order once the payment of the order is known. The offer of a completed payment is
+completed so that it cannot be checked out again, even if its reservation ran out while the buyer was paying. The
+offer of a failed payment is detached from the order and stays accepted, so that the buyer can still check it out.
+*/
+func UpdateOrderOfferStatus(tx *sql.Tx, orderId string, paymentStatus string) *utils.ErrorHandler {
+	var query string
+
+	switch paymentStatus {
+	case "completed":
+		query = `UPDATE offers SET status = 'completed' WHERE order_id = $1 AND status IN ('accepted', 'expired');`
+	case "failed":
+		query = `UPDATE offers SET order_id = NULL WHERE order_id = $1 AND status = 'accepted';`
+	default:
+		return nil
+	}
+
+	_, err := tx.ExecContext(context.Background(), query, orderId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Offer rows")
+		return errResp
+	}
+
+	return nil
+}
+
+/*
+Gets the quantity of each product that is reserved by the accepted offers of other buyers. An empty
+buyer id counts the reservations of every buyer, which is used for guest orders.
+*/
+func GetReservedQuantities(db *sql.DB, productIds []string, buyerId string) (map[string]int, *utils.ErrorHandler) {
+	reserved := make(map[string]int)
+
+	query := `SELECT product_id, SUM(quantity) FROM offers
+		WHERE status = 'accepted' AND reserved_until > NOW() AND product_id::TEXT = ANY($1) AND buyer_id::TEXT <> $2
+		GROUP BY product_id;`
+	rows, err := db.QueryContext(context.Background(), query, pq.Array(productIds), buyerId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting reserved Offer rows")
+		return reserved, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var productId string
+		var quantity int
+		err = rows.Scan(&productId, &quantity)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting reserved Offer rows")
+			return reserved, errResp
+		}
+
+		reserved[productId] = quantity
+	}
+
+	return reserved, nil
+}
+
+/*
+Accepts an offer at the latest price and reserves its quantity for the buyer. The product row is locked so that
+offers accepted at the same time cannot reserve more than the remaining stock.
+*/
+func acceptOffer(db *sql.DB, offer data.OfferData, fromStatus string, recipientType string) (data.OfferData, *utils.ErrorHandler) {
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Offer transaction")
+		return offer, errResp
+	}
+
+	defer tx.Rollback()
+
+	var remaining, reserved int
+	query := `SELECT product_quantity - sold_quantity FROM products WHERE product_id = $1 FOR UPDATE;`
+	err = tx.QueryRowContext(context.Background(), query, offer.ProductId).Scan(&remaining)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting product rows")
+		return offer, errResp
+	}
+
+	query = `SELECT COALESCE(SUM(quantity), 0) FROM offers
+		WHERE product_id = $1 AND status = 'accepted' AND reserved_until > NOW();`
+	err = tx.QueryRowContext(context.Background(), query, offer.ProductId).Scan(&reserved)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting reserved Offer rows")
+		return offer, errResp
+	}
+
+	if remaining-reserved < offer.Quantity {
+		return offer, utils.BadRequestError("Not enough stock left to accept the offer")
+	}
+
+	query = `UPDATE offers SET status = 'accepted', reserved_until = $3
+		WHERE offer_id = $1 AND status = $2 AND NOT ` + expiredOfferCondition + `;`
+	result, err := tx.ExecContext(context.Background(), query, offer.OfferId, fromStatus, time.Now().Add(getOfferReservation()))
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Offer rows")
+		return offer, errResp
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return offer, utils.BadRequestError("Offer is no longer open")
+	}
+
+	commitErr := commitOffer(db, tx, offer.OfferId, recipientType)
+
+	if commitErr != nil {
+		return offer, commitErr
+	}
+
+	return GetOfferById(db, offer.OfferId)
+}
+
+/*
+Moves an open offer to a new status, as long as the offer is still open
+*/
+func updateOfferStatus(db *sql.DB, offer data.OfferData, fromStatus string, toStatus string, recipientType string) (data.OfferData, *utils.ErrorHandler) {
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Offer transaction")
+		return offer, errResp
+	}
+
+	defer tx.Rollback()
+
+	query := `UPDATE offers SET status = $3 WHERE offer_id = $1 AND status = $2 AND NOT ` + expiredOfferCondition + `;`
+	result, err := tx.ExecContext(context.Background(), query, offer.OfferId, fromStatus, toStatus)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Offer rows")
+		return offer, errResp
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return offer, utils.BadRequestError("Offer is no longer open")
+	}
+
+	commitErr := commitOffer(db, tx, offer.OfferId, recipientType)
+
+	if commitErr != nil {
+		return offer, commitErr
+	}
+
+	return GetOfferById(db, offer.OfferId)
+}
+
+/*
+Commits a change to an offer along with the Telegram message that tells the other side of the offer about it. The
+message and any other outbox messages added with the change are delivered once the change is committed.
+*/
+func commitOffer(db *sql.DB, tx *sql.Tx, offerId string, recipientType string, messageIds ...string) *utils.ErrorHandler {
+	messageId, outboxErr := telegram.AddOfferMessage(tx, offerId, recipientType)
+
+	if outboxErr != nil {
+		return outboxErr
+	}
+
+	err := tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Offer transaction")
+		return errResp
+	}
+
+	if messageId != "" {
+		messageIds = append(messageIds, messageId)
+	}
+
+	outbox.DeliverMessages(db, messageIds)
+
+	return nil
+}
+
+/*
+Gets the offers that match a condition on a single id
+*/
+func getOffers(db *sql.DB, condition string, id string) (data.GetOffersResponseData, *utils.ErrorHandler) {
+	response := data.GetOffersResponseData{Offers: []data.OfferData{}}
+
+	query := selectOfferQuery + ` WHERE ` + condition + ` ORDER BY offers.created_date DESC;`
+	rows, err := db.QueryContext(context.Background(), query, id)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Offer rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var offer data.OfferData
+		err = scanOffer(rows, &offer)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Offer rows")
+			return response, errResp
+		}
+
+		response.Offers = append(response.Offers, offer)
+	}
+
+	return response, nil
+}
+
+/*
+Scans a row selected with the offer query into an offer
+*/
+func scanOffer(row interface{ Scan(...interface{}) error }, offer *data.OfferData) error {
+	return row.Scan(&offer.OfferId, &offer.ProductId, &offer.BuyerId, &offer.SellerId, &offer.Quantity, &offer.Amount,
+		&offer.CounterAmount, &offer.AgreedAmount, &offer.Status, &offer.CreatedDate, &offer.ExpiresAt,
+		&offer.ReservedUntil, &offer.OrderId)
+}
+
+/*
+Gets the price that a product is listed at after its discount
+*/
+func getListedPrice(db *sql.DB, productId string) (int, *utils.ErrorHandler) {
+	var price int
+
+	query := `SELECT price - COALESCE(discount, 0)
+		FROM products LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id
+		WHERE products.product_id = $1;`
+	err := db.QueryRowContext(context.Background(), query, productId).Scan(&price)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting product rows")
+		return price, errResp
+	}
+
+	return price, nil
+}
+
+/*
+Validates a new offer on a product
+*/
+func validateCreateOffer(db *sql.DB, productId string, request data.CreateOfferRequestData) *utils.ErrorHandler {
+	var productType string
+	var remaining int
+
+	query := `SELECT product_type, product_quantity - sold_quantity FROM products WHERE product_id = $1;`
+	err := db.QueryRowContext(context.Background(), query, productId).Scan(&productType, &remaining)
+
+	if err != nil {
+		utils.LogMessage("Product with given id does not exist")
+		return utils.NotFoundError("Product with given id does not exist")
+	}
+
+	if productType != "Buy-Now" {
+		return utils.BadRequestError("Offers can only be made on Buy-Now products")
+	}
+
+	if !buyer.DoesBuyerExist(db, request.BuyerId) {
+		utils.LogMessage("Buyer with given id does not exist")
+		return utils.BadRequestError("Bad buyer_id data")
+	}
+
+	if request.Quantity < 0 || request.Quantity > remaining {
+		utils.LogMessage("Offer quantity is greater than available stock")
+		return utils.BadRequestError("Bad quantity data")
+	}
+
+	listedPrice, priceErr := getListedPrice(db, productId)
+
+	if priceErr != nil {
+		return priceErr
+	}
+
+	if request.Amount <= 0 || request.Amount >= listedPrice {
+		utils.LogMessage("Offer amount is not below the listed price")
+		return utils.BadRequestError("Bad amount data")
+	}
+
+	return nil
+}
+
+/*
+Gets how long offers stay open for a response, set by OFFER_EXPIRY_HOURS
+*/
+func getOfferExpiry() time.Duration {
+	hours, err := utils.GetDotEnvInt("OFFER_EXPIRY_HOURS")
+
+	if err != nil || hours <= 0 {
+		hours = defaultOfferExpiryHours
+	}
+
+	return time.Duration(hours) * time.Hour
+}
+
+/*
+Gets how long an accepted offer is reserved for the buyer to check out, set by OFFER_RESERVATION_HOURS
+*/
+func getOfferReservation() time.Duration {
+	hours, err := utils.GetDotEnvInt("OFFER_RESERVATION_HOURS")
+
+	if err != nil || hours <= 0 {
+		hours = defaultOfferReservationHours
+	}
+
+	return time.Duration(hours) * time.Hour
+}
@@ -0,0 +1,251 @@
+package offer
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateOffer(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerId, buyerIds, productIds := createDummyData(t, db)
+
+	//Test 1: Valid offer
+	res, resErr := CreateOffer(db, productIds[0], data.CreateOfferRequestData{BuyerId: buyerIds[0], Amount: 8000})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "pending", res.Status)
+	assert.Equal(t, 1, res.Quantity)
+	assert.Equal(t, sellerId, res.SellerId)
+
+	//Test 2: Buyer already has an open offer
+	_, resErr = CreateOffer(db, productIds[0], data.CreateOfferRequestData{BuyerId: buyerIds[0], Amount: 8500})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Buyer already has an open offer on this product", resErr.Error())
+
+	//Test 3: Offer at the listed price
+	_, resErr = CreateOffer(db, productIds[0], data.CreateOfferRequestData{BuyerId: buyerIds[1], Amount: 10000})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad amount data", resErr.Error())
+
+	//Test 4: Offer above the stock
+	_, resErr = CreateOffer(db, productIds[0], data.CreateOfferRequestData{BuyerId: buyerIds[1], Amount: 8000, Quantity: 4})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad quantity data", resErr.Error())
+
+	//Test 5: Offers are only for Buy-Now products
+	_, resErr = CreateOffer(db, productIds[1], data.CreateOfferRequestData{BuyerId: buyerIds[1], Amount: 8000})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Offers can only be made on Buy-Now products", resErr.Error())
+
+	//Test 6: Expired offer does not block a new offer
+	_, execErr := db.ExecContext(context.Background(), `UPDATE offers SET expires_at = NOW() - INTERVAL '1 minute';`)
+	assert.NoError(t, execErr)
+	res, resErr = CreateOffer(db, productIds[0], data.CreateOfferRequestData{BuyerId: buyerIds[0], Amount: 8500})
+	assert.Empty(t, resErr)
+	assert.Equal(t, 8500, res.Amount)
+
+	store.CloseDB(db)
+}
+
+func TestRespondToOffer(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerId, buyerIds, productIds := createDummyData(t, db)
+
+	offer1, _ := CreateOffer(db, productIds[0], data.CreateOfferRequestData{BuyerId: buyerIds[0], Amount: 7000, Quantity: 2})
+	offer2, _ := CreateOffer(db, productIds[0], data.CreateOfferRequestData{BuyerId: buyerIds[1], Amount: 6000, Quantity: 2})
+
+	//Test 1: Only the seller of the product can respond
+	_, resErr := RespondToOfferAsSeller(db, offer1.OfferId, data.SellerOfferResponseRequestData{SellerId: buyerIds[0], Action: "accept"})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad seller_id data", resErr.Error())
+
+	//Test 2: Counter offer has to be above the offer
+	_, resErr = RespondToOfferAsSeller(db, offer1.OfferId, data.SellerOfferResponseRequestData{SellerId: sellerId, Action: "counter", CounterAmount: 6500})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad counter_amount data", resErr.Error())
+
+	//Test 3: Valid counter offer
+	res, resErr := RespondToOfferAsSeller(db, offer1.OfferId, data.SellerOfferResponseRequestData{SellerId: sellerId, Action: "counter", CounterAmount: 9000})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "countered", res.Status)
+	assert.Equal(t, 9000, res.CounterAmount)
+
+	//Test 4: Seller cannot respond to a counter offer
+	_, resErr = RespondToOfferAsSeller(db, offer1.OfferId, data.SellerOfferResponseRequestData{SellerId: sellerId, Action: "accept"})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Offer is no longer open", resErr.Error())
+
+	//Test 5: Buyer accepts the counter offer at the counter price
+	res, resErr = RespondToOfferAsBuyer(db, offer1.OfferId, data.BuyerOfferResponseRequestData{BuyerId: buyerIds[0], Action: "accept"})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "accepted", res.Status)
+	assert.Equal(t, 9000, res.AgreedAmount)
+	assert.NotEqual(t, "", res.ReservedUntil)
+
+	//Test 6: Reserved stock cannot be reserved again
+	_, resErr = RespondToOfferAsSeller(db, offer2.OfferId, data.SellerOfferResponseRequestData{SellerId: sellerId, Action: "accept"})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Not enough stock left to accept the offer", resErr.Error())
+
+	reserved, resErr := GetReservedQuantities(db, productIds[:1], buyerIds[1])
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, reserved[productIds[0]])
+	reserved, resErr = GetReservedQuantities(db, productIds[:1], buyerIds[0])
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, reserved[productIds[0]])
+
+	//Test 7: Seller rejects an offer
+	res, resErr = RespondToOfferAsSeller(db, offer2.OfferId, data.SellerOfferResponseRequestData{SellerId: sellerId, Action: "reject"})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "rejected", res.Status)
+
+	//Test 8: Offers of a seller and a buyer
+	offers, resErr := GetSellerOffers(db, sellerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, len(offers.Offers))
+	offers, resErr = GetBuyerOffers(db, buyerIds[0])
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(offers.Offers))
+
+	store.CloseDB(db)
+}
+
+func TestCheckoutOffer(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerId, buyerIds, productIds := createDummyData(t, db)
+
+	offer, _ := CreateOffer(db, productIds[0], data.CreateOfferRequestData{BuyerId: buyerIds[0], Amount: 7000})
+
+	//Test 1: Offer that is not accepted cannot be checked out
+	_, resErr := GetCheckoutOffer(db, offer.OfferId, buyerIds[0])
+	assert.Error(t, resErr)
+	assert.Equal(t, "Offer is not accepted or its reservation has ended", resErr.Error())
+
+	//Test 2: Accepted offer of another buyer
+	_, resErr = RespondToOfferAsSeller(db, offer.OfferId, data.SellerOfferResponseRequestData{SellerId: sellerId, Action: "accept"})
+	assert.Empty(t, resErr)
+	_, resErr = GetCheckoutOffer(db, offer.OfferId, buyerIds[1])
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad offer_id data", resErr.Error())
+
+	//Test 3: Accepted offer of the buyer
+	res, resErr := GetCheckoutOffer(db, offer.OfferId, buyerIds[0])
+	assert.Empty(t, resErr)
+	assert.Equal(t, 7000, res.AgreedAmount)
+
+	//Test 4: Reservation ends and the offer expires
+	_, execErr := db.ExecContext(context.Background(), `UPDATE offers SET reserved_until = NOW() - INTERVAL '1 minute';`)
+	assert.NoError(t, execErr)
+	_, resErr = GetCheckoutOffer(db, offer.OfferId, buyerIds[0])
+	assert.Error(t, resErr)
+
+	expired, resErr := ExpireOffers(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, []string{offer.OfferId}, expired.Expired)
+
+	store.CloseDB(db)
+}
+
+func TestUpdateOrderOfferStatus(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerId, buyerIds, productIds := createDummyData(t, db)
+
+	offer, _ := CreateOffer(db, productIds[0], data.CreateOfferRequestData{BuyerId: buyerIds[0], Amount: 7000})
+	_, resErr := RespondToOfferAsSeller(db, offer.OfferId, data.SellerOfferResponseRequestData{SellerId: sellerId, Action: "accept"})
+	assert.Empty(t, resErr)
+
+	orderIds := []string{createDummyOrder(t, db, buyerIds[0]), createDummyOrder(t, db, buyerIds[0])}
+
+	//Test 1: Offer attached to an order waiting on payment stays accepted
+	updateOrderOffer(t, db, func(tx *sql.Tx) *utils.ErrorHandler { return AttachOffer(tx, offer.OfferId, orderIds[0]) })
+	res, resErr := GetOfferById(db, offer.OfferId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, "accepted", res.Status)
+	assert.Equal(t, orderIds[0], res.OrderId)
+
+	//Test 2: Offer of a failed payment is detached and can be checked out again
+	updateOrderOffer(t, db, func(tx *sql.Tx) *utils.ErrorHandler { return UpdateOrderOfferStatus(tx, orderIds[0], "failed") })
+	res, resErr = GetCheckoutOffer(db, offer.OfferId, buyerIds[0])
+	assert.Empty(t, resErr)
+	assert.Empty(t, res.OrderId)
+
+	//Test 3: Offer of a completed payment is completed and cannot be checked out again
+	updateOrderOffer(t, db, func(tx *sql.Tx) *utils.ErrorHandler { return AttachOffer(tx, offer.OfferId, orderIds[1]) })
+	updateOrderOffer(t, db, func(tx *sql.Tx) *utils.ErrorHandler { return UpdateOrderOfferStatus(tx, orderIds[1], "completed") })
+	res, resErr = GetOfferById(db, offer.OfferId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, "completed", res.Status)
+	assert.Equal(t, orderIds[1], res.OrderId)
+
+	tx, err := db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	resErr = AttachOffer(tx, offer.OfferId, orderIds[0])
+	assert.Error(t, resErr)
+	assert.Equal(t, "Offer is not accepted or its reservation has ended", resErr.Error())
+	assert.NoError(t, tx.Rollback())
+
+	store.CloseDB(db)
+}
+
+func createDummyOrder(t *testing.T, db *sql.DB, buyerId string) string {
+	var orderId string
+	query := `INSERT INTO orders(buyer_id, delivery_type, delivery_fee, payment_type, payment_fee, small_order_fee, total_paid,
+			phone_number, order_date, address_line_1, postal_code)
+		VALUES ($1, 'self_collection', 0, 'paynow_online', 0, 0, 7000, '91234567', NOW(), 'Test', '123456') RETURNING order_id;`
+	err := db.QueryRowContext(context.Background(), query, buyerId).Scan(&orderId)
+	assert.NoError(t, err)
+	return orderId
+}
+
+func updateOrderOffer(t *testing.T, db *sql.DB, update func(tx *sql.Tx) *utils.ErrorHandler) {
+	tx, err := db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, update(tx))
+	assert.NoError(t, tx.Commit())
+}
+
+func createDummyData(t *testing.T, db *sql.DB) (string, []string, []string) {
+	var sellerId string
+	var buyerIds, productIds []string
+
+	query := `INSERT INTO sellers(email, seller_name, password) VALUES ('test@aucto.io','test','test') RETURNING seller_id`
+	err := db.QueryRowContext(context.Background(), query).Scan(&sellerId)
+	assert.NoError(t, err)
+
+	emails := []string{"test@aucto.io", "test2@aucto.io"}
+	for i := 0; i < len(emails); i++ {
+		var buyerId string
+		query = `INSERT INTO buyers(email, password) VALUES ($1,'test') RETURNING buyer_id;`
+		err = db.QueryRowContext(context.Background(), query, emails[i]).Scan(&buyerId)
+		assert.NoError(t, err)
+		buyerIds = append(buyerIds, buyerId)
+	}
+
+	productTypes := []string{"Buy-Now", "Pre-Order"}
+	for i := 0; i < len(productTypes); i++ {
+		var productId string
+		query = `INSERT INTO products(
+			title, seller_id, description, product_type, language, expansion, posted_date, price, condition, product_quantity) 
+			VALUES ('Test', $1, 'This is a test description', $2, 'Eng', 'Test', $3, 10000, 5, 3) RETURNING product_id;`
+		err = db.QueryRowContext(context.Background(), query, sellerId, productTypes[i], time.Now()).Scan(&productId)
+		assert.NoError(t, err)
+		productIds = append(productIds, productId)
+	}
+
+	return sellerId, buyerIds, productIds
+}
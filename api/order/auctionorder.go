@@ -0,0 +1,99 @@
+package order
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"time"
+)
+
+/*
+Creates the order for the winning bid of an auction at the hammer price, using the checkout details the
+winner registered when bidding, with a single sub-order for the seller. The order is created in the
+transaction that closes the auction, the payment request is created with CreatePaymentRequest once the
+transaction is committed.
+*/
+func CreateAuctionOrder(tx *sql.Tx, productId string, buyerId string, hammerPrice int,
+	checkout data.AuctionCheckoutData) (string, data.OrderFees, *utils.ErrorHandler) {
+	var orderId string
+	fees := calculateOrderFees(hammerPrice, 1, checkout.DeliveryType, checkout.PaymentType)
+
+	query := `INSERT INTO orders(
+		buyer_id, 
+		delivery_type, 
+		delivery_fee, 
+		payment_type, 
+		payment_fee, 
+		small_order_fee, 
+		total_paid,
+		phone_number, 
+		order_date, 
+		address_line_1, 
+		address_line_2, 
+		postal_code, 
+		telegram_handle) 
+		VALUES 
+		($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13) 
+		RETURNING order_id;`
+
+	err := tx.QueryRowContext(
+		context.Background(), query,
+		buyerId, fees.DeliveryType, fees.DeliveryFee,
+		fees.PaymentType, fees.PaymentFee, fees.SmallOrderFee, fees.TotalPaid,
+		checkout.PhoneNumber, time.Now(), checkout.AddressLine1, utils.NewNullableString(checkout.AddressLine2),
+		checkout.PostalCode, utils.NewNullableString(checkout.TelegramHandle)).Scan(&orderId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Order rows")
+		return orderId, fees, errResp
+	}
+
+	query = `INSERT INTO order_products(product_id, order_id, quantity) VALUES ($1,$2,1);`
+	_, err = tx.ExecContext(context.Background(), query, productId, orderId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Order Product rows")
+		return orderId, fees, errResp
+	}
+
+	var sellerId string
+	query = `SELECT seller_id FROM products WHERE product_id = $1;`
+	err = tx.QueryRowContext(context.Background(), query, productId).Scan(&sellerId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Product rows")
+		return orderId, fees, errResp
+	}
+
+	subOrders := []data.SubOrderData{{SellerId: sellerId, Products: []data.ProductOrder{{ProductId: productId, OrderQuantity: 1}},
+		ProductsCost: hammerPrice, DeliveryFee: fees.DeliveryFee}}
+	subOrderErr := createSubOrders(tx, orderId, false, subOrders)
+
+	return orderId, fees, subOrderErr
+}
+
+/*
+Validates the checkout details a buyer registers to bid on an auction
+*/
+func ValidateAuctionCheckout(checkout data.AuctionCheckoutData) *utils.ErrorHandler {
+	if len(checkout.PostalCode) != 6 {
+		utils.LogMessage("Postal Code Data Incorrect")
+		return utils.BadRequestError("Bad postal code data")
+	}
+
+	if checkout.PaymentType != "card" && checkout.PaymentType != "paynow_online" {
+		utils.LogMessage("Payment Type is invalid")
+		return utils.BadRequestError("Bad payment_type data")
+	}
+
+	if checkout.DeliveryType != "standard_delivery" && checkout.DeliveryType != "self_collection" {
+		utils.LogMessage("Delivery Type is invalid")
+		return utils.BadRequestError("Bad delivery_type data")
+	}
+
+	return nil
+}
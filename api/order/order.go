@@ -2,17 +2,20 @@ package order
 
 import (
 	"BackendAPI/api/buyer"
+	"BackendAPI/api/offer"
 	"BackendAPI/api/product"
+	"BackendAPI/api/promo"
 	"BackendAPI/data"
 	"BackendAPI/utils"
 	"context"
 	"database/sql"
-	"fmt"
 	"math"
 	"strconv"
 	"time"
 )
 
+const standardDeliveryFee = 400
+
 /*
 Create a order for a specific product by a specific buyer from the order request and store it in the database. If the
 buyer or product do not exist, return a BadRequestError (400).
@@ -27,12 +30,36 @@ func CreateOrder(db *sql.DB, request data.CreateOrderRequestData) (data.CreateOr
 		return response, validErr
 	}
 
-	//validate payment amount details
-	amountErr := validatePaymentAmount(db, request.Products, request.Fees)
+	//products bought with an accepted offer are paid at the agreed price
+	agreedPrices, offerErr := getAgreedPrices(db, request)
+	if offerErr != nil {
+		return response, offerErr
+	}
+
+	//promo codes are checked against the buyer before the discount is validated
+	promoCode, promoErr := getCheckoutPromoCode(db, request.PromoCode, request.BuyerId, "")
+	if promoErr != nil {
+		return response, promoErr
+	}
+
+	//validate payment amount details, the order is split into a sub-order for each seller
+	subOrders, amountErr := validatePaymentAmount(db, request.Products, request.Fees, agreedPrices, promoCode)
 	if amountErr != nil {
 		return response, amountErr
 	}
 
+	//the order, its products and sub-orders and the use of its promo code are created together, so that nothing is
+	//left behind, such as a reserved promo code, if any of them fails
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Order transaction")
+		return response, errResp
+	}
+
+	defer tx.Rollback()
+
 	//SQL Query to insert new order
 	query := `INSERT INTO orders(
 		buyer_id, 
@@ -47,17 +74,18 @@ func CreateOrder(db *sql.DB, request data.CreateOrderRequestData) (data.CreateOr
 		address_line_1, 
 		address_line_2, 
 		postal_code, 
-		telegram_handle) 
+		telegram_handle,
+		discount) 
 		VALUES 
-		($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13) 
+		($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14) 
 		RETURNING order_id;`
 
-	err := db.QueryRowContext(
+	err = tx.QueryRowContext(
 		context.Background(), query,
 		request.BuyerId, request.Fees.DeliveryType, request.Fees.DeliveryFee,
 		request.Fees.PaymentType, request.Fees.PaymentFee, request.Fees.SmallOrderFee, request.Fees.TotalPaid,
 		request.PhoneNumber, orderDate, request.AddressLine1, utils.NewNullableString(request.AddressLine2),
-		request.PostalCode, utils.NewNullableString(request.TelegramHandle)).Scan(&response.OrderId)
+		request.PostalCode, utils.NewNullableString(request.TelegramHandle), request.Fees.Discount).Scan(&response.OrderId)
 
 	if err != nil {
 		errResp := utils.InternalServerError(nil)
@@ -65,6 +93,12 @@ func CreateOrder(db *sql.DB, request data.CreateOrderRequestData) (data.CreateOr
 		return response, errResp
 	}
 
+	//a use of the promo code is reserved with the order so that its usage limits hold
+	promoErr = reservePromoCode(tx, promoCode, request.BuyerId, "", request.Fees.Discount, response.OrderId, false)
+	if promoErr != nil {
+		return response, promoErr
+	}
+
 	query = `INSERT INTO order_products(product_id, order_id, quantity) VALUES`
 	for i := 0; i < len(request.Products); i++ {
 		query += `('` + request.Products[i].ProductId + `','` + response.OrderId + `',` + strconv.Itoa(request.Products[i].OrderQuantity) + `)`
@@ -72,7 +106,34 @@ func CreateOrder(db *sql.DB, request data.CreateOrderRequestData) (data.CreateOr
 			query += `,`
 		}
 	}
-	_, err = db.ExecContext(context.Background(), query)
+	_, err = tx.ExecContext(context.Background(), query)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Order Product rows")
+		return response, errResp
+	}
+
+	subOrderErr := createSubOrders(tx, response.OrderId, false, subOrders)
+	if subOrderErr != nil {
+		return response, subOrderErr
+	}
+
+	//the offer is only completed once the order is paid, so a failed payment does not lose the agreed price
+	if request.OfferId != "" {
+		attachErr := offer.AttachOffer(tx, request.OfferId, response.OrderId)
+		if attachErr != nil {
+			return response, attachErr
+		}
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Order transaction")
+		return response, errResp
+	}
 
 	paymentResponse, paymentErr := CreatePaymentRequest(float64(request.Fees.TotalPaid)/100, response.OrderId, request.Fees.PaymentType, false)
 	response.RedirectUrl = paymentResponse.Url
@@ -93,12 +154,27 @@ func CreateGuestOrder(db *sql.DB, request data.CreateGuestOrderRequestData) (dat
 		return response, validErr
 	}
 
-	//validate payment amount details
-	amountErr := validatePaymentAmount(db, request.Products, request.Fees)
+	promoCode, promoErr := getCheckoutPromoCode(db, request.PromoCode, "", request.Email)
+	if promoErr != nil {
+		return response, promoErr
+	}
+
+	//validate payment amount details, the order is split into a sub-order for each seller
+	subOrders, amountErr := validatePaymentAmount(db, request.Products, request.Fees, nil, promoCode)
 	if amountErr != nil {
 		return response, amountErr
 	}
 
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Order transaction")
+		return response, errResp
+	}
+
+	defer tx.Rollback()
+
 	//SQL Query to insert new guest order
 	query := `INSERT INTO guest_orders(
 		email, 
@@ -113,17 +189,18 @@ func CreateGuestOrder(db *sql.DB, request data.CreateGuestOrderRequestData) (dat
 		address_line_1, 
 		address_line_2, 
 		postal_code, 
-		telegram_handle) 
+		telegram_handle,
+		discount) 
 		VALUES 
-		($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13) 
+		($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14) 
 		RETURNING guest_order_id;`
 
-	err := db.QueryRowContext(
+	err = tx.QueryRowContext(
 		context.Background(), query,
 		request.Email, request.Fees.DeliveryType, request.Fees.DeliveryFee,
 		request.Fees.PaymentType, request.Fees.PaymentFee, request.Fees.SmallOrderFee, request.Fees.TotalPaid,
 		request.PhoneNumber, orderDate, request.AddressLine1, utils.NewNullableString(request.AddressLine2),
-		request.PostalCode, utils.NewNullableString(request.TelegramHandle)).Scan(&response.GuestOrderId)
+		request.PostalCode, utils.NewNullableString(request.TelegramHandle), request.Fees.Discount).Scan(&response.GuestOrderId)
 
 	if err != nil {
 		errResp := utils.InternalServerError(nil)
@@ -131,6 +208,11 @@ func CreateGuestOrder(db *sql.DB, request data.CreateGuestOrderRequestData) (dat
 		return response, errResp
 	}
 
+	promoErr = reservePromoCode(tx, promoCode, "", request.Email, request.Fees.Discount, response.GuestOrderId, true)
+	if promoErr != nil {
+		return response, promoErr
+	}
+
 	query = `INSERT INTO guest_order_products(product_id, guest_order_id, quantity) VALUES`
 	for i := 0; i < len(request.Products); i++ {
 		query += `('` + request.Products[i].ProductId + `','` + response.GuestOrderId + `',` + strconv.Itoa(request.Products[i].OrderQuantity) + `)`
@@ -138,7 +220,26 @@ func CreateGuestOrder(db *sql.DB, request data.CreateGuestOrderRequestData) (dat
 			query += `,`
 		}
 	}
-	_, err = db.ExecContext(context.Background(), query)
+	_, err = tx.ExecContext(context.Background(), query)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Guest Order Product rows")
+		return response, errResp
+	}
+
+	subOrderErr := createSubOrders(tx, response.GuestOrderId, true, subOrders)
+	if subOrderErr != nil {
+		return response, subOrderErr
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Order transaction")
+		return response, errResp
+	}
 
 	paymentResponse, paymentErr := CreatePaymentRequest(float64(request.Fees.TotalPaid)/100, response.GuestOrderId, request.Fees.PaymentType, true)
 	response.RedirectUrl = paymentResponse.Url
@@ -163,6 +264,7 @@ func GetOrderById(db *sql.DB, orderId string) (data.GetOrderByIdResponseData, *u
 		payment_fee, 
 		small_order_fee, 
 		total_paid,
+		discount,
 		phone_number, 
 		order_date::TEXT, 
 		address_line_1,
@@ -188,7 +290,7 @@ func GetOrderById(db *sql.DB, orderId string) (data.GetOrderByIdResponseData, *u
 		var quantity int
 		err = rows.Scan(
 			&response.BuyerId, &response.Fees.DeliveryType, &response.Fees.DeliveryFee,
-			&response.Fees.PaymentType, &response.Fees.PaymentFee, &response.Fees.SmallOrderFee, &response.Fees.TotalPaid,
+			&response.Fees.PaymentType, &response.Fees.PaymentFee, &response.Fees.SmallOrderFee, &response.Fees.TotalPaid, &response.Fees.Discount,
 			&response.PhoneNumber, &response.OrderDate, &response.AddressLine1, &response.AddressLine2,
 			&response.PostalCode, &response.PaymentStatus, &response.TelegramHandle, &productId, &quantity)
 
@@ -202,7 +304,10 @@ func GetOrderById(db *sql.DB, orderId string) (data.GetOrderByIdResponseData, *u
 	}
 
 	response.OrderId = orderId
-	return response, nil
+
+	var subOrderErr *utils.ErrorHandler
+	response.SubOrders, subOrderErr = GetOrderSubOrders(db, orderId, false)
+	return response, subOrderErr
 }
 
 /*
@@ -223,6 +328,7 @@ func GetGuestOrderById(db *sql.DB, guestOrderId string) (data.GetGuestOrderByIdR
 		payment_fee, 
 		small_order_fee, 
 		total_paid,
+		discount,
 		phone_number, 
 		order_date::TEXT, 
 		address_line_1, 
@@ -248,7 +354,7 @@ func GetGuestOrderById(db *sql.DB, guestOrderId string) (data.GetGuestOrderByIdR
 		var quantity int
 		err = rows.Scan(
 			&response.Email, &response.Fees.DeliveryType, &response.Fees.DeliveryFee,
-			&response.Fees.PaymentType, &response.Fees.PaymentFee, &response.Fees.SmallOrderFee, &response.Fees.TotalPaid,
+			&response.Fees.PaymentType, &response.Fees.PaymentFee, &response.Fees.SmallOrderFee, &response.Fees.TotalPaid, &response.Fees.Discount,
 			&response.PhoneNumber, &response.OrderDate, &response.AddressLine1, &response.AddressLine2,
 			&response.PostalCode, &response.PaymentStatus, &response.TelegramHandle, &productId, &quantity)
 
@@ -262,7 +368,10 @@ func GetGuestOrderById(db *sql.DB, guestOrderId string) (data.GetGuestOrderByIdR
 	}
 
 	response.GuestOrderId = guestOrderId
-	return response, nil
+
+	var subOrderErr *utils.ErrorHandler
+	response.SubOrders, subOrderErr = GetOrderSubOrders(db, guestOrderId, true)
+	return response, subOrderErr
 }
 
 /*
@@ -284,6 +393,16 @@ func validateCreateOrderRequest(db *sql.DB, request data.CreateOrderRequestData)
 			utils.LogMessage("Product with given id does not exist")
 			return utils.BadRequestError("Bad product_id data")
 		}
+
+		if product.IsAuctionProduct(db, request.Products[i].ProductId) {
+			utils.LogMessage("Auction products can only be bought by bidding")
+			return utils.BadRequestError("Bad product_id data")
+		}
+
+		if product.IsPreOrderClosed(db, request.Products[i].ProductId) {
+			utils.LogMessage("Pre-order is past its order by date or has been released")
+			return utils.BadRequestError("Pre-order has closed")
+		}
 	}
 
 	if !buyer.DoesBuyerExist(db, request.BuyerId) {
@@ -343,8 +462,14 @@ func validateCreateOrderRequest(db *sql.DB, request data.CreateOrderRequestData)
 		productMap[productId] = quantity
 	}
 
+	//Stock reserved by the accepted offers of other buyers cannot be ordered
+	reserved, reservedErr := offer.GetReservedQuantities(db, getOrderProductIds(request.Products), request.BuyerId)
+	if reservedErr != nil {
+		return reservedErr
+	}
+
 	for i := 0; i < len(request.Products); i++ {
-		if productMap[request.Products[i].ProductId] < request.Products[i].OrderQuantity {
+		if productMap[request.Products[i].ProductId]-reserved[request.Products[i].ProductId] < request.Products[i].OrderQuantity {
 			utils.LogMessage("Quantity ordered is greater than available stock")
 			return utils.BadRequestError("Bad quantity data")
 		}
@@ -372,6 +497,16 @@ func validateCreateGuestOrderRequest(db *sql.DB, request data.CreateGuestOrderRe
 			utils.LogMessage("Product with given id does not exist")
 			return utils.BadRequestError("Bad product_id data")
 		}
+
+		if product.IsAuctionProduct(db, request.Products[i].ProductId) {
+			utils.LogMessage("Auction products can only be bought by bidding")
+			return utils.BadRequestError("Bad product_id data")
+		}
+
+		if product.IsPreOrderClosed(db, request.Products[i].ProductId) {
+			utils.LogMessage("Pre-order is past its order by date or has been released")
+			return utils.BadRequestError("Pre-order has closed")
+		}
 	}
 
 	if len(request.PostalCode) != 6 {
@@ -426,8 +561,13 @@ func validateCreateGuestOrderRequest(db *sql.DB, request data.CreateGuestOrderRe
 		productMap[productId] = quantity
 	}
 
+	reserved, reservedErr := offer.GetReservedQuantities(db, getOrderProductIds(request.Products), "")
+	if reservedErr != nil {
+		return reservedErr
+	}
+
 	for i := 0; i < len(request.Products); i++ {
-		if productMap[request.Products[i].ProductId] < request.Products[i].OrderQuantity {
+		if productMap[request.Products[i].ProductId]-reserved[request.Products[i].ProductId] < request.Products[i].OrderQuantity {
 			utils.LogMessage("Quantity ordered is greater than available stock")
 			return utils.BadRequestError("Bad quantity data")
 		}
@@ -453,44 +593,14 @@ func UpdateOrderPaymentStatus(db *sql.DB, orderId string, req data.PaymentValida
 		return errResp
 	}
 
-	var products []data.ProductOrder
-
-	query = `SELECT product_id, quantity FROM order_products WHERE order_id = $1;`
-	rows, err := db.QueryContext(context.Background(), query, orderId)
-	defer rows.Close()
-
-	if err != nil {
-		errResp := utils.InternalServerError(nil)
-		utils.LogError(err, "Error in selecting product order rows")
-		return errResp
+	promoErr := promo.UpdateRedemptionStatus(db, orderId, false, req.Status)
+	if promoErr != nil {
+		return promoErr
 	}
 
-	for rows.Next() {
-		var productId string
-		var quantity int
-		err = rows.Scan(&productId, &quantity)
-
-		if err != nil {
-			errResp := utils.InternalServerError(nil)
-			utils.LogError(err, "Error in selecting product order rows")
-			return errResp
-		}
-
-		prod := data.ProductOrder{ProductId: productId, OrderQuantity: quantity}
-		products = append(products, prod)
-	}
-
-	if req.Status == "completed" {
-		for i := 0; i < len(products); i++ {
-			query = `UPDATE products SET sold_quantity = sold_quantity + $1 WHERE product_id = $2;`
-			_, err = db.ExecContext(context.Background(), query, products[i].OrderQuantity, products[i].ProductId)
-
-			if err != nil {
-				errResp := utils.InternalServerError(nil)
-				utils.LogError(err, "Error in Updating product rows")
-				return errResp
-			}
-		}
+	subOrderErr := updateSubOrdersPaymentStatus(db, orderId, false, req.Status)
+	if subOrderErr != nil {
+		return subOrderErr
 	}
 
 	return nil
@@ -513,57 +623,30 @@ func UpdateGuestOrderPaymentStatus(db *sql.DB, guestOrderId string, req data.Pay
 		return errResp
 	}
 
-	var products []data.ProductOrder
-
-	query = `SELECT product_id, quantity FROM guest_order_products WHERE guest_order_id = $1;`
-	rows, err := db.QueryContext(context.Background(), query, guestOrderId)
-	defer rows.Close()
-
-	if err != nil {
-		errResp := utils.InternalServerError(nil)
-		utils.LogError(err, "Error in selecting guest product order rows")
-		return errResp
+	promoErr := promo.UpdateRedemptionStatus(db, guestOrderId, true, req.Status)
+	if promoErr != nil {
+		return promoErr
 	}
 
-	for rows.Next() {
-		var productId string
-		var quantity int
-		err = rows.Scan(&productId, &quantity)
-
-		if err != nil {
-			errResp := utils.InternalServerError(nil)
-			utils.LogError(err, "Error in selecting guest product order rows")
-			return errResp
-		}
-
-		prod := data.ProductOrder{ProductId: productId, OrderQuantity: quantity}
-		products = append(products, prod)
-	}
-
-	if req.Status == "completed" {
-		for i := 0; i < len(products); i++ {
-			query = `UPDATE products SET sold_quantity = sold_quantity + $1 WHERE product_id = $2;`
-			_, err = db.ExecContext(context.Background(), query, products[i].OrderQuantity, products[i].ProductId)
-
-			if err != nil {
-				errResp := utils.InternalServerError(nil)
-				utils.LogError(err, "Error in Updating product rows")
-				return errResp
-			}
-		}
+	subOrderErr := updateSubOrdersPaymentStatus(db, guestOrderId, true, req.Status)
+	if subOrderErr != nil {
+		return subOrderErr
 	}
 
 	return nil
 }
 
 /*
-Calculate the payment amount given an order request
+Calculate the payment amount given an order request and returns the sub-orders of the order, one for each seller.
+Products with an agreed price from an accepted offer are charged at the agreed price instead of their listed price,
+and the discount of the promo code, if any, is taken off before the fees are calculated.
 */
-func validatePaymentAmount(db *sql.DB, products []data.ProductOrder, fees data.OrderFees) *utils.ErrorHandler {
+func validatePaymentAmount(db *sql.DB, products []data.ProductOrder, fees data.OrderFees, agreedPrices map[string]int,
+	promoCode *data.PromoCodeData) ([]data.SubOrderData, *utils.ErrorHandler) {
 	//calculate product costs
-	query := `SELECT products.product_id, (price - COALESCE(discount, 0))
+	query := `SELECT products.product_id, (price - COALESCE(discount, 0)), products.seller_id, products.expansion
 		FROM 
-			(products LEFT OUTER JOIN product_discounts ON product_discounts.product_id = products.product_id)
+			(products LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id)
 	 	WHERE products.product_id IN (`
 	for i := 0; i < len(products); i++ {
 		query += `'` + products[i].ProductId + `'`
@@ -575,6 +658,7 @@ func validatePaymentAmount(db *sql.DB, products []data.ProductOrder, fees data.O
 
 	var productMap map[string]int
 	productMap = make(map[string]int)
+	itemMap := make(map[string]data.PromoOrderItemData)
 
 	rows, err := db.QueryContext(context.Background(), query)
 	defer rows.Close()
@@ -582,65 +666,224 @@ func validatePaymentAmount(db *sql.DB, products []data.ProductOrder, fees data.O
 	if err != nil {
 		errResp := utils.InternalServerError(nil)
 		utils.LogError(err, "Error in Selecting product rows")
-		return errResp
+		return nil, errResp
 	}
 
 	for rows.Next() {
 		var productId string
 		var price int
+		var item data.PromoOrderItemData
 
-		err = rows.Scan(&productId, &price)
+		err = rows.Scan(&productId, &price, &item.SellerId, &item.Expansion)
 
 		if err != nil {
 			errResp := utils.InternalServerError(nil)
 			utils.LogError(err, "Error in Selecting product rows")
-			return errResp
+			return nil, errResp
 		}
 
 		productMap[productId] = price
+		itemMap[productId] = item
+
+		if agreedPrice, hasAgreedPrice := agreedPrices[productId]; hasAgreedPrice {
+			productMap[productId] = agreedPrice
+		}
 	}
 
 	var amountToBePaid int
+	var items []data.PromoOrderItemData
 	for i := 0; i < len(products); i++ {
-		amountToBePaid += productMap[products[i].ProductId] * products[i].OrderQuantity
+		cost := productMap[products[i].ProductId] * products[i].OrderQuantity
+		amountToBePaid += cost
+
+		item := itemMap[products[i].ProductId]
+		item.Cost = cost
+		items = append(items, item)
+	}
+
+	var discount int
+	var itemDiscounts []int
+	if promoCode != nil {
+		var promoErr *utils.ErrorHandler
+		discount, promoErr = promo.CalculatePromoDiscount(*promoCode, items)
+
+		if promoErr != nil {
+			return nil, promoErr
+		}
+
+		itemDiscounts = promo.AllocatePromoDiscount(*promoCode, items, discount)
+	}
+
+	if fees.Discount != discount {
+		utils.LogMessage("Promo code discount is incorrect")
+		return nil, utils.BadRequestError("Bad discount data")
+	}
+
+	subOrders := splitSubOrders(products, items, itemDiscounts, fees.DeliveryType)
+	expectedFees := calculateOrderFees(amountToBePaid-discount, len(subOrders), fees.DeliveryType, fees.PaymentType)
+
+	if expectedFees.SmallOrderFee > 0 && fees.SmallOrderFee != expectedFees.SmallOrderFee {
+		utils.LogMessage("Small Order fee is incorrect")
+		return nil, utils.BadRequestError("Bad small_order_fee data")
+	}
+
+	if expectedFees.DeliveryFee > 0 && fees.DeliveryFee != expectedFees.DeliveryFee {
+		utils.LogMessage("Delivery fee is incorrect")
+		return nil, utils.BadRequestError("Bad delivery_fee data")
 	}
 
+	if fees.PaymentType == "card" && fees.PaymentFee != expectedFees.PaymentFee {
+		utils.LogMessage("Payment fee is incorrect")
+		return nil, utils.BadRequestError("Bad payment_fee data")
+	}
+
+	if expectedFees.TotalPaid != fees.TotalPaid {
+		utils.LogMessage("Total Paid amount is incorrect")
+		return nil, utils.BadRequestError("Bad total paid data")
+	}
+
+	return subOrders, nil
+}
+
+/*
+Gets the agreed price of the product bought with the accepted offer of an order request. The order has to
+be for the quantity of the offer.
+*/
+func getAgreedPrices(db *sql.DB, request data.CreateOrderRequestData) (map[string]int, *utils.ErrorHandler) {
+	if request.OfferId == "" {
+		return nil, nil
+	}
+
+	acceptedOffer, err := offer.GetCheckoutOffer(db, request.OfferId, request.BuyerId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < len(request.Products); i++ {
+		if request.Products[i].ProductId == acceptedOffer.ProductId {
+			if request.Products[i].OrderQuantity != acceptedOffer.Quantity {
+				utils.LogMessage("Quantity ordered does not match the quantity of the offer")
+				return nil, utils.BadRequestError("Bad quantity data")
+			}
+
+			return map[string]int{acceptedOffer.ProductId: acceptedOffer.AgreedAmount}, nil
+		}
+	}
+
+	utils.LogMessage("Order does not contain the product of the offer")
+	return nil, utils.BadRequestError("Bad offer_id data")
+}
+
+/*
+Gets the promo code of an order request, or nil if the order does not use a promo code
+*/
+func getCheckoutPromoCode(db *sql.DB, code string, buyerId string, email string) (*data.PromoCodeData, *utils.ErrorHandler) {
+	if code == "" {
+		return nil, nil
+	}
+
+	promoCode, err := promo.GetCheckoutPromoCode(db, code, buyerId, email)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &promoCode, nil
+}
+
+/*
+Reserves a use of the promo code of an order request in the transaction that creates the order, orders that do not
+use a promo code have nothing to reserve
+*/
+func reservePromoCode(tx *sql.Tx, promoCode *data.PromoCodeData, buyerId string, email string, discount int, orderId string,
+	isGuest bool) *utils.ErrorHandler {
+	if promoCode == nil {
+		return nil
+	}
+
+	_, err := promo.ReservePromoCode(tx, promoCode.PromoCodeId, buyerId, email, discount, orderId, isGuest)
+	return err
+}
+
+/*
+Gets the ids of the products being ordered
+*/
+func getOrderProductIds(products []data.ProductOrder) []string {
+	var productIds []string
+	for i := 0; i < len(products); i++ {
+		productIds = append(productIds, products[i].ProductId)
+	}
+
+	return productIds
+}
+
+/*
+Calculates the fees of an order from the cost of its products. Orders under $25 have a small order fee,
+standard delivery has a delivery fee for each of the shipments of the order and card payments have a 2%
+payment fee over the rest of the order.
+*/
+func calculateOrderFees(productsCost int, shipments int, deliveryType string, paymentType string) data.OrderFees {
+	fees := data.OrderFees{DeliveryType: deliveryType, PaymentType: paymentType}
+	amountToBePaid := productsCost
+
 	//calculate small order fee
 	if amountToBePaid < 2500 {
-		if fees.SmallOrderFee != 100 {
-			utils.LogMessage("Small Order fee is incorrect")
-			return utils.BadRequestError("Bad small_order_fee data")
-		}
-		amountToBePaid += 100
+		fees.SmallOrderFee = 100
+		amountToBePaid += fees.SmallOrderFee
 	}
 
 	//calculate delivery fee
-	if fees.DeliveryType == "standard_delivery" {
-		if fees.DeliveryFee != 400 {
-			utils.LogMessage("Delivery fee is incorrect")
-			return utils.BadRequestError("Bad delivery_fee data")
-		}
-		amountToBePaid += 400
+	if deliveryType == "standard_delivery" {
+		fees.DeliveryFee = standardDeliveryFee * shipments
+		amountToBePaid += fees.DeliveryFee
 	}
 
-	var paymentFee int
 	//calculate payment fee
-	if fees.PaymentType == "card" {
-		paymentFee = int(math.Ceil((float64(amountToBePaid) * 2 / 100)))
-		amountToBePaid += paymentFee
-		if fees.PaymentFee != paymentFee {
-			fmt.Println(paymentFee)
-			utils.LogMessage("Payment fee is incorrect")
-			return utils.BadRequestError("Bad payment_fee data")
-		}
+	if paymentType == "card" {
+		fees.PaymentFee = int(math.Ceil((float64(amountToBePaid) * 2 / 100)))
+		amountToBePaid += fees.PaymentFee
 	}
 
-	if amountToBePaid != fees.TotalPaid {
-		utils.LogMessage("Total Paid amount is incorrect")
-		return utils.BadRequestError("Bad total paid data")
+	fees.TotalPaid = amountToBePaid
+	return fees
+}
+
+/*
+Gets the ids of the products in an order or guest order, isGuest selects which kind of order the id belongs to
+*/
+func GetOrderProductIds(db *sql.DB, orderId string, isGuest bool) ([]string, *utils.ErrorHandler) {
+	var productIds []string
+
+	query := `SELECT product_id FROM order_products WHERE order_id = $1;`
+	if isGuest {
+		query = `SELECT product_id FROM guest_order_products WHERE guest_order_id = $1;`
 	}
 
-	return nil
+	rows, err := db.QueryContext(context.Background(), query, orderId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting product order rows")
+		return productIds, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var productId string
+		err = rows.Scan(&productId)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting product order rows")
+			return productIds, errResp
+		}
+
+		productIds = append(productIds, productId)
+	}
+
+	return productIds, nil
 }
 
 /*
@@ -77,44 +77,90 @@ func TestValidatePaymentAmount(t *testing.T) {
 	var fees data.OrderFees = data.OrderFees{PaymentType: "paynow_online", DeliveryType: "self_collection",
 		PaymentFee: 0, DeliveryFee: 0, SmallOrderFee: 0, TotalPaid: 20000}
 	var products []data.ProductOrder = []data.ProductOrder{{ProductId: productIds[0], OrderQuantity: 1}, {ProductId: productIds[1], OrderQuantity: 1}}
-	err = validatePaymentAmount(db, products, fees)
+	_, err = validatePaymentAmount(db, products, fees, nil, nil)
 	assert.Empty(t, err)
 	//Test 2: Minimum order fee only
 	fees = data.OrderFees{PaymentType: "paynow_online", DeliveryType: "self_collection",
 		PaymentFee: 0, DeliveryFee: 0, SmallOrderFee: 100, TotalPaid: 2100}
 	products = []data.ProductOrder{{ProductId: productIds[5], OrderQuantity: 2}}
-	err = validatePaymentAmount(db, products, fees)
+	_, err = validatePaymentAmount(db, products, fees, nil, nil)
 	assert.Empty(t, err)
 	//Test 3: Delivery fee only
 	fees = data.OrderFees{PaymentType: "paynow_online", DeliveryType: "standard_delivery",
 		PaymentFee: 0, DeliveryFee: 400, SmallOrderFee: 0, TotalPaid: 20400}
 	products = []data.ProductOrder{{ProductId: productIds[0], OrderQuantity: 1}, {ProductId: productIds[1], OrderQuantity: 1}}
-	err = validatePaymentAmount(db, products, fees)
+	_, err = validatePaymentAmount(db, products, fees, nil, nil)
 	assert.Empty(t, err)
 	//Test 4: Delivery fee and minumum order fee
 	fees = data.OrderFees{PaymentType: "paynow_online", DeliveryType: "standard_delivery",
 		PaymentFee: 0, DeliveryFee: 400, SmallOrderFee: 100, TotalPaid: 2500}
 	products = []data.ProductOrder{{ProductId: productIds[5], OrderQuantity: 2}}
-	err = validatePaymentAmount(db, products, fees)
+	_, err = validatePaymentAmount(db, products, fees, nil, nil)
 	assert.Empty(t, err)
 	//Test 5: Card fee only
 	fees = data.OrderFees{PaymentType: "card", DeliveryType: "self_collection",
 		PaymentFee: 400, DeliveryFee: 0, SmallOrderFee: 0, TotalPaid: 20400}
 	products = []data.ProductOrder{{ProductId: productIds[0], OrderQuantity: 1}, {ProductId: productIds[1], OrderQuantity: 1}}
-	err = validatePaymentAmount(db, products, fees)
+	_, err = validatePaymentAmount(db, products, fees, nil, nil)
 	assert.Empty(t, err)
 	//Test 5: Card fee and delivery fee
 	fees = data.OrderFees{PaymentType: "card", DeliveryType: "standard_delivery",
 		DeliveryFee: 400, PaymentFee: 408, TotalPaid: 20808}
 	products = []data.ProductOrder{{ProductId: productIds[0], OrderQuantity: 1}, {ProductId: productIds[1], OrderQuantity: 1}}
-	err = validatePaymentAmount(db, products, fees)
+	_, err = validatePaymentAmount(db, products, fees, nil, nil)
 	assert.Empty(t, err)
 	//Test 5: Card fee and delivery fee and minimum order fee
 	fees = data.OrderFees{PaymentType: "card", DeliveryType: "standard_delivery",
 		DeliveryFee: 400, SmallOrderFee: 100, PaymentFee: 50, TotalPaid: 2550}
 	products = []data.ProductOrder{{ProductId: productIds[5], OrderQuantity: 2}}
-	err = validatePaymentAmount(db, products, fees)
+	_, err = validatePaymentAmount(db, products, fees, nil, nil)
 	assert.Empty(t, err)
+	//Test 8: Agreed offer price is charged instead of the listed price
+	fees = data.OrderFees{PaymentType: "paynow_online", DeliveryType: "self_collection", TotalPaid: 8000}
+	products = []data.ProductOrder{{ProductId: productIds[4], OrderQuantity: 1}}
+	_, err = validatePaymentAmount(db, products, fees, map[string]int{productIds[4]: 8000}, nil)
+	assert.Empty(t, err)
+	//Test 9: Listed price after discount is not accepted with an agreed price
+	fees = data.OrderFees{PaymentType: "paynow_online", DeliveryType: "self_collection", TotalPaid: 9000}
+	_, err = validatePaymentAmount(db, products, fees, map[string]int{productIds[4]: 8000}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, "Bad total paid data", err.Error())
+	//Test 10: Promo code discount is taken off before the fees
+	promoCode := data.PromoCodeData{DiscountType: "percentage", Amount: 10, SellerIds: []string{}, Expansions: []string{"Test2"}}
+	fees = data.OrderFees{PaymentType: "paynow_online", DeliveryType: "self_collection", Discount: 1000, TotalPaid: 19000}
+	products = []data.ProductOrder{{ProductId: productIds[0], OrderQuantity: 1}, {ProductId: productIds[2], OrderQuantity: 1}}
+	_, err = validatePaymentAmount(db, products, fees, nil, &promoCode)
+	assert.Empty(t, err)
+	//Test 11: Discount line does not match the promo code
+	fees = data.OrderFees{PaymentType: "paynow_online", DeliveryType: "self_collection", Discount: 2000, TotalPaid: 18000}
+	_, err = validatePaymentAmount(db, products, fees, nil, &promoCode)
+	assert.Error(t, err)
+	assert.Equal(t, "Bad discount data", err.Error())
+	//Test 12: Discount without a promo code
+	_, err = validatePaymentAmount(db, products, fees, nil, nil)
+	assert.Error(t, err)
+	assert.Equal(t, "Bad discount data", err.Error())
+}
+
+func TestCalculateOrderFees(t *testing.T) {
+	//Test 1: No additional fees
+	fees := calculateOrderFees(20000, 1, "self_collection", "paynow_online")
+	assert.Equal(t, data.OrderFees{PaymentType: "paynow_online", DeliveryType: "self_collection", TotalPaid: 20000}, fees)
+
+	//Test 2: Card fee and delivery fee
+	fees = calculateOrderFees(20000, 1, "standard_delivery", "card")
+	assert.Equal(t, data.OrderFees{PaymentType: "card", DeliveryType: "standard_delivery",
+		DeliveryFee: 400, PaymentFee: 408, TotalPaid: 20808}, fees)
+
+	//Test 3: Card fee and delivery fee and minimum order fee
+	fees = calculateOrderFees(2000, 1, "standard_delivery", "card")
+	assert.Equal(t, data.OrderFees{PaymentType: "card", DeliveryType: "standard_delivery",
+		DeliveryFee: 400, SmallOrderFee: 100, PaymentFee: 50, TotalPaid: 2550}, fees)
+
+	//Test 4: Delivery fee for each shipment of the order
+	fees = calculateOrderFees(20000, 2, "standard_delivery", "paynow_online")
+	assert.Equal(t, data.OrderFees{PaymentType: "paynow_online", DeliveryType: "standard_delivery",
+		DeliveryFee: 800, TotalPaid: 20800}, fees)
 }
 
 func TestValidateCreateGuestOrderRequest(t *testing.T) {
@@ -320,6 +366,19 @@ func TestValidateCreateOrderRequest(t *testing.T) {
 	assert.NotEmpty(t, valErr)
 	assert.Equal(t, 400, valErr.ErrorCode())
 
+	//Test 12: Pre-order past its order by date
+	_, execErr := db.ExecContext(context.Background(), `INSERT INTO preorder_information(product_id, order_by, releases_on) 
+		VALUES ($1, NOW() - INTERVAL '1 day', NOW() + INTERVAL '1 day');`, productIds[3])
+	assert.NoError(t, execErr)
+	order = data.CreateOrderRequestData{
+		Products: []data.ProductOrder{{ProductId: productIds[3], OrderQuantity: 1}}, BuyerId: buyerIds[0],
+		Fees: data.OrderFees{PaymentType: "paynow_online", DeliveryType: "self_collection",
+			TotalPaid: 10000, PaymentFee: 0, DeliveryFee: 0, SmallOrderFee: 0},
+		PhoneNumber: "12345678", AddressLine1: "Test", AddressLine2: "Test", PostalCode: "123456"}
+	valErr = validateCreateOrderRequest(db, order)
+	assert.NotEmpty(t, valErr)
+	assert.Equal(t, "Pre-order has closed", valErr.Error())
+
 	store.CloseDB(db)
 }
 
@@ -0,0 +1,180 @@
+package order
+
+import (
+	"BackendAPI/api/outbox"
+	"BackendAPI/mailing"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+)
+
+/*
+Adds the mail that lets the buyer of an order or guest order know that its payment went through to the outbox. Mails
+that cannot be composed are logged and left out, the order itself is not affected. Returns the id of the mail in the
+outbox, if it was added.
+*/
+func addOrderConfirmationMail(tx *sql.Tx, orderId string, isGuest bool) (string, *utils.ErrorHandler) {
+	var email, locale string
+	order := mailing.OrderConfirmationMailData{OrderId: orderId}
+
+	query := `SELECT buyers.email, buyers.locale, orders.total_paid
+		FROM orders INNER JOIN buyers ON buyers.buyer_id = orders.buyer_id
+		WHERE orders.order_id = $1;`
+	itemsQuery := `SELECT products.title, order_products.quantity
+		FROM order_products INNER JOIN products ON products.product_id = order_products.product_id
+		WHERE order_products.order_id = $1;`
+	if isGuest {
+		query = `SELECT email, $2::VARCHAR, total_paid FROM guest_orders WHERE guest_order_id = $1;`
+		itemsQuery = `SELECT products.title, guest_order_products.quantity
+		FROM guest_order_products INNER JOIN products ON products.product_id = guest_order_products.product_id
+		WHERE guest_order_products.guest_order_id = $1;`
+	}
+
+	args := []interface{}{orderId}
+	if isGuest {
+		args = append(args, mailing.DefaultLocale)
+	}
+
+	err := tx.QueryRowContext(context.Background(), query, args...).Scan(&email, &locale, &order.Total)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting order mail rows")
+		return "", errResp
+	}
+
+	items, itemErr := getOrderMailItems(tx, itemsQuery, orderId)
+
+	if itemErr != nil {
+		return "", itemErr
+	}
+
+	order.Items = items
+
+	message, composeErr := mailing.ComposeOrderConfirmationMail(email, locale, order)
+
+	return addOrderMail(tx, message, composeErr, "order "+orderId)
+}
+
+/*
+Adds the mail that lets the buyer of a sub-order know that the seller has shipped it to the outbox
+*/
+func addSubOrderShippedMail(tx *sql.Tx, subOrderId string) (string, *utils.ErrorHandler) {
+	email, locale, orderId, recipientErr := getSubOrderRecipient(tx, subOrderId)
+
+	if recipientErr != nil {
+		return "", recipientErr
+	}
+
+	shipment := mailing.OrderShippedMailData{OrderId: orderId}
+
+	query := `SELECT sellers.seller_name FROM sub_orders INNER JOIN sellers ON sellers.seller_id = sub_orders.seller_id
+		WHERE sub_orders.sub_order_id = $1;`
+	err := tx.QueryRowContext(context.Background(), query, subOrderId).Scan(&shipment.SellerName)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting order mail rows")
+		return "", errResp
+	}
+
+	query = `SELECT products.title, sub_order_products.quantity
+		FROM (SELECT product_id, quantity FROM order_products WHERE sub_order_id = $1
+			UNION ALL SELECT product_id, quantity FROM guest_order_products WHERE sub_order_id = $1) AS sub_order_products
+			INNER JOIN products ON products.product_id = sub_order_products.product_id;`
+	items, itemErr := getOrderMailItems(tx, query, subOrderId)
+
+	if itemErr != nil {
+		return "", itemErr
+	}
+
+	shipment.Items = items
+
+	message, composeErr := mailing.ComposeOrderShippedMail(email, locale, shipment)
+
+	return addOrderMail(tx, message, composeErr, "sub-order "+subOrderId)
+}
+
+/*
+Adds the mail that lets the buyer of a sub-order know that part of it has been refunded to the outbox
+*/
+func addSubOrderRefundMail(tx *sql.Tx, subOrderId string, amount int, reason string) (string, *utils.ErrorHandler) {
+	email, locale, orderId, recipientErr := getSubOrderRecipient(tx, subOrderId)
+
+	if recipientErr != nil {
+		return "", recipientErr
+	}
+
+	refund := mailing.RefundMailData{OrderId: orderId, Amount: amount, Reason: reason}
+
+	message, composeErr := mailing.ComposeRefundMail(email, locale, refund)
+
+	return addOrderMail(tx, message, composeErr, "sub-order "+subOrderId)
+}
+
+/*
+Adds a composed order mail to the outbox. A mail that could not be composed is logged and left out.
+*/
+func addOrderMail(tx *sql.Tx, message mailing.Message, composeErr error, subject string) (string, *utils.ErrorHandler) {
+	if composeErr != nil {
+		utils.LogError(composeErr, "Error in composing mail for "+subject)
+		return "", nil
+	}
+
+	return outbox.AddMail(tx, message)
+}
+
+/*
+Gets the email and locale of the buyer or guest buyer of a sub-order along with the id of its order
+*/
+func getSubOrderRecipient(tx *sql.Tx, subOrderId string) (string, string, string, *utils.ErrorHandler) {
+	var email, locale, orderId string
+
+	query := `SELECT COALESCE(buyers.email, guest_orders.email), COALESCE(buyers.locale, $2),
+			COALESCE(sub_orders.order_id, sub_orders.guest_order_id)::TEXT
+		FROM ((sub_orders LEFT OUTER JOIN orders ON orders.order_id = sub_orders.order_id)
+			LEFT OUTER JOIN buyers ON buyers.buyer_id = orders.buyer_id)
+			LEFT OUTER JOIN guest_orders ON guest_orders.guest_order_id = sub_orders.guest_order_id
+		WHERE sub_orders.sub_order_id = $1;`
+	err := tx.QueryRowContext(context.Background(), query, subOrderId, mailing.DefaultLocale).Scan(&email, &locale, &orderId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting order mail rows")
+		return email, locale, orderId, errResp
+	}
+
+	return email, locale, orderId, nil
+}
+
+/*
+Gets the title and quantity of the products of an order for a mail
+*/
+func getOrderMailItems(tx *sql.Tx, query string, id string) ([]mailing.OrderMailItem, *utils.ErrorHandler) {
+	var items []mailing.OrderMailItem
+
+	rows, err := tx.QueryContext(context.Background(), query, id)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting order mail rows")
+		return items, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var item mailing.OrderMailItem
+		err = rows.Scan(&item.Title, &item.Quantity)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting order mail rows")
+			return items, errResp
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
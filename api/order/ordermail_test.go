@@ -0,0 +1,67 @@
+package order
+
+import (
+	"BackendAPI/api/outbox"
+	"BackendAPI/data"
+	"BackendAPI/mailing"
+	"BackendAPI/store"
+	"context"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderMails(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	mailer := mailing.NewMemoryMailer()
+	mailing.UseMailer(mailer)
+	defer mailing.UseMailer(nil)
+
+	sellerIds, _, orderId := createDummySubOrderData(t, db)
+	_, err = db.ExecContext(context.Background(), `UPDATE buyers SET locale = 'es' WHERE email = 'test@aucto.io';`)
+	assert.NoError(t, err)
+
+	//Test 1: Buyer is mailed a confirmation of the order in their locale once it is paid
+	resErr := updateSubOrdersPaymentStatus(db, orderId, false, "completed")
+	assert.Empty(t, resErr)
+	messages := mailer.Messages()
+	assert.Equal(t, 1, len(messages))
+	assert.Equal(t, "test@aucto.io", messages[0].ToEmail)
+	assert.Equal(t, "Tu pedido ha sido confirmado", messages[0].Subject)
+	assert.Contains(t, messages[0].Text, "$208.00")
+
+	//Test 2: Buyer is mailed when a seller ships their sub-order
+	subOrders, resErr := GetOrderSubOrders(db, orderId, false)
+	assert.Empty(t, resErr)
+	_, resErr = UpdateSubOrderStatus(db, sellerIds[0], subOrders[0].SubOrderId, data.UpdateSubOrderStatusRequestData{Status: "shipped"})
+	assert.Empty(t, resErr)
+	messages = mailer.Messages()
+	assert.Equal(t, 2, len(messages))
+	assert.Equal(t, "Tu pedido está en camino", messages[1].Subject)
+	assert.Contains(t, messages[1].Text, "test ha enviado")
+
+	//Test 3: Buyer is mailed when a sub-order is refunded
+	_, resErr = RefundSubOrder(db, sellerIds[1], subOrders[1].SubOrderId, data.RefundSubOrderRequestData{Amount: 500, Reason: "Damaged"})
+	assert.Empty(t, resErr)
+	messages = mailer.Messages()
+	assert.Equal(t, 3, len(messages))
+	assert.Contains(t, messages[2].Text, "$5.00")
+	assert.Contains(t, messages[2].Text, "Damaged")
+
+	//Test 4: Order events are added to the outbox along with the mails
+	var topics []string
+	rows, err := db.QueryContext(context.Background(), `SELECT topic FROM outbox_messages WHERE topic != 'mail' ORDER BY created_date;`)
+	assert.NoError(t, err)
+	for rows.Next() {
+		var topic string
+		assert.NoError(t, rows.Scan(&topic))
+		topics = append(topics, topic)
+	}
+	rows.Close()
+	assert.Equal(t, []string{outbox.OrderPaidTopic, outbox.SubOrderShippedTopic, outbox.SubOrderRefundedTopic}, topics)
+
+	store.CloseDB(db)
+}
@@ -0,0 +1,865 @@
+package order
+
+import (
+	"BackendAPI/api/ledger"
+	"BackendAPI/api/offer"
+	"BackendAPI/api/outbox"
+	"BackendAPI/api/seller"
+	"BackendAPI/api/telegram"
+	"BackendAPI/api/webhook"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const subOrderColumns = `sub_orders.sub_order_id, COALESCE(sub_orders.order_id::TEXT, ''), COALESCE(sub_orders.guest_order_id::TEXT, ''),
+	sub_orders.seller_id, sub_orders.products_cost, sub_orders.discount, sub_orders.delivery_fee,
+	sub_orders.products_cost - sub_orders.discount + sub_orders.delivery_fee, sub_orders.refunded_amount, sub_orders.status,
+	sub_orders.created_date::TEXT, sub_orders.updated_date::TEXT`
+
+const sellerSubOrderQuery = `SELECT ` + subOrderColumns + `,
+		COALESCE(orders.delivery_type, guest_orders.delivery_type), COALESCE(orders.payment_status, guest_orders.payment_status),
+		COALESCE(orders.phone_number, guest_orders.phone_number), COALESCE(orders.address_line_1, guest_orders.address_line_1),
+		COALESCE(orders.address_line_2, guest_orders.address_line_2, ''), COALESCE(orders.postal_code, guest_orders.postal_code),
+		COALESCE(orders.telegram_handle, guest_orders.telegram_handle, '')
+	FROM (sub_orders LEFT OUTER JOIN orders ON orders.order_id = sub_orders.order_id)
+		LEFT OUTER JOIN guest_orders ON guest_orders.guest_order_id = sub_orders.guest_order_id`
+
+/*
+Gets the sub-orders of an order or guest order, one for each seller in the order. isGuest selects which kind of
+order the id belongs to.
+*/
+func GetOrderSubOrders(db *sql.DB, orderId string, isGuest bool) ([]data.SubOrderData, *utils.ErrorHandler) {
+	subOrders := []data.SubOrderData{}
+
+	query := `SELECT ` + subOrderColumns + ` FROM sub_orders WHERE order_id = $1 ORDER BY created_date ASC, sub_order_id ASC;`
+	if isGuest {
+		query = `SELECT ` + subOrderColumns + ` FROM sub_orders WHERE guest_order_id = $1 ORDER BY created_date ASC, sub_order_id ASC;`
+	}
+
+	rows, err := db.QueryContext(context.Background(), query, orderId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Sub Order rows")
+		return subOrders, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var subOrder data.SubOrderData
+		err = scanSubOrder(rows, &subOrder)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Sub Order rows")
+			return subOrders, errResp
+		}
+
+		subOrders = append(subOrders, subOrder)
+	}
+
+	productErr := addSubOrderProducts(db, subOrders)
+	return subOrders, productErr
+}
+
+/*
+Gets the sub-orders of a seller with the delivery details of their orders, from the newest sub-order. If the seller
+does not exist, a 404 Not found error is returned.
+*/
+func GetSellerSubOrders(db *sql.DB, sellerId string) (data.GetSellerSubOrdersResponseData, *utils.ErrorHandler) {
+	response := data.GetSellerSubOrdersResponseData{SubOrders: []data.SellerSubOrderData{}}
+
+	if !seller.DoesSellerExist(db, sellerId) {
+		return response, utils.NotFoundError("Seller with given id does not exist")
+	}
+
+	query := sellerSubOrderQuery + ` WHERE sub_orders.seller_id = $1 ORDER BY sub_orders.created_date DESC, sub_orders.sub_order_id ASC;`
+	rows, err := db.QueryContext(context.Background(), query, sellerId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Sub Order rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	var subOrders []data.SubOrderData
+
+	for rows.Next() {
+		var subOrder data.SellerSubOrderData
+		err = scanSellerSubOrder(rows, &subOrder)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Sub Order rows")
+			return response, errResp
+		}
+
+		response.SubOrders = append(response.SubOrders, subOrder)
+		subOrders = append(subOrders, subOrder.SubOrderData)
+	}
+
+	productErr := addSubOrderProducts(db, subOrders)
+
+	for i := 0; i < len(subOrders); i++ {
+		response.SubOrders[i].Products = subOrders[i].Products
+	}
+
+	return response, productErr
+}
+
+/*
+Gets a sub-order of a seller with the delivery details of its order. Returns a 404 Not found error if the sub-order
+does not exist or belongs to another seller.
+*/
+func GetSellerSubOrder(db *sql.DB, sellerId string, subOrderId string) (data.SellerSubOrderData, *utils.ErrorHandler) {
+	var response data.SellerSubOrderData
+
+	query := sellerSubOrderQuery + ` WHERE sub_orders.sub_order_id::TEXT = $1 AND sub_orders.seller_id::TEXT = $2;`
+	err := scanSellerSubOrder(db.QueryRowContext(context.Background(), query, subOrderId, sellerId), &response)
+
+	if err == sql.ErrNoRows {
+		return response, utils.NotFoundError("Sub-order with given id does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Sub Order rows")
+		return response, errResp
+	}
+
+	subOrders := []data.SubOrderData{response.SubOrderData}
+	productErr := addSubOrderProducts(db, subOrders)
+	response.Products = subOrders[0].Products
+
+	return response, productErr
+}
+
+/*
+Moves a paid sub-order of a seller through fulfilment, a paid sub-order can be marked as 'shipped' and a shipped
+sub-order as 'delivered'. The delivery date starts the dispute window before the seller is paid out. Returns a 404
+Not found error if the sub-order is not one of the seller's and a 400 bad request for any other change of status.
+*/
+func UpdateSubOrderStatus(db *sql.DB, sellerId string, subOrderId string, request data.UpdateSubOrderStatusRequestData) (data.SellerSubOrderData, *utils.ErrorHandler) {
+	subOrder, err := GetSellerSubOrder(db, sellerId, subOrderId)
+
+	if err != nil {
+		return subOrder, err
+	}
+
+	previousStatus := map[string]string{"shipped": "paid", "delivered": "shipped"}
+	from, isValidStatus := previousStatus[request.Status]
+
+	if !isValidStatus {
+		utils.LogMessage("Sub-order status is invalid")
+		return subOrder, utils.BadRequestError("Bad status data")
+	}
+
+	tx, txErr := db.BeginTx(context.Background(), nil)
+
+	if txErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(txErr, "Error in starting Sub Order transaction")
+		return subOrder, errResp
+	}
+
+	defer tx.Rollback()
+
+	query := `UPDATE sub_orders SET status = $2, updated_date = NOW(),
+			delivered_date = CASE WHEN $2 = 'delivered' THEN NOW() ELSE delivered_date END
+		WHERE sub_order_id = $1 AND status = $3;`
+	result, execErr := tx.ExecContext(context.Background(), query, subOrderId, request.Status, from)
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in updating Sub Order rows")
+		return subOrder, errResp
+	}
+
+	if updated, _ := result.RowsAffected(); updated == 0 {
+		utils.LogMessage("Sub-order is not " + from)
+		return subOrder, utils.BadRequestError("Sub-order can only be marked as " + request.Status + " once it is " + from)
+	}
+
+	var messageIds []string
+
+	if request.Status == "shipped" {
+		mailId, outboxErr := addSubOrderShippedMail(tx, subOrderId)
+
+		if outboxErr != nil {
+			return subOrder, outboxErr
+		}
+
+		messageIds = appendMessageId(messageIds, mailId)
+		_, outboxErr = outbox.AddMessage(tx, outbox.SubOrderShippedTopic, data.SubOrderEventData{SubOrderId: subOrderId})
+
+		if outboxErr != nil {
+			return subOrder, outboxErr
+		}
+	}
+
+	telegramId, outboxErr := telegram.AddSubOrderStatusMessage(tx, subOrderId, request.Status)
+
+	if outboxErr != nil {
+		return subOrder, outboxErr
+	}
+
+	messageIds = appendMessageId(messageIds, telegramId)
+
+	execErr = tx.Commit()
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in committing Sub Order transaction")
+		return subOrder, errResp
+	}
+
+	outbox.DeliverMessages(db, messageIds)
+
+	return GetSellerSubOrder(db, sellerId, subOrderId)
+}
+
+/*
+Cancels a sub-order of a seller that has been paid but not shipped, refunding what is left of its total and
+returning its products to stock. Returns a 404 Not found error if the sub-order is not one of the seller's.
+*/
+func CancelSellerSubOrder(db *sql.DB, sellerId string, subOrderId string) (data.SellerSubOrderData, *utils.ErrorHandler) {
+	_, err := GetSellerSubOrder(db, sellerId, subOrderId)
+
+	if err != nil {
+		return data.SellerSubOrderData{}, err
+	}
+
+	cancelErr := cancelSubOrder(db, subOrderId, "Cancelled by seller")
+
+	if cancelErr != nil {
+		return data.SellerSubOrderData{}, cancelErr
+	}
+
+	return GetSellerSubOrder(db, sellerId, subOrderId)
+}
+
+/*
+Cancels a sub-order of an order or guest order for the buyer, which can be done until the seller ships it. The rest
+of the order is not affected. Returns a 404 Not found error if the sub-order is not part of the order.
+*/
+func CancelOrderSubOrder(db *sql.DB, orderId string, subOrderId string) (data.SubOrderData, *utils.ErrorHandler) {
+	var response data.SubOrderData
+
+	query := `SELECT ` + subOrderColumns + ` FROM sub_orders
+		WHERE sub_order_id::TEXT = $1 AND (order_id::TEXT = $2 OR guest_order_id::TEXT = $2);`
+	err := scanSubOrder(db.QueryRowContext(context.Background(), query, subOrderId, orderId), &response)
+
+	if err == sql.ErrNoRows {
+		return response, utils.NotFoundError("Sub-order with given id does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Sub Order rows")
+		return response, errResp
+	}
+
+	cancelErr := cancelSubOrder(db, subOrderId, "Cancelled by buyer")
+
+	if cancelErr != nil {
+		return response, cancelErr
+	}
+
+	err = scanSubOrder(db.QueryRowContext(context.Background(), query, subOrderId, orderId), &response)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Sub Order rows")
+		return response, errResp
+	}
+
+	subOrders := []data.SubOrderData{response}
+	productErr := addSubOrderProducts(db, subOrders)
+
+	return subOrders[0], productErr
+}
+
+/*
+Refunds part or all of a paid sub-order of a seller. The refunds of a sub-order cannot add up to more than its total
+and a sub-order that is refunded in full is marked as 'refunded'. Returns a 404 Not found error if the sub-order is
+not one of the seller's.
+*/
+func RefundSubOrder(db *sql.DB, sellerId string, subOrderId string, request data.RefundSubOrderRequestData) (data.SellerSubOrderData, *utils.ErrorHandler) {
+	_, err := GetSellerSubOrder(db, sellerId, subOrderId)
+
+	if err != nil {
+		return data.SellerSubOrderData{}, err
+	}
+
+	tx, txErr := db.BeginTx(context.Background(), nil)
+
+	if txErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(txErr, "Error in starting Sub Order transaction")
+		return data.SellerSubOrderData{}, errResp
+	}
+
+	defer tx.Rollback()
+
+	status, remaining, lockErr := lockSubOrder(tx, subOrderId)
+
+	if lockErr != nil {
+		return data.SellerSubOrderData{}, lockErr
+	}
+
+	if status != "paid" && status != "shipped" && status != "delivered" {
+		utils.LogMessage("Sub-order cannot be refunded when it is " + status)
+		return data.SellerSubOrderData{}, utils.BadRequestError("Sub-order cannot be refunded when it is " + status)
+	}
+
+	if request.Amount <= 0 || request.Amount > remaining {
+		utils.LogMessage("Refund amount is more than what is left of the sub-order")
+		return data.SellerSubOrderData{}, utils.BadRequestError("Bad amount data")
+	}
+
+	refundId, refundErr := insertSubOrderRefund(tx, subOrderId, request.Amount, request.Reason)
+
+	if refundErr != nil {
+		return data.SellerSubOrderData{}, refundErr
+	}
+
+	query := `UPDATE sub_orders SET refunded_amount = refunded_amount + $2,
+			status = CASE WHEN refunded_amount + $2 = products_cost - discount + delivery_fee THEN 'refunded' ELSE status END,
+			updated_date = NOW()
+		WHERE sub_order_id = $1;`
+	_, execErr := tx.ExecContext(context.Background(), query, subOrderId, request.Amount)
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in updating Sub Order rows")
+		return data.SellerSubOrderData{}, errResp
+	}
+
+	ledgerErr := ledger.RecordSubOrderRefund(tx, subOrderId, refundId, request.Amount)
+
+	if ledgerErr != nil {
+		return data.SellerSubOrderData{}, ledgerErr
+	}
+
+	messageIds, outboxErr := addSubOrderRefundMessages(tx, outbox.SubOrderRefundedTopic, subOrderId, request.Amount, request.Reason)
+
+	if outboxErr != nil {
+		return data.SellerSubOrderData{}, outboxErr
+	}
+
+	execErr = tx.Commit()
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in committing Sub Order transaction")
+		return data.SellerSubOrderData{}, errResp
+	}
+
+	outbox.DeliverMessages(db, messageIds)
+
+	return GetSellerSubOrder(db, sellerId, subOrderId)
+}
+
+/*
+Splits the products of an order into one sub-order for each seller, in the order the sellers first appear. The
+items hold the seller and cost of each product and discounts the share of the promo code discount of each product.
+With standard delivery each sub-order is shipped separately and has its own delivery fee.
+*/
+func splitSubOrders(products []data.ProductOrder, items []data.PromoOrderItemData, discounts []int, deliveryType string) []data.SubOrderData {
+	var subOrders []data.SubOrderData
+	sellerIndex := make(map[string]int)
+
+	for i := 0; i < len(products); i++ {
+		index, hasSubOrder := sellerIndex[items[i].SellerId]
+
+		if !hasSubOrder {
+			subOrder := data.SubOrderData{SellerId: items[i].SellerId, Status: "pending"}
+			if deliveryType == "standard_delivery" {
+				subOrder.DeliveryFee = standardDeliveryFee
+			}
+
+			index = len(subOrders)
+			sellerIndex[items[i].SellerId] = index
+			subOrders = append(subOrders, subOrder)
+		}
+
+		subOrders[index].Products = append(subOrders[index].Products, products[i])
+		subOrders[index].ProductsCost += items[i].Cost
+		if i < len(discounts) {
+			subOrders[index].Discount += discounts[i]
+		}
+	}
+
+	for i := 0; i < len(subOrders); i++ {
+		subOrders[i].Total = subOrders[i].ProductsCost - subOrders[i].Discount + subOrders[i].DeliveryFee
+	}
+
+	return subOrders
+}
+
+/*
+Stores the sub-orders of a new order or guest order and links the products of the order to their sub-order. Takes
+either the database or the transaction the order is created in.
+*/
+func createSubOrders(db interface {
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+	ExecContext(context.Context, string, ...interface{}) (sql.Result, error)
+}, orderId string, isGuest bool, subOrders []data.SubOrderData) *utils.ErrorHandler {
+	orderColumn, productsTable := "order_id", "order_products"
+	if isGuest {
+		orderColumn, productsTable = "guest_order_id", "guest_order_products"
+	}
+
+	createdDate := time.Now()
+
+	for i := 0; i < len(subOrders); i++ {
+		var subOrderId string
+		query := `INSERT INTO sub_orders(` + orderColumn + `, seller_id, products_cost, discount, delivery_fee, created_date, updated_date)
+			VALUES ($1, $2, $3, $4, $5, $6, $6) RETURNING sub_order_id;`
+		err := db.QueryRowContext(context.Background(), query, orderId, subOrders[i].SellerId, subOrders[i].ProductsCost,
+			subOrders[i].Discount, subOrders[i].DeliveryFee, createdDate).Scan(&subOrderId)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in inserting Sub Order rows")
+			return errResp
+		}
+
+		query = `UPDATE ` + productsTable + ` SET sub_order_id = $1 WHERE ` + orderColumn + ` = $2 AND product_id::TEXT = ANY($3);`
+		_, err = db.ExecContext(context.Background(), query, subOrderId, orderId, pq.Array(getOrderProductIds(subOrders[i].Products)))
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in updating Order Product rows")
+			return errResp
+		}
+	}
+
+	return nil
+}
+
+/*
+Moves the sub-orders of an order or guest order waiting on payment to 'paid' or 'failed' once the payment of the
+order completes or fails. Paid sub-orders are credited to their sellers in the ledger and their products are counted
+as sold, and the offer the order was checked out with is completed or, if the payment failed, left for the buyer to
+check out again. Only sub-orders that are still pending are changed, so a repeated payment callback does nothing.
+*/
+func updateSubOrdersPaymentStatus(db *sql.DB, orderId string, isGuest bool, paymentStatus string) *utils.ErrorHandler {
+	status := "failed"
+	if paymentStatus == "completed" {
+		status = "paid"
+	}
+
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Sub Order transaction")
+		return errResp
+	}
+
+	defer tx.Rollback()
+
+	query := `UPDATE sub_orders SET status = $2, updated_date = NOW() WHERE order_id = $1 AND status = 'pending'
+		RETURNING sub_order_id;`
+	if isGuest {
+		query = `UPDATE sub_orders SET status = $2, updated_date = NOW() WHERE guest_order_id = $1 AND status = 'pending'
+		RETURNING sub_order_id;`
+	}
+
+	rows, err := tx.QueryContext(context.Background(), query, orderId, status)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Sub Order rows")
+		return errResp
+	}
+
+	var subOrderIds []string
+
+	for rows.Next() {
+		var subOrderId string
+		err = rows.Scan(&subOrderId)
+
+		if err != nil {
+			rows.Close()
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in updating Sub Order rows")
+			return errResp
+		}
+
+		subOrderIds = append(subOrderIds, subOrderId)
+	}
+
+	rows.Close()
+
+	if !isGuest {
+		offerErr := offer.UpdateOrderOfferStatus(tx, orderId, paymentStatus)
+
+		if offerErr != nil {
+			return offerErr
+		}
+	}
+
+	for i := 0; i < len(subOrderIds) && status == "paid"; i++ {
+		ledgerErr := ledger.RecordSubOrderPayment(tx, subOrderIds[i])
+
+		if ledgerErr != nil {
+			return ledgerErr
+		}
+	}
+
+	var messageIds []string
+
+	if status == "paid" && len(subOrderIds) > 0 {
+		soldOutIds, soldErr := addSoldQuantities(tx, subOrderIds)
+
+		if soldErr != nil {
+			return soldErr
+		}
+
+		messageIds = append(messageIds, soldOutIds...)
+		mailId, outboxErr := addOrderConfirmationMail(tx, orderId, isGuest)
+
+		if outboxErr != nil {
+			return outboxErr
+		}
+
+		messageIds = appendMessageId(messageIds, mailId)
+		_, outboxErr = outbox.AddMessage(tx, outbox.OrderPaidTopic, data.OrderEventData{OrderId: orderId, IsGuest: isGuest})
+
+		if outboxErr != nil {
+			return outboxErr
+		}
+
+		telegramIds, outboxErr := telegram.AddOrderPaidMessages(tx, orderId, isGuest)
+
+		if outboxErr != nil {
+			return outboxErr
+		}
+
+		messageIds = append(messageIds, telegramIds...)
+		webhookIds, outboxErr := webhook.AddOrderPaidEvents(tx, orderId, isGuest)
+
+		if outboxErr != nil {
+			return outboxErr
+		}
+
+		messageIds = append(messageIds, webhookIds...)
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Sub Order transaction")
+		return errResp
+	}
+
+	outbox.DeliverMessages(db, messageIds)
+
+	return nil
+}
+
+/*
+Adds the quantities of paid sub-orders to the sold quantities of their products. Products that sell their last unit
+send the product.sold_out event to the webhooks of their seller, the ids of the outbox messages are returned.
+*/
+func addSoldQuantities(tx *sql.Tx, subOrderIds []string) ([]string, *utils.ErrorHandler) {
+	var products []data.ProductOrder
+	var messageIds []string
+
+	query := `SELECT product_id, SUM(quantity)
+		FROM (SELECT product_id, quantity FROM order_products WHERE sub_order_id::TEXT = ANY($1)
+			UNION ALL SELECT product_id, quantity FROM guest_order_products WHERE sub_order_id::TEXT = ANY($1)) AS sub_order_products
+		GROUP BY product_id;`
+	rows, err := tx.QueryContext(context.Background(), query, pq.Array(subOrderIds))
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting product order rows")
+		return messageIds, errResp
+	}
+
+	for rows.Next() {
+		var product data.ProductOrder
+		err = rows.Scan(&product.ProductId, &product.OrderQuantity)
+
+		if err != nil {
+			rows.Close()
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting product order rows")
+			return messageIds, errResp
+		}
+
+		products = append(products, product)
+	}
+
+	rows.Close()
+
+	for i := 0; i < len(products); i++ {
+		var soldOut bool
+		query = `UPDATE products SET sold_quantity = sold_quantity + $1 WHERE product_id = $2
+			RETURNING sold_quantity >= product_quantity AND sold_quantity - $1 < product_quantity;`
+		err = tx.QueryRowContext(context.Background(), query, products[i].OrderQuantity, products[i].ProductId).Scan(&soldOut)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in Updating product rows")
+			return messageIds, errResp
+		}
+
+		if soldOut {
+			webhookIds, webhookErr := webhook.AddProductSoldOutEvent(tx, products[i].ProductId)
+
+			if webhookErr != nil {
+				return messageIds, webhookErr
+			}
+
+			messageIds = append(messageIds, webhookIds...)
+		}
+	}
+
+	return messageIds, nil
+}
+
+/*
+Cancels a paid sub-order, refunding what is left of its total and returning its products to stock
+*/
+func cancelSubOrder(db *sql.DB, subOrderId string, reason string) *utils.ErrorHandler {
+	tx, txErr := db.BeginTx(context.Background(), nil)
+
+	if txErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(txErr, "Error in starting Sub Order transaction")
+		return errResp
+	}
+
+	defer tx.Rollback()
+
+	status, remaining, lockErr := lockSubOrder(tx, subOrderId)
+
+	if lockErr != nil {
+		return lockErr
+	}
+
+	if status == "shipped" || status == "delivered" {
+		utils.LogMessage("Sub-order has already been shipped")
+		return utils.BadRequestError("Sub-order can only be cancelled before it is shipped")
+	}
+
+	if status != "paid" {
+		utils.LogMessage("Sub-order cannot be cancelled when it is " + status)
+		return utils.BadRequestError("Sub-order cannot be cancelled when it is " + status)
+	}
+
+	query := `UPDATE sub_orders SET status = 'cancelled', refunded_amount = refunded_amount + $2, updated_date = NOW()
+		WHERE sub_order_id = $1;`
+	_, err := tx.ExecContext(context.Background(), query, subOrderId, remaining)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Sub Order rows")
+		return errResp
+	}
+
+	if remaining > 0 {
+		refundId, refundErr := insertSubOrderRefund(tx, subOrderId, remaining, reason)
+
+		if refundErr != nil {
+			return refundErr
+		}
+
+		ledgerErr := ledger.RecordSubOrderRefund(tx, subOrderId, refundId, remaining)
+
+		if ledgerErr != nil {
+			return ledgerErr
+		}
+	}
+
+	query = `UPDATE products SET sold_quantity = products.sold_quantity - sub_order_products.quantity
+		FROM (SELECT product_id, quantity FROM order_products WHERE sub_order_id = $1
+			UNION ALL SELECT product_id, quantity FROM guest_order_products WHERE sub_order_id = $1) AS sub_order_products
+		WHERE products.product_id = sub_order_products.product_id;`
+	_, err = tx.ExecContext(context.Background(), query, subOrderId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Product rows")
+		return errResp
+	}
+
+	messageIds, outboxErr := addSubOrderRefundMessages(tx, outbox.SubOrderCancelledTopic, subOrderId, remaining, reason)
+
+	if outboxErr != nil {
+		return outboxErr
+	}
+
+	webhookIds, outboxErr := webhook.AddOrderCancelledEvent(tx, subOrderId, reason, remaining)
+
+	if outboxErr != nil {
+		return outboxErr
+	}
+
+	messageIds = append(messageIds, webhookIds...)
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Sub Order transaction")
+		return errResp
+	}
+
+	outbox.DeliverMessages(db, messageIds)
+
+	return nil
+}
+
+/*
+Adds the event of a sub-order being refunded or cancelled to the outbox, along with the refund mail to its buyer if
+any amount was refunded. Returns the ids of the messages to deliver once the transaction commits.
+*/
+func addSubOrderRefundMessages(tx *sql.Tx, topic string, subOrderId string, amount int, reason string) ([]string, *utils.ErrorHandler) {
+	var messageIds []string
+
+	if amount > 0 {
+		mailId, outboxErr := addSubOrderRefundMail(tx, subOrderId, amount, reason)
+
+		if outboxErr != nil {
+			return messageIds, outboxErr
+		}
+
+		messageIds = appendMessageId(messageIds, mailId)
+	}
+
+	event := data.SubOrderEventData{SubOrderId: subOrderId, Amount: amount, Reason: reason}
+	_, outboxErr := outbox.AddMessage(tx, topic, event)
+
+	return messageIds, outboxErr
+}
+
+/*
+Adds the id of an outbox message to the messages to deliver, mails that were left out have no id
+*/
+func appendMessageId(messageIds []string, messageId string) []string {
+	if messageId == "" {
+		return messageIds
+	}
+
+	return append(messageIds, messageId)
+}
+
+/*
+Locks a sub-order for the rest of the transaction and returns its status and the amount that can still be refunded
+*/
+func lockSubOrder(tx *sql.Tx, subOrderId string) (string, int, *utils.ErrorHandler) {
+	var status string
+	var remaining int
+
+	query := `SELECT status, products_cost - discount + delivery_fee - refunded_amount FROM sub_orders
+		WHERE sub_order_id = $1 FOR UPDATE;`
+	err := tx.QueryRowContext(context.Background(), query, subOrderId).Scan(&status, &remaining)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Sub Order rows")
+		return status, remaining, errResp
+	}
+
+	return status, remaining, nil
+}
+
+/*
+Records a refund of a sub-order and returns the id of the refund
+*/
+func insertSubOrderRefund(tx *sql.Tx, subOrderId string, amount int, reason string) (string, *utils.ErrorHandler) {
+	var refundId string
+
+	query := `INSERT INTO sub_order_refunds(sub_order_id, amount, reason, created_date) VALUES ($1, $2, $3, NOW())
+		RETURNING refund_id;`
+	err := tx.QueryRowContext(context.Background(), query, subOrderId, amount, utils.NewNullableString(reason)).Scan(&refundId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Sub Order Refund rows")
+		return refundId, errResp
+	}
+
+	return refundId, nil
+}
+
+/*
+Adds the products of each sub-order to the sub-orders
+*/
+func addSubOrderProducts(db *sql.DB, subOrders []data.SubOrderData) *utils.ErrorHandler {
+	if len(subOrders) == 0 {
+		return nil
+	}
+
+	var subOrderIds []string
+	for i := 0; i < len(subOrders); i++ {
+		subOrderIds = append(subOrderIds, subOrders[i].SubOrderId)
+	}
+
+	query := `SELECT sub_order_id, product_id, quantity FROM order_products WHERE sub_order_id::TEXT = ANY($1)
+		UNION ALL SELECT sub_order_id, product_id, quantity FROM guest_order_products WHERE sub_order_id::TEXT = ANY($1);`
+	rows, err := db.QueryContext(context.Background(), query, pq.Array(subOrderIds))
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Order Product rows")
+		return errResp
+	}
+
+	defer rows.Close()
+
+	products := make(map[string][]data.ProductOrder)
+
+	for rows.Next() {
+		var subOrderId string
+		var product data.ProductOrder
+		err = rows.Scan(&subOrderId, &product.ProductId, &product.OrderQuantity)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Order Product rows")
+			return errResp
+		}
+
+		products[subOrderId] = append(products[subOrderId], product)
+	}
+
+	for i := 0; i < len(subOrders); i++ {
+		subOrders[i].Products = products[subOrders[i].SubOrderId]
+	}
+
+	return nil
+}
+
+/*
+Scans a row of sub-order columns into a sub-order
+*/
+func scanSubOrder(row interface{ Scan(...interface{}) error }, subOrder *data.SubOrderData) error {
+	return row.Scan(&subOrder.SubOrderId, &subOrder.OrderId, &subOrder.GuestOrderId, &subOrder.SellerId,
+		&subOrder.ProductsCost, &subOrder.Discount, &subOrder.DeliveryFee, &subOrder.Total, &subOrder.RefundedAmount,
+		&subOrder.Status, &subOrder.CreatedDate, &subOrder.UpdatedDate)
+}
+
+/*
+Scans a row of sub-order columns and order delivery details into a seller sub-order
+*/
+func scanSellerSubOrder(row interface{ Scan(...interface{}) error }, subOrder *data.SellerSubOrderData) error {
+	return row.Scan(&subOrder.SubOrderId, &subOrder.OrderId, &subOrder.GuestOrderId, &subOrder.SellerId,
+		&subOrder.ProductsCost, &subOrder.Discount, &subOrder.DeliveryFee, &subOrder.Total, &subOrder.RefundedAmount,
+		&subOrder.Status, &subOrder.CreatedDate, &subOrder.UpdatedDate, &subOrder.DeliveryType, &subOrder.PaymentStatus,
+		&subOrder.PhoneNumber, &subOrder.AddressLine1, &subOrder.AddressLine2, &subOrder.PostalCode, &subOrder.TelegramHandle)
+}
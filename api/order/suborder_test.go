@@ -0,0 +1,190 @@
+package order
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitSubOrders(t *testing.T) {
+	products := []data.ProductOrder{{ProductId: "p1", OrderQuantity: 1}, {ProductId: "p2", OrderQuantity: 2},
+		{ProductId: "p3", OrderQuantity: 1}}
+	items := []data.PromoOrderItemData{{SellerId: "a", Cost: 10000}, {SellerId: "b", Cost: 4000}, {SellerId: "a", Cost: 2000}}
+
+	//Test 1: Products are grouped by seller with a delivery fee for each seller
+	subOrders := splitSubOrders(products, items, nil, "standard_delivery")
+	assert.Equal(t, 2, len(subOrders))
+	assert.Equal(t, "a", subOrders[0].SellerId)
+	assert.Equal(t, []data.ProductOrder{products[0], products[2]}, subOrders[0].Products)
+	assert.Equal(t, 12000, subOrders[0].ProductsCost)
+	assert.Equal(t, 400, subOrders[0].DeliveryFee)
+	assert.Equal(t, 12400, subOrders[0].Total)
+	assert.Equal(t, "b", subOrders[1].SellerId)
+	assert.Equal(t, 4400, subOrders[1].Total)
+
+	//Test 2: Each seller carries the discount of their products and self collection has no delivery fee
+	subOrders = splitSubOrders(products, items, []int{500, 200, 100}, "self_collection")
+	assert.Equal(t, 600, subOrders[0].Discount)
+	assert.Equal(t, 11400, subOrders[0].Total)
+	assert.Equal(t, 200, subOrders[1].Discount)
+	assert.Equal(t, 3800, subOrders[1].Total)
+}
+
+func TestGetSellerSubOrders(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerIds, productIds, orderId := createDummySubOrderData(t, db)
+
+	//Test 1: Order has a sub-order for each seller
+	subOrders, resErr := GetOrderSubOrders(db, orderId, false)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, len(subOrders))
+
+	//Test 2: Seller only sees their own sub-order
+	res, resErr := GetSellerSubOrders(db, sellerIds[1])
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(res.SubOrders))
+	assert.Equal(t, []data.ProductOrder{{ProductId: productIds[1], OrderQuantity: 1}}, res.SubOrders[0].Products)
+	assert.Equal(t, "Test", res.SubOrders[0].AddressLine1)
+
+	//Test 3: Sub-order of another seller
+	_, resErr = GetSellerSubOrder(db, sellerIds[0], res.SubOrders[0].SubOrderId)
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	//Test 4: Seller does not exist
+	_, resErr = GetSellerSubOrders(db, orderId)
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func TestUpdateSubOrderStatus(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerIds, productIds, orderId := createDummySubOrderData(t, db)
+	subOrders, resErr := GetOrderSubOrders(db, orderId, false)
+	assert.Empty(t, resErr)
+
+	//Test 1: Unpaid sub-order cannot be shipped
+	_, resErr = UpdateSubOrderStatus(db, sellerIds[0], subOrders[0].SubOrderId, data.UpdateSubOrderStatusRequestData{Status: "shipped"})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Sub-order can only be marked as shipped once it is paid", resErr.Error())
+
+	//Test 2: Payment of the order marks every sub-order as paid and counts its products as sold once
+	resErr = updateSubOrdersPaymentStatus(db, orderId, false, "completed")
+	assert.Empty(t, resErr)
+	resErr = updateSubOrdersPaymentStatus(db, orderId, false, "completed")
+	assert.Empty(t, resErr)
+
+	var soldQuantity int
+	err = db.QueryRowContext(context.Background(), `SELECT sold_quantity FROM products WHERE product_id = $1;`, productIds[0]).Scan(&soldQuantity)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, soldQuantity)
+
+	res, resErr := UpdateSubOrderStatus(db, sellerIds[0], subOrders[0].SubOrderId, data.UpdateSubOrderStatusRequestData{Status: "shipped"})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "shipped", res.Status)
+
+	//Test 3: Unknown status
+	_, resErr = UpdateSubOrderStatus(db, sellerIds[0], subOrders[0].SubOrderId, data.UpdateSubOrderStatusRequestData{Status: "lost"})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad status data", resErr.Error())
+
+	//Test 4: Shipped sub-order cannot be cancelled
+	_, resErr = CancelSellerSubOrder(db, sellerIds[0], subOrders[0].SubOrderId)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Sub-order can only be cancelled before it is shipped", resErr.Error())
+
+	store.CloseDB(db)
+}
+
+func TestRefundSubOrder(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerIds, productIds, orderId := createDummySubOrderData(t, db)
+	subOrders, resErr := GetOrderSubOrders(db, orderId, false)
+	assert.Empty(t, resErr)
+	resErr = updateSubOrdersPaymentStatus(db, orderId, false, "completed")
+	assert.Empty(t, resErr)
+	_, execErr := db.ExecContext(context.Background(), `UPDATE products SET sold_quantity = 1;`)
+	assert.NoError(t, execErr)
+
+	//Test 1: Partial refund
+	res, resErr := RefundSubOrder(db, sellerIds[0], subOrders[0].SubOrderId, data.RefundSubOrderRequestData{Amount: 4000, Reason: "Damaged"})
+	assert.Empty(t, resErr)
+	assert.Equal(t, 4000, res.RefundedAmount)
+	assert.Equal(t, "paid", res.Status)
+
+	//Test 2: Refund cannot be more than what is left
+	_, resErr = RefundSubOrder(db, sellerIds[0], subOrders[0].SubOrderId, data.RefundSubOrderRequestData{Amount: 7000})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad amount data", resErr.Error())
+
+	//Test 3: Buyer cancels the sub-order, the rest is refunded and the product is back in stock
+	cancelled, resErr := CancelOrderSubOrder(db, orderId, subOrders[0].SubOrderId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, "cancelled", cancelled.Status)
+	assert.Equal(t, cancelled.Total, cancelled.RefundedAmount)
+
+	var soldQuantity int
+	err = db.QueryRowContext(context.Background(), `SELECT sold_quantity FROM products WHERE product_id = $1;`, productIds[0]).Scan(&soldQuantity)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, soldQuantity)
+
+	//Test 4: The other sub-order is not affected
+	other, resErr := GetSellerSubOrder(db, sellerIds[1], subOrders[1].SubOrderId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, "paid", other.Status)
+	assert.Equal(t, 0, other.RefundedAmount)
+
+	store.CloseDB(db)
+}
+
+func createDummySubOrderData(t *testing.T, db *sql.DB) ([]string, []string, string) {
+	var sellerIds, productIds []string
+	buyerIds := createDummyBuyers(db)
+
+	names := []string{"test", "test2"}
+	for i := 0; i < len(names); i++ {
+		var sellerId string
+		query := `INSERT INTO sellers(email, seller_name, password) VALUES ($1, $2, 'test') RETURNING seller_id`
+		err := db.QueryRowContext(context.Background(), query, names[i]+"@aucto.io", names[i]).Scan(&sellerId)
+		assert.NoError(t, err)
+		sellerIds = append(sellerIds, sellerId)
+
+		sellerProductIds, err := createDummyProducts(db, sellerId)
+		assert.NoError(t, err)
+		productIds = append(productIds, sellerProductIds[0])
+	}
+
+	products := []data.ProductOrder{{ProductId: productIds[0], OrderQuantity: 1}, {ProductId: productIds[1], OrderQuantity: 1}}
+	fees := data.OrderFees{PaymentType: "paynow_online", DeliveryType: "standard_delivery", DeliveryFee: 800, TotalPaid: 20800}
+	subOrders, resErr := validatePaymentAmount(db, products, fees, nil, nil)
+	assert.Empty(t, resErr)
+
+	var orderId string
+	query := `INSERT INTO orders(buyer_id, delivery_type, delivery_fee, payment_type, payment_fee, small_order_fee, total_paid,
+			phone_number, order_date, address_line_1, postal_code)
+		VALUES ($1, 'standard_delivery', 800, 'paynow_online', 0, 0, 20800, '91234567', NOW(), 'Test', '123456') RETURNING order_id;`
+	err := db.QueryRowContext(context.Background(), query, buyerIds[0]).Scan(&orderId)
+	assert.NoError(t, err)
+
+	query = `INSERT INTO order_products(order_id, product_id, quantity) VALUES ($1, $2, 1), ($1, $3, 1);`
+	_, err = db.ExecContext(context.Background(), query, orderId, productIds[0], productIds[1])
+	assert.NoError(t, err)
+
+	resErr = createSubOrders(db, orderId, false, subOrders)
+	assert.Empty(t, resErr)
+
+	return sellerIds, productIds, orderId
+}
@@ -0,0 +1,330 @@
+package outbox
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/mailing"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	MailTopic              = "mail"
+	BuyerSignedUpTopic     = "buyer.signed_up"
+	OrderPaidTopic         = "order.paid"
+	SubOrderShippedTopic   = "sub_order.shipped"
+	SubOrderRefundedTopic  = "sub_order.refunded"
+	SubOrderCancelledTopic = "sub_order.cancelled"
+)
+
+const (
+	outboxBatchSize   = 100
+	outboxMaxAttempts = 8
+	outboxBaseBackoff = 30 * time.Second
+	outboxMaxBackoff  = 6 * time.Hour
+	outboxListLimit   = 200
+)
+
+const outboxMessageColumns = `message_id, topic, payload::TEXT, status, attempts, last_error, next_attempt_date::TEXT,
+	created_date::TEXT, COALESCE(delivered_date::TEXT, '')`
+
+/*
+Delivers the payload of an outbox message to whatever is interested in its topic
+*/
+type Handler func(payload []byte) error
+
+var (
+	handlersMutex sync.Mutex
+	handlers      = map[string]Handler{MailTopic: deliverMail}
+)
+
+/*
+Sets the handler that the messages of a topic are delivered to. Messages of topics without a handler are marked
+as delivered without doing anything, so that events can be recorded before anything consumes them.
+*/
+func RegisterHandler(topic string, handler Handler) {
+	handlersMutex.Lock()
+	defer handlersMutex.Unlock()
+
+	handlers[topic] = handler
+}
+
+/*
+Adds a message to the outbox as part of a transaction, so that it is only delivered if the transaction commits.
+Returns the id of the message.
+*/
+func AddMessage(tx *sql.Tx, topic string, payload interface{}) (string, *utils.ErrorHandler) {
+	var messageId string
+
+	content, err := json.Marshal(payload)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in encoding outbox payload")
+		return messageId, errResp
+	}
+
+	query := `INSERT INTO outbox_messages(topic, payload) VALUES ($1, $2) RETURNING message_id;`
+	err = tx.QueryRowContext(context.Background(), query, topic, string(content)).Scan(&messageId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Outbox Message rows")
+		return messageId, errResp
+	}
+
+	return messageId, nil
+}
+
+/*
+Adds a mail that has been composed to the outbox as part of a transaction
+*/
+func AddMail(tx *sql.Tx, message mailing.Message) (string, *utils.ErrorHandler) {
+	return AddMessage(tx, MailTopic, message)
+}
+
+/*
+Tries to deliver messages right after the transaction that added them commits, so that buyers do not wait on the
+worker for mails such as their OTP. Messages that fail are left for the worker to retry.
+*/
+func DeliverMessages(db *sql.DB, messageIds []string) {
+	if len(messageIds) == 0 {
+		return
+	}
+
+	_, err := deliverOutboxMessages(db, messageIds, getHandlers())
+
+	if err != nil {
+		utils.LogMessage("Delivery of outbox messages after commit failed: " + err.Error())
+	}
+}
+
+/*
+Delivers the outbox messages that are due. A message that fails is retried with exponential backoff and is marked
+as 'dead' once it has failed too many times. Delivered messages are removed after a week.
+*/
+func DeliverOutboxMessages(db *sql.DB) (data.DeliverOutboxMessagesResponseData, *utils.ErrorHandler) {
+	query := `DELETE FROM outbox_messages WHERE status = 'delivered' AND delivered_date < NOW() - INTERVAL '7 days';`
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in deleting Outbox Message rows")
+		return data.DeliverOutboxMessagesResponseData{}, errResp
+	}
+
+	return deliverOutboxMessages(db, nil, getHandlers())
+}
+
+/*
+Gets the messages that are stuck in the outbox, from the newest message. Stuck messages are the dead messages and the
+pending messages that have failed at least once. Messages can also be filtered by status instead.
+*/
+func GetOutboxMessages(db *sql.DB, status string) (data.GetOutboxMessagesResponseData, *utils.ErrorHandler) {
+	response := data.GetOutboxMessagesResponseData{Messages: []data.OutboxMessageData{}}
+
+	if status != "" && status != "pending" && status != "delivered" && status != "dead" {
+		return response, utils.BadRequestError("Bad status data")
+	}
+
+	query := `SELECT ` + outboxMessageColumns + ` FROM outbox_messages
+		WHERE ($1 = '' AND (status = 'dead' OR (status = 'pending' AND attempts > 0))) OR status = $1
+		ORDER BY created_date DESC LIMIT $2;`
+	rows, err := db.QueryContext(context.Background(), query, status, outboxListLimit)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Outbox Message rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		message, scanErr := scanOutboxMessage(rows)
+
+		if scanErr != nil {
+			return response, scanErr
+		}
+
+		response.Messages = append(response.Messages, message)
+	}
+
+	return response, nil
+}
+
+/*
+Puts a dead message back in the outbox to be delivered again from its first attempt. Returns a 404 Not found error
+if the message does not exist and a 400 bad request if it is not dead.
+*/
+func RetryOutboxMessage(db *sql.DB, messageId string) (data.OutboxMessageData, *utils.ErrorHandler) {
+	message, err := getOutboxMessage(db, messageId)
+
+	if err != nil {
+		return message, err
+	}
+
+	if message.Status != "dead" {
+		return message, utils.BadRequestError("Only dead messages can be retried")
+	}
+
+	query := `UPDATE outbox_messages SET status = 'pending', attempts = 0, last_error = '', next_attempt_date = NOW()
+		WHERE message_id = $1 AND status = 'dead';`
+	_, execErr := db.ExecContext(context.Background(), query, messageId)
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in updating Outbox Message rows")
+		return message, errResp
+	}
+
+	return getOutboxMessage(db, messageId)
+}
+
+/*
+Claims the due messages, or only the given messages if there are any, and delivers them to the handler of their
+topic. Claimed messages are not due again for five minutes, so that other workers leave them alone and a worker that
+stops midway does not lose them.
+*/
+func deliverOutboxMessages(db *sql.DB, messageIds []string, handlers map[string]Handler) (data.DeliverOutboxMessagesResponseData, *utils.ErrorHandler) {
+	var response data.DeliverOutboxMessagesResponseData
+
+	query := `UPDATE outbox_messages SET next_attempt_date = NOW() + INTERVAL '5 minutes'
+		WHERE message_id IN (SELECT message_id FROM outbox_messages
+			WHERE status = 'pending' AND next_attempt_date <= NOW() AND ($2::TEXT[] IS NULL OR message_id::TEXT = ANY($2))
+			ORDER BY next_attempt_date LIMIT $1 FOR UPDATE SKIP LOCKED)
+		RETURNING message_id, topic, payload::TEXT, attempts;`
+	rows, err := db.QueryContext(context.Background(), query, outboxBatchSize, pq.Array(messageIds))
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in claiming Outbox Message rows")
+		return response, errResp
+	}
+
+	type claimedMessage struct {
+		messageId string
+		topic     string
+		payload   string
+		attempts  int
+	}
+
+	var claimed []claimedMessage
+
+	for rows.Next() {
+		var message claimedMessage
+		err = rows.Scan(&message.messageId, &message.topic, &message.payload, &message.attempts)
+
+		if err != nil {
+			rows.Close()
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in claiming Outbox Message rows")
+			return response, errResp
+		}
+
+		claimed = append(claimed, message)
+	}
+
+	rows.Close()
+
+	for i := 0; i < len(claimed); i++ {
+		var deliveryErr error
+		if handler, hasHandler := handlers[claimed[i].topic]; hasHandler {
+			deliveryErr = handler([]byte(claimed[i].payload))
+		}
+
+		attempts := claimed[i].attempts + 1
+
+		if deliveryErr == nil {
+			query = `UPDATE outbox_messages SET status = 'delivered', attempts = $2, last_error = '', delivered_date = NOW()
+				WHERE message_id = $1;`
+			_, err = db.ExecContext(context.Background(), query, claimed[i].messageId, attempts)
+			response.Delivered++
+		} else if attempts >= outboxMaxAttempts {
+			query = `UPDATE outbox_messages SET status = 'dead', attempts = $2, last_error = $3 WHERE message_id = $1;`
+			_, err = db.ExecContext(context.Background(), query, claimed[i].messageId, attempts, deliveryErr.Error())
+			response.DeadLettered++
+			utils.LogMessage("Outbox message " + claimed[i].messageId + " is dead after failing to be delivered")
+		} else {
+			query = `UPDATE outbox_messages SET attempts = $2, last_error = $3,
+					next_attempt_date = NOW() + $4 * INTERVAL '1 second'
+				WHERE message_id = $1;`
+			backoff := utils.GetBackoff(attempts, outboxBaseBackoff, outboxMaxBackoff)
+			_, err = db.ExecContext(context.Background(), query, claimed[i].messageId, attempts, deliveryErr.Error(), int(backoff.Seconds()))
+			response.Retried++
+		}
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in updating Outbox Message rows")
+			return response, errResp
+		}
+	}
+
+	return response, nil
+}
+
+/*
+Gets a copy of the handlers of every topic
+*/
+func getHandlers() map[string]Handler {
+	handlersMutex.Lock()
+	defer handlersMutex.Unlock()
+
+	copied := make(map[string]Handler, len(handlers))
+	for topic, handler := range handlers {
+		copied[topic] = handler
+	}
+
+	return copied
+}
+
+/*
+Sends a mail from the outbox with the mailer in use
+*/
+func deliverMail(payload []byte) error {
+	var message mailing.Message
+	err := json.Unmarshal(payload, &message)
+
+	if err != nil {
+		return err
+	}
+
+	return mailing.Deliver(message)
+}
+
+/*
+Gets a message in the outbox, returns a 404 Not found error if it does not exist
+*/
+func getOutboxMessage(db *sql.DB, messageId string) (data.OutboxMessageData, *utils.ErrorHandler) {
+	query := `SELECT ` + outboxMessageColumns + ` FROM outbox_messages WHERE message_id::TEXT = $1;`
+
+	return scanOutboxMessage(db.QueryRowContext(context.Background(), query, messageId))
+}
+
+/*
+Scans a row of outbox message columns
+*/
+func scanOutboxMessage(row interface{ Scan(...interface{}) error }) (data.OutboxMessageData, *utils.ErrorHandler) {
+	var message data.OutboxMessageData
+
+	err := row.Scan(&message.MessageId, &message.Topic, &message.Payload, &message.Status, &message.Attempts,
+		&message.LastError, &message.NextAttemptDate, &message.CreatedDate, &message.DeliveredDate)
+
+	if err == sql.ErrNoRows {
+		return message, utils.NotFoundError("Outbox message with given id does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Outbox Message rows")
+		return message, errResp
+	}
+
+	return message, nil
+}
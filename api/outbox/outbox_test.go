@@ -0,0 +1,208 @@
+package outbox
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/mailing"
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddMessage(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	//Test 1: Message is not added when the transaction is rolled back
+	tx, err := db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	_, resErr := AddMessage(tx, BuyerSignedUpTopic, data.BuyerEventData{BuyerId: "buyer"})
+	assert.Empty(t, resErr)
+	assert.NoError(t, tx.Rollback())
+	assert.Equal(t, 0, countOutboxMessages(t, db, "pending"))
+
+	//Test 2: Message is pending once the transaction commits
+	messageId := addTestMessage(t, db, BuyerSignedUpTopic, data.BuyerEventData{BuyerId: "buyer"})
+	message, resErr := getOutboxMessage(db, messageId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, "pending", message.Status)
+	assert.Equal(t, 0, message.Attempts)
+	assert.JSONEq(t, `{"buyer_id": "buyer"}`, message.Payload)
+
+	store.CloseDB(db)
+}
+
+func TestDeliverOutboxMessages(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	var delivered []string
+	failing := true
+	testHandlers := map[string]Handler{
+		"test.event": func(payload []byte) error {
+			if failing {
+				return errors.New("Endpoint is down")
+			}
+			delivered = append(delivered, string(payload))
+			return nil
+		},
+	}
+
+	messageId := addTestMessage(t, db, "test.event", data.BuyerEventData{BuyerId: "buyer"})
+	otherId := addTestMessage(t, db, "other.event", data.BuyerEventData{BuyerId: "buyer"})
+
+	//Test 1: Message that fails is retried after a backoff and message without a handler is delivered
+	res, resErr := deliverOutboxMessages(db, nil, testHandlers)
+	assert.Empty(t, resErr)
+	assert.Equal(t, data.DeliverOutboxMessagesResponseData{Delivered: 1, Retried: 1}, res)
+	message, _ := getOutboxMessage(db, messageId)
+	assert.Equal(t, "pending", message.Status)
+	assert.Equal(t, 1, message.Attempts)
+	assert.Equal(t, "Endpoint is down", message.LastError)
+	other, _ := getOutboxMessage(db, otherId)
+	assert.Equal(t, "delivered", other.Status)
+	assert.NotEmpty(t, other.DeliveredDate)
+
+	//Test 2: Message is not delivered again before its backoff is over
+	res, resErr = deliverOutboxMessages(db, nil, testHandlers)
+	assert.Empty(t, resErr)
+	assert.Equal(t, data.DeliverOutboxMessagesResponseData{}, res)
+
+	//Test 3: Message that fails too many times is dead
+	_, err = db.ExecContext(context.Background(), `UPDATE outbox_messages SET attempts = $2, next_attempt_date = NOW()
+		WHERE message_id = $1;`, messageId, outboxMaxAttempts-1)
+	assert.NoError(t, err)
+	res, resErr = deliverOutboxMessages(db, nil, testHandlers)
+	assert.Empty(t, resErr)
+	assert.Equal(t, data.DeliverOutboxMessagesResponseData{DeadLettered: 1}, res)
+	message, _ = getOutboxMessage(db, messageId)
+	assert.Equal(t, "dead", message.Status)
+	assert.Equal(t, outboxMaxAttempts, message.Attempts)
+
+	//Test 4: Dead message is not delivered
+	_, err = db.ExecContext(context.Background(), `UPDATE outbox_messages SET next_attempt_date = NOW() - INTERVAL '1 hour';`)
+	assert.NoError(t, err)
+	res, resErr = deliverOutboxMessages(db, nil, testHandlers)
+	assert.Empty(t, resErr)
+	assert.Equal(t, data.DeliverOutboxMessagesResponseData{}, res)
+
+	//Test 5: Retried dead message is delivered from its first attempt
+	failing = false
+	message, resErr = RetryOutboxMessage(db, messageId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, "pending", message.Status)
+	assert.Equal(t, 0, message.Attempts)
+	res, resErr = deliverOutboxMessages(db, nil, testHandlers)
+	assert.Empty(t, resErr)
+	assert.Equal(t, data.DeliverOutboxMessagesResponseData{Delivered: 1}, res)
+	assert.Equal(t, 1, len(delivered))
+	assert.JSONEq(t, `{"buyer_id": "buyer"}`, delivered[0])
+
+	//Test 6: Only the given messages are delivered when there are any
+	firstId := addTestMessage(t, db, "test.event", data.BuyerEventData{BuyerId: "first"})
+	addTestMessage(t, db, "test.event", data.BuyerEventData{BuyerId: "second"})
+	res, resErr = deliverOutboxMessages(db, []string{firstId}, testHandlers)
+	assert.Empty(t, resErr)
+	assert.Equal(t, data.DeliverOutboxMessagesResponseData{Delivered: 1}, res)
+	assert.Equal(t, 1, countOutboxMessages(t, db, "pending"))
+
+	store.CloseDB(db)
+}
+
+func TestDeliverMail(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	mailer := mailing.NewMemoryMailer()
+	mailing.UseMailer(mailer)
+	defer mailing.UseMailer(nil)
+
+	message, err := mailing.ComposeOtpMail("test@aucto.io", "en", "000000")
+	assert.NoError(t, err)
+
+	tx, err := db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	mailId, resErr := AddMail(tx, message)
+	assert.Empty(t, resErr)
+	assert.NoError(t, tx.Commit())
+
+	//Test 1: Mail is sent with the mailer in use once it is delivered
+	DeliverMessages(db, []string{mailId})
+	messages := mailer.Messages()
+	assert.Equal(t, 1, len(messages))
+	assert.Equal(t, message, messages[0])
+
+	//Test 2: Delivered mail is not sent again
+	res, resErr := DeliverOutboxMessages(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, res.Delivered)
+	assert.Equal(t, 1, len(mailer.Messages()))
+
+	store.CloseDB(db)
+}
+
+func TestGetOutboxMessages(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	deadId := addTestMessage(t, db, "test.event", data.BuyerEventData{BuyerId: "dead"})
+	failingId := addTestMessage(t, db, "test.event", data.BuyerEventData{BuyerId: "failing"})
+	addTestMessage(t, db, "test.event", data.BuyerEventData{BuyerId: "pending"})
+	_, err = db.ExecContext(context.Background(), `UPDATE outbox_messages SET status = 'dead', attempts = 8 WHERE message_id = $1;`, deadId)
+	assert.NoError(t, err)
+	_, err = db.ExecContext(context.Background(), `UPDATE outbox_messages SET attempts = 2 WHERE message_id = $1;`, failingId)
+	assert.NoError(t, err)
+
+	//Test 1: Stuck messages are listed by default
+	res, resErr := GetOutboxMessages(db, "")
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, len(res.Messages))
+
+	//Test 2: Messages are filtered by status
+	res, resErr = GetOutboxMessages(db, "pending")
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, len(res.Messages))
+	res, resErr = GetOutboxMessages(db, "dead")
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(res.Messages))
+	assert.Equal(t, deadId, res.Messages[0].MessageId)
+
+	//Test 3: Bad status
+	_, resErr = GetOutboxMessages(db, "wrong")
+	assert.NotEmpty(t, resErr)
+	assert.Equal(t, 400, resErr.ErrorCode())
+
+	//Test 4: Message that is not dead cannot be retried
+	_, resErr = RetryOutboxMessage(db, failingId)
+	assert.NotEmpty(t, resErr)
+	assert.Equal(t, 400, resErr.ErrorCode())
+
+	//Test 5: Message that does not exist cannot be retried
+	_, resErr = RetryOutboxMessage(db, "wrong")
+	assert.NotEmpty(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func addTestMessage(t *testing.T, db *sql.DB, topic string, payload interface{}) string {
+	tx, err := db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	messageId, resErr := AddMessage(tx, topic, payload)
+	assert.Empty(t, resErr)
+	assert.NoError(t, tx.Commit())
+
+	return messageId
+}
+
+func countOutboxMessages(t *testing.T, db *sql.DB, status string) int {
+	var count int
+	err := db.QueryRowContext(context.Background(), `SELECT COUNT(*) FROM outbox_messages WHERE status = $1;`, status).Scan(&count)
+	assert.NoError(t, err)
+
+	return count
+}
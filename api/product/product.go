@@ -1,13 +1,17 @@
 package product
 
 import (
+	"BackendAPI/api/catalogue"
 	"BackendAPI/api/seller"
 	"BackendAPI/data"
 	"BackendAPI/utils"
 	"context"
 	"database/sql"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 /*
@@ -25,6 +29,8 @@ func GetProductById(db *sql.DB, productId string) (data.GetProductResponseData,
 		`SELECT 
 		products.seller_id, 
 		sellers.seller_name, 
+		COALESCE(seller_ratings.rating, 0), 
+		COALESCE(seller_ratings.review_count, 0), 
 		title, 
 		description, 
 		condition, 
@@ -39,12 +45,26 @@ func GetProductById(db *sql.DB, productId string) (data.GetProductResponseData,
 		image_no, 
 		COALESCE(preorder_information.order_by::TEXT, ''), 
 		COALESCE(preorder_information.releases_on::TEXT, ''), 
-		COALESCE(product_discounts.discount, 0) 
-	FROM ((((
+		COALESCE(preorder_information.status, ''), 
+		COALESCE(product_effective_discounts.discount, 0),
+		product_card_attributes.product_id IS NOT NULL,
+		COALESCE(product_card_attributes.card_number, ''),
+		COALESCE(product_card_attributes.rarity, ''),
+		COALESCE(product_card_attributes.finish, ''),
+		COALESCE(product_card_attributes.edition, ''),
+		COALESCE(product_card_attributes.grading_company, ''),
+		COALESCE(product_card_attributes.grade, 0),
+		product_sealed_attributes.product_id IS NOT NULL,
+		COALESCE(product_sealed_attributes.sealed_type, ''),
+		COALESCE(product_sealed_attributes.pack_count, 0)
+	FROM (((((((
 		products INNER JOIN product_images ON products.product_id = product_images.product_id)
 			INNER JOIN sellers ON products.seller_id = sellers.seller_id)
-				LEFT OUTER JOIN preorder_information ON products.product_id = preorder_information.product_id)
-					LEFT OUTER JOIN product_discounts ON product_discounts.product_id = products.product_id)
+				LEFT OUTER JOIN seller_ratings ON seller_ratings.seller_id = products.seller_id)
+					LEFT OUTER JOIN preorder_information ON products.product_id = preorder_information.product_id)
+						LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id)
+							LEFT OUTER JOIN product_card_attributes ON product_card_attributes.product_id = products.product_id)
+								LEFT OUTER JOIN product_sealed_attributes ON product_sealed_attributes.product_id = products.product_id)
 	WHERE products.product_id = $1;`
 	rows, err := db.QueryContext(context.Background(), query, productId)
 	defer rows.Close()
@@ -52,11 +72,25 @@ func GetProductById(db *sql.DB, productId string) (data.GetProductResponseData,
 	for rows.Next() {
 		var image string
 		var imageNo int
+		var hasCardAttributes, hasSealedAttributes bool
+		var cardAttributes data.CardAttributesData
+		var sealedAttributes data.SealedAttributesData
 		rows.Scan(
 			&response.SellerInfo.SellerId, &response.SellerInfo.SellerName,
+			&response.SellerInfo.Rating, &response.SellerInfo.ReviewCount,
 			&response.Title, &response.Description, &response.Condition, &response.Price,
 			&response.ProductType, &response.Language, &response.Expansion, &response.PostedDate, &response.Quantity,
-			&response.SoldQuantity, &image, &imageNo, &response.OrderBy, &response.ReleasesOn, &response.Discount)
+			&response.SoldQuantity, &image, &imageNo, &response.OrderBy, &response.ReleasesOn, &response.ReleaseStatus, &response.Discount,
+			&hasCardAttributes, &cardAttributes.CardNumber, &cardAttributes.Rarity, &cardAttributes.Finish,
+			&cardAttributes.Edition, &cardAttributes.GradingCompany, &cardAttributes.Grade,
+			&hasSealedAttributes, &sealedAttributes.SealedType, &sealedAttributes.PackCount)
+
+		if hasCardAttributes {
+			response.CardAttributes = &cardAttributes
+		}
+		if hasSealedAttributes {
+			response.SealedAttributes = &sealedAttributes
+		}
 
 		image, pathErr := makeImagePath(image)
 		if err != nil {
@@ -71,6 +105,12 @@ func GetProductById(db *sql.DB, productId string) (data.GetProductResponseData,
 		return response, errResp
 	}
 
+	auction, auctionErr := getAuctionInformation(db, productId)
+	if auctionErr != nil {
+		return response, auctionErr
+	}
+	response.Auction = auction
+
 	response.ProductId = productId
 
 	return response, nil
@@ -91,6 +131,9 @@ func CreateProduct(db *sql.DB, product data.CreateProductData) (data.CreateProdu
 	if product.ProductType == "Buy-Now" {
 		response, err = CreateBuyNow(db, product)
 
+	} else if product.ProductType == "Auction" {
+		response, err = CreateAuction(db, product)
+
 	} else {
 		response, err = CreatePreOrder(db, product)
 	}
@@ -103,6 +146,24 @@ func CreateProduct(db *sql.DB, product data.CreateProductData) (data.CreateProdu
 		err = addDiscount(db, response.ProductId, product.Discount)
 	}
 
+	if err != nil {
+		return response, err
+	}
+
+	err = addProductAttributes(db, response.ProductId, product)
+
+	if err != nil {
+		return response, err
+	}
+
+	_, err = RecordPriceHistory(db, []string{response.ProductId})
+
+	if err != nil {
+		return response, err
+	}
+
+	err = addNewListing(db, response.ProductId)
+
 	return response, err
 
 }
@@ -176,6 +237,22 @@ func CreatePreOrder(db *sql.DB, product data.CreateProductData) (data.CreateProd
 	return response, nil
 }
 
+/*
+Queues a new listing to be matched against the saved searches of buyers
+*/
+func addNewListing(db *sql.DB, productId string) *utils.ErrorHandler {
+	query := `INSERT INTO new_listings(product_id, created_date) VALUES ($1,$2);`
+
+	_, err := db.ExecContext(context.Background(), query, productId, time.Now())
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in Inserting New Listing rows")
+		return errResp
+	}
+
+	return nil
+}
+
 /*
 Adds discount for a product in terms of the product amount in cents
 */
@@ -210,6 +287,8 @@ func GetProductList(db *sql.DB, request data.GetProductListRequestData) (data.Ge
 		products.product_id,
 		seller_id,
 		seller_name,
+		seller_rating,
+		seller_review_count,
 		title,
 		description,
 		condition,
@@ -224,7 +303,17 @@ func GetProductList(db *sql.DB, request data.GetProductListRequestData) (data.Ge
 		image_no,
 		COALESCE(order_by::TEXT, '') AS order_by,
 		COALESCE(releases_on::TEXT, '') AS releases_on,
-		COALESCE(discount, 0) AS discount
+		COALESCE(discount, 0) AS discount,
+		has_card_attributes,
+		COALESCE(card_number, '') AS card_number,
+		COALESCE(rarity, '') AS rarity,
+		COALESCE(finish, '') AS finish,
+		COALESCE(edition, '') AS edition,
+		COALESCE(grading_company, '') AS grading_company,
+		COALESCE(grade, 0) AS grade,
+		has_sealed_attributes,
+		COALESCE(sealed_type, '') AS sealed_type,
+		COALESCE(pack_count, 0) AS pack_count
 	FROM
 		product_images
 		RIGHT JOIN (
@@ -232,6 +321,8 @@ func GetProductList(db *sql.DB, request data.GetProductListRequestData) (data.Ge
 				products.product_id,
 				sellers.seller_id,
 				seller_name,
+				COALESCE(seller_ratings.rating, 0) AS seller_rating,
+				COALESCE(seller_ratings.review_count, 0) AS seller_review_count,
 				title,
 				description,
 				condition,
@@ -244,17 +335,55 @@ func GetProductList(db *sql.DB, request data.GetProductListRequestData) (data.Ge
 				sold_quantity,
 				order_by,
 				releases_on,
-				discount
-			FROM (((products
-						INNER JOIN sellers ON products.seller_id = sellers.seller_id)
-					LEFT OUTER JOIN preorder_information ON products.product_id = preorder_information.product_id)
-				LEFT OUTER JOIN product_discounts ON product_discounts.product_id = products.product_id)`
+				discount,
+				search_vector,
+				product_card_attributes.product_id IS NOT NULL AS has_card_attributes,
+				card_number,
+				rarity,
+				finish,
+				edition,
+				grading_company,
+				grade,
+				product_sealed_attributes.product_id IS NOT NULL AS has_sealed_attributes,
+				sealed_type,
+				pack_count
+			FROM ((((((products
+									INNER JOIN sellers ON products.seller_id = sellers.seller_id)
+								LEFT OUTER JOIN seller_ratings ON seller_ratings.seller_id = sellers.seller_id)
+							LEFT OUTER JOIN preorder_information ON products.product_id = preorder_information.product_id)
+						LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id)
+					LEFT OUTER JOIN product_card_attributes ON product_card_attributes.product_id = products.product_id)
+				LEFT OUTER JOIN product_sealed_attributes ON product_sealed_attributes.product_id = products.product_id)`
+
+	limit := request.Limit
+	if limit <= 0 {
+		limit = defaultProductPageSize
+	}
+	if limit > maxProductPageSize {
+		limit = maxProductPageSize
+	}
+
+	conditions := getProductFilterConditions(request)
+	keys := getProductSortKeys(request.SortBy, request.Search)
+
+	if request.Cursor != "" {
+		cursorValues, cursorErr := decodeProductCursor(request.Cursor, request.SortBy, request.Search)
+		if cursorErr != nil {
+			return response, cursorErr
+		}
+		conditions = append(conditions, productFilterCondition{facet: cursorFacet, condition: makeProductCursorCondition(keys, cursorValues)})
+	}
 
-	query = AddProductFiltering(query, request.Prices, request.Languages, request.ProductTypes, request.Expansions)
-	query = AddProductSorting(query, request.SortBy)
-	query = AddPagesProduct(query, request.Anchor, request.Limit)
+	// One more product than the page size is fetched to know if there is a next page
+	query = addProductConditions(query, conditions, "")
+	query = AddProductSorting(query, request.SortBy, request.Search)
+	if request.Cursor != "" {
+		query += ` LIMIT ` + strconv.Itoa(limit+1)
+	} else {
+		query = AddPagesProduct(query, request.Anchor, limit+1)
+	}
 	query += `) products ON products.product_id = product_images.product_id`
-	query = AddProductSorting(query, request.SortBy)
+	query = AddProductSorting(query, request.SortBy, request.Search)
 	query += `, image_no ASC`
 
 	rows, err := db.QueryContext(context.Background(), query)
@@ -271,11 +400,18 @@ func GetProductList(db *sql.DB, request data.GetProductListRequestData) (data.Ge
 		var product data.GetProductResponseData
 		var imagePath string
 		var imageNo int
+		var hasCardAttributes, hasSealedAttributes bool
+		var cardAttributes data.CardAttributesData
+		var sealedAttributes data.SealedAttributesData
 		// scan the product
 		err = rows.Scan(&product.ProductId, &product.SellerInfo.SellerId, &product.SellerInfo.SellerName,
+			&product.SellerInfo.Rating, &product.SellerInfo.ReviewCount,
 			&product.Title, &product.Description, &product.Condition, &product.Price, &product.ProductType,
 			&product.Language, &product.Expansion, &product.PostedDate, &product.Quantity,
-			&product.SoldQuantity, &imagePath, &imageNo, &product.OrderBy, &product.ReleasesOn, &product.Discount)
+			&product.SoldQuantity, &imagePath, &imageNo, &product.OrderBy, &product.ReleasesOn, &product.Discount,
+			&hasCardAttributes, &cardAttributes.CardNumber, &cardAttributes.Rarity, &cardAttributes.Finish,
+			&cardAttributes.Edition, &cardAttributes.GradingCompany, &cardAttributes.Grade,
+			&hasSealedAttributes, &sealedAttributes.SealedType, &sealedAttributes.PackCount)
 
 		if err != nil {
 			errResp := utils.InternalServerError(nil)
@@ -283,6 +419,13 @@ func GetProductList(db *sql.DB, request data.GetProductListRequestData) (data.Ge
 			return response, errResp
 		}
 
+		if hasCardAttributes {
+			product.CardAttributes = &cardAttributes
+		}
+		if hasSealedAttributes {
+			product.SealedAttributes = &sealedAttributes
+		}
+
 		//Convert image id to path
 		imagePath, pathErr := makeImagePath(imagePath)
 		if err != nil {
@@ -306,8 +449,24 @@ func GetProductList(db *sql.DB, request data.GetProductListRequestData) (data.Ge
 
 	}
 
+	if len(products) > limit {
+		products = products[:limit]
+
+		cursorValues, cursorErr := getProductCursorValues(db, keys, products[limit-1].ProductId)
+		if cursorErr != nil {
+			return response, cursorErr
+		}
+		response.NextCursor = encodeProductCursor(request.SortBy, request.Search, cursorValues)
+	}
+
 	response.Products = products
-	response.ProductCount = getProductCount(db, request.Prices, request.Languages, request.ProductTypes, request.Expansions)
+	response.ProductCount = getProductCount(db, request)
+
+	facets, facetErr := getProductFacets(db, request)
+	if facetErr != nil {
+		return response, facetErr
+	}
+	response.Facets = facets
 
 	return response, nil
 }
@@ -315,10 +474,11 @@ func GetProductList(db *sql.DB, request data.GetProductListRequestData) (data.Ge
 /*
 Gets the total number of products after applying filters
 */
-func getProductCount(db *sql.DB, prices []string, languages []string, productTypes []string, expansions []string) int {
+func getProductCount(db *sql.DB, request data.GetProductListRequestData) int {
 	var count int
-	query := `SELECT COUNT(*) FROM products`
-	query = AddProductFiltering(query, prices, languages, productTypes, expansions)
+	query := `SELECT COUNT(*)
+		FROM products LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id`
+	query = AddProductFiltering(query, request)
 
 	db.QueryRowContext(context.Background(), query).Scan(&count)
 
@@ -326,147 +486,131 @@ func getProductCount(db *sql.DB, prices []string, languages []string, productTyp
 }
 
 /*
-Adds the sorting to the query to determine the order of the products
+Adds the sorting to the query to determine the order of the products. Products are sorted by relevance
+to the search query if relevance is selected or no sorting is selected while searching.
 */
-func AddProductSorting(query string, sortBy string) string {
-	if sortBy == "price-low" {
-		query += ` ORDER BY products.price ASC, discount DESC`
-	} else if sortBy == "price-high" {
-		query += ` ORDER BY products.price DESC, discount ASC`
-	} else if sortBy == "name-asc" {
-		query += ` ORDER BY products.title ASC`
-	} else if sortBy == "name-desc" {
-		query += ` ORDER BY products.title DESC`
-	} else {
-		query += ` ORDER BY products.posted_date DESC`
+func AddProductSorting(query string, sortBy string, search string) string {
+	keys := getProductSortKeys(sortBy, search)
+	var orderings []string
+
+	for i := 0; i < len(keys); i++ {
+		if keys[i].descending {
+			orderings = append(orderings, keys[i].expression+` DESC`)
+		} else {
+			orderings = append(orderings, keys[i].expression+` ASC`)
+		}
 	}
 
+	query += ` ORDER BY ` + strings.Join(orderings, `, `)
 	return query
 }
 
 /*
-Adds the filtering to the query to filter out certain products
+Adds the filtering to the query to filter out certain products. Products must match one of the selected
+values of every filter and the search query if there is one.
 */
-func AddProductFiltering(query string, prices []string, languages []string, productTypes []string, expansions []string) string {
-	var hasFiltered bool = false
-
-	if len(productTypes) > 0 {
-		for i := 0; i < len(productTypes); i++ {
-			if !hasFiltered {
-				filter := ` WHERE products.product_type =`
-				if productTypes[i] == "Pre-Order" {
-					filter += ` 'Pre-Order'`
-					query += filter
-				}
-				if productTypes[i] == "Buy-Now" {
-					filter += ` 'Buy-Now'`
-					query += filter
-				}
-				hasFiltered = true
-			} else {
-				filter := ` OR products.product_type =`
-				if productTypes[i] == "Pre-Order" {
-					filter += ` 'Pre-Order'`
-					query += filter
-				}
-				if productTypes[i] == "Buy-Now" {
-					filter += ` 'Buy-Now'`
-					query += filter
-				}
-			}
+func AddProductFiltering(query string, request data.GetProductListRequestData) string {
+	return addProductConditions(query, getProductFilterConditions(request), "")
+}
+
+/*
+Gets the condition for each filter in the request along with the facet that it filters on
+*/
+func getProductFilterConditions(request data.GetProductListRequestData) []productFilterCondition {
+	var conditions []productFilterCondition
+
+	var productTypeConditions []string
+	for i := 0; i < len(request.ProductTypes); i++ {
+		if request.ProductTypes[i] == "Pre-Order" || request.ProductTypes[i] == "Buy-Now" || request.ProductTypes[i] == "Auction" {
+			productTypeConditions = append(productTypeConditions, `products.product_type = '`+request.ProductTypes[i]+`'`)
 		}
 	}
+	conditions = appendProductCondition(conditions, productTypeFacet, productTypeConditions)
 
-	if len(languages) > 0 {
-		for i := 0; i < len(languages); i++ {
-			if !hasFiltered {
-				filter := ` WHERE products.language =`
-				if languages[i] == "Eng" {
-					filter += ` 'Eng'`
-					query += filter
-				}
-				if languages[i] == "Jap" {
-					filter += ` 'Jap'`
-					query += filter
-				}
-				hasFiltered = true
-			} else {
-				var filter string
-				if i == 0 {
-					filter += ` AND products.language =`
-				} else {
-					filter += ` OR products.language =`
-				}
-
-				if languages[i] == "Eng" {
-					filter += ` 'Eng'`
-					query += filter
-				}
-				if languages[i] == "Jap" {
-					filter += ` 'Jap'`
-					query += filter
-				}
-			}
+	var languageConditions []string
+	for i := 0; i < len(request.Languages); i++ {
+		languageConditions = append(languageConditions, `products.language = `+pq.QuoteLiteral(request.Languages[i]))
+	}
+	conditions = appendProductCondition(conditions, languageFacet, languageConditions)
+
+	var expansionConditions []string
+	for i := 0; i < len(request.Expansions); i++ {
+		expansionConditions = append(expansionConditions, `products.expansion = `+pq.QuoteLiteral(request.Expansions[i]))
+	}
+	conditions = appendProductCondition(conditions, expansionFacet, expansionConditions)
+
+	var conditionConditions []string
+	for i := 0; i < len(request.Conditions); i++ {
+		condition, err := strconv.Atoi(request.Conditions[i])
+		if err == nil && condition >= 0 && condition <= 5 {
+			conditionConditions = append(conditionConditions, `products.condition = `+strconv.Itoa(condition))
 		}
 	}
+	conditions = appendProductCondition(conditions, conditionFacet, conditionConditions)
 
-	if len(expansions) > 0 {
-		for i := 0; i < len(expansions); i++ {
-			if !hasFiltered {
-				query += ` WHERE products.expansion = '` + expansions[i] + `'`
-				hasFiltered = true
-			} else {
-				if i == 0 {
-					query += ` AND products.expansion = '` + expansions[i] + `'`
-				} else {
-					query += ` OR products.expansion = '` + expansions[i] + `'`
-				}
+	var priceConditions []string
+	for i := 0; i < len(request.Prices); i++ {
+		for j := 0; j < len(productPriceRanges); j++ {
+			if request.Prices[i] == productPriceRanges[j].value {
+				priceConditions = append(priceConditions, makePriceRangeCondition(productPriceRanges[j]))
 			}
 		}
 	}
+	conditions = appendProductCondition(conditions, priceFacet, priceConditions)
+	conditions = appendProductAttributeConditions(conditions, request)
 
-	if len(prices) > 0 {
-		for i := 0; i < len(prices); i++ {
-			var filter string
-			if !hasFiltered {
-				filter += ` WHERE products.price`
-				hasFiltered = true
-			} else {
-				if i == 0 {
-					filter += ` AND products.price`
-				} else {
-					filter += ` OR products.price`
-				}
-			}
+	var sellerConditions []string
+	for i := 0; i < len(request.SellerIds); i++ {
+		sellerConditions = append(sellerConditions, `products.seller_id::TEXT = `+pq.QuoteLiteral(request.SellerIds[i]))
+	}
+	conditions = appendProductCondition(conditions, sellerFacet, sellerConditions)
 
-			if prices[i] == "0-20" {
-				filter += ` BETWEEN 0 AND 2000`
-				query += filter
-			}
-			if prices[i] == "20-50" {
-				filter += ` BETWEEN 2000 AND 5000`
-				query += filter
-			}
-			if prices[i] == "50-100" {
-				filter += ` BETWEEN 5000 AND 10000`
-				query += filter
-			}
-			if prices[i] == "100-200" {
-				filter += ` BETWEEN 10000 AND 20000`
-				query += filter
-			}
-			if prices[i] == "200" {
-				filter += ` >= 20000`
-				query += filter
-			}
+	searchQuery := makeSearchQuery(request.Search)
+	if searchQuery != "" {
+		conditions = append(conditions, productFilterCondition{facet: searchFacet, condition: `products.search_vector @@ ` + searchQuery})
+	}
+
+	return conditions
+}
+
+/*
+Adds the conditions for a single filter to the list of conditions. A product only has to match one of
+the values of a filter so multiple values are grouped together.
+*/
+func appendProductCondition(conditions []productFilterCondition, facet string, filterConditions []string) []productFilterCondition {
+	if len(filterConditions) == 1 {
+		return append(conditions, productFilterCondition{facet: facet, condition: filterConditions[0]})
+	}
+
+	if len(filterConditions) > 1 {
+		return append(conditions, productFilterCondition{facet: facet, condition: `(` + strings.Join(filterConditions, ` OR `) + `)`})
+	}
+
+	return conditions
+}
+
+/*
+Adds the conditions to the query, leaving out the conditions of the excluded facet
+*/
+func addProductConditions(query string, conditions []productFilterCondition, excludedFacet string) string {
+	var clauses []string
+
+	for i := 0; i < len(conditions); i++ {
+		if conditions[i].facet != excludedFacet {
+			clauses = append(clauses, conditions[i].condition)
 		}
 	}
 
+	if len(clauses) > 0 {
+		query += ` WHERE ` + strings.Join(clauses, ` AND `)
+	}
+
 	return query
 }
 
 /*
-Adds pages to the query to allow for pagination
+Adds pages to the query to allow for pagination using an offset. Deprecated, cursors should be used
+for pagination instead as they do not slow down or repeat products deeper into the product list.
 */
 func AddPagesProduct(query string, anchor int, limit int) string {
 	query += ` OFFSET ` + strconv.Itoa(anchor) + ` LIMIT ` + strconv.Itoa(limit)
@@ -489,6 +633,21 @@ func DoesProductExist(db *sql.DB, productId string) bool {
 	return productExists
 }
 
+/*
+Gets the id of the seller who posted a product, or an empty string if the product does not exist
+*/
+func GetProductSellerId(db *sql.DB, productId string) string {
+	var sellerId string
+	query := `SELECT seller_id FROM products WHERE product_id::TEXT = $1;`
+	err := db.QueryRowContext(context.Background(), query, productId).Scan(&sellerId)
+
+	if err != nil {
+		return ""
+	}
+
+	return sellerId
+}
+
 /*
 Validates the various fields in the create product request body to ensure they are valid.
 Returns error if request body is not valid
@@ -504,8 +663,18 @@ func validateCreateProduct(db *sql.DB, product data.CreateProductData) *utils.Er
 		return utils.BadRequestError("Bad discount data")
 	}
 
-	if product.Language != "Eng" && product.Language != "Jap" {
-		utils.LogMessage("Product language is not recognised")
+	if !catalogue.DoesLanguageExist(db, product.Language) {
+		utils.LogMessage("Product language is not in the catalogue")
+		return utils.BadRequestError("Bad language data")
+	}
+
+	if !catalogue.DoesExpansionExist(db, product.Expansion) {
+		utils.LogMessage("Product expansion is not in the catalogue")
+		return utils.BadRequestError("Bad expansion data")
+	}
+
+	if !catalogue.IsExpansionInLanguage(db, product.Expansion, product.Language) {
+		utils.LogMessage("Product expansion was not released in the product language")
 		return utils.BadRequestError("Bad language data")
 	}
 
@@ -514,7 +683,7 @@ func validateCreateProduct(db *sql.DB, product data.CreateProductData) *utils.Er
 		return utils.BadRequestError("Bad price data")
 	}
 
-	if product.ProductType != "Buy-Now" && product.ProductType != "Pre-Order" {
+	if product.ProductType != "Buy-Now" && product.ProductType != "Pre-Order" && product.ProductType != "Auction" {
 		utils.LogMessage("Product type is not recognised")
 		return utils.BadRequestError("Bad product_type data")
 	}
@@ -529,6 +698,13 @@ func validateCreateProduct(db *sql.DB, product data.CreateProductData) *utils.Er
 		return utils.BadRequestError("Bad quantity data")
 	}
 
+	if product.ProductType == "Auction" {
+		auctionErr := validateAuction(product)
+		if auctionErr != nil {
+			return auctionErr
+		}
+	}
+
 	if product.ProductType == "Pre-Order" && product.ReleasesOn == "" {
 		utils.LogMessage("Pre orders need a release date")
 		return utils.BadRequestError("Bad pre-order data")
@@ -539,5 +715,5 @@ func validateCreateProduct(db *sql.DB, product data.CreateProductData) *utils.Er
 		return utils.BadRequestError("Bad pre-order data")
 	}
 
-	return nil
+	return validateProductAttributes(product)
 }
@@ -19,6 +19,8 @@ func TestValidateCreateProduct(t *testing.T) {
 
 	sellerId, dataErr := createDummySeller(db)
 	assert.NoError(t, dataErr)
+	expansionErr := createDummyExpansions(db)
+	assert.NoError(t, expansionErr)
 
 	//Test 1: No errors, product is valid
 	testCreateProduct1 := data.CreateProductData{
@@ -125,6 +127,24 @@ func TestValidateCreateProduct(t *testing.T) {
 	assert.Equal(t, "Bad language data", err.Error())
 	assert.Equal(t, 400, err.ErrorCode())
 
+	//Test 13: Expansion is not in the catalogue
+	testCreateProduct13 := data.CreateProductData{
+		Title: "test", SellerId: sellerId, Description: "This is a test description",
+		ProductType: "Buy-Now", Price: 10, Condition: 3, Quantity: 3, Language: "Eng", Expansion: "Wrong"}
+	err = validateCreateProduct(db, testCreateProduct13)
+	assert.Error(t, err)
+	assert.Equal(t, "Bad expansion data", err.Error())
+	assert.Equal(t, 400, err.ErrorCode())
+
+	//Test 14: Expansion was not released in the language
+	testCreateProduct14 := data.CreateProductData{
+		Title: "test", SellerId: sellerId, Description: "This is a test description",
+		ProductType: "Buy-Now", Price: 10, Condition: 3, Quantity: 3, Language: "Jap", Expansion: "Test2"}
+	err = validateCreateProduct(db, testCreateProduct14)
+	assert.Error(t, err)
+	assert.Equal(t, "Bad language data", err.Error())
+	assert.Equal(t, 400, err.ErrorCode())
+
 	store.CloseDB(db)
 }
 
@@ -233,6 +253,8 @@ func TestCreateProduct(t *testing.T) {
 
 	sellerId, dataErr := createDummySeller(db)
 	assert.NoError(t, dataErr)
+	expansionErr := createDummyExpansions(db)
+	assert.NoError(t, expansionErr)
 
 	//Test 1: No error, product is created
 	product := data.CreateProductData{
@@ -329,76 +351,122 @@ func TestCreateProduct(t *testing.T) {
 	assert.Equal(t, "Bad language data", err.Error())
 	assert.Equal(t, 400, err.ErrorCode())
 
+	//Test 10: Only created products are queued to be matched against saved searches
+	var newListings int
+	countErr := db.QueryRowContext(context.Background(), `SELECT COUNT(*) FROM new_listings;`).Scan(&newListings)
+	assert.NoError(t, countErr)
+	assert.Equal(t, 2, newListings)
+
 	store.CloseDB(db)
 }
 func TestAddProductSorting(t *testing.T) {
 	//Test 1: Sort by price low to high
-	query := AddProductSorting("", "price-low")
-	assert.Equal(t, ` ORDER BY products.price ASC, discount DESC`, query)
+	query := AddProductSorting("", "price-low", "")
+	assert.Equal(t, ` ORDER BY (products.price - COALESCE(product_effective_discounts.discount, 0)) ASC, products.product_id ASC`, query)
 
 	//Test 2: Sort by price high to low
-	query = AddProductSorting("", "price-high")
-	assert.Equal(t, ` ORDER BY products.price DESC, discount ASC`, query)
+	query = AddProductSorting("", "price-high", "")
+	assert.Equal(t, ` ORDER BY (products.price - COALESCE(product_effective_discounts.discount, 0)) DESC, products.product_id ASC`, query)
 
 	//Test 3: Name ascending
-	query = AddProductSorting("", "name-asc")
-	assert.Equal(t, ` ORDER BY products.title ASC`, query)
+	query = AddProductSorting("", "name-asc", "")
+	assert.Equal(t, ` ORDER BY products.title ASC, products.product_id ASC`, query)
 
 	//Test 4: Name descending
-	query = AddProductSorting("", "name-desc")
-	assert.Equal(t, ` ORDER BY products.title DESC`, query)
+	query = AddProductSorting("", "name-desc", "")
+	assert.Equal(t, ` ORDER BY products.title DESC, products.product_id ASC`, query)
 
 	//Test 5: Default
-	query = AddProductSorting("", "None")
-	assert.Equal(t, ` ORDER BY products.posted_date DESC`, query)
+	query = AddProductSorting("", "None", "")
+	assert.Equal(t, ` ORDER BY products.posted_date DESC, products.product_id ASC`, query)
 
 	//Test 6: Random string
-	query = AddProductSorting("", "sdjknvjk")
-	assert.Equal(t, ` ORDER BY products.posted_date DESC`, query)
+	query = AddProductSorting("", "sdjknvjk", "")
+	assert.Equal(t, ` ORDER BY products.posted_date DESC, products.product_id ASC`, query)
+
+	//Test 7: Relevance when searching
+	query = AddProductSorting("", "relevance", "charizard")
+	assert.Equal(t, ` ORDER BY ts_rank(products.search_vector, to_tsquery('english', 'charizard:*')) DESC, products.posted_date DESC, products.product_id ASC`, query)
+
+	//Test 8: Default is relevance when searching
+	query = AddProductSorting("", "None", "charizard")
+	assert.Equal(t, ` ORDER BY ts_rank(products.search_vector, to_tsquery('english', 'charizard:*')) DESC, products.posted_date DESC, products.product_id ASC`, query)
+
+	//Test 9: Relevance without search is the default sorting
+	query = AddProductSorting("", "relevance", "")
+	assert.Equal(t, ` ORDER BY products.posted_date DESC, products.product_id ASC`, query)
+
+	//Test 10: Explicit sorting is used when searching
+	query = AddProductSorting("", "price-low", "charizard")
+	assert.Equal(t, ` ORDER BY (products.price - COALESCE(product_effective_discounts.discount, 0)) ASC, products.product_id ASC`, query)
+
+	//Test 11: Grade high to low
+	query = AddProductSorting("", "grade-high", "")
+	assert.Equal(t, ` ORDER BY COALESCE(grade, 0) DESC, products.product_id ASC`, query)
+
+	//Test 12: Grade low to high keeps ungraded products last
+	query = AddProductSorting("", "grade-low", "")
+	assert.Equal(t, ` ORDER BY COALESCE(grade, 11) ASC, products.product_id ASC`, query)
 
 }
 
 func TestAddProductFiltering(t *testing.T) {
 
 	//Test 1: No filters
-	query := AddProductFiltering("", nil, nil, nil, nil)
+	query := AddProductFiltering("", data.GetProductListRequestData{})
 	assert.Equal(t, "", query)
 
 	//Test 2: Preorders
-	query = AddProductFiltering("", nil, nil, []string{"Pre-Order"}, nil)
+	query = AddProductFiltering("", data.GetProductListRequestData{ProductTypes: []string{"Pre-Order"}})
 	assert.Equal(t, query, ` WHERE products.product_type = 'Pre-Order'`)
 
 	//Test 3: Buy-Now
-	query = AddProductFiltering("", nil, nil, []string{"Buy-Now"}, nil)
+	query = AddProductFiltering("", data.GetProductListRequestData{ProductTypes: []string{"Buy-Now"}})
 	assert.Equal(t, query, ` WHERE products.product_type = 'Buy-Now'`)
 
 	//Test 4: Buy-Now
-	query = AddProductFiltering("", nil, nil, []string{"Buy-Now", "Pre-Order"}, nil)
-	assert.Equal(t, query, ` WHERE products.product_type = 'Buy-Now' OR products.product_type = 'Pre-Order'`)
+	query = AddProductFiltering("", data.GetProductListRequestData{ProductTypes: []string{"Buy-Now", "Pre-Order"}})
+	assert.Equal(t, query, ` WHERE (products.product_type = 'Buy-Now' OR products.product_type = 'Pre-Order')`)
 
 	//Test 5: English
-	query = AddProductFiltering("", nil, []string{"Eng"}, nil, nil)
+	query = AddProductFiltering("", data.GetProductListRequestData{Languages: []string{"Eng"}})
 	assert.Equal(t, query, ` WHERE products.language = 'Eng'`)
 
 	//Test 6: Preorders and Japanese
-	query = AddProductFiltering("", nil, []string{"Jap"}, []string{"Pre-Order"}, nil)
+	query = AddProductFiltering("", data.GetProductListRequestData{Languages: []string{"Jap"}, ProductTypes: []string{"Pre-Order"}})
 	assert.Equal(t, query, ` WHERE products.product_type = 'Pre-Order' AND products.language = 'Jap'`)
 
 	//Test 7: Min price
-	query = AddProductFiltering("", []string{"0-20"}, nil, nil, nil)
-	assert.Equal(t, query, ` WHERE products.price BETWEEN 0 AND 2000`)
+	query = AddProductFiltering("", data.GetProductListRequestData{Prices: []string{"0-20"}})
+	assert.Equal(t, query, ` WHERE (products.price - COALESCE(product_effective_discounts.discount, 0)) < 2000`)
 
 	//Test 8: Max price in japanese
-	query = AddProductFiltering("", []string{"200"}, []string{"Jap", "Eng"}, nil, nil)
-	assert.Equal(t, query, ` WHERE products.language = 'Jap' OR products.language = 'Eng' AND products.price >= 20000`)
+	query = AddProductFiltering("", data.GetProductListRequestData{Prices: []string{"200"}, Languages: []string{"Jap", "Eng"}})
+	assert.Equal(t, query, ` WHERE (products.language = 'Jap' OR products.language = 'Eng') AND (products.price - COALESCE(product_effective_discounts.discount, 0)) >= 20000`)
 
 	//Test 9: Max price & min price in japanese for buy-now
-	query = AddProductFiltering("", []string{"0-20"}, []string{"Jap", "Eng"}, []string{"Buy-Now"}, nil)
-	assert.Equal(t, query, ` WHERE products.product_type = 'Buy-Now' AND products.language = 'Jap' OR products.language = 'Eng' AND products.price BETWEEN 0 AND 2000`)
+	query = AddProductFiltering("", data.GetProductListRequestData{Prices: []string{"0-20"}, Languages: []string{"Jap", "Eng"}, ProductTypes: []string{"Buy-Now"}})
+	assert.Equal(t, query, ` WHERE products.product_type = 'Buy-Now' AND (products.language = 'Jap' OR products.language = 'Eng') AND (products.price - COALESCE(product_effective_discounts.discount, 0)) < 2000`)
+
+	//Test 10: Incorrect inputs, unknown languages do not match any product
+	query = AddProductFiltering("", data.GetProductListRequestData{Prices: []string{"dksknfjk"}, Languages: []string{"csdjknv"}, ProductTypes: []string{"vsjdv"}})
+	assert.Equal(t, query, ` WHERE products.language = 'csdjknv'`)
 
-	//Test 10: Incorrect inputs
-	query = AddProductFiltering("", []string{"dksknfjk"}, []string{"csdjknv"}, []string{"vsjdv"}, nil)
-	assert.Equal(t, "", "")
+	//Test 11: Expansions are quoted
+	query = AddProductFiltering("", data.GetProductListRequestData{Expansions: []string{"Test", "Crown's Zenith"}})
+	assert.Equal(t, query, ` WHERE (products.expansion = 'Test' OR products.expansion = 'Crown''s Zenith')`)
+
+	//Test 12: Conditions, invalid conditions are ignored
+	query = AddProductFiltering("", data.GetProductListRequestData{Conditions: []string{"5", "4", "9", "x"}})
+	assert.Equal(t, query, ` WHERE (products.condition = 5 OR products.condition = 4)`)
+
+	//Test 13: Search combined with filters
+	query = AddProductFiltering("", data.GetProductListRequestData{Languages: []string{"Eng"}, Search: "charizard"})
+	assert.Equal(t, query, ` WHERE products.language = 'Eng' AND products.search_vector @@ to_tsquery('english', 'charizard:*')`)
+
+	//Test 14: Sellers
+	query = AddProductFiltering("", data.GetProductListRequestData{SellerIds: []string{"a", "b"}})
+	assert.Equal(t, query, ` WHERE (products.seller_id::TEXT = 'a' OR products.seller_id::TEXT = 'b')`)
 }
 
 func TestGetProductList(t *testing.T) {
@@ -517,6 +585,28 @@ func TestGetProductList(t *testing.T) {
 	assert.Empty(t, err)
 	assert.Equal(t, 5, res.ProductCount)
 	assert.Equal(t, 0, len(res.Products))
+
+	//Test 14: Keyword search on title
+	req = data.GetProductListRequestData{SortBy: "relevance", Search: "test2", Anchor: 0, Limit: 10}
+	res, err = GetProductList(db, req)
+	assert.Empty(t, err)
+	assert.Equal(t, 1, res.ProductCount)
+	assert.Equal(t, 1, len(res.Products))
+	assert.Equal(t, "Test2", res.Products[0].Title)
+
+	//Test 15: Keyword search on description combined with filters
+	req = data.GetProductListRequestData{SortBy: "None", Search: "descrip", Languages: []string{"Jap"}, Anchor: 0, Limit: 10}
+	res, err = GetProductList(db, req)
+	assert.Empty(t, err)
+	assert.Equal(t, 1, res.ProductCount)
+	assert.Equal(t, "Jap", res.Products[0].Language)
+
+	//Test 16: Keyword search with no matches
+	req = data.GetProductListRequestData{SortBy: "None", Search: "charizard", Anchor: 0, Limit: 10}
+	res, err = GetProductList(db, req)
+	assert.Empty(t, err)
+	assert.Equal(t, 0, res.ProductCount)
+	assert.Equal(t, 0, len(res.Products))
 	store.CloseDB(db)
 }
 
@@ -528,6 +618,30 @@ func createDummySeller(db *sql.DB) (string, error) {
 	return sellerId, err
 }
 
+func createDummyExpansions(db *sql.DB) error {
+	query := `INSERT INTO games(code, name) VALUES ('pokemon', 'Pokemon TCG');`
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `INSERT INTO expansions(game_id, code, name) 
+		SELECT game_id, expansion.code, expansion.code FROM games, (VALUES ('Test'), ('Test2')) AS expansion(code) 
+		WHERE games.code = 'pokemon';`
+	_, err = db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `INSERT INTO expansion_languages(expansion_id, language_code)
+		SELECT expansion_id, 'Eng' FROM expansions 
+		UNION SELECT expansion_id, 'Jap' FROM expansions WHERE code = 'Test';`
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
+
 func createDummyProducts(db *sql.DB, sellerId string) ([]string, error) {
 	var dummyCreateProducts []data.CreateProductData = []data.CreateProductData{
 		//Product 1: Test Buy-Now Product English
@@ -0,0 +1,202 @@
+package product
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+const (
+	cardNumberFacet     = "card_number"
+	rarityFacet         = "rarity"
+	finishFacet         = "finish"
+	editionFacet        = "edition"
+	gradingCompanyFacet = "grading_company"
+	gradeFacet          = "grade"
+	sealedTypeFacet     = "sealed_type"
+	maxCardNumberLength = 20
+	maxRarityLength     = 50
+)
+
+var cardFinishes = map[string]bool{
+	"Non-Holo":     true,
+	"Holo":         true,
+	"Reverse-Holo": true,
+}
+
+var cardEditions = map[string]bool{
+	"Unlimited":   true,
+	"1st-Edition": true,
+}
+
+var gradingCompanies = map[string]bool{
+	"PSA": true,
+	"BGS": true,
+	"CGC": true,
+}
+
+var sealedTypes = map[string]bool{
+	"Booster-Box":  true,
+	"Booster-Pack": true,
+	"ETB":          true,
+}
+
+/*
+Validates the card or sealed attributes of a product. A product can either be a single card or a sealed
+product so it cannot have both. Products without attributes are still allowed.
+*/
+func validateProductAttributes(product data.CreateProductData) *utils.ErrorHandler {
+	if product.CardAttributes != nil && product.SealedAttributes != nil {
+		utils.LogMessage("Product has both card and sealed attributes")
+		return utils.BadRequestError("Bad attributes data, a product cannot have both card and sealed attributes")
+	}
+
+	if product.CardAttributes != nil {
+		return validateCardAttributes(*product.CardAttributes)
+	}
+
+	if product.SealedAttributes != nil {
+		return validateSealedAttributes(*product.SealedAttributes)
+	}
+
+	return nil
+}
+
+/*
+Validates the attributes of a single card. Graded cards need both the grading company and a grade
+from 1 to 10 in steps of 0.5.
+*/
+func validateCardAttributes(attributes data.CardAttributesData) *utils.ErrorHandler {
+	if attributes.CardNumber == "" || len(attributes.CardNumber) > maxCardNumberLength {
+		utils.LogMessage("Card number is empty or too long")
+		return utils.BadRequestError("Bad card_number data")
+	}
+
+	if len(attributes.Rarity) > maxRarityLength {
+		utils.LogMessage("Card rarity is too long")
+		return utils.BadRequestError("Bad rarity data")
+	}
+
+	if !cardFinishes[attributes.Finish] {
+		utils.LogMessage("Card finish is not recognised")
+		return utils.BadRequestError("Bad finish data")
+	}
+
+	if !cardEditions[attributes.Edition] {
+		utils.LogMessage("Card edition is not recognised")
+		return utils.BadRequestError("Bad edition data")
+	}
+
+	if attributes.GradingCompany == "" && attributes.Grade == 0 {
+		return nil
+	}
+
+	if !gradingCompanies[attributes.GradingCompany] {
+		utils.LogMessage("Grading company is not recognised")
+		return utils.BadRequestError("Bad grading_company data")
+	}
+
+	if attributes.Grade < 1 || attributes.Grade > 10 || attributes.Grade*2 != math.Trunc(attributes.Grade*2) {
+		utils.LogMessage("Grade is not between 1 and 10 in steps of 0.5")
+		return utils.BadRequestError("Bad grade data")
+	}
+
+	return nil
+}
+
+/*
+Validates the attributes of a sealed product
+*/
+func validateSealedAttributes(attributes data.SealedAttributesData) *utils.ErrorHandler {
+	if !sealedTypes[attributes.SealedType] {
+		utils.LogMessage("Sealed product type is not recognised")
+		return utils.BadRequestError("Bad sealed_type data")
+	}
+
+	if attributes.PackCount < 0 {
+		utils.LogMessage("Pack count is less than 0")
+		return utils.BadRequestError("Bad pack_count data")
+	}
+
+	return nil
+}
+
+/*
+Adds the card or sealed attributes of a product if it has any
+*/
+func addProductAttributes(db *sql.DB, productId string, product data.CreateProductData) *utils.ErrorHandler {
+	if product.CardAttributes != nil {
+		attributes := product.CardAttributes
+		query := `INSERT INTO product_card_attributes(
+			product_id, card_number, rarity, finish, edition, grading_company, grade) 
+			VALUES ($1,$2,$3,$4,$5,NULLIF($6, ''),NULLIF($7, 0));`
+
+		_, err := db.ExecContext(context.Background(), query, productId, attributes.CardNumber, attributes.Rarity,
+			attributes.Finish, attributes.Edition, attributes.GradingCompany, attributes.Grade)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in Inserting Product Card Attributes rows")
+			return errResp
+		}
+	}
+
+	if product.SealedAttributes != nil {
+		query := `INSERT INTO product_sealed_attributes(product_id, sealed_type, pack_count) VALUES ($1,$2,$3);`
+
+		_, err := db.ExecContext(context.Background(), query, productId, product.SealedAttributes.SealedType, product.SealedAttributes.PackCount)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in Inserting Product Sealed Attributes rows")
+			return errResp
+		}
+	}
+
+	return nil
+}
+
+/*
+Adds the conditions for the card and sealed attribute filters. Attributes are kept in their own tables,
+so the conditions select the matching product ids which lets them be used in any product query.
+*/
+func appendProductAttributeConditions(conditions []productFilterCondition, request data.GetProductListRequestData) []productFilterCondition {
+	conditions = appendAttributeCondition(conditions, cardNumberFacet, `product_card_attributes`, `card_number`, request.CardNumbers)
+	conditions = appendAttributeCondition(conditions, rarityFacet, `product_card_attributes`, `rarity`, request.Rarities)
+	conditions = appendAttributeCondition(conditions, finishFacet, `product_card_attributes`, `finish`, request.Finishes)
+	conditions = appendAttributeCondition(conditions, editionFacet, `product_card_attributes`, `edition`, request.Editions)
+	conditions = appendAttributeCondition(conditions, gradingCompanyFacet, `product_card_attributes`, `grading_company`, request.GradingCompanies)
+
+	if request.MinGrade > 0 {
+		conditions = append(conditions, productFilterCondition{facet: gradeFacet,
+			condition: `products.product_id IN (SELECT product_id FROM product_card_attributes WHERE grade >= ` +
+				strconv.FormatFloat(request.MinGrade, 'f', -1, 64) + `)`})
+	}
+
+	conditions = appendAttributeCondition(conditions, sealedTypeFacet, `product_sealed_attributes`, `sealed_type`, request.SealedTypes)
+
+	return conditions
+}
+
+/*
+Adds the condition that keeps products where the attribute column has one of the values
+*/
+func appendAttributeCondition(conditions []productFilterCondition, facet string, table string, column string, values []string) []productFilterCondition {
+	if len(values) == 0 {
+		return conditions
+	}
+
+	var quotedValues []string
+	for i := 0; i < len(values); i++ {
+		quotedValues = append(quotedValues, pq.QuoteLiteral(values[i]))
+	}
+
+	return append(conditions, productFilterCondition{facet: facet,
+		condition: `products.product_id IN (SELECT product_id FROM ` + table + ` WHERE ` + column + ` IN (` + strings.Join(quotedValues, `, `) + `))`})
+}
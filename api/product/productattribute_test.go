@@ -0,0 +1,154 @@
+package product
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"BackendAPI/utils"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateProductAttributes(t *testing.T) {
+	card := data.CardAttributesData{CardNumber: "4/102", Rarity: "Holo Rare", Finish: "Holo", Edition: "1st-Edition"}
+
+	//Test 1: No attributes
+	err := validateProductAttributes(data.CreateProductData{})
+	assert.Empty(t, err)
+
+	//Test 2: Ungraded card
+	err = validateProductAttributes(data.CreateProductData{CardAttributes: &card})
+	assert.Empty(t, err)
+
+	//Test 3: Graded card with a half grade
+	graded := card
+	graded.GradingCompany = "BGS"
+	graded.Grade = 9.5
+	err = validateProductAttributes(data.CreateProductData{CardAttributes: &graded})
+	assert.Empty(t, err)
+
+	//Test 4: Grade without a grading company
+	graded.GradingCompany = ""
+	err = validateProductAttributes(data.CreateProductData{CardAttributes: &graded})
+	assert.Error(t, err)
+	assert.Equal(t, "Bad grading_company data", err.Error())
+
+	//Test 5: Grade that is not a step of 0.5
+	graded.GradingCompany = "PSA"
+	graded.Grade = 9.3
+	err = validateProductAttributes(data.CreateProductData{CardAttributes: &graded})
+	assert.Error(t, err)
+	assert.Equal(t, "Bad grade data", err.Error())
+
+	//Test 6: Grading company without a grade
+	graded.Grade = 0
+	err = validateProductAttributes(data.CreateProductData{CardAttributes: &graded})
+	assert.Error(t, err)
+	assert.Equal(t, "Bad grade data", err.Error())
+
+	//Test 7: Unknown finish
+	wrongFinish := card
+	wrongFinish.Finish = "Shiny"
+	err = validateProductAttributes(data.CreateProductData{CardAttributes: &wrongFinish})
+	assert.Error(t, err)
+	assert.Equal(t, "Bad finish data", err.Error())
+
+	//Test 8: Missing card number
+	noNumber := card
+	noNumber.CardNumber = ""
+	err = validateProductAttributes(data.CreateProductData{CardAttributes: &noNumber})
+	assert.Error(t, err)
+	assert.Equal(t, "Bad card_number data", err.Error())
+
+	//Test 9: Sealed product
+	sealed := data.SealedAttributesData{SealedType: "Booster-Box", PackCount: 36}
+	err = validateProductAttributes(data.CreateProductData{SealedAttributes: &sealed})
+	assert.Empty(t, err)
+
+	//Test 10: Unknown sealed type
+	wrongSealed := data.SealedAttributesData{SealedType: "Tin"}
+	err = validateProductAttributes(data.CreateProductData{SealedAttributes: &wrongSealed})
+	assert.Error(t, err)
+	assert.Equal(t, "Bad sealed_type data", err.Error())
+
+	//Test 11: Both card and sealed attributes
+	err = validateProductAttributes(data.CreateProductData{CardAttributes: &card, SealedAttributes: &sealed})
+	assert.Error(t, err)
+	assert.Equal(t, 400, err.ErrorCode())
+}
+
+func TestAddProductAttributeFiltering(t *testing.T) {
+	//Test 1: Attribute values are grouped in a single condition
+	query := AddProductFiltering("", data.GetProductListRequestData{Finishes: []string{"Holo", "Reverse-Holo"}})
+	assert.Equal(t, ` WHERE products.product_id IN (SELECT product_id FROM product_card_attributes WHERE finish IN ('Holo', 'Reverse-Holo'))`, query)
+
+	//Test 2: Grading company with a minimum grade
+	query = AddProductFiltering("", data.GetProductListRequestData{GradingCompanies: []string{"PSA"}, MinGrade: 9.5})
+	assert.Equal(t, ` WHERE products.product_id IN (SELECT product_id FROM product_card_attributes WHERE grading_company IN ('PSA'))`+
+		` AND products.product_id IN (SELECT product_id FROM product_card_attributes WHERE grade >= 9.5)`, query)
+
+	//Test 3: Sealed types combined with other filters, values are quoted
+	query = AddProductFiltering("", data.GetProductListRequestData{Languages: []string{"Eng"}, SealedTypes: []string{"ETB", "Box's"}})
+	assert.Equal(t, ` WHERE products.language = 'Eng'`+
+		` AND products.product_id IN (SELECT product_id FROM product_sealed_attributes WHERE sealed_type IN ('ETB', 'Box''s'))`, query)
+}
+
+func TestCreateProductWithAttributes(t *testing.T) {
+	utils.LoadDotEnv("../../.env")
+	db, startupErr := store.SetupTestDB("../../.env")
+	assert.NoError(t, startupErr)
+
+	sellerId, sellerErr := createDummySeller(db)
+	assert.NoError(t, sellerErr)
+	expansionErr := createDummyExpansions(db)
+	assert.NoError(t, expansionErr)
+
+	//Test 1: Graded card is created with its attributes
+	card := data.CardAttributesData{CardNumber: "4/102", Rarity: "Holo Rare", Finish: "Holo", Edition: "1st-Edition", GradingCompany: "PSA", Grade: 10}
+	product := data.CreateProductData{
+		Title: "Charizard", SellerId: sellerId, Description: "This is a test description",
+		ProductType: "Buy-Now", Price: 10, Condition: 5, Quantity: 1, Language: "Eng", Expansion: "Test", CardAttributes: &card}
+	cardResponse, err := CreateProduct(db, product)
+	assert.Empty(t, err)
+	assert.Equal(t, "4/102", cardResponse.CardAttributes.CardNumber)
+
+	//Test 2: Sealed product is created with its attributes
+	sealed := data.SealedAttributesData{SealedType: "Booster-Box", PackCount: 36}
+	product = data.CreateProductData{
+		Title: "Booster Box", SellerId: sellerId, Description: "This is a test description",
+		ProductType: "Buy-Now", Price: 10, Condition: 5, Quantity: 1, Language: "Eng", Expansion: "Test", SealedAttributes: &sealed}
+	sealedResponse, err := CreateProduct(db, product)
+	assert.Empty(t, err)
+
+	createDummyProductImages(db, []string{cardResponse.ProductId, sealedResponse.ProductId})
+
+	//Test 3: Attributes are returned with the product
+	res, err := GetProductById(db, cardResponse.ProductId)
+	assert.Empty(t, err)
+	assert.Equal(t, card, *res.CardAttributes)
+	assert.Nil(t, res.SealedAttributes)
+
+	res, err = GetProductById(db, sealedResponse.ProductId)
+	assert.Empty(t, err)
+	assert.Equal(t, sealed, *res.SealedAttributes)
+	assert.Nil(t, res.CardAttributes)
+
+	//Test 4: Products are filtered by their attributes
+	list, err := GetProductList(db, data.GetProductListRequestData{GradingCompanies: []string{"PSA"}, MinGrade: 9})
+	assert.Empty(t, err)
+	assert.Equal(t, 1, len(list.Products))
+	assert.Equal(t, cardResponse.ProductId, list.Products[0].ProductId)
+
+	list, err = GetProductList(db, data.GetProductListRequestData{SealedTypes: []string{"Booster-Box"}})
+	assert.Empty(t, err)
+	assert.Equal(t, 1, len(list.Products))
+	assert.Equal(t, sealedResponse.ProductId, list.Products[0].ProductId)
+
+	//Test 5: Graded products are sorted first by grade
+	list, err = GetProductList(db, data.GetProductListRequestData{SortBy: "grade-high"})
+	assert.Empty(t, err)
+	assert.Equal(t, cardResponse.ProductId, list.Products[0].ProductId)
+
+	store.CloseDB(db)
+}
@@ -0,0 +1,231 @@
+package product
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"time"
+)
+
+const (
+	defaultAuctionExtensionSeconds = 120
+	maxAuctionExtensionSeconds     = 3600
+)
+
+/*
+The minimum amount that a bid has to be raised by, based on the current price of the auction. Each
+increment applies to prices below its limit, all prices are in cents.
+*/
+var bidIncrements = []struct {
+	below     int
+	increment int
+}{
+	{below: 1000, increment: 50},
+	{below: 5000, increment: 100},
+	{below: 10000, increment: 200},
+	{below: 50000, increment: 500},
+	{below: 100000, increment: 1000},
+}
+
+const maxBidIncrement = 2500
+
+/*
+Gets the bid increment for the current price of an auction
+*/
+func GetBidIncrement(price int) int {
+	for i := 0; i < len(bidIncrements); i++ {
+		if price < bidIncrements[i].below {
+			return bidIncrements[i].increment
+		}
+	}
+
+	return maxBidIncrement
+}
+
+/*
+Gets the lowest amount that can be bid on an auction. The first bid can be the start price,
+every bid after has to raise the current price by at least the bid increment.
+*/
+func GetMinimumBid(startPrice int, currentPrice int, bidCount int) int {
+	if bidCount == 0 {
+		return startPrice
+	}
+
+	return currentPrice + GetBidIncrement(currentPrice)
+}
+
+/*
+Handles the creation of an auction product listing. The price of the product starts at the start price
+and is raised with every bid. Auctions are for a single item.
+*/
+func CreateAuction(db *sql.DB, product data.CreateProductData) (data.CreateProductResponseData, *utils.ErrorHandler) {
+	var response data.CreateProductResponseData
+	postedDate := time.Now()
+	auction := product.Auction
+
+	startsAt := postedDate
+	if auction.StartsAt != "" {
+		startsAt, _ = time.Parse(time.RFC3339, auction.StartsAt)
+	}
+	endsAt, _ := time.Parse(time.RFC3339, auction.EndsAt)
+
+	extensionSeconds := auction.ExtensionSeconds
+	if extensionSeconds == 0 {
+		extensionSeconds = defaultAuctionExtensionSeconds
+	}
+
+	product.Price = auction.StartPrice
+	product.Quantity = 1
+
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Auction transaction")
+		return response, errResp
+	}
+
+	defer tx.Rollback()
+
+	query := `INSERT INTO products(
+		title, seller_id, description, product_type, language, expansion, 
+		posted_date, price, condition, product_quantity) 
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10) RETURNING product_id, posted_date::TEXT;`
+
+	err = tx.QueryRowContext(
+		context.Background(), query,
+		product.Title, product.SellerId, product.Description,
+		product.ProductType, product.Language, product.Expansion, postedDate, product.Price,
+		product.Condition, product.Quantity).Scan(&response.ProductId, &response.PostedDate)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in Inserting Product rows")
+		return response, errResp
+	}
+
+	query = `INSERT INTO auction_information(
+		product_id, start_price, reserve_price, starts_at, ends_at, extension_seconds) 
+		VALUES ($1,$2,$3,$4,$5,$6);`
+
+	_, err = tx.ExecContext(context.Background(), query, response.ProductId, auction.StartPrice,
+		auction.ReservePrice, startsAt, endsAt, extensionSeconds)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in Inserting Auction Information rows")
+		return response, errResp
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Auction transaction")
+		return response, errResp
+	}
+
+	product.ProductCreateResponseFromRequest(&response)
+
+	return response, nil
+}
+
+/*
+Gets the auction information of a product, or nil if the product is not an auction. The reserve price
+itself is kept hidden, only wether it has been met is returned.
+*/
+func getAuctionInformation(db *sql.DB, productId string) (*data.AuctionData, *utils.ErrorHandler) {
+	var auction data.AuctionData
+	var reservePrice int
+
+	query := `SELECT start_price, products.price, reserve_price, bid_count, starts_at::TEXT, ends_at::TEXT, 
+			extension_seconds, status
+		FROM auction_information INNER JOIN products ON products.product_id = auction_information.product_id
+		WHERE auction_information.product_id = $1;`
+
+	err := db.QueryRowContext(context.Background(), query, productId).Scan(&auction.StartPrice, &auction.CurrentPrice,
+		&reservePrice, &auction.BidCount, &auction.StartsAt, &auction.EndsAt, &auction.ExtensionSeconds, &auction.Status)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Auction Information rows")
+		return nil, errResp
+	}
+
+	auction.MinimumBid = GetMinimumBid(auction.StartPrice, auction.CurrentPrice, auction.BidCount)
+	auction.ReserveMet = auction.BidCount > 0 && auction.CurrentPrice >= reservePrice
+
+	return &auction, nil
+}
+
+/*
+Validates the auction information of an auction product
+*/
+func validateAuction(product data.CreateProductData) *utils.ErrorHandler {
+	auction := product.Auction
+
+	if auction == nil {
+		utils.LogMessage("Auction products need auction information")
+		return utils.BadRequestError("Bad auction data")
+	}
+
+	if product.Quantity != 1 {
+		utils.LogMessage("Auctions are for a single item")
+		return utils.BadRequestError("Bad quantity data")
+	}
+
+	if product.Discount != 0 {
+		utils.LogMessage("Auctions cannot be discounted")
+		return utils.BadRequestError("Bad discount data")
+	}
+
+	if auction.StartPrice <= 0 || auction.ReservePrice < 0 {
+		utils.LogMessage("Auction start price or reserve price is invalid")
+		return utils.BadRequestError("Bad auction price data")
+	}
+
+	startsAt := time.Now()
+	if auction.StartsAt != "" {
+		var err error
+		startsAt, err = time.Parse(time.RFC3339, auction.StartsAt)
+
+		if err != nil {
+			utils.LogMessage("Auction start time is not a RFC3339 time")
+			return utils.BadRequestError("Bad starts_at data")
+		}
+	}
+
+	endsAt, err := time.Parse(time.RFC3339, auction.EndsAt)
+
+	if err != nil || !endsAt.After(startsAt) || !endsAt.After(time.Now()) {
+		utils.LogMessage("Auction end time is not a RFC3339 time after the start time")
+		return utils.BadRequestError("Bad ends_at data")
+	}
+
+	if auction.ExtensionSeconds < 0 || auction.ExtensionSeconds > maxAuctionExtensionSeconds {
+		utils.LogMessage("Auction extension is not between 0 and 3600 seconds")
+		return utils.BadRequestError("Bad extension_seconds data")
+	}
+
+	return nil
+}
+
+/*
+Checks wether a product is an auction, auction products can only be bought by bidding
+*/
+func IsAuctionProduct(db *sql.DB, productId string) bool {
+	var isAuction bool
+	query := `SELECT EXISTS(SELECT * FROM auction_information WHERE product_id = $1);`
+	err := db.QueryRowContext(context.Background(), query, productId).Scan(&isAuction)
+
+	if err != nil {
+		return false
+	}
+
+	return isAuction
+}
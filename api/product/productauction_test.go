@@ -0,0 +1,109 @@
+package product
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"BackendAPI/utils"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMinimumBid(t *testing.T) {
+	//Test 1: First bid can be the start price
+	assert.Equal(t, 1000, GetMinimumBid(1000, 1000, 0))
+
+	//Test 2: Increments grow with the current price
+	assert.Equal(t, 550, GetMinimumBid(100, 500, 1))
+	assert.Equal(t, 1100, GetMinimumBid(100, 1000, 1))
+	assert.Equal(t, 10500, GetMinimumBid(100, 10000, 3))
+
+	//Test 3: Largest increment above the table
+	assert.Equal(t, 202500, GetMinimumBid(100, 200000, 10))
+}
+
+func TestValidateAuction(t *testing.T) {
+	endsAt := time.Now().Add(24 * time.Hour).Format(time.RFC3339)
+	product := data.CreateProductData{ProductType: "Auction", Quantity: 1,
+		Auction: &data.CreateAuctionData{StartPrice: 1000, ReservePrice: 5000, EndsAt: endsAt}}
+
+	//Test 1: Valid auction
+	err := validateAuction(product)
+	assert.Empty(t, err)
+
+	//Test 2: Missing auction information
+	err = validateAuction(data.CreateProductData{ProductType: "Auction", Quantity: 1})
+	assert.Error(t, err)
+	assert.Equal(t, "Bad auction data", err.Error())
+
+	//Test 3: Auctions are for a single item
+	product.Quantity = 2
+	err = validateAuction(product)
+	assert.Error(t, err)
+	assert.Equal(t, "Bad quantity data", err.Error())
+	product.Quantity = 1
+
+	//Test 4: End time in the past
+	product.Auction = &data.CreateAuctionData{StartPrice: 1000, EndsAt: time.Now().Add(-time.Hour).Format(time.RFC3339)}
+	err = validateAuction(product)
+	assert.Error(t, err)
+	assert.Equal(t, "Bad ends_at data", err.Error())
+
+	//Test 5: End time before the start time
+	product.Auction = &data.CreateAuctionData{StartPrice: 1000, EndsAt: endsAt,
+		StartsAt: time.Now().Add(48 * time.Hour).Format(time.RFC3339)}
+	err = validateAuction(product)
+	assert.Error(t, err)
+	assert.Equal(t, "Bad ends_at data", err.Error())
+
+	//Test 6: Start price must be positive
+	product.Auction = &data.CreateAuctionData{StartPrice: 0, EndsAt: endsAt}
+	err = validateAuction(product)
+	assert.Error(t, err)
+	assert.Equal(t, "Bad auction price data", err.Error())
+
+	//Test 7: Extension is too long
+	product.Auction = &data.CreateAuctionData{StartPrice: 1000, EndsAt: endsAt, ExtensionSeconds: 7200}
+	err = validateAuction(product)
+	assert.Error(t, err)
+	assert.Equal(t, "Bad extension_seconds data", err.Error())
+}
+
+func TestCreateAuction(t *testing.T) {
+	utils.LoadDotEnv("../../.env")
+	db, startupErr := store.SetupTestDB("../../.env")
+	assert.NoError(t, startupErr)
+
+	sellerId, sellerErr := createDummySeller(db)
+	assert.NoError(t, sellerErr)
+	expansionErr := createDummyExpansions(db)
+	assert.NoError(t, expansionErr)
+
+	//Test 1: Auction is created with the start price as its price
+	product := data.CreateProductData{
+		Title: "Charizard", SellerId: sellerId, Description: "This is a test description",
+		ProductType: "Auction", Condition: 5, Quantity: 1, Language: "Eng", Expansion: "Test",
+		Auction: &data.CreateAuctionData{StartPrice: 1000, ReservePrice: 5000, EndsAt: time.Now().Add(time.Hour).Format(time.RFC3339)}}
+	response, err := CreateProduct(db, product)
+	assert.Empty(t, err)
+	assert.Equal(t, 1000, response.Price)
+	assert.Equal(t, true, IsAuctionProduct(db, response.ProductId))
+
+	//Test 2: Auction information is returned with the product
+	createDummyProductImages(db, []string{response.ProductId})
+	res, err := GetProductById(db, response.ProductId)
+	assert.Empty(t, err)
+	assert.Equal(t, 1000, res.Auction.MinimumBid)
+	assert.Equal(t, false, res.Auction.ReserveMet)
+	assert.Equal(t, defaultAuctionExtensionSeconds, res.Auction.ExtensionSeconds)
+	assert.Equal(t, "open", res.Auction.Status)
+
+	//Test 3: Buy-Now products are not auctions
+	productIds, productErr := createDummyProducts(db, sellerId)
+	assert.NoError(t, productErr)
+	assert.Equal(t, false, IsAuctionProduct(db, productIds[0]))
+
+	store.CloseDB(db)
+}
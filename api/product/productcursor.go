@@ -0,0 +1,187 @@
+package product
+
+import (
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+const (
+	defaultProductPageSize = 20
+	maxProductPageSize     = 100
+	cursorFacet            = "cursor"
+)
+
+/*
+A column that products are sorted by, along with the type used to compare cursor values against it
+*/
+type productSortKey struct {
+	expression string
+	descending bool
+	valueType  string
+}
+
+/*
+Position of the last product of a page in the product ordering. The sort mode and search are kept
+so a cursor cannot be used with a different ordering than the one it was created for.
+*/
+type productCursor struct {
+	SortMode string   `json:"s"`
+	Search   string   `json:"q,omitempty"`
+	Values   []string `json:"v"`
+}
+
+/*
+Gets the sort mode used for a product list request
+*/
+func getProductSortMode(sortBy string, search string) string {
+	if makeSearchQuery(search) != "" && (sortBy == "relevance" || sortBy == "None") {
+		return "relevance"
+	}
+
+	if sortBy == "price-low" || sortBy == "price-high" || sortBy == "name-asc" || sortBy == "name-desc" ||
+		sortBy == "grade-high" || sortBy == "grade-low" {
+		return sortBy
+	}
+
+	return "posted-date"
+}
+
+/*
+Gets the keys that products are sorted by for a sort mode. The product id is always the last key so that
+every product has a unique position, which keeps pages stable when products share the same values.
+*/
+func getProductSortKeys(sortBy string, search string) []productSortKey {
+	var keys []productSortKey
+
+	switch getProductSortMode(sortBy, search) {
+	case "relevance":
+		keys = []productSortKey{
+			{expression: `ts_rank(products.search_vector, ` + makeSearchQuery(search) + `)`, descending: true, valueType: "real"},
+			{expression: `products.posted_date`, descending: true, valueType: "timestamptz"}}
+	case "price-low":
+		keys = []productSortKey{{expression: productPriceExpression, descending: false, valueType: "int"}}
+	case "price-high":
+		keys = []productSortKey{{expression: productPriceExpression, descending: true, valueType: "int"}}
+	case "name-asc":
+		keys = []productSortKey{{expression: `products.title`, descending: false, valueType: "text"}}
+	case "name-desc":
+		keys = []productSortKey{{expression: `products.title`, descending: true, valueType: "text"}}
+	case "grade-high":
+		keys = []productSortKey{{expression: `COALESCE(grade, 0)`, descending: true, valueType: "numeric"}}
+	case "grade-low":
+		// Ungraded products come last when sorting by the lowest grade as well
+		keys = []productSortKey{{expression: `COALESCE(grade, 11)`, descending: false, valueType: "numeric"}}
+	default:
+		keys = []productSortKey{{expression: `products.posted_date`, descending: true, valueType: "timestamptz"}}
+	}
+
+	return append(keys, productSortKey{expression: `products.product_id`, descending: false, valueType: "uuid"})
+}
+
+/*
+Creates the condition that only keeps products that come after the cursor values in the ordering of the sort keys
+*/
+func makeProductCursorCondition(keys []productSortKey, values []string) string {
+	var alternatives []string
+
+	for i := 0; i < len(keys); i++ {
+		var comparisons []string
+
+		for j := 0; j < i; j++ {
+			comparisons = append(comparisons, keys[j].expression+` = `+makeCursorValue(keys[j], values[j]))
+		}
+
+		operator := ` > `
+		if keys[i].descending {
+			operator = ` < `
+		}
+		comparisons = append(comparisons, keys[i].expression+operator+makeCursorValue(keys[i], values[i]))
+
+		alternatives = append(alternatives, `(`+strings.Join(comparisons, ` AND `)+`)`)
+	}
+
+	return `(` + strings.Join(alternatives, ` OR `) + `)`
+}
+
+/*
+Creates the literal for a cursor value with the type of its sort key
+*/
+func makeCursorValue(key productSortKey, value string) string {
+	return pq.QuoteLiteral(value) + `::` + key.valueType
+}
+
+/*
+Creates an opaque cursor token from the sort key values of the last product in a page
+*/
+func encodeProductCursor(sortBy string, search string, values []string) string {
+	cursor := productCursor{SortMode: getProductSortMode(sortBy, search), Values: values}
+
+	if cursor.SortMode == "relevance" {
+		cursor.Search = search
+	}
+
+	cursorJSON, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(cursorJSON)
+}
+
+/*
+Reads the sort key values from a cursor token. Returns a 400 bad request if the cursor is malformed or
+was created for a different ordering than the current request.
+*/
+func decodeProductCursor(token string, sortBy string, search string) ([]string, *utils.ErrorHandler) {
+	var cursor productCursor
+
+	cursorJSON, err := base64.RawURLEncoding.DecodeString(token)
+
+	if err != nil || json.Unmarshal(cursorJSON, &cursor) != nil {
+		utils.LogMessage("Cursor could not be decoded")
+		return nil, utils.BadRequestError("Bad cursor param")
+	}
+
+	sortMode := getProductSortMode(sortBy, search)
+	keys := getProductSortKeys(sortBy, search)
+
+	if cursor.SortMode != sortMode || (sortMode == "relevance" && cursor.Search != search) || len(cursor.Values) != len(keys) {
+		utils.LogMessage("Cursor does not match the sorting of the request")
+		return nil, utils.BadRequestError("Bad cursor param")
+	}
+
+	return cursor.Values, nil
+}
+
+/*
+Gets the values of the sort keys of a product, used to create the cursor that continues after that product
+*/
+func getProductCursorValues(db *sql.DB, keys []productSortKey, productId string) ([]string, *utils.ErrorHandler) {
+	var expressions []string
+	for i := 0; i < len(keys); i++ {
+		expressions = append(expressions, `(`+keys[i].expression+`)::TEXT`)
+	}
+
+	query := `SELECT ` + strings.Join(expressions, `, `) + `
+		FROM (products LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id)
+			LEFT OUTER JOIN product_card_attributes ON product_card_attributes.product_id = products.product_id
+		WHERE products.product_id = $1;`
+
+	values := make([]string, len(keys))
+	pointers := make([]interface{}, len(keys))
+	for i := 0; i < len(values); i++ {
+		pointers[i] = &values[i]
+	}
+
+	err := db.QueryRowContext(context.Background(), query, productId).Scan(pointers...)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting product cursor values")
+		return nil, errResp
+	}
+
+	return values, nil
+}
@@ -0,0 +1,112 @@
+package product
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"BackendAPI/utils"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeProductCursorCondition(t *testing.T) {
+	//Test 1: Default sorting continues after the posted date and product id
+	keys := getProductSortKeys("None", "")
+	condition := makeProductCursorCondition(keys, []string{"2023-08-03 02:50:26.034552+00", "a"})
+	assert.Equal(t, `((products.posted_date < '2023-08-03 02:50:26.034552+00'::timestamptz) OR `+
+		`(products.posted_date = '2023-08-03 02:50:26.034552+00'::timestamptz AND products.product_id > 'a'::uuid))`, condition)
+
+	//Test 2: Mixed directions for price sorting
+	keys = getProductSortKeys("price-low", "")
+	condition = makeProductCursorCondition(keys, []string{"90", "a"})
+	assert.Equal(t, `(((products.price - COALESCE(product_effective_discounts.discount, 0)) > '90'::int) OR `+
+		`((products.price - COALESCE(product_effective_discounts.discount, 0)) = '90'::int AND products.product_id > 'a'::uuid))`, condition)
+
+	//Test 3: Values are quoted
+	keys = getProductSortKeys("name-asc", "")
+	condition = makeProductCursorCondition(keys, []string{"Crown's", "a"})
+	assert.Equal(t, `((products.title > 'Crown''s'::text) OR (products.title = 'Crown''s'::text AND products.product_id > 'a'::uuid))`, condition)
+}
+
+func TestDecodeProductCursor(t *testing.T) {
+	//Test 1: Cursor is decoded for the same sorting
+	cursor := encodeProductCursor("price-high", "", []string{"100", "a"})
+	values, err := decodeProductCursor(cursor, "price-high", "")
+	assert.Empty(t, err)
+	assert.Equal(t, []string{"100", "a"}, values)
+
+	//Test 2: Cursor cannot be used with different sorting
+	values, err = decodeProductCursor(cursor, "price-low", "")
+	assert.Error(t, err)
+	assert.Equal(t, "Bad cursor param", err.Error())
+	assert.Equal(t, 400, err.ErrorCode())
+
+	//Test 3: Relevance cursor cannot be used with a different search
+	cursor = encodeProductCursor("relevance", "charizard", []string{"0.1", "2023-08-03 02:50:26.034552+00", "a"})
+	values, err = decodeProductCursor(cursor, "relevance", "charizard")
+	assert.Empty(t, err)
+	values, err = decodeProductCursor(cursor, "relevance", "pikachu")
+	assert.Error(t, err)
+
+	//Test 4: Malformed cursors
+	values, err = decodeProductCursor("not a cursor", "None", "")
+	assert.Error(t, err)
+	values, err = decodeProductCursor(encodeProductCursor("None", "", []string{"a"}), "None", "")
+	assert.Error(t, err)
+}
+
+func TestGetProductListCursor(t *testing.T) {
+	utils.LoadDotEnv("../../.env")
+	db, startupErr := store.SetupTestDB("../../.env")
+	assert.NoError(t, startupErr)
+
+	sellerid, sellerErr := createDummySeller(db)
+	assert.NoError(t, sellerErr)
+	productIds, productErr := createDummyProducts(db, sellerid)
+	assert.NoError(t, productErr)
+	_, productImageErr := createDummyProductImages(db, productIds)
+	assert.NoError(t, productImageErr)
+
+	for _, sortBy := range []string{"None", "price-low", "price-high", "name-asc", "name-desc"} {
+		//Test 1: Paging through every sorting returns every product once in the same order as a single page
+		req := data.GetProductListRequestData{SortBy: sortBy, Limit: 10}
+		all, err := GetProductList(db, req)
+		assert.Empty(t, err)
+		assert.Equal(t, 5, len(all.Products))
+		assert.Equal(t, "", all.NextCursor)
+
+		var paged []string
+		req = data.GetProductListRequestData{SortBy: sortBy, Limit: 2}
+		for i := 0; i < 3; i++ {
+			res, err := GetProductList(db, req)
+			assert.Empty(t, err)
+			assert.Equal(t, 5, res.ProductCount)
+			for _, product := range res.Products {
+				paged = append(paged, product.ProductId)
+			}
+			req.Cursor = res.NextCursor
+		}
+		assert.Equal(t, "", req.Cursor)
+
+		var expected []string
+		for _, product := range all.Products {
+			expected = append(expected, product.ProductId)
+		}
+		assert.Equal(t, expected, paged)
+	}
+
+	//Test 2: Default page size when no limit is given
+	res, err := GetProductList(db, data.GetProductListRequestData{SortBy: "None"})
+	assert.Empty(t, err)
+	assert.Equal(t, 5, len(res.Products))
+
+	//Test 3: Cursor for a different sorting
+	res, err = GetProductList(db, data.GetProductListRequestData{SortBy: "None", Limit: 2})
+	assert.Empty(t, err)
+	res, err = GetProductList(db, data.GetProductListRequestData{SortBy: "name-asc", Limit: 2, Cursor: res.NextCursor})
+	assert.Error(t, err)
+	assert.Equal(t, 400, err.ErrorCode())
+
+	store.CloseDB(db)
+}
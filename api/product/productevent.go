@@ -0,0 +1,103 @@
+package product
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+const (
+	ProductEventChannel = "product_events"
+	maxEventProducts    = 100
+)
+
+/*
+Gets the live state of a product that is pushed to buyers watching it. Auctions take their status from
+the auction, other products are sold out once there is no remaining quantity.
+*/
+func GetProductEvent(db *sql.DB, productId string, event string) (data.ProductEventData, *utils.ErrorHandler) {
+	response := data.ProductEventData{ProductId: productId, Event: event}
+
+	query := `SELECT price, product_quantity - sold_quantity FROM products WHERE product_id = $1;`
+	err := db.QueryRowContext(context.Background(), query, productId).Scan(&response.Price, &response.RemainingQuantity)
+
+	if err == sql.ErrNoRows {
+		return response, utils.NotFoundError("Product with given id does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting product event rows")
+		return response, errResp
+	}
+
+	auction, auctionErr := getAuctionInformation(db, productId)
+
+	if auctionErr != nil {
+		return response, auctionErr
+	}
+
+	response.Auction = auction
+
+	if auction != nil {
+		response.Status = auction.Status
+	} else if response.RemainingQuantity <= 0 {
+		response.Status = "sold_out"
+	} else {
+		response.Status = "available"
+	}
+
+	return response, nil
+}
+
+/*
+Publishes the live state of products after they have changed. Failing to publish does not fail
+the change itself, so errors are only logged.
+*/
+func PublishProductEvents(db *sql.DB, eventBus store.EventBus, productIds []string, event string) {
+	if eventBus == nil {
+		return
+	}
+
+	for i := 0; i < len(productIds); i++ {
+		productEvent, err := GetProductEvent(db, productIds[i], event)
+
+		if err != nil {
+			continue
+		}
+
+		payload, marshalErr := json.Marshal(productEvent)
+
+		if marshalErr != nil {
+			utils.LogError(marshalErr, "Error in encoding product event")
+			continue
+		}
+
+		publishErr := eventBus.Publish(ProductEventChannel, string(payload))
+
+		if publishErr != nil {
+			utils.LogError(publishErr, "Error in publishing product event")
+		}
+	}
+}
+
+/*
+Validates the product ids of a live update subscription
+*/
+func ValidateProductEventIds(db *sql.DB, productIds []string) *utils.ErrorHandler {
+	if len(productIds) == 0 || len(productIds) > maxEventProducts {
+		utils.LogMessage("Live updates need between 1 and 100 products")
+		return utils.BadRequestError("Bad ids param")
+	}
+
+	for i := 0; i < len(productIds); i++ {
+		if !DoesProductExist(db, productIds[i]) {
+			return utils.NotFoundError("Product with given id does not exist")
+		}
+	}
+
+	return nil
+}
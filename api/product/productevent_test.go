@@ -0,0 +1,98 @@
+package product
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"BackendAPI/utils"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetProductEvent(t *testing.T) {
+	utils.LoadDotEnv("../../.env")
+	db, startupErr := store.SetupTestDB("../../.env")
+	assert.NoError(t, startupErr)
+
+	sellerId, sellerErr := createDummySeller(db)
+	assert.NoError(t, sellerErr)
+	expansionErr := createDummyExpansions(db)
+	assert.NoError(t, expansionErr)
+	productIds, productErr := createDummyProducts(db, sellerId)
+	assert.NoError(t, productErr)
+
+	//Test 1: Product with remaining quantity is available
+	event, err := GetProductEvent(db, productIds[0], "stock")
+	assert.Empty(t, err)
+	assert.Equal(t, "available", event.Status)
+	assert.Equal(t, "stock", event.Event)
+	assert.Nil(t, event.Auction)
+
+	//Test 2: Product without remaining quantity is sold out
+	_, execErr := db.ExecContext(context.Background(),
+		`UPDATE products SET sold_quantity = product_quantity WHERE product_id = $1;`, productIds[0])
+	assert.NoError(t, execErr)
+	event, err = GetProductEvent(db, productIds[0], "stock")
+	assert.Empty(t, err)
+	assert.Equal(t, 0, event.RemainingQuantity)
+	assert.Equal(t, "sold_out", event.Status)
+
+	//Test 3: Auctions take the status of the auction
+	auction := data.CreateProductData{
+		Title: "Charizard", SellerId: sellerId, Description: "This is a test description",
+		ProductType: "Auction", Condition: 5, Quantity: 1, Language: "Eng", Expansion: "Test",
+		Auction: &data.CreateAuctionData{StartPrice: 1000, EndsAt: time.Now().Add(time.Hour).Format(time.RFC3339)}}
+	response, err := CreateProduct(db, auction)
+	assert.Empty(t, err)
+	event, err = GetProductEvent(db, response.ProductId, "bid")
+	assert.Empty(t, err)
+	assert.Equal(t, "open", event.Status)
+	assert.Equal(t, 1000, event.Auction.MinimumBid)
+
+	//Test 4: Product does not exist
+	_, err = GetProductEvent(db, sellerId, "stock")
+	assert.Error(t, err)
+	assert.Equal(t, 404, err.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func TestPublishProductEvents(t *testing.T) {
+	utils.LoadDotEnv("../../.env")
+	db, startupErr := store.SetupTestDB("../../.env")
+	assert.NoError(t, startupErr)
+
+	sellerId, sellerErr := createDummySeller(db)
+	assert.NoError(t, sellerErr)
+	productIds, productErr := createDummyProducts(db, sellerId)
+	assert.NoError(t, productErr)
+
+	bus := store.NewLocalEventBus()
+	events, unsubscribe := bus.Subscribe(ProductEventChannel)
+	defer unsubscribe()
+
+	//Test 1: State of each product is published
+	PublishProductEvents(db, bus, productIds[:2], "stock")
+	assert.Equal(t, 2, len(events))
+
+	var event data.ProductEventData
+	assert.NoError(t, json.Unmarshal([]byte(<-events), &event))
+	assert.Equal(t, productIds[0], event.ProductId)
+	assert.Equal(t, "stock", event.Event)
+
+	//Test 2: Products that do not exist are skipped
+	<-events
+	PublishProductEvents(db, bus, []string{sellerId}, "stock")
+	assert.Equal(t, 0, len(events))
+
+	//Test 3: No products to watch
+	validateErr := ValidateProductEventIds(db, []string{})
+	assert.Error(t, validateErr)
+	assert.Equal(t, "Bad ids param", validateErr.Error())
+
+	store.CloseDB(db)
+}
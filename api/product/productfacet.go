@@ -0,0 +1,180 @@
+package product
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+)
+
+const (
+	productTypeFacet = "product_type"
+	languageFacet    = "language"
+	expansionFacet   = "expansion"
+	conditionFacet   = "condition"
+	priceFacet       = "price"
+	searchFacet      = "search"
+	sellerFacet      = "seller"
+)
+
+/*
+A filter condition on products and the facet that it filters on
+*/
+type productFilterCondition struct {
+	facet     string
+	condition string
+}
+
+/*
+Expressions that group products into the values of each facet and how the values are ordered
+*/
+var productFacetExpressions = map[string]struct {
+	value string
+	order string
+}{
+	productTypeFacet: {value: `products.product_type`, order: `COUNT(*) DESC, 1 ASC`},
+	languageFacet:    {value: `products.language`, order: `COUNT(*) DESC, 1 ASC`},
+	expansionFacet:   {value: `products.expansion`, order: `COUNT(*) DESC, 1 ASC`},
+	conditionFacet:   {value: `products.condition::TEXT`, order: `1 DESC`},
+	priceFacet:       {value: makePriceFacetValue(), order: `MIN(` + productPriceExpression + `) ASC`},
+}
+
+/*
+Price of a product after its discount that the price filter and facet use, the same price that products are
+sorted by. Queries that filter on price must join product_effective_discounts.
+*/
+const productPriceExpression = `(products.price - COALESCE(product_effective_discounts.discount, 0))`
+
+/*
+A price range in cents that products are filtered and grouped on
+*/
+type productPriceRange struct {
+	value string
+	min   int
+	max   int
+}
+
+/*
+Price ranges of the price filter and facet. A range includes its minimum and excludes its maximum so every
+price is in exactly one range. The first range has no minimum and the last range has no maximum.
+*/
+var productPriceRanges = []productPriceRange{
+	{value: "0-20", max: 2000},
+	{value: "20-50", min: 2000, max: 5000},
+	{value: "50-100", min: 5000, max: 10000},
+	{value: "100-200", min: 10000, max: 20000},
+	{value: "200", min: 20000},
+}
+
+/*
+Creates the condition that a product's price is in a price range
+*/
+func makePriceRangeCondition(priceRange productPriceRange) string {
+	var bounds []string
+
+	if priceRange.min > 0 {
+		bounds = append(bounds, productPriceExpression+` >= `+strconv.Itoa(priceRange.min))
+	}
+	if priceRange.max > 0 {
+		bounds = append(bounds, productPriceExpression+` < `+strconv.Itoa(priceRange.max))
+	}
+
+	return strings.Join(bounds, ` AND `)
+}
+
+/*
+Creates the expression that groups products into the price ranges
+*/
+func makePriceFacetValue() string {
+	value := `CASE`
+	for i := 0; i < len(productPriceRanges); i++ {
+		value += ` WHEN ` + makePriceRangeCondition(productPriceRanges[i]) + ` THEN '` + productPriceRanges[i].value + `'`
+	}
+	return value + ` END`
+}
+
+/*
+Gets the number of products for each value of each facet. The counts of a facet are calculated with
+every filter except the facet's own filter, so that selecting a value does not hide the other values.
+*/
+func getProductFacets(db *sql.DB, request data.GetProductListRequestData) (data.ProductFacetsData, *utils.ErrorHandler) {
+	var response data.ProductFacetsData
+	var err *utils.ErrorHandler
+	conditions := getProductFilterConditions(request)
+
+	response.ProductTypes, err = getProductFacetCounts(db, conditions, productTypeFacet)
+	if err != nil {
+		return response, err
+	}
+
+	response.Languages, err = getProductFacetCounts(db, conditions, languageFacet)
+	if err != nil {
+		return response, err
+	}
+
+	response.Expansions, err = getProductFacetCounts(db, conditions, expansionFacet)
+	if err != nil {
+		return response, err
+	}
+
+	response.Conditions, err = getProductFacetCounts(db, conditions, conditionFacet)
+	if err != nil {
+		return response, err
+	}
+
+	response.Prices, err = getProductFacetCounts(db, conditions, priceFacet)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
+
+/*
+Gets the number of products for each value of a single facet
+*/
+func getProductFacetCounts(db *sql.DB, conditions []productFilterCondition, facet string) ([]data.FacetCountData, *utils.ErrorHandler) {
+	counts := []data.FacetCountData{}
+	query := makeProductFacetQuery(conditions, facet)
+
+	rows, err := db.QueryContext(context.Background(), query)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting product facet rows")
+		return counts, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var count data.FacetCountData
+		err = rows.Scan(&count.Value, &count.Count)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting product facet rows")
+			return counts, errResp
+		}
+
+		counts = append(counts, count)
+	}
+
+	return counts, nil
+}
+
+/*
+Creates the query that counts the products for each value of a facet
+*/
+func makeProductFacetQuery(conditions []productFilterCondition, facet string) string {
+	expression := productFacetExpressions[facet]
+
+	query := `SELECT ` + expression.value + `, COUNT(*) FROM products` +
+		` LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id`
+	query = addProductConditions(query, conditions, facet)
+	query += ` GROUP BY 1 ORDER BY ` + expression.order
+
+	return query
+}
@@ -0,0 +1,74 @@
+package product
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"BackendAPI/utils"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeProductFacetQuery(t *testing.T) {
+	request := data.GetProductListRequestData{Languages: []string{"Eng"}, ProductTypes: []string{"Buy-Now"}}
+	conditions := getProductFilterConditions(request)
+
+	//Test 1: Language facet leaves out the language filter
+	query := makeProductFacetQuery(conditions, languageFacet)
+	assert.Equal(t, `SELECT products.language, COUNT(*) FROM products LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id WHERE products.product_type = 'Buy-Now' GROUP BY 1 ORDER BY COUNT(*) DESC, 1 ASC`, query)
+
+	//Test 2: Product type facet leaves out the product type filter
+	query = makeProductFacetQuery(conditions, productTypeFacet)
+	assert.Equal(t, `SELECT products.product_type, COUNT(*) FROM products LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id WHERE products.language = 'Eng' GROUP BY 1 ORDER BY COUNT(*) DESC, 1 ASC`, query)
+
+	//Test 3: Facets without their own filter use every filter
+	query = makeProductFacetQuery(conditions, conditionFacet)
+	assert.Equal(t, `SELECT products.condition::TEXT, COUNT(*) FROM products LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id WHERE products.product_type = 'Buy-Now' AND products.language = 'Eng' GROUP BY 1 ORDER BY 1 DESC`, query)
+
+	//Test 4: Search is never left out
+	conditions = getProductFilterConditions(data.GetProductListRequestData{Expansions: []string{"Test"}, Search: "test"})
+	query = makeProductFacetQuery(conditions, expansionFacet)
+	assert.Equal(t, `SELECT products.expansion, COUNT(*) FROM products LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id WHERE products.search_vector @@ to_tsquery('english', 'test:*') GROUP BY 1 ORDER BY COUNT(*) DESC, 1 ASC`, query)
+
+	//Test 5: Price facet groups products into the same half open ranges as the price filter, on the price after discounts
+	conditions = getProductFilterConditions(data.GetProductListRequestData{Prices: []string{"20-50", "200"}})
+	query = makeProductFacetQuery(conditions, conditionFacet)
+	assert.Equal(t, `SELECT products.condition::TEXT, COUNT(*) FROM products LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id WHERE ((products.price - COALESCE(product_effective_discounts.discount, 0)) >= 2000 AND (products.price - COALESCE(product_effective_discounts.discount, 0)) < 5000 OR (products.price - COALESCE(product_effective_discounts.discount, 0)) >= 20000) GROUP BY 1 ORDER BY 1 DESC`, query)
+	query = makeProductFacetQuery(conditions, priceFacet)
+	assert.Equal(t, `SELECT CASE WHEN (products.price - COALESCE(product_effective_discounts.discount, 0)) < 2000 THEN '0-20' WHEN (products.price - COALESCE(product_effective_discounts.discount, 0)) >= 2000 AND (products.price - COALESCE(product_effective_discounts.discount, 0)) < 5000 THEN '20-50' WHEN (products.price - COALESCE(product_effective_discounts.discount, 0)) >= 5000 AND (products.price - COALESCE(product_effective_discounts.discount, 0)) < 10000 THEN '50-100' WHEN (products.price - COALESCE(product_effective_discounts.discount, 0)) >= 10000 AND (products.price - COALESCE(product_effective_discounts.discount, 0)) < 20000 THEN '100-200' WHEN (products.price - COALESCE(product_effective_discounts.discount, 0)) >= 20000 THEN '200' END, COUNT(*) FROM products LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id GROUP BY 1 ORDER BY MIN((products.price - COALESCE(product_effective_discounts.discount, 0))) ASC`, query)
+}
+
+func TestGetProductFacets(t *testing.T) {
+	utils.LoadDotEnv("../../.env")
+	db, startupErr := store.SetupTestDB("../../.env")
+	assert.NoError(t, startupErr)
+
+	sellerid, sellerErr := createDummySeller(db)
+	assert.NoError(t, sellerErr)
+	_, productErr := createDummyProducts(db, sellerid)
+	assert.NoError(t, productErr)
+
+	//Test 1: Counts with no filters
+	res, err := getProductFacets(db, data.GetProductListRequestData{})
+	assert.Empty(t, err)
+	assert.Equal(t, []data.FacetCountData{{Value: "Buy-Now", Count: 4}, {Value: "Pre-Order", Count: 1}}, res.ProductTypes)
+	assert.Equal(t, []data.FacetCountData{{Value: "Eng", Count: 4}, {Value: "Jap", Count: 1}}, res.Languages)
+	assert.Equal(t, []data.FacetCountData{{Value: "Test", Count: 4}, {Value: "Test2", Count: 1}}, res.Expansions)
+	assert.Equal(t, []data.FacetCountData{{Value: "5", Count: 1}, {Value: "4", Count: 3}, {Value: "3", Count: 1}}, res.Conditions)
+	assert.Equal(t, []data.FacetCountData{{Value: "100-200", Count: 4}, {Value: "200", Count: 1}}, res.Prices)
+
+	//Test 2: Selecting a language keeps the counts of the other languages
+	res, err = getProductFacets(db, data.GetProductListRequestData{Languages: []string{"Jap"}})
+	assert.Empty(t, err)
+	assert.Equal(t, []data.FacetCountData{{Value: "Eng", Count: 4}, {Value: "Jap", Count: 1}}, res.Languages)
+	assert.Equal(t, []data.FacetCountData{{Value: "Buy-Now", Count: 1}}, res.ProductTypes)
+	assert.Equal(t, []data.FacetCountData{{Value: "200", Count: 1}}, res.Prices)
+
+	//Test 3: No products match
+	res, err = getProductFacets(db, data.GetProductListRequestData{Search: "charizard"})
+	assert.Empty(t, err)
+	assert.Equal(t, []data.FacetCountData{}, res.Languages)
+
+	store.CloseDB(db)
+}
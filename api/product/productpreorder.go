@@ -0,0 +1,339 @@
+package product
+
+import (
+	"BackendAPI/api/outbox"
+	"BackendAPI/data"
+	"BackendAPI/mailing"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"time"
+)
+
+/*
+Changes the order by and release dates of an open pre-order. Buyers who have paid for the pre-order are
+notified when the release date changes.
+*/
+func UpdatePreOrderDates(db *sql.DB, productId string, request data.UpdatePreOrderRequestData) (data.PreOrderData, *utils.ErrorHandler) {
+	preOrder, err := getPreOrder(db, productId, request.SellerId)
+
+	if err != nil {
+		return preOrder, err
+	}
+
+	if preOrder.Status != "open" {
+		return preOrder, utils.BadRequestError("Pre-order has already been released")
+	}
+
+	orderBy, parseErr := time.Parse(time.RFC3339, request.OrderBy)
+
+	if parseErr != nil {
+		utils.LogMessage("Pre-order order by date is not in RFC3339 format")
+		return preOrder, utils.BadRequestError("Bad order_by data")
+	}
+
+	releasesOn, parseErr := time.Parse(time.RFC3339, request.ReleasesOn)
+
+	if parseErr != nil || !releasesOn.After(time.Now()) {
+		utils.LogMessage("Pre-order release date is invalid or in the past")
+		return preOrder, utils.BadRequestError("Bad releases_on data")
+	}
+
+	if orderBy.After(releasesOn) {
+		utils.LogMessage("Pre-order order by date is after the release date")
+		return preOrder, utils.BadRequestError("Bad order_by data")
+	}
+
+	tx, txErr := db.BeginTx(context.Background(), nil)
+
+	if txErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(txErr, "Error in starting Preorder transaction")
+		return preOrder, errResp
+	}
+
+	defer tx.Rollback()
+
+	var releaseDateChanged bool
+	query := `UPDATE preorder_information SET order_by = $2, releases_on = $3
+		WHERE product_id = $1 AND status = 'open'
+		RETURNING releases_on <> $4::timestamptz;`
+	scanErr := tx.QueryRowContext(context.Background(), query, productId, orderBy, releasesOn, preOrder.ReleasesOn).Scan(&releaseDateChanged)
+
+	if scanErr == sql.ErrNoRows {
+		return preOrder, utils.BadRequestError("Pre-order has already been released")
+	}
+
+	if scanErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(scanErr, "Error in updating Preorder Information rows")
+		return preOrder, errResp
+	}
+
+	var notifiedBuyers int
+
+	if releaseDateChanged {
+		notifiedBuyers, err = addPreOrderMails(tx, productId, func(email string, locale string, title string) (mailing.Message, error) {
+			return mailing.ComposePreOrderReleaseDateMail(email, locale, title, releasesOn.Format("2 Jan 2006"))
+		})
+
+		if err != nil {
+			return preOrder, err
+		}
+	}
+
+	commitErr := tx.Commit()
+
+	if commitErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(commitErr, "Error in committing Preorder transaction")
+		return preOrder, errResp
+	}
+
+	preOrder, err = getPreOrder(db, productId, request.SellerId)
+	preOrder.NotifiedBuyers = notifiedBuyers
+
+	return preOrder, err
+}
+
+/*
+Marks the pre-orders that have reached their release date as released and notifies the buyers of each
+pre-order. Each pre-order is only released once, so buyers are only notified once. The mails are added to the
+outbox along with the release and sent by the outbox job, so a large pre-order does not hold up the release.
+*/
+func ReleasePreOrders(db *sql.DB) (data.ReleasePreOrdersResponseData, *utils.ErrorHandler) {
+	response := data.ReleasePreOrdersResponseData{Released: []string{}}
+
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Preorder transaction")
+		return response, errResp
+	}
+
+	defer tx.Rollback()
+
+	query := `UPDATE preorder_information SET status = 'released', released_at = NOW()
+		WHERE status = 'open' AND releases_on <= NOW() RETURNING product_id;`
+	rows, err := tx.QueryContext(context.Background(), query)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Preorder Information rows")
+		return response, errResp
+	}
+
+	for rows.Next() {
+		var productId string
+		err = rows.Scan(&productId)
+
+		if err != nil {
+			rows.Close()
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in updating Preorder Information rows")
+			return response, errResp
+		}
+
+		response.Released = append(response.Released, productId)
+	}
+
+	rows.Close()
+
+	for i := 0; i < len(response.Released); i++ {
+		_, mailErr := addPreOrderMails(tx, response.Released[i], mailing.ComposePreOrderReleasedMail)
+
+		if mailErr != nil {
+			return data.ReleasePreOrdersResponseData{Released: []string{}}, mailErr
+		}
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Preorder transaction")
+		return data.ReleasePreOrdersResponseData{Released: []string{}}, errResp
+	}
+
+	return response, nil
+}
+
+/*
+Turns a released pre-order into a Buy-Now listing so that its remaining stock can be sold
+*/
+func ConvertPreOrderToBuyNow(db *sql.DB, productId string, request data.ConvertPreOrderRequestData) (data.PreOrderData, *utils.ErrorHandler) {
+	preOrder, err := getPreOrder(db, productId, request.SellerId)
+
+	if err != nil {
+		return preOrder, err
+	}
+
+	if preOrder.Status != "released" {
+		return preOrder, utils.BadRequestError("Only released pre-orders can be converted to Buy-Now")
+	}
+
+	tx, txErr := db.BeginTx(context.Background(), nil)
+
+	if txErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(txErr, "Error in starting Preorder transaction")
+		return preOrder, errResp
+	}
+
+	defer tx.Rollback()
+
+	query := `UPDATE preorder_information SET status = 'converted' WHERE product_id = $1 AND status = 'released';`
+	result, execErr := tx.ExecContext(context.Background(), query, productId)
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in updating Preorder Information rows")
+		return preOrder, errResp
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return preOrder, utils.BadRequestError("Only released pre-orders can be converted to Buy-Now")
+	}
+
+	query = `UPDATE products SET product_type = 'Buy-Now' WHERE product_id = $1;`
+	_, execErr = tx.ExecContext(context.Background(), query, productId)
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in updating Product rows")
+		return preOrder, errResp
+	}
+
+	execErr = tx.Commit()
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in committing Preorder transaction")
+		return preOrder, errResp
+	}
+
+	return getPreOrder(db, productId, request.SellerId)
+}
+
+/*
+Checks whether a product is a pre-order that can no longer be ordered, either because its order by
+date has passed or because it has been released
+*/
+func IsPreOrderClosed(db *sql.DB, productId string) bool {
+	var isClosed bool
+	query := `SELECT EXISTS(
+		SELECT * FROM products INNER JOIN preorder_information ON preorder_information.product_id = products.product_id
+		WHERE products.product_id = $1 AND products.product_type = 'Pre-Order' 
+			AND (preorder_information.status <> 'open' OR preorder_information.order_by < NOW()));`
+	err := db.QueryRowContext(context.Background(), query, productId).Scan(&isClosed)
+
+	if err != nil {
+		return false
+	}
+
+	return isClosed
+}
+
+/*
+Gets the pre-order information of a product for the seller of the product
+*/
+func getPreOrder(db *sql.DB, productId string, sellerId string) (data.PreOrderData, *utils.ErrorHandler) {
+	preOrder := data.PreOrderData{ProductId: productId}
+	var productSellerId string
+
+	query := `SELECT products.seller_id, products.product_type, order_by::TEXT, releases_on::TEXT, status
+		FROM products INNER JOIN preorder_information ON preorder_information.product_id = products.product_id
+		WHERE products.product_id = $1;`
+	err := db.QueryRowContext(context.Background(), query, productId).Scan(&productSellerId, &preOrder.ProductType,
+		&preOrder.OrderBy, &preOrder.ReleasesOn, &preOrder.Status)
+
+	if err == sql.ErrNoRows {
+		return preOrder, utils.NotFoundError("Pre-order with given id does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Preorder Information rows")
+		return preOrder, errResp
+	}
+
+	if productSellerId != sellerId {
+		utils.LogMessage("Seller does not own the pre-order")
+		return preOrder, utils.BadRequestError("Bad seller_id data")
+	}
+
+	return preOrder, nil
+}
+
+/*
+Adds a mail for every buyer who has paid for a pre-order, including guest buyers, to the outbox as part of a
+transaction and returns the number of buyers that will be mailed. Mails that cannot be composed are logged and
+left out.
+*/
+func addPreOrderMails(tx *sql.Tx, productId string, compose func(email string, locale string, title string) (mailing.Message, error)) (int, *utils.ErrorHandler) {
+	var title string
+	var emails, locales []string
+
+	query := `SELECT title FROM products WHERE product_id = $1;`
+	err := tx.QueryRowContext(context.Background(), query, productId).Scan(&title)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Product rows")
+		return 0, errResp
+	}
+
+	query = `SELECT buyers.email, buyers.locale
+		FROM (orders INNER JOIN order_products ON order_products.order_id = orders.order_id)
+			INNER JOIN buyers ON buyers.buyer_id = orders.buyer_id
+		WHERE order_products.product_id = $1 AND orders.payment_status = 'completed'
+		UNION
+		SELECT guest_orders.email, $2::VARCHAR
+		FROM guest_orders INNER JOIN guest_order_products ON guest_order_products.guest_order_id = guest_orders.guest_order_id
+		WHERE guest_order_products.product_id = $1 AND guest_orders.payment_status = 'completed';`
+	rows, err := tx.QueryContext(context.Background(), query, productId, mailing.DefaultLocale)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting pre-order buyer rows")
+		return 0, errResp
+	}
+
+	for rows.Next() {
+		var email, locale string
+		err = rows.Scan(&email, &locale)
+
+		if err != nil {
+			rows.Close()
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting pre-order buyer rows")
+			return 0, errResp
+		}
+
+		emails = append(emails, email)
+		locales = append(locales, locale)
+	}
+
+	rows.Close()
+
+	var mailed int
+	for i := 0; i < len(emails); i++ {
+		message, composeErr := compose(emails[i], locales[i], title)
+
+		if composeErr != nil {
+			utils.LogError(composeErr, "Error in composing pre-order mail for product "+productId)
+			continue
+		}
+
+		_, outboxErr := outbox.AddMail(tx, message)
+
+		if outboxErr != nil {
+			return 0, outboxErr
+		}
+
+		mailed++
+	}
+
+	return mailed, nil
+}
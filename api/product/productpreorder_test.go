@@ -0,0 +1,123 @@
+package product
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdatePreOrderDates(t *testing.T) {
+	utils.LoadDotEnv("../../.env")
+	db, startupErr := store.SetupTestDB("../../.env")
+	assert.NoError(t, startupErr)
+
+	sellerId, sellerErr := createDummySeller(db)
+	assert.NoError(t, sellerErr)
+	productIds, productErr := createDummyProducts(db, sellerId)
+	assert.NoError(t, productErr)
+	preOrderId := createDummyPreOrder(t, db, sellerId, "NOW() + INTERVAL '1 day'", "NOW() + INTERVAL '2 days'")
+
+	orderBy := time.Now().Add(48 * time.Hour).Format(time.RFC3339)
+	releasesOn := time.Now().Add(72 * time.Hour).Format(time.RFC3339)
+
+	//Test 1: Valid date change without any buyers to notify
+	res, err := UpdatePreOrderDates(db, preOrderId, data.UpdatePreOrderRequestData{SellerId: sellerId, OrderBy: orderBy, ReleasesOn: releasesOn})
+	assert.Empty(t, err)
+	assert.Equal(t, "open", res.Status)
+	assert.Equal(t, 0, res.NotifiedBuyers)
+
+	//Test 2: Seller does not own the pre-order
+	_, err = UpdatePreOrderDates(db, preOrderId, data.UpdatePreOrderRequestData{SellerId: productIds[0], OrderBy: orderBy, ReleasesOn: releasesOn})
+	assert.Error(t, err)
+	assert.Equal(t, "Bad seller_id data", err.Error())
+
+	//Test 3: Order by date after the release date
+	_, err = UpdatePreOrderDates(db, preOrderId, data.UpdatePreOrderRequestData{SellerId: sellerId, OrderBy: releasesOn, ReleasesOn: orderBy})
+	assert.Error(t, err)
+	assert.Equal(t, "Bad order_by data", err.Error())
+
+	//Test 4: Release date in the past
+	_, err = UpdatePreOrderDates(db, preOrderId, data.UpdatePreOrderRequestData{SellerId: sellerId, OrderBy: orderBy,
+		ReleasesOn: time.Now().Add(-time.Hour).Format(time.RFC3339)})
+	assert.Error(t, err)
+	assert.Equal(t, "Bad releases_on data", err.Error())
+
+	//Test 5: Product is not a pre-order
+	_, err = UpdatePreOrderDates(db, productIds[0], data.UpdatePreOrderRequestData{SellerId: sellerId, OrderBy: orderBy, ReleasesOn: releasesOn})
+	assert.Error(t, err)
+	assert.Equal(t, 404, err.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func TestReleasePreOrders(t *testing.T) {
+	utils.LoadDotEnv("../../.env")
+	db, startupErr := store.SetupTestDB("../../.env")
+	assert.NoError(t, startupErr)
+
+	sellerId, sellerErr := createDummySeller(db)
+	assert.NoError(t, sellerErr)
+	releasedId := createDummyPreOrder(t, db, sellerId, "NOW() - INTERVAL '2 days'", "NOW() - INTERVAL '1 day'")
+	openId := createDummyPreOrder(t, db, sellerId, "NOW() + INTERVAL '1 day'", "NOW() + INTERVAL '2 days'")
+
+	//Test 1: Open pre-order cannot be converted
+	_, err := ConvertPreOrderToBuyNow(db, openId, data.ConvertPreOrderRequestData{SellerId: sellerId})
+	assert.Error(t, err)
+	assert.Equal(t, "Only released pre-orders can be converted to Buy-Now", err.Error())
+	assert.Equal(t, false, IsPreOrderClosed(db, openId))
+
+	//Test 2: Only pre-orders past their release date are released, and only once, with a mail to each buyer in the outbox
+	var guestOrderId string
+	query := `INSERT INTO guest_orders(email, delivery_type, delivery_fee, payment_type, payment_fee, small_order_fee, total_paid,
+			phone_number, order_date, address_line_1, postal_code, payment_status)
+		VALUES ('guest@aucto.io', 'self_collection', 0, 'paynow_online', 0, 0, 10000, '91234567', NOW(), 'Test', '123456', 'completed')
+		RETURNING guest_order_id;`
+	assert.NoError(t, db.QueryRowContext(context.Background(), query).Scan(&guestOrderId))
+	_, dbErr := db.ExecContext(context.Background(),
+		`INSERT INTO guest_order_products(product_id, guest_order_id, quantity) VALUES ($1, $2, 1);`, releasedId, guestOrderId)
+	assert.NoError(t, dbErr)
+
+	res, err := ReleasePreOrders(db)
+	assert.Empty(t, err)
+	assert.Equal(t, []string{releasedId}, res.Released)
+	res, err = ReleasePreOrders(db)
+	assert.Empty(t, err)
+	assert.Equal(t, 0, len(res.Released))
+	assert.Equal(t, true, IsPreOrderClosed(db, releasedId))
+
+	var mails int
+	query = `SELECT COUNT(*) FROM outbox_messages WHERE topic = 'mail' AND payload->>'ToEmail' = 'guest@aucto.io';`
+	assert.NoError(t, db.QueryRowContext(context.Background(), query).Scan(&mails))
+	assert.Equal(t, 1, mails)
+
+	//Test 3: Released pre-order is converted to Buy-Now and can be ordered again
+	preOrder, err := ConvertPreOrderToBuyNow(db, releasedId, data.ConvertPreOrderRequestData{SellerId: sellerId})
+	assert.Empty(t, err)
+	assert.Equal(t, "converted", preOrder.Status)
+	assert.Equal(t, "Buy-Now", preOrder.ProductType)
+	assert.Equal(t, false, IsPreOrderClosed(db, releasedId))
+
+	store.CloseDB(db)
+}
+
+func createDummyPreOrder(t *testing.T, db *sql.DB, sellerId string, orderBy string, releasesOn string) string {
+	var productId string
+	query := `INSERT INTO products(
+		title, seller_id, description, product_type, language, expansion, posted_date, price, condition, product_quantity) 
+		VALUES ('Test', $1, 'This is a test description', 'Pre-Order', 'Eng', 'Test', NOW(), 10000, 5, 3) RETURNING product_id;`
+	err := db.QueryRowContext(context.Background(), query, sellerId).Scan(&productId)
+	assert.NoError(t, err)
+
+	query = `INSERT INTO preorder_information(product_id, order_by, releases_on) VALUES ($1, ` + orderBy + `, ` + releasesOn + `);`
+	_, err = db.ExecContext(context.Background(), query, productId)
+	assert.NoError(t, err)
+
+	return productId
+}
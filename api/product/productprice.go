@@ -0,0 +1,152 @@
+package product
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+// Number of days before a price takes effect that are looked at for the was price of a product
+const wasPriceDays = 30
+
+/*
+Records the price of every product whose price or effective price has changed since it was last recorded
+and returns the ids of those products. If product ids are given only those products are recorded, otherwise
+every product is. Auctions are not recorded since they are not discounted.
+*/
+func RecordPriceHistory(db *sql.DB, productIds []string) (data.RecordPricesResponseData, *utils.ErrorHandler) {
+	response := data.RecordPricesResponseData{Recorded: []string{}}
+
+	query := `INSERT INTO product_price_history(product_id, price, effective_price, recorded_at)
+		SELECT products.product_id, products.price, products.price - COALESCE(product_effective_discounts.discount, 0), NOW()
+		FROM (products LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id)
+			LEFT OUTER JOIN LATERAL (
+				SELECT price, effective_price FROM product_price_history
+				WHERE product_price_history.product_id = products.product_id
+				ORDER BY recorded_at DESC LIMIT 1) AS latest ON TRUE
+		WHERE products.product_type <> 'Auction' AND ($1::uuid[] IS NULL OR products.product_id = ANY($1::uuid[]))
+			AND (latest.price IS NULL OR latest.price <> products.price
+				OR latest.effective_price <> products.price - COALESCE(product_effective_discounts.discount, 0))
+		RETURNING product_id;`
+	rows, err := db.QueryContext(context.Background(), query, pq.Array(productIds))
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Product Price History rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var productId string
+		err = rows.Scan(&productId)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in inserting Product Price History rows")
+			return response, errResp
+		}
+
+		response.Recorded = append(response.Recorded, productId)
+	}
+
+	return response, nil
+}
+
+/*
+Gets the current price of a product along with its price history. The was price is the lowest price the
+product had in the 30 days before its current price took effect, it is only given when it is higher than
+the current price. If the product does not exist, a 404 Not found error is returned.
+*/
+func GetPriceHistory(db *sql.DB, productId string) (data.GetPriceHistoryResponseData, *utils.ErrorHandler) {
+	response := data.GetPriceHistoryResponseData{ProductId: productId, History: []data.PriceHistoryData{}}
+
+	if !DoesProductExist(db, productId) {
+		return response, utils.NotFoundError("Product with given id does not exist")
+	}
+
+	// A scheduled discount may have started or ended since prices were last recorded
+	_, err := RecordPriceHistory(db, []string{productId})
+
+	if err != nil {
+		return response, err
+	}
+
+	query := `SELECT products.price, products.price - COALESCE(product_effective_discounts.discount, 0)
+		FROM products LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id
+		WHERE products.product_id = $1;`
+	scanErr := db.QueryRowContext(context.Background(), query, productId).Scan(&response.Price, &response.EffectivePrice)
+
+	if scanErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(scanErr, "Error in selecting Product rows")
+		return response, errResp
+	}
+
+	query = `SELECT price, effective_price, recorded_at::TEXT FROM product_price_history
+		WHERE product_id = $1 ORDER BY recorded_at DESC;`
+	rows, queryErr := db.QueryContext(context.Background(), query, productId)
+
+	if queryErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(queryErr, "Error in selecting Product Price History rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var history data.PriceHistoryData
+		queryErr = rows.Scan(&history.Price, &history.EffectivePrice, &history.RecordedAt)
+
+		if queryErr != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(queryErr, "Error in selecting Product Price History rows")
+			return response, errResp
+		}
+
+		response.History = append(response.History, history)
+	}
+
+	wasPrice, err := getWasPrice(db, productId)
+
+	if err != nil {
+		return response, err
+	}
+
+	if wasPrice > response.EffectivePrice {
+		response.WasPrice = wasPrice
+	}
+
+	return response, nil
+}
+
+/*
+Gets the lowest effective price of a product in the 30 days before its latest recorded price took effect,
+including the price that was already in effect at the start of that period. Returns 0 if the product has
+no earlier price.
+*/
+func getWasPrice(db *sql.DB, productId string) (int, *utils.ErrorHandler) {
+	var wasPrice int
+
+	query := `WITH latest AS (
+			SELECT MAX(recorded_at) AS recorded_at FROM product_price_history WHERE product_id = $1)
+		SELECT COALESCE(MIN(effective_price), 0) FROM product_price_history, latest
+		WHERE product_price_history.product_id = $1 AND product_price_history.recorded_at < latest.recorded_at
+			AND product_price_history.recorded_at >= (
+				SELECT COALESCE(MAX(earlier.recorded_at), '-infinity'::timestamptz) FROM product_price_history AS earlier
+				WHERE earlier.product_id = $1 AND earlier.recorded_at <= latest.recorded_at - make_interval(days => $2));`
+	err := db.QueryRowContext(context.Background(), query, productId, wasPriceDays).Scan(&wasPrice)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Product Price History rows")
+		return 0, errResp
+	}
+
+	return wasPrice, nil
+}
@@ -0,0 +1,112 @@
+package product
+
+import (
+	"BackendAPI/store"
+	"BackendAPI/utils"
+	"context"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordPriceHistory(t *testing.T) {
+	utils.LoadDotEnv("../../.env")
+	db, startupErr := store.SetupTestDB("../../.env")
+	assert.NoError(t, startupErr)
+
+	sellerId, sellerErr := createDummySeller(db)
+	assert.NoError(t, sellerErr)
+	productIds, productErr := createDummyProducts(db, sellerId)
+	assert.NoError(t, productErr)
+
+	//Test 1: Every product is recorded the first time
+	res, err := RecordPriceHistory(db, nil)
+	assert.Empty(t, err)
+	assert.ElementsMatch(t, productIds, res.Recorded)
+
+	//Test 2: Unchanged prices are not recorded again
+	res, err = RecordPriceHistory(db, nil)
+	assert.Empty(t, err)
+	assert.Equal(t, []string{}, res.Recorded)
+
+	//Test 3: A running sale changes the effective price of the product
+	query := `INSERT INTO discounts(seller_id, product_id, discount_type, amount, starts_at, created_date)
+		VALUES ($1, $2, 'percentage', 50, NOW() - INTERVAL '1 hour', NOW());`
+	_, execErr := db.ExecContext(context.Background(), query, sellerId, productIds[0])
+	assert.NoError(t, execErr)
+
+	res, err = RecordPriceHistory(db, nil)
+	assert.Empty(t, err)
+	assert.Equal(t, []string{productIds[0]}, res.Recorded)
+
+	//Test 4: A storewide sale only applies when it is larger than the listing discount
+	query = `INSERT INTO discounts(seller_id, discount_type, amount, starts_at, created_date)
+		VALUES ($1, 'fixed', 500, NOW() - INTERVAL '1 hour', NOW());`
+	_, execErr = db.ExecContext(context.Background(), query, sellerId)
+	assert.NoError(t, execErr)
+
+	res, err = RecordPriceHistory(db, nil)
+	assert.Empty(t, err)
+	assert.ElementsMatch(t, []string{productIds[1], productIds[2], productIds[3]}, res.Recorded)
+
+	store.CloseDB(db)
+}
+
+func TestGetPriceHistory(t *testing.T) {
+	utils.LoadDotEnv("../../.env")
+	db, startupErr := store.SetupTestDB("../../.env")
+	assert.NoError(t, startupErr)
+
+	sellerId, sellerErr := createDummySeller(db)
+	assert.NoError(t, sellerErr)
+	productIds, productErr := createDummyProducts(db, sellerId)
+	assert.NoError(t, productErr)
+
+	//Test 1: Product without a sale has no was price
+	res, err := GetPriceHistory(db, productIds[0])
+	assert.Empty(t, err)
+	assert.Equal(t, 10000, res.Price)
+	assert.Equal(t, 10000, res.EffectivePrice)
+	assert.Equal(t, 0, res.WasPrice)
+	assert.Equal(t, 1, len(res.History))
+
+	//Test 2: Product on sale shows the price before the sale
+	query := `INSERT INTO discounts(seller_id, product_id, discount_type, amount, starts_at, created_date)
+		VALUES ($1, $2, 'fixed', 2500, NOW() - INTERVAL '1 hour', NOW());`
+	_, execErr := db.ExecContext(context.Background(), query, sellerId, productIds[0])
+	assert.NoError(t, execErr)
+
+	res, err = GetPriceHistory(db, productIds[0])
+	assert.Empty(t, err)
+	assert.Equal(t, 10000, res.Price)
+	assert.Equal(t, 7500, res.EffectivePrice)
+	assert.Equal(t, 10000, res.WasPrice)
+	assert.Equal(t, 2, len(res.History))
+	assert.Equal(t, 7500, res.History[0].EffectivePrice)
+
+	//Test 3: Auctions are never discounted
+	var auctionId string
+	query = `INSERT INTO products(
+		title, seller_id, description, product_type, language, expansion, posted_date, price, condition, product_quantity)
+		VALUES ('Test', $1, 'This is a test description', 'Auction', 'Eng', 'Test', NOW(), 10000, 5, 1) RETURNING product_id;`
+	execErr = db.QueryRowContext(context.Background(), query, sellerId).Scan(&auctionId)
+	assert.NoError(t, execErr)
+
+	query = `INSERT INTO discounts(seller_id, discount_type, amount, starts_at, created_date)
+		VALUES ($1, 'fixed', 500, NOW() - INTERVAL '1 hour', NOW());`
+	_, execErr = db.ExecContext(context.Background(), query, sellerId)
+	assert.NoError(t, execErr)
+
+	res, err = GetPriceHistory(db, auctionId)
+	assert.Empty(t, err)
+	assert.Equal(t, res.Price, res.EffectivePrice)
+	assert.Equal(t, 0, len(res.History))
+
+	//Test 4: Product does not exist
+	_, err = GetPriceHistory(db, sellerId)
+	assert.Error(t, err)
+	assert.Equal(t, 404, err.ErrorCode())
+
+	store.CloseDB(db)
+}
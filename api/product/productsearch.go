@@ -0,0 +1,46 @@
+package product
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/lib/pq"
+)
+
+const maxSearchTerms = 10
+
+/*
+Splits a keyword search into terms made up of only letters and numbers so that they can
+safely be used in a text search query
+*/
+func getSearchTerms(search string) []string {
+	var terms []string
+
+	words := strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	for i := 0; i < len(words) && len(terms) < maxSearchTerms; i++ {
+		terms = append(terms, words[i])
+	}
+
+	return terms
+}
+
+/*
+Creates the text search query for a keyword search. Every term has to match and terms are matched
+as prefixes so partially typed card names still find results. Returns an empty string if the search has no terms.
+*/
+func makeSearchQuery(search string) string {
+	terms := getSearchTerms(search)
+
+	if len(terms) == 0 {
+		return ""
+	}
+
+	for i := 0; i < len(terms); i++ {
+		terms[i] = terms[i] + `:*`
+	}
+
+	return `to_tsquery('english', ` + pq.QuoteLiteral(strings.Join(terms, ` & `)) + `)`
+}
@@ -0,0 +1,43 @@
+package product
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSearchTerms(t *testing.T) {
+	//Test 1: Terms are split on spaces and lower cased
+	terms := getSearchTerms("Charizard VMAX")
+	assert.Equal(t, []string{"charizard", "vmax"}, terms)
+
+	//Test 2: Punctuation is removed
+	terms = getSearchTerms("Pikachu's 025/165 & (promo)")
+	assert.Equal(t, []string{"pikachu", "s", "025", "165", "promo"}, terms)
+
+	//Test 3: Empty search
+	terms = getSearchTerms("  !! ")
+	assert.Empty(t, terms)
+
+	//Test 4: Number of terms is limited
+	terms = getSearchTerms("a b c d e f g h i j k l")
+	assert.Equal(t, maxSearchTerms, len(terms))
+}
+
+func TestMakeSearchQuery(t *testing.T) {
+	//Test 1: Single term is prefix matched
+	query := makeSearchQuery("chari")
+	assert.Equal(t, `to_tsquery('english', 'chari:*')`, query)
+
+	//Test 2: All terms have to match
+	query = makeSearchQuery("charizard vmax")
+	assert.Equal(t, `to_tsquery('english', 'charizard:* & vmax:*')`, query)
+
+	//Test 3: Quotes and tsquery operators cannot be injected
+	query = makeSearchQuery("x') OR 1=1; -- | !y")
+	assert.Equal(t, `to_tsquery('english', 'x:* & or:* & 1:* & 1:* & y:*')`, query)
+
+	//Test 4: No terms
+	query = makeSearchQuery("")
+	assert.Equal(t, "", query)
+}
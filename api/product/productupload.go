@@ -0,0 +1,311 @@
+package product
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+)
+
+const (
+	maxProductImages         = 5
+	maxProductImageSize      = 10 * 1024 * 1024
+	productImageUploadExpiry = 15 * time.Minute
+)
+
+var productImageContentTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/webp": true,
+}
+
+/*
+Creates upload slots for the images of a product. Each slot has a presigned url that the client uses
+to upload the image directly to the image store. Any previous slots that were not completed are cancelled, in the
+same transaction that the new slots are added in.
+*/
+func CreateProductImageUploads(db *sql.DB, imageStore store.ImageStore, productId string,
+	request data.CreateProductImageUploadsRequestData) (data.CreateProductImageUploadsResponseData, *utils.ErrorHandler) {
+	var response data.CreateProductImageUploadsResponseData
+
+	validateErr := validateCreateProductImageUploads(db, productId, request)
+
+	if validateErr != nil {
+		return response, validateErr
+	}
+
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Product Image Upload transaction")
+		return response, errResp
+	}
+
+	defer tx.Rollback()
+
+	// Requests for the same product wait for each other so only the slots of the last request are left pending
+	query := `SELECT product_id FROM products WHERE product_id = $1 FOR UPDATE;`
+	_, err = tx.ExecContext(context.Background(), query, productId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in Selecting Product rows")
+		return response, errResp
+	}
+
+	query = `UPDATE product_image_uploads SET status = 'cancelled' WHERE product_id = $1 AND status = 'pending';`
+	_, err = tx.ExecContext(context.Background(), query, productId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in Updating Product Image Upload rows")
+		return response, errResp
+	}
+
+	expiresAt := time.Now().Add(productImageUploadExpiry)
+
+	for i := 0; i < len(request.Images); i++ {
+		upload := data.ProductImageUploadData{
+			ImageNo:     i + 1,
+			ContentType: request.Images[i].ContentType,
+			Size:        request.Images[i].Size}
+
+		query = `INSERT INTO product_image_uploads(product_id, image_no, content_type, size, expires_at)
+			VALUES ($1,$2,$3,$4,$5) RETURNING upload_id, expires_at::TEXT;`
+		err = tx.QueryRowContext(
+			context.Background(), query,
+			productId, upload.ImageNo, upload.ContentType, upload.Size, expiresAt).Scan(&upload.UploadId, &upload.ExpiresAt)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in Inserting Product Image Upload rows")
+			return response, errResp
+		}
+
+		presigned, err := imageStore.PresignImageUpload(upload.UploadId, upload.ContentType, upload.Size, productImageUploadExpiry)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in presigning product image upload")
+			return response, errResp
+		}
+
+		upload.UploadUrl = presigned.Url
+		upload.Method = presigned.Method
+		upload.Headers = presigned.Headers
+		response.Uploads = append(response.Uploads, upload)
+	}
+
+	// The slots are only saved once every upload url has been presigned
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Product Image Upload transaction")
+		return data.CreateProductImageUploadsResponseData{}, errResp
+	}
+
+	response.ProductId = productId
+	return response, nil
+}
+
+/*
+Completes the direct upload of product images. Each uploaded image is checked against the image store
+to make sure it was uploaded with the content type and size of its slot before the product images are recorded.
+*/
+func CompleteProductImageUploads(db *sql.DB, imageStore store.ImageStore, productId string,
+	request data.CompleteProductImageUploadsRequestData) (data.CreateProductImageData, *utils.ErrorHandler) {
+	var response data.CreateProductImageData
+
+	validateErr := validateCompleteProductImageUploads(db, productId, request)
+
+	if validateErr != nil {
+		return response, validateErr
+	}
+
+	pendingUploads, selectErr := getPendingProductImageUploads(db, productId)
+
+	if selectErr != nil {
+		return response, selectErr
+	}
+
+	var uploads []data.ProductImageUploadData
+	for i := 0; i < len(request.UploadIds); i++ {
+		upload, exists := pendingUploads[request.UploadIds[i]]
+
+		if !exists {
+			return response, utils.BadRequestError("Upload with given id does not exist or has expired")
+		}
+
+		info, err := imageStore.StatImage(upload.UploadId)
+
+		if err != nil {
+			utils.LogError(err, "Error in fetching uploaded product image")
+			return response, utils.BadRequestError("Image has not been uploaded for upload " + upload.UploadId)
+		}
+
+		if info.Size != upload.Size || info.ContentType != upload.ContentType {
+			utils.LogMessage("Uploaded image does not match the upload content type or size")
+			return response, utils.BadRequestError("Uploaded image does not match upload " + upload.UploadId)
+		}
+
+		uploads = append(uploads, upload)
+	}
+
+	// Keep the order the slots were requested in and number the images without gaps
+	sort.Slice(uploads, func(i, j int) bool { return uploads[i].ImageNo < uploads[j].ImageNo })
+
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Product Image transaction")
+		return response, errResp
+	}
+
+	defer tx.Rollback()
+
+	for i := 0; i < len(uploads); i++ {
+		query := `INSERT INTO product_images(product_image_id, product_id, image_no) VALUES ($1,$2,$3);`
+		_, err = tx.ExecContext(context.Background(), query, uploads[i].UploadId, productId, i+1)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in Inserting Product Image Rows")
+			return response, errResp
+		}
+
+		query = `UPDATE product_image_uploads SET status = 'completed' WHERE upload_id = $1;`
+		_, err = tx.ExecContext(context.Background(), query, uploads[i].UploadId)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in Updating Product Image Upload rows")
+			return response, errResp
+		}
+
+		response.Images = append(response.Images, uploads[i].UploadId)
+	}
+
+	query := `UPDATE products SET image_count = $1 WHERE product_id = $2;`
+	_, err = tx.ExecContext(context.Background(), query, len(uploads), productId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in Updating Product rows")
+		return response, errResp
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Product Image transaction")
+		return response, errResp
+	}
+
+	response.ProductId = productId
+	return response, nil
+}
+
+/*
+Gets the pending upload slots of a product that have not expired, keyed by their upload id
+*/
+func getPendingProductImageUploads(db *sql.DB, productId string) (map[string]data.ProductImageUploadData, *utils.ErrorHandler) {
+	uploads := make(map[string]data.ProductImageUploadData)
+
+	query := `SELECT upload_id::TEXT, image_no, content_type, size, expires_at::TEXT
+		FROM product_image_uploads
+		WHERE product_id = $1 AND status = 'pending' AND expires_at > NOW();`
+
+	rows, err := db.QueryContext(context.Background(), query, productId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in Selecting Product Image Upload rows")
+		return uploads, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var upload data.ProductImageUploadData
+		err = rows.Scan(&upload.UploadId, &upload.ImageNo, &upload.ContentType, &upload.Size, &upload.ExpiresAt)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in Selecting Product Image Upload rows")
+			return uploads, errResp
+		}
+
+		uploads[upload.UploadId] = upload
+	}
+
+	return uploads, nil
+}
+
+/*
+Validates a request for product image upload slots
+*/
+func validateCreateProductImageUploads(db *sql.DB, productId string, request data.CreateProductImageUploadsRequestData) *utils.ErrorHandler {
+	if !DoesProductExist(db, productId) {
+		return utils.BadRequestError("Product with given id does not exist")
+	}
+
+	if doProductImagesExist(db, productId) {
+		return utils.BadRequestError("Product with given id already has images")
+	}
+
+	if len(request.Images) > maxProductImages {
+		return utils.BadRequestError("Too many images uploaded, at most 5 images per post")
+	}
+
+	if len(request.Images) == 0 {
+		return utils.BadRequestError("No images attached, at least 1 image per post")
+	}
+
+	for i := 0; i < len(request.Images); i++ {
+		if !productImageContentTypes[request.Images[i].ContentType] {
+			utils.LogMessage("Product image content type is not supported")
+			return utils.BadRequestError("Bad content_type data")
+		}
+
+		if request.Images[i].Size <= 0 || request.Images[i].Size > maxProductImageSize {
+			utils.LogMessage("Product image size is not within the upload limits")
+			return utils.BadRequestError("Bad size data")
+		}
+	}
+
+	return nil
+}
+
+/*
+Validates a request to complete product image uploads
+*/
+func validateCompleteProductImageUploads(db *sql.DB, productId string, request data.CompleteProductImageUploadsRequestData) *utils.ErrorHandler {
+	if !DoesProductExist(db, productId) {
+		return utils.BadRequestError("Product with given id does not exist")
+	}
+
+	if doProductImagesExist(db, productId) {
+		return utils.BadRequestError("Product with given id already has images")
+	}
+
+	if len(request.UploadIds) == 0 {
+		return utils.BadRequestError("No images attached, at least 1 image per post")
+	}
+
+	seen := make(map[string]bool)
+	for i := 0; i < len(request.UploadIds); i++ {
+		if seen[request.UploadIds[i]] {
+			return utils.BadRequestError("Bad upload_ids data")
+		}
+		seen[request.UploadIds[i]] = true
+	}
+
+	return nil
+}
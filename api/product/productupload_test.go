@@ -0,0 +1,131 @@
+package product
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"BackendAPI/utils"
+	"bytes"
+	"net/url"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCreateProductImageUploads(t *testing.T) {
+	utils.LoadDotEnv("../../.env")
+	db, startupErr := store.SetupTestDB("../../.env")
+	assert.NoError(t, startupErr)
+
+	sellerid, sellerErr := createDummySeller(db)
+	assert.NoError(t, sellerErr)
+	productIds, productErr := createDummyProducts(db, sellerid)
+	assert.NoError(t, productErr)
+
+	//Test 1: Valid upload request
+	req := data.CreateProductImageUploadsRequestData{Images: []data.ProductImageUploadRequestData{{ContentType: "image/png", Size: 100}}}
+	err := validateCreateProductImageUploads(db, productIds[0], req)
+	assert.Empty(t, err)
+
+	//Test 2: Product does not exist
+	err = validateCreateProductImageUploads(db, "wrong id", req)
+	assert.Error(t, err)
+	assert.Equal(t, "Product with given id does not exist", err.Error())
+
+	//Test 3: Unsupported content type
+	req = data.CreateProductImageUploadsRequestData{Images: []data.ProductImageUploadRequestData{{ContentType: "text/plain", Size: 100}}}
+	err = validateCreateProductImageUploads(db, productIds[0], req)
+	assert.Error(t, err)
+	assert.Equal(t, "Bad content_type data", err.Error())
+	assert.Equal(t, 400, err.ErrorCode())
+
+	//Test 4: Image too large
+	req = data.CreateProductImageUploadsRequestData{Images: []data.ProductImageUploadRequestData{{ContentType: "image/png", Size: maxProductImageSize + 1}}}
+	err = validateCreateProductImageUploads(db, productIds[0], req)
+	assert.Error(t, err)
+	assert.Equal(t, "Bad size data", err.Error())
+
+	//Test 5: No images
+	req = data.CreateProductImageUploadsRequestData{}
+	err = validateCreateProductImageUploads(db, productIds[0], req)
+	assert.Error(t, err)
+	assert.Equal(t, "No images attached, at least 1 image per post", err.Error())
+
+	//Test 6: Product already has images
+	createDummyProductImages(db, []string{productIds[1]})
+	req = data.CreateProductImageUploadsRequestData{Images: []data.ProductImageUploadRequestData{{ContentType: "image/png", Size: 100}}}
+	err = validateCreateProductImageUploads(db, productIds[1], req)
+	assert.Error(t, err)
+	assert.Equal(t, "Product with given id already has images", err.Error())
+
+	store.CloseDB(db)
+}
+
+func TestCompleteProductImageUploads(t *testing.T) {
+	utils.LoadDotEnv("../../.env")
+	db, startupErr := store.SetupTestDB("../../.env")
+	assert.NoError(t, startupErr)
+	imageStore, storeErr := store.NewLocalImageStore(t.TempDir(), "http://localhost:8080", "secret")
+	assert.NoError(t, storeErr)
+
+	sellerid, sellerErr := createDummySeller(db)
+	assert.NoError(t, sellerErr)
+	productIds, productErr := createDummyProducts(db, sellerid)
+	assert.NoError(t, productErr)
+
+	image1 := []byte("first image")
+	image2 := []byte("second image")
+	req := data.CreateProductImageUploadsRequestData{Images: []data.ProductImageUploadRequestData{
+		{ContentType: "image/png", Size: int64(len(image1))},
+		{ContentType: "image/jpeg", Size: int64(len(image2))}}}
+
+	//Test 1: Upload slots created
+	uploads, err := CreateProductImageUploads(db, imageStore, productIds[0], req)
+	assert.Empty(t, err)
+	assert.Equal(t, productIds[0], uploads.ProductId)
+	assert.Equal(t, 2, len(uploads.Uploads))
+	assert.Equal(t, 1, uploads.Uploads[0].ImageNo)
+	assert.Equal(t, "image/jpeg", uploads.Uploads[1].Headers["Content-Type"])
+
+	uploadIds := []string{uploads.Uploads[0].UploadId, uploads.Uploads[1].UploadId}
+
+	//Test 2: Completing before the images are uploaded fails
+	res, err := CompleteProductImageUploads(db, imageStore, productIds[0], data.CompleteProductImageUploadsRequestData{UploadIds: uploadIds})
+	assert.Error(t, err)
+	assert.Equal(t, 400, err.ErrorCode())
+
+	//Test 3: Unknown upload id
+	res, err = CompleteProductImageUploads(db, imageStore, productIds[0], data.CompleteProductImageUploadsRequestData{UploadIds: []string{productIds[1]}})
+	assert.Error(t, err)
+	assert.Equal(t, "Upload with given id does not exist or has expired", err.Error())
+
+	//Test 4: Completing after the images are uploaded records the images
+	for i, image := range [][]byte{image1, image2} {
+		uploadUrl, _ := url.Parse(uploads.Uploads[i].UploadUrl)
+		saveErr := imageStore.SaveImage(uploads.Uploads[i].UploadId, uploadUrl.Query(), uploads.Uploads[i].ContentType, bytes.NewReader(image))
+		assert.NoError(t, saveErr)
+	}
+
+	res, err = CompleteProductImageUploads(db, imageStore, productIds[0], data.CompleteProductImageUploadsRequestData{UploadIds: uploadIds})
+	assert.Empty(t, err)
+	assert.Equal(t, uploadIds, res.Images)
+	assert.Equal(t, true, doProductImagesExist(db, productIds[0]))
+
+	//Test 5: Completed uploads cannot be completed again
+	res, err = CompleteProductImageUploads(db, imageStore, productIds[0], data.CompleteProductImageUploadsRequestData{UploadIds: uploadIds})
+	assert.Error(t, err)
+	assert.Equal(t, "Product with given id already has images", err.Error())
+
+	//Test 6: Requesting new slots cancels the previous slots
+	req = data.CreateProductImageUploadsRequestData{Images: []data.ProductImageUploadRequestData{{ContentType: "image/png", Size: int64(len(image1))}}}
+	oldUploads, err := CreateProductImageUploads(db, imageStore, productIds[2], req)
+	assert.Empty(t, err)
+	_, err = CreateProductImageUploads(db, imageStore, productIds[2], req)
+	assert.Empty(t, err)
+	res, err = CompleteProductImageUploads(db, imageStore, productIds[2],
+		data.CompleteProductImageUploadsRequestData{UploadIds: []string{oldUploads.Uploads[0].UploadId}})
+	assert.Error(t, err)
+	assert.Equal(t, "Upload with given id does not exist or has expired", err.Error())
+
+	store.CloseDB(db)
+}
@@ -0,0 +1,595 @@
+package promo
+
+import (
+	"BackendAPI/api/catalogue"
+	"BackendAPI/api/seller"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const selectPromoCodeQuery = `SELECT promo_code_id, code, COALESCE(seller_id::TEXT, ''), discount_type, amount, min_spend,
+		COALESCE(expires_at::TEXT, ''), COALESCE(max_uses, 0), COALESCE(max_uses_per_buyer, 0), first_order_only,
+		ARRAY(SELECT seller_id::TEXT FROM promo_code_sellers WHERE promo_code_sellers.promo_code_id = promo_codes.promo_code_id),
+		ARRAY(SELECT expansion FROM promo_code_expansions WHERE promo_code_expansions.promo_code_id = promo_codes.promo_code_id),
+		used_count, CASE WHEN status = 'active' AND expires_at <= NOW() THEN 'expired' ELSE status END, created_date::TEXT
+	FROM promo_codes`
+
+// Time an order has to be paid in before the use of its promo code is released
+const redemptionReservationTimeout = time.Hour
+
+var promoCodePattern = regexp.MustCompile(`^[A-Z0-9]{3,20}$`)
+
+/*
+Creates a promo code. Codes created by a seller only apply to the products of that seller, codes created by the
+platform, with an empty seller id, can be limited to specific sellers. Either kind can be limited to specific
+expansions. If there is an issue with the inputed data, it returns a 400 bad request.
+*/
+func CreatePromoCode(db *sql.DB, sellerId string, request data.CreatePromoCodeRequestData) (data.PromoCodeData, *utils.ErrorHandler) {
+	var promoCode data.PromoCodeData
+
+	if sellerId != "" && !seller.DoesSellerExist(db, sellerId) {
+		return promoCode, utils.NotFoundError("Seller with given id does not exist")
+	}
+
+	request.Code = strings.ToUpper(strings.TrimSpace(request.Code))
+	expiresAt, err := validateCreatePromoCode(db, sellerId, request)
+
+	if err != nil {
+		return promoCode, err
+	}
+
+	tx, txErr := db.BeginTx(context.Background(), nil)
+
+	if txErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(txErr, "Error in starting Promo Code transaction")
+		return promoCode, errResp
+	}
+
+	defer tx.Rollback()
+
+	var promoCodeId string
+	query := `INSERT INTO promo_codes(code, seller_id, discount_type, amount, min_spend, expires_at, max_uses,
+			max_uses_per_buyer, first_order_only, created_date)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, 0), NULLIF($8, 0), $9, NOW())
+		ON CONFLICT (code) DO NOTHING RETURNING promo_code_id;`
+	scanErr := tx.QueryRowContext(context.Background(), query, request.Code, utils.NewNullableString(sellerId),
+		request.DiscountType, request.Amount, request.MinSpend, expiresAt, request.MaxUses, request.MaxUsesPerBuyer,
+		request.FirstOrderOnly).Scan(&promoCodeId)
+
+	if scanErr == sql.ErrNoRows {
+		return promoCode, utils.BadRequestError("Promo code already exists")
+	}
+
+	if scanErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(scanErr, "Error in inserting Promo Code rows")
+		return promoCode, errResp
+	}
+
+	for i := 0; i < len(request.SellerIds); i++ {
+		query = `INSERT INTO promo_code_sellers(promo_code_id, seller_id) VALUES ($1, $2) ON CONFLICT DO NOTHING;`
+		_, execErr := tx.ExecContext(context.Background(), query, promoCodeId, request.SellerIds[i])
+
+		if execErr != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(execErr, "Error in inserting Promo Code Seller rows")
+			return promoCode, errResp
+		}
+	}
+
+	for i := 0; i < len(request.Expansions); i++ {
+		query = `INSERT INTO promo_code_expansions(promo_code_id, expansion) VALUES ($1, $2) ON CONFLICT DO NOTHING;`
+		_, execErr := tx.ExecContext(context.Background(), query, promoCodeId, request.Expansions[i])
+
+		if execErr != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(execErr, "Error in inserting Promo Code Expansion rows")
+			return promoCode, errResp
+		}
+	}
+
+	commitErr := tx.Commit()
+
+	if commitErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(commitErr, "Error in committing Promo Code transaction")
+		return promoCode, errResp
+	}
+
+	return GetPromoCode(db, request.Code)
+}
+
+/*
+Gets a promo code by its code, codes are not case sensitive. If the code does not exist, a 404 Not found error is returned
+*/
+func GetPromoCode(db *sql.DB, code string) (data.PromoCodeData, *utils.ErrorHandler) {
+	var promoCode data.PromoCodeData
+
+	query := selectPromoCodeQuery + ` WHERE code = $1;`
+	err := scanPromoCode(db.QueryRowContext(context.Background(), query, strings.ToUpper(strings.TrimSpace(code))), &promoCode)
+
+	if err == sql.ErrNoRows {
+		return promoCode, utils.NotFoundError("Promo code does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Promo Code rows")
+		return promoCode, errResp
+	}
+
+	return promoCode, nil
+}
+
+/*
+Gets the promo codes created by a seller, from the newest code
+*/
+func GetSellerPromoCodes(db *sql.DB, sellerId string) (data.GetPromoCodesResponseData, *utils.ErrorHandler) {
+	response := data.GetPromoCodesResponseData{PromoCodes: []data.PromoCodeData{}}
+
+	if !seller.DoesSellerExist(db, sellerId) {
+		return response, utils.NotFoundError("Seller with given id does not exist")
+	}
+
+	query := selectPromoCodeQuery + ` WHERE seller_id = $1 ORDER BY created_date DESC;`
+	rows, err := db.QueryContext(context.Background(), query, sellerId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Promo Code rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var promoCode data.PromoCodeData
+		err = scanPromoCode(rows, &promoCode)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Promo Code rows")
+			return response, errResp
+		}
+
+		response.PromoCodes = append(response.PromoCodes, promoCode)
+	}
+
+	return response, nil
+}
+
+/*
+Gets the promo code used at checkout by a buyer, or by a guest when the buyer id is empty. Returns a 400 bad
+request if the code does not exist, is no longer active or is for first orders only and the buyer has ordered
+before. Usage limits are checked when the code is reserved.
+*/
+func GetCheckoutPromoCode(db *sql.DB, code string, buyerId string, email string) (data.PromoCodeData, *utils.ErrorHandler) {
+	promoCode, err := GetPromoCode(db, code)
+
+	if err != nil || promoCode.Status != "active" {
+		utils.LogMessage("Promo code does not exist or is no longer active")
+		return promoCode, utils.BadRequestError("Bad promo_code data")
+	}
+
+	if !promoCode.FirstOrderOnly {
+		return promoCode, nil
+	}
+
+	email, err = getBuyerEmail(db, buyerId, email)
+
+	if err != nil {
+		return promoCode, err
+	}
+
+	var hasOrdered bool
+	query := `SELECT EXISTS(
+			SELECT * FROM orders INNER JOIN buyers ON buyers.buyer_id = orders.buyer_id
+			WHERE orders.payment_status = 'completed' AND LOWER(buyers.email) = LOWER($1))
+		OR EXISTS(
+			SELECT * FROM guest_orders WHERE payment_status = 'completed' AND LOWER(email) = LOWER($1));`
+	scanErr := db.QueryRowContext(context.Background(), query, email).Scan(&hasOrdered)
+
+	if scanErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(scanErr, "Error in selecting Order rows")
+		return promoCode, errResp
+	}
+
+	if hasOrdered {
+		return promoCode, utils.BadRequestError("Promo code is only valid on a first order")
+	}
+
+	return promoCode, nil
+}
+
+/*
+Calculates the discount of a promo code on the items of an order. Only the items of the sellers and expansions
+the code is limited to count towards the minimum spend and are discounted. Returns a 400 bad request if no item
+is eligible or the eligible items do not reach the minimum spend.
+*/
+func CalculatePromoDiscount(promoCode data.PromoCodeData, items []data.PromoOrderItemData) (int, *utils.ErrorHandler) {
+	var eligibleCost int
+
+	for i := 0; i < len(items); i++ {
+		if isPromoItemEligible(promoCode, items[i]) {
+			eligibleCost += items[i].Cost
+		}
+	}
+
+	if eligibleCost == 0 {
+		utils.LogMessage("Promo code does not apply to any of the ordered products")
+		return 0, utils.BadRequestError("Promo code does not apply to the products ordered")
+	}
+
+	if eligibleCost < promoCode.MinSpend {
+		utils.LogMessage("Order does not reach the minimum spend of the promo code")
+		return 0, utils.BadRequestError("Order does not reach the minimum spend of the promo code")
+	}
+
+	if promoCode.DiscountType == "percentage" {
+		return eligibleCost * promoCode.Amount / 100, nil
+	}
+
+	if promoCode.Amount > eligibleCost {
+		return eligibleCost, nil
+	}
+
+	return promoCode.Amount, nil
+}
+
+/*
+Splits the discount of a promo code over the items of an order in proportion to their cost, so that each seller
+of the order carries the share of the discount taken off their products. Items the code does not apply to get
+no share and any rounding remainder goes to the last eligible item.
+*/
+func AllocatePromoDiscount(promoCode data.PromoCodeData, items []data.PromoOrderItemData, discount int) []int {
+	shares := make([]int, len(items))
+	var eligibleCost, allocated, lastEligible int
+	lastEligible = -1
+
+	for i := 0; i < len(items); i++ {
+		if isPromoItemEligible(promoCode, items[i]) {
+			eligibleCost += items[i].Cost
+			lastEligible = i
+		}
+	}
+
+	if eligibleCost == 0 {
+		return shares
+	}
+
+	for i := 0; i < len(items); i++ {
+		if isPromoItemEligible(promoCode, items[i]) {
+			shares[i] = discount * items[i].Cost / eligibleCost
+			allocated += shares[i]
+		}
+	}
+
+	shares[lastEligible] += discount - allocated
+	return shares
+}
+
+/*
+Reserves a use of a promo code for an order or guest order as part of the transaction that creates the order, and
+returns the id of the redemption. The promo code is locked while its usage is counted, so concurrent checkouts cannot
+use the code more often than its usage limits allow, and the reservation is rolled back with the order if the order
+cannot be created. Returns a 400 bad request if the code or the buyer has reached its usage limit.
+*/
+func ReservePromoCode(tx *sql.Tx, promoCodeId string, buyerId string, email string, discount int, orderId string,
+	isGuest bool) (string, *utils.ErrorHandler) {
+	var redemptionId string
+
+	email, err := getBuyerEmail(tx, buyerId, email)
+
+	if err != nil {
+		return redemptionId, err
+	}
+
+	var maxUses, maxUsesPerBuyer, usedCount, buyerUses int
+	var isActive bool
+	query := `SELECT COALESCE(max_uses, 0), COALESCE(max_uses_per_buyer, 0), used_count,
+			status = 'active' AND (expires_at IS NULL OR expires_at > NOW())
+		FROM promo_codes WHERE promo_code_id = $1 FOR UPDATE;`
+	scanErr := tx.QueryRowContext(context.Background(), query, promoCodeId).Scan(&maxUses, &maxUsesPerBuyer, &usedCount, &isActive)
+
+	if scanErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(scanErr, "Error in selecting Promo Code rows")
+		return redemptionId, errResp
+	}
+
+	if !isActive {
+		return redemptionId, utils.BadRequestError("Bad promo_code data")
+	}
+
+	if maxUses > 0 && usedCount >= maxUses {
+		utils.LogMessage("Promo code has reached its usage limit")
+		return redemptionId, utils.BadRequestError("Promo code has reached its usage limit")
+	}
+
+	query = `SELECT COUNT(*) FROM promo_code_redemptions
+		WHERE promo_code_id = $1 AND status <> 'released' AND (buyer_id::TEXT = $2 OR LOWER(email) = LOWER($3));`
+	scanErr = tx.QueryRowContext(context.Background(), query, promoCodeId, buyerId, email).Scan(&buyerUses)
+
+	if scanErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(scanErr, "Error in selecting Promo Code Redemption rows")
+		return redemptionId, errResp
+	}
+
+	if maxUsesPerBuyer > 0 && buyerUses >= maxUsesPerBuyer {
+		utils.LogMessage("Buyer has reached the usage limit of the promo code")
+		return redemptionId, utils.BadRequestError("Promo code has reached its usage limit")
+	}
+
+	query = `INSERT INTO promo_code_redemptions(promo_code_id, buyer_id, email, discount, order_id, created_date)
+		VALUES ($1, $2, $3, $4, $5, NOW()) RETURNING redemption_id;`
+	if isGuest {
+		query = `INSERT INTO promo_code_redemptions(promo_code_id, buyer_id, email, discount, guest_order_id, created_date)
+		VALUES ($1, $2, $3, $4, $5, NOW()) RETURNING redemption_id;`
+	}
+
+	scanErr = tx.QueryRowContext(context.Background(), query, promoCodeId, utils.NewNullableString(buyerId), email,
+		discount, utils.NewNullableString(orderId)).Scan(&redemptionId)
+
+	if scanErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(scanErr, "Error in inserting Promo Code Redemption rows")
+		return redemptionId, errResp
+	}
+
+	query = `UPDATE promo_codes SET used_count = used_count + 1 WHERE promo_code_id = $1;`
+	_, execErr := tx.ExecContext(context.Background(), query, promoCodeId)
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in updating Promo Code rows")
+		return redemptionId, errResp
+	}
+
+	return redemptionId, nil
+}
+
+/*
+Updates the redemption of an order once its payment status is known. The redemption of a completed payment is
+redeemed, while the redemption of a failed payment is released so that it no longer counts towards the usage limits.
+*/
+func UpdateRedemptionStatus(db *sql.DB, orderId string, isGuest bool, paymentStatus string) *utils.ErrorHandler {
+	orderColumn := `order_id`
+	if isGuest {
+		orderColumn = `guest_order_id`
+	}
+
+	//a redemption released because its payment took too long counts towards the usage limits again once it is paid
+	if paymentStatus == "completed" {
+		query := `WITH redeemed AS (
+				UPDATE promo_code_redemptions SET status = 'redeemed'
+				WHERE ` + orderColumn + ` = $1 AND status = 'released' RETURNING promo_code_id)
+			UPDATE promo_codes SET used_count = used_count + 1
+			FROM redeemed WHERE promo_codes.promo_code_id = redeemed.promo_code_id;`
+		_, err := db.ExecContext(context.Background(), query, orderId)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in updating Promo Code Redemption rows")
+			return errResp
+		}
+
+		query = `UPDATE promo_code_redemptions SET status = 'redeemed' WHERE ` + orderColumn + ` = $1 AND status = 'reserved';`
+		_, err = db.ExecContext(context.Background(), query, orderId)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in updating Promo Code Redemption rows")
+			return errResp
+		}
+
+		return nil
+	}
+
+	if paymentStatus != "failed" {
+		return nil
+	}
+
+	query := `WITH released AS (
+			UPDATE promo_code_redemptions SET status = 'released'
+			WHERE ` + orderColumn + ` = $1 AND status = 'reserved' RETURNING promo_code_id)
+		UPDATE promo_codes SET used_count = used_count - 1
+		FROM released WHERE promo_codes.promo_code_id = released.promo_code_id;`
+	_, err := db.ExecContext(context.Background(), query, orderId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in releasing Promo Code Redemption rows")
+		return errResp
+	}
+
+	return nil
+}
+
+/*
+Releases the reserved redemptions of orders that are still waiting on payment after the reservation timeout, such as
+orders whose buyer left the payment page, so that they no longer count towards the usage limits of their promo code
+*/
+func ReleaseUnpaidRedemptions(db *sql.DB) (data.ReleaseRedemptionsResponseData, *utils.ErrorHandler) {
+	response := data.ReleaseRedemptionsResponseData{Released: []string{}}
+
+	query := `WITH released AS (
+			UPDATE promo_code_redemptions SET status = 'released'
+			WHERE status = 'reserved' AND created_date <= NOW() - make_interval(mins => $1)
+				AND NOT EXISTS(SELECT 1 FROM orders WHERE orders.order_id = promo_code_redemptions.order_id
+					AND orders.payment_status <> 'pending')
+				AND NOT EXISTS(SELECT 1 FROM guest_orders WHERE guest_orders.guest_order_id = promo_code_redemptions.guest_order_id
+					AND guest_orders.payment_status <> 'pending')
+			RETURNING redemption_id, promo_code_id),
+		released_codes AS (
+			UPDATE promo_codes SET used_count = used_count - counts.released_count
+			FROM (SELECT promo_code_id, COUNT(*) AS released_count FROM released GROUP BY promo_code_id) AS counts
+			WHERE promo_codes.promo_code_id = counts.promo_code_id)
+		SELECT redemption_id FROM released;`
+	rows, err := db.QueryContext(context.Background(), query, int(redemptionReservationTimeout/time.Minute))
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in releasing Promo Code Redemption rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var redemptionId string
+		err = rows.Scan(&redemptionId)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in releasing Promo Code Redemption rows")
+			return response, errResp
+		}
+
+		response.Released = append(response.Released, redemptionId)
+	}
+
+	return response, nil
+}
+
+/*
+Scans a row selected with the promo code select query
+*/
+func scanPromoCode(row interface{ Scan(...interface{}) error }, promoCode *data.PromoCodeData) error {
+	err := row.Scan(&promoCode.PromoCodeId, &promoCode.Code, &promoCode.SellerId, &promoCode.DiscountType, &promoCode.Amount,
+		&promoCode.MinSpend, &promoCode.ExpiresAt, &promoCode.MaxUses, &promoCode.MaxUsesPerBuyer, &promoCode.FirstOrderOnly,
+		pq.Array(&promoCode.SellerIds), pq.Array(&promoCode.Expansions), &promoCode.UsedCount, &promoCode.Status,
+		&promoCode.CreatedDate)
+
+	if promoCode.SellerIds == nil {
+		promoCode.SellerIds = []string{}
+	}
+	if promoCode.Expansions == nil {
+		promoCode.Expansions = []string{}
+	}
+
+	return err
+}
+
+/*
+Gets the email that identifies a buyer for the usage limits of promo codes, guests are identified by the
+email of their order
+*/
+func getBuyerEmail(db interface {
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}, buyerId string, email string) (string, *utils.ErrorHandler) {
+	if buyerId == "" {
+		return email, nil
+	}
+
+	query := `SELECT email FROM buyers WHERE buyer_id = $1;`
+	err := db.QueryRowContext(context.Background(), query, buyerId).Scan(&email)
+
+	if err != nil {
+		utils.LogMessage("Buyer with given id does not exist")
+		return email, utils.BadRequestError("Bad buyer_id data")
+	}
+
+	return email, nil
+}
+
+/*
+Validates a promo code and returns its expiry date, which is nil for a code that does not expire
+*/
+func validateCreatePromoCode(db *sql.DB, sellerId string, request data.CreatePromoCodeRequestData) (*time.Time, *utils.ErrorHandler) {
+	if !promoCodePattern.MatchString(request.Code) {
+		utils.LogMessage("Promo code is not 3 to 20 letters and digits")
+		return nil, utils.BadRequestError("Bad code data")
+	}
+
+	if request.DiscountType != "fixed" && request.DiscountType != "percentage" {
+		utils.LogMessage("Promo code discount type is not fixed or percentage")
+		return nil, utils.BadRequestError("Bad discount_type data")
+	}
+
+	if request.Amount <= 0 || (request.DiscountType == "percentage" && request.Amount > 100) {
+		utils.LogMessage("Promo code amount is out of range")
+		return nil, utils.BadRequestError("Bad amount data")
+	}
+
+	if request.MinSpend < 0 {
+		return nil, utils.BadRequestError("Bad min_spend data")
+	}
+
+	if request.MaxUses < 0 || request.MaxUsesPerBuyer < 0 {
+		return nil, utils.BadRequestError("Bad max_uses data")
+	}
+
+	if sellerId != "" && len(request.SellerIds) > 0 {
+		utils.LogMessage("Seller promo codes cannot be limited to other sellers")
+		return nil, utils.BadRequestError("Bad seller_ids data")
+	}
+
+	for i := 0; i < len(request.SellerIds); i++ {
+		if !seller.DoesSellerExist(db, request.SellerIds[i]) {
+			utils.LogMessage("Seller with given id does not exist")
+			return nil, utils.BadRequestError("Bad seller_ids data")
+		}
+	}
+
+	for i := 0; i < len(request.Expansions); i++ {
+		if !catalogue.DoesExpansionExist(db, request.Expansions[i]) {
+			utils.LogMessage("Expansion with given code does not exist")
+			return nil, utils.BadRequestError("Bad expansions data")
+		}
+	}
+
+	if request.ExpiresAt == "" {
+		return nil, nil
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, request.ExpiresAt)
+
+	if err != nil || !expiresAt.After(time.Now()) {
+		utils.LogMessage("Promo code expiry date is invalid or in the past")
+		return nil, utils.BadRequestError("Bad expires_at data")
+	}
+
+	return &expiresAt, nil
+}
+
+/*
+Checks whether an item of an order is from the sellers and expansions a promo code is limited to
+*/
+func isPromoItemEligible(promoCode data.PromoCodeData, item data.PromoOrderItemData) bool {
+	if promoCode.SellerId != "" && item.SellerId != promoCode.SellerId {
+		return false
+	}
+
+	if len(promoCode.SellerIds) > 0 && !containsString(promoCode.SellerIds, item.SellerId) {
+		return false
+	}
+
+	if len(promoCode.Expansions) > 0 && !containsString(promoCode.Expansions, item.Expansion) {
+		return false
+	}
+
+	return true
+}
+
+/*
+Checks whether a list of strings contains a value
+*/
+func containsString(values []string, value string) bool {
+	for i := 0; i < len(values); i++ {
+		if values[i] == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,313 @@
+package promo
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculatePromoDiscount(t *testing.T) {
+	items := []data.PromoOrderItemData{
+		{SellerId: "a", Expansion: "Test", Cost: 10000},
+		{SellerId: "b", Expansion: "Test2", Cost: 5000}}
+
+	//Test 1: Percentage discount over the whole order
+	promoCode := data.PromoCodeData{DiscountType: "percentage", Amount: 10}
+	discount, err := CalculatePromoDiscount(promoCode, items)
+	assert.Empty(t, err)
+	assert.Equal(t, 1500, discount)
+
+	//Test 2: Seller code only discounts the products of the seller
+	promoCode = data.PromoCodeData{SellerId: "b", DiscountType: "percentage", Amount: 10}
+	discount, err = CalculatePromoDiscount(promoCode, items)
+	assert.Empty(t, err)
+	assert.Equal(t, 500, discount)
+
+	//Test 3: Expansion limited code only discounts products of the expansion
+	promoCode = data.PromoCodeData{DiscountType: "fixed", Amount: 2000, Expansions: []string{"Test"}}
+	discount, err = CalculatePromoDiscount(promoCode, items)
+	assert.Empty(t, err)
+	assert.Equal(t, 2000, discount)
+
+	//Test 4: Fixed discount is never more than the eligible products
+	promoCode = data.PromoCodeData{DiscountType: "fixed", Amount: 8000, SellerIds: []string{"b"}}
+	discount, err = CalculatePromoDiscount(promoCode, items)
+	assert.Empty(t, err)
+	assert.Equal(t, 5000, discount)
+
+	//Test 5: Minimum spend only counts eligible products
+	promoCode = data.PromoCodeData{DiscountType: "fixed", Amount: 500, MinSpend: 6000, SellerIds: []string{"b"}}
+	_, err = CalculatePromoDiscount(promoCode, items)
+	assert.Error(t, err)
+	assert.Equal(t, "Order does not reach the minimum spend of the promo code", err.Error())
+
+	//Test 6: No eligible products
+	promoCode = data.PromoCodeData{SellerId: "c", DiscountType: "fixed", Amount: 500}
+	_, err = CalculatePromoDiscount(promoCode, items)
+	assert.Error(t, err)
+	assert.Equal(t, "Promo code does not apply to the products ordered", err.Error())
+}
+
+func TestAllocatePromoDiscount(t *testing.T) {
+	items := []data.PromoOrderItemData{
+		{SellerId: "a", Expansion: "Test", Cost: 10000},
+		{SellerId: "b", Expansion: "Test2", Cost: 5000},
+		{SellerId: "c", Expansion: "Test", Cost: 5000}}
+
+	//Test 1: Discount is split in proportion to the cost of each item
+	promoCode := data.PromoCodeData{DiscountType: "fixed", Amount: 1000}
+	assert.Equal(t, []int{500, 250, 250}, AllocatePromoDiscount(promoCode, items, 1000))
+
+	//Test 2: Items the code does not apply to get no share
+	promoCode = data.PromoCodeData{DiscountType: "fixed", Amount: 1000, Expansions: []string{"Test"}}
+	assert.Equal(t, []int{666, 0, 334}, AllocatePromoDiscount(promoCode, items, 1000))
+
+	//Test 3: Seller code only discounts the items of the seller
+	promoCode = data.PromoCodeData{SellerId: "b", DiscountType: "fixed", Amount: 1000}
+	assert.Equal(t, []int{0, 1000, 0}, AllocatePromoDiscount(promoCode, items, 1000))
+}
+
+func TestCreatePromoCode(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerId, _ := createDummyData(t, db)
+
+	//Test 1: Valid seller code, codes are stored in upper case
+	res, resErr := CreatePromoCode(db, sellerId, data.CreatePromoCodeRequestData{Code: "spring10", DiscountType: "percentage",
+		Amount: 10, ExpiresAt: time.Now().Add(24 * time.Hour).Format(time.RFC3339), MaxUses: 5})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "SPRING10", res.Code)
+	assert.Equal(t, sellerId, res.SellerId)
+	assert.Equal(t, "active", res.Status)
+	assert.Equal(t, 5, res.MaxUses)
+
+	//Test 2: Duplicate code
+	_, resErr = CreatePromoCode(db, "", data.CreatePromoCodeRequestData{Code: "Spring10", DiscountType: "fixed", Amount: 500})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Promo code already exists", resErr.Error())
+
+	//Test 3: Valid platform code limited to a seller
+	res, resErr = CreatePromoCode(db, "", data.CreatePromoCodeRequestData{Code: "WELCOME", DiscountType: "fixed", Amount: 500,
+		SellerIds: []string{sellerId}, FirstOrderOnly: true})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "", res.SellerId)
+	assert.Equal(t, []string{sellerId}, res.SellerIds)
+
+	//Test 4: Seller codes cannot be limited to other sellers
+	_, resErr = CreatePromoCode(db, sellerId, data.CreatePromoCodeRequestData{Code: "OTHER", DiscountType: "fixed", Amount: 500,
+		SellerIds: []string{sellerId}})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad seller_ids data", resErr.Error())
+
+	//Test 5: Unknown expansion
+	_, resErr = CreatePromoCode(db, "", data.CreatePromoCodeRequestData{Code: "NOEXP", DiscountType: "fixed", Amount: 500,
+		Expansions: []string{"Unknown"}})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad expansions data", resErr.Error())
+
+	//Test 6: Invalid code and amount
+	_, resErr = CreatePromoCode(db, "", data.CreatePromoCodeRequestData{Code: "NO", DiscountType: "fixed", Amount: 500})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad code data", resErr.Error())
+	_, resErr = CreatePromoCode(db, "", data.CreatePromoCodeRequestData{Code: "HALF", DiscountType: "percentage", Amount: 150})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad amount data", resErr.Error())
+
+	//Test 7: Seller codes are listed
+	list, resErr := GetSellerPromoCodes(db, sellerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(list.PromoCodes))
+
+	store.CloseDB(db)
+}
+
+func TestReservePromoCode(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerId, buyerIds := createDummyData(t, db)
+
+	promoCode, resErr := CreatePromoCode(db, sellerId, data.CreatePromoCodeRequestData{Code: "LIMITED", DiscountType: "fixed",
+		Amount: 500, MaxUses: 3, MaxUsesPerBuyer: 1})
+	assert.Empty(t, resErr)
+
+	//Test 1: A reservation whose order is rolled back does not count
+	tx, err := db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	redemptionId, resErr := ReservePromoCode(tx, promoCode.PromoCodeId, buyerIds[0], "", 500, "", false)
+	assert.Empty(t, resErr)
+	assert.NotEmpty(t, redemptionId)
+	assert.NoError(t, tx.Rollback())
+
+	res, resErr := GetPromoCode(db, "limited")
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, res.UsedCount)
+
+	//Test 2: Buyer can use the code once
+	resErr = reservePromoCodeForTest(db, promoCode.PromoCodeId, buyerIds[0], "")
+	assert.Empty(t, resErr)
+	resErr = reservePromoCodeForTest(db, promoCode.PromoCodeId, buyerIds[0], "")
+	assert.Error(t, resErr)
+	assert.Equal(t, "Promo code has reached its usage limit", resErr.Error())
+
+	//Test 3: Concurrent checkouts cannot exceed the usage limit
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var reserved int
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := reservePromoCodeForTest(db, promoCode.PromoCodeId, "", "guest"+strconv.Itoa(i)+"@aucto.io")
+			if err == nil {
+				mutex.Lock()
+				reserved++
+				mutex.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 2, reserved)
+
+	res, resErr = GetPromoCode(db, "limited")
+	assert.Empty(t, resErr)
+	assert.Equal(t, 3, res.UsedCount)
+
+	store.CloseDB(db)
+}
+
+func TestReleaseUnpaidRedemptions(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerId, buyerIds := createDummyData(t, db)
+
+	promoCode, resErr := CreatePromoCode(db, sellerId, data.CreatePromoCodeRequestData{Code: "ONCE", DiscountType: "fixed",
+		Amount: 500, MaxUsesPerBuyer: 1})
+	assert.Empty(t, resErr)
+
+	var orderId string
+	query := `INSERT INTO orders(buyer_id, delivery_type, delivery_fee, payment_type, payment_fee, small_order_fee, total_paid,
+			phone_number, order_date, address_line_1, postal_code)
+		VALUES ($1, 'self_collection', 0, 'paynow_online', 0, 0, 10000, '91234567', NOW(), 'Test', '123456') RETURNING order_id;`
+	err = db.QueryRowContext(context.Background(), query, buyerIds[0]).Scan(&orderId)
+	assert.NoError(t, err)
+
+	tx, err := db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	redemptionId, resErr := ReservePromoCode(tx, promoCode.PromoCodeId, buyerIds[0], "", 500, orderId, false)
+	assert.Empty(t, resErr)
+	assert.NoError(t, tx.Commit())
+
+	//Test 1: Redemption of an order that can still be paid is kept
+	res, resErr := ReleaseUnpaidRedemptions(db)
+	assert.Empty(t, resErr)
+	assert.Empty(t, res.Released)
+
+	//Test 2: Redemption of an order that has not been paid in time is released and the buyer can use the code again
+	_, err = db.ExecContext(context.Background(), `UPDATE promo_code_redemptions SET created_date = NOW() - INTERVAL '2 hours';`)
+	assert.NoError(t, err)
+	res, resErr = ReleaseUnpaidRedemptions(db)
+	assert.Empty(t, resErr)
+	assert.Equal(t, []string{redemptionId}, res.Released)
+
+	updated, resErr := GetPromoCode(db, "once")
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, updated.UsedCount)
+
+	//Test 3: Released redemption whose order is paid after all counts again
+	resErr = UpdateRedemptionStatus(db, orderId, false, "completed")
+	assert.Empty(t, resErr)
+
+	updated, resErr = GetPromoCode(db, "once")
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, updated.UsedCount)
+
+	resErr = reservePromoCodeForTest(db, promoCode.PromoCodeId, buyerIds[0], "")
+	assert.Error(t, resErr)
+
+	store.CloseDB(db)
+}
+
+func TestGetCheckoutPromoCode(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	_, buyerIds := createDummyData(t, db)
+
+	_, resErr := CreatePromoCode(db, "", data.CreatePromoCodeRequestData{Code: "FIRST", DiscountType: "fixed", Amount: 500,
+		FirstOrderOnly: true})
+	assert.Empty(t, resErr)
+
+	//Test 1: Buyer without orders can use a first order code
+	_, resErr = GetCheckoutPromoCode(db, "first", buyerIds[0], "")
+	assert.Empty(t, resErr)
+
+	//Test 2: Buyer with a completed order cannot use a first order code
+	query := `INSERT INTO orders(buyer_id, delivery_type, delivery_fee, payment_type, payment_fee, small_order_fee, total_paid,
+			phone_number, order_date, address_line_1, postal_code, payment_status)
+		VALUES ($1, 'self_collection', 0, 'paynow_online', 0, 0, 10000, '91234567', NOW(), 'Test', '123456', 'completed');`
+	_, execErr := db.ExecContext(context.Background(), query, buyerIds[0])
+	assert.NoError(t, execErr)
+
+	_, resErr = GetCheckoutPromoCode(db, "first", buyerIds[0], "")
+	assert.Error(t, resErr)
+	assert.Equal(t, "Promo code is only valid on a first order", resErr.Error())
+
+	//Test 3: Unknown code
+	_, resErr = GetCheckoutPromoCode(db, "unknown", buyerIds[1], "")
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad promo_code data", resErr.Error())
+
+	store.CloseDB(db)
+}
+
+func createDummyData(t *testing.T, db *sql.DB) (string, []string) {
+	var sellerId string
+	var buyerIds []string
+
+	query := `INSERT INTO sellers(email, seller_name, password) VALUES ('test@aucto.io','test','test') RETURNING seller_id`
+	err := db.QueryRowContext(context.Background(), query).Scan(&sellerId)
+	assert.NoError(t, err)
+
+	emails := []string{"test@aucto.io", "test2@aucto.io"}
+	for i := 0; i < len(emails); i++ {
+		var buyerId string
+		query = `INSERT INTO buyers(email, password) VALUES ($1,'test') RETURNING buyer_id;`
+		err = db.QueryRowContext(context.Background(), query, emails[i]).Scan(&buyerId)
+		assert.NoError(t, err)
+		buyerIds = append(buyerIds, buyerId)
+	}
+
+	return sellerId, buyerIds
+}
+
+func reservePromoCodeForTest(db *sql.DB, promoCodeId string, buyerId string, email string) *utils.ErrorHandler {
+	tx, err := db.BeginTx(context.Background(), nil)
+	if err != nil {
+		return utils.InternalServerError(err)
+	}
+
+	defer tx.Rollback()
+
+	_, resErr := ReservePromoCode(tx, promoCodeId, buyerId, email, 500, "", email != "")
+	if resErr != nil {
+		return resErr
+	}
+
+	if tx.Commit() != nil {
+		return utils.InternalServerError(nil)
+	}
+
+	return nil
+}
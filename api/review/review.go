@@ -0,0 +1,391 @@
+package review
+
+import (
+	"BackendAPI/api/buyer"
+	"BackendAPI/api/seller"
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	maxReviewTextLength  = 2000
+	maxReviewReplyLength = 1000
+)
+
+const reviewQuery = `SELECT reviews.review_id, reviews.order_id, reviews.seller_id, reviews.buyer_id, reviews.rating,
+		reviews.review_text, COALESCE(reviews.reply, ''), COALESCE(reviews.replied_date::TEXT, ''), reviews.status,
+		COALESCE(reviews.moderation_reason, ''), reviews.created_date::TEXT,
+		COALESCE(ARRAY_AGG(review_images.review_image_id::TEXT ORDER BY review_images.image_no)
+			FILTER (WHERE review_images.review_image_id IS NOT NULL), '{}')
+	FROM reviews LEFT OUTER JOIN review_images ON review_images.review_id = reviews.review_id`
+
+/*
+Creates a review of a seller by the buyer of an order. Each seller of an order can be reviewed once, after their part
+of the order has been delivered. Photos are uploaded beforehand with CreateReviewImageUploads.
+*/
+func CreateReview(db *sql.DB, imageStore store.ImageStore, buyerId string, request data.CreateReviewRequestData) (data.ReviewData, *utils.ErrorHandler) {
+	var response data.ReviewData
+
+	if !buyer.DoesBuyerExist(db, buyerId) {
+		return response, utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	request.Text = strings.TrimSpace(request.Text)
+	validateErr := validateCreateReview(request)
+
+	if validateErr != nil {
+		return response, validateErr
+	}
+
+	var subOrderId, status string
+
+	query := `SELECT sub_orders.sub_order_id, sub_orders.status
+		FROM sub_orders INNER JOIN orders ON orders.order_id = sub_orders.order_id
+		WHERE sub_orders.order_id::TEXT = $1 AND sub_orders.seller_id::TEXT = $2 AND orders.buyer_id::TEXT = $3;`
+	err := db.QueryRowContext(context.Background(), query, request.OrderId, request.SellerId, buyerId).Scan(&subOrderId, &status)
+
+	if err == sql.ErrNoRows {
+		return response, utils.NotFoundError("Order with given id and seller does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Sub-Order rows")
+		return response, errResp
+	}
+
+	if status != "delivered" {
+		return response, utils.BadRequestError("Order can only be reviewed once it is delivered")
+	}
+
+	if hasReview(db, request.OrderId, request.SellerId) {
+		return response, utils.BadRequestError("Seller has already been reviewed for this order")
+	}
+
+	images, imageErr := getCompletedReviewImageUploads(db, imageStore, buyerId, request.UploadIds)
+
+	if imageErr != nil {
+		return response, imageErr
+	}
+
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Review transaction")
+		return response, errResp
+	}
+
+	defer tx.Rollback()
+
+	var reviewId string
+	createdDate := time.Now()
+
+	query = `INSERT INTO reviews(order_id, sub_order_id, seller_id, buyer_id, rating, review_text, created_date, updated_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $7) ON CONFLICT (order_id, seller_id) DO NOTHING RETURNING review_id;`
+	err = tx.QueryRowContext(context.Background(), query, request.OrderId, subOrderId, request.SellerId, buyerId,
+		request.Rating, request.Text, createdDate).Scan(&reviewId)
+
+	if err == sql.ErrNoRows {
+		return response, utils.BadRequestError("Seller has already been reviewed for this order")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Review rows")
+		return response, errResp
+	}
+
+	for i := 0; i < len(images); i++ {
+		query = `INSERT INTO review_images(review_image_id, review_id, image_no) VALUES ($1, $2, $3);`
+		_, err = tx.ExecContext(context.Background(), query, images[i], reviewId, i+1)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in inserting Review Image rows")
+			return response, errResp
+		}
+	}
+
+	query = `UPDATE review_image_uploads SET status = 'completed' WHERE upload_id::TEXT = ANY($1);`
+	_, err = tx.ExecContext(context.Background(), query, pq.Array(images))
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Review Image Upload rows")
+		return response, errResp
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Review transaction")
+		return response, errResp
+	}
+
+	return getReview(db, reviewId)
+}
+
+/*
+Gets the rating of a seller and their visible reviews from the newest review. Returns a 404 Not found error if the
+seller does not exist.
+*/
+func GetSellerReviews(db *sql.DB, sellerId string) (data.GetSellerReviewsResponseData, *utils.ErrorHandler) {
+	response := data.GetSellerReviewsResponseData{SellerId: sellerId, Reviews: []data.ReviewData{}}
+
+	if !seller.DoesSellerExist(db, sellerId) {
+		return response, utils.NotFoundError("Seller with given id does not exist")
+	}
+
+	query := `SELECT rating, review_count FROM seller_ratings WHERE seller_id = $1;`
+	err := db.QueryRowContext(context.Background(), query, sellerId).Scan(&response.Rating, &response.ReviewCount)
+
+	if err != nil && err != sql.ErrNoRows {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Seller Rating rows")
+		return response, errResp
+	}
+
+	reviews, reviewErr := getReviews(db, `reviews.seller_id = $1 AND reviews.status = 'visible'`, sellerId)
+	response.Reviews = append(response.Reviews, reviews...)
+
+	return response, reviewErr
+}
+
+/*
+Gets the reviews written by a buyer from the newest review, including reviews hidden by a moderator
+*/
+func GetBuyerReviews(db *sql.DB, buyerId string) (data.GetReviewsResponseData, *utils.ErrorHandler) {
+	response := data.GetReviewsResponseData{Reviews: []data.ReviewData{}}
+
+	if !buyer.DoesBuyerExist(db, buyerId) {
+		return response, utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	reviews, reviewErr := getReviews(db, `reviews.buyer_id = $1`, buyerId)
+	response.Reviews = append(response.Reviews, reviews...)
+
+	return response, reviewErr
+}
+
+/*
+Replies to a review of a seller. A seller can only reply to a review once, even if the reply is later removed by a
+moderator.
+*/
+func ReplyToReview(db *sql.DB, sellerId string, reviewId string, request data.ReplyReviewRequestData) (data.ReviewData, *utils.ErrorHandler) {
+	reply := strings.TrimSpace(request.Reply)
+
+	if reply == "" || len(reply) > maxReviewReplyLength {
+		return data.ReviewData{}, utils.BadRequestError("Bad reply data")
+	}
+
+	var hasReplied bool
+
+	query := `SELECT replied_date IS NOT NULL FROM reviews WHERE review_id::TEXT = $1 AND seller_id::TEXT = $2;`
+	err := db.QueryRowContext(context.Background(), query, reviewId, sellerId).Scan(&hasReplied)
+
+	if err == sql.ErrNoRows {
+		return data.ReviewData{}, utils.NotFoundError("Review with given id does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Review rows")
+		return data.ReviewData{}, errResp
+	}
+
+	if hasReplied {
+		return data.ReviewData{}, utils.BadRequestError("Review has already been replied to")
+	}
+
+	query = `UPDATE reviews SET reply = $2, replied_date = NOW(), updated_date = NOW()
+		WHERE review_id = $1 AND replied_date IS NULL;`
+	res, err := db.ExecContext(context.Background(), query, reviewId, reply)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Review rows")
+		return data.ReviewData{}, errResp
+	}
+
+	if count, _ := res.RowsAffected(); count == 0 {
+		return data.ReviewData{}, utils.BadRequestError("Review has already been replied to")
+	}
+
+	return getReview(db, reviewId)
+}
+
+/*
+Gets every review for moderation from the newest review, or only the reviews with the given status
+*/
+func GetReviews(db *sql.DB, status string) (data.GetReviewsResponseData, *utils.ErrorHandler) {
+	response := data.GetReviewsResponseData{Reviews: []data.ReviewData{}}
+
+	if status != "" && status != "visible" && status != "hidden" {
+		return response, utils.BadRequestError("Bad status data")
+	}
+
+	reviews, reviewErr := getReviews(db, `$1 = '' OR reviews.status = $1`, status)
+	response.Reviews = append(response.Reviews, reviews...)
+
+	return response, reviewErr
+}
+
+/*
+Hides a review or makes a hidden review visible again. Hidden reviews are only shown to their buyer and do not count
+towards the rating of the seller.
+*/
+func ModerateReview(db *sql.DB, reviewId string, request data.ModerateReviewRequestData) (data.ReviewData, *utils.ErrorHandler) {
+	if request.Status != "visible" && request.Status != "hidden" {
+		return data.ReviewData{}, utils.BadRequestError("Bad status data")
+	}
+
+	var reason interface{}
+	if request.Status == "hidden" && strings.TrimSpace(request.Reason) != "" {
+		reason = strings.TrimSpace(request.Reason)
+	}
+
+	query := `UPDATE reviews SET status = $2, moderation_reason = $3, updated_date = NOW() WHERE review_id::TEXT = $1;`
+	res, err := db.ExecContext(context.Background(), query, reviewId, request.Status, reason)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Review rows")
+		return data.ReviewData{}, errResp
+	}
+
+	if count, _ := res.RowsAffected(); count == 0 {
+		return data.ReviewData{}, utils.NotFoundError("Review with given id does not exist")
+	}
+
+	return getReview(db, reviewId)
+}
+
+/*
+Removes the reply of the seller from a review. The seller cannot reply to the review again.
+*/
+func RemoveReviewReply(db *sql.DB, reviewId string) (data.ReviewData, *utils.ErrorHandler) {
+	query := `UPDATE reviews SET reply = NULL, updated_date = NOW() WHERE review_id::TEXT = $1;`
+	res, err := db.ExecContext(context.Background(), query, reviewId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Review rows")
+		return data.ReviewData{}, errResp
+	}
+
+	if count, _ := res.RowsAffected(); count == 0 {
+		return data.ReviewData{}, utils.NotFoundError("Review with given id does not exist")
+	}
+
+	return getReview(db, reviewId)
+}
+
+/*
+Validates a request to create a review
+*/
+func validateCreateReview(request data.CreateReviewRequestData) *utils.ErrorHandler {
+	if request.Rating < 1 || request.Rating > 5 {
+		return utils.BadRequestError("Bad rating data")
+	}
+
+	if len(request.Text) > maxReviewTextLength {
+		return utils.BadRequestError("Bad text data")
+	}
+
+	if len(request.UploadIds) > maxReviewImages {
+		return utils.BadRequestError("Too many images uploaded, at most 3 images per review")
+	}
+
+	return nil
+}
+
+/*
+Checks wether a seller has already been reviewed for an order
+*/
+func hasReview(db *sql.DB, orderId string, sellerId string) bool {
+	var reviewExists bool
+	query := `SELECT EXISTS(SELECT * FROM reviews WHERE order_id::TEXT = $1 AND seller_id::TEXT = $2);`
+	err := db.QueryRowContext(context.Background(), query, orderId, sellerId).Scan(&reviewExists)
+
+	if err != nil {
+		return false
+	}
+
+	return reviewExists
+}
+
+/*
+Gets a review with its photos
+*/
+func getReview(db *sql.DB, reviewId string) (data.ReviewData, *utils.ErrorHandler) {
+	reviews, reviewErr := getReviews(db, `reviews.review_id::TEXT = $1`, reviewId)
+
+	if reviewErr != nil {
+		return data.ReviewData{}, reviewErr
+	}
+
+	if len(reviews) == 0 {
+		return data.ReviewData{}, utils.NotFoundError("Review with given id does not exist")
+	}
+
+	return reviews[0], nil
+}
+
+/*
+Gets the reviews with their photos that match a condition from the newest review
+*/
+func getReviews(db *sql.DB, condition string, args ...interface{}) ([]data.ReviewData, *utils.ErrorHandler) {
+	var reviews []data.ReviewData
+
+	query := reviewQuery + ` WHERE ` + condition + ` GROUP BY reviews.review_id ORDER BY reviews.created_date DESC;`
+	rows, err := db.QueryContext(context.Background(), query, args...)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Review rows")
+		return reviews, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var review data.ReviewData
+		var imageIds []string
+		err = scanReview(rows, &review, &imageIds)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Review rows")
+			return reviews, errResp
+		}
+
+		images, pathErr := makeReviewImagePaths(imageIds)
+
+		if pathErr != nil {
+			return reviews, pathErr
+		}
+
+		review.Images = images
+		reviews = append(reviews, review)
+	}
+
+	return reviews, nil
+}
+
+/*
+Scans a row of review columns into a review and the ids of its photos
+*/
+func scanReview(row interface{ Scan(...interface{}) error }, review *data.ReviewData, imageIds *[]string) error {
+	return row.Scan(&review.ReviewId, &review.OrderId, &review.SellerId, &review.BuyerId, &review.Rating,
+		&review.Text, &review.Reply, &review.RepliedDate, &review.Status, &review.ModerationReason,
+		&review.CreatedDate, pq.Array(imageIds))
+}
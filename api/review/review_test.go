@@ -0,0 +1,207 @@
+package review
+
+import (
+	"BackendAPI/api/seller"
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCreateReview(t *testing.T) {
+	//Test 1: Valid review
+	err := validateCreateReview(data.CreateReviewRequestData{Rating: 5, Text: "Great seller"})
+	assert.Empty(t, err)
+
+	//Test 2: Rating out of range
+	err = validateCreateReview(data.CreateReviewRequestData{Rating: 6})
+	assert.Error(t, err)
+	assert.Equal(t, "Bad rating data", err.Error())
+	err = validateCreateReview(data.CreateReviewRequestData{Rating: 0})
+	assert.Error(t, err)
+	assert.Equal(t, "Bad rating data", err.Error())
+
+	//Test 3: Text too long
+	err = validateCreateReview(data.CreateReviewRequestData{Rating: 4, Text: strings.Repeat("a", maxReviewTextLength+1)})
+	assert.Error(t, err)
+	assert.Equal(t, "Bad text data", err.Error())
+
+	//Test 4: Too many photos
+	err = validateCreateReview(data.CreateReviewRequestData{Rating: 4, UploadIds: []string{"1", "2", "3", "4"}})
+	assert.Error(t, err)
+	assert.Equal(t, 400, err.ErrorCode())
+}
+
+func TestCreateReview(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+	imageStore, storeErr := store.NewLocalImageStore(t.TempDir(), "http://localhost:8080", "secret")
+	assert.NoError(t, storeErr)
+
+	buyerId, sellerId, orderId, subOrderId := createDummyReviewData(t, db)
+	request := data.CreateReviewRequestData{OrderId: orderId, SellerId: sellerId, Rating: 4, Text: " Fast shipping "}
+
+	//Test 1: Order that has not been delivered cannot be reviewed
+	_, resErr := CreateReview(db, imageStore, buyerId, request)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Order can only be reviewed once it is delivered", resErr.Error())
+
+	//Test 2: Other buyers cannot review the order
+	_, resErr = CreateReview(db, imageStore, sellerId, request)
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	//Test 3: Delivered order is reviewed
+	_, err = db.ExecContext(context.Background(), `UPDATE sub_orders SET status = 'delivered' WHERE sub_order_id = $1;`, subOrderId)
+	assert.NoError(t, err)
+	review, resErr := CreateReview(db, imageStore, buyerId, request)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 4, review.Rating)
+	assert.Equal(t, "Fast shipping", review.Text)
+	assert.Equal(t, "visible", review.Status)
+	assert.Equal(t, 0, len(review.Images))
+
+	//Test 4: Seller is only reviewed once per order
+	_, resErr = CreateReview(db, imageStore, buyerId, request)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Seller has already been reviewed for this order", resErr.Error())
+
+	//Test 5: Rating is shown on the seller
+	sellerInfo, resErr := seller.GetSellerById(db, sellerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 4.0, sellerInfo.Rating)
+	assert.Equal(t, 1, sellerInfo.ReviewCount)
+
+	sellerReviews, resErr := GetSellerReviews(db, sellerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, sellerReviews.ReviewCount)
+	assert.Equal(t, review.ReviewId, sellerReviews.Reviews[0].ReviewId)
+
+	store.CloseDB(db)
+}
+
+func TestReplyToReview(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerId, sellerId, orderId, subOrderId := createDummyReviewData(t, db)
+	reviewId := createDummyReview(t, db, buyerId, sellerId, orderId, subOrderId)
+
+	//Test 1: Empty reply
+	_, resErr := ReplyToReview(db, sellerId, reviewId, data.ReplyReviewRequestData{Reply: "  "})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad reply data", resErr.Error())
+
+	//Test 2: Other sellers cannot reply
+	_, resErr = ReplyToReview(db, buyerId, reviewId, data.ReplyReviewRequestData{Reply: "Thanks"})
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	//Test 3: Seller replies to the review
+	review, resErr := ReplyToReview(db, sellerId, reviewId, data.ReplyReviewRequestData{Reply: "Thanks"})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "Thanks", review.Reply)
+	assert.NotEqual(t, "", review.RepliedDate)
+
+	//Test 4: Seller only replies once
+	_, resErr = ReplyToReview(db, sellerId, reviewId, data.ReplyReviewRequestData{Reply: "Thanks again"})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Review has already been replied to", resErr.Error())
+
+	//Test 5: Removed reply cannot be replaced
+	review, resErr = RemoveReviewReply(db, reviewId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, "", review.Reply)
+	_, resErr = ReplyToReview(db, sellerId, reviewId, data.ReplyReviewRequestData{Reply: "Thanks again"})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Review has already been replied to", resErr.Error())
+
+	store.CloseDB(db)
+}
+
+func TestModerateReview(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerId, sellerId, orderId, subOrderId := createDummyReviewData(t, db)
+	reviewId := createDummyReview(t, db, buyerId, sellerId, orderId, subOrderId)
+
+	//Test 1: Bad status
+	_, resErr := ModerateReview(db, reviewId, data.ModerateReviewRequestData{Status: "deleted"})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad status data", resErr.Error())
+
+	//Test 2: Hidden review is not shown on the seller or counted in the rating
+	review, resErr := ModerateReview(db, reviewId, data.ModerateReviewRequestData{Status: "hidden", Reason: "Spam"})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "hidden", review.Status)
+	assert.Equal(t, "Spam", review.ModerationReason)
+
+	sellerReviews, resErr := GetSellerReviews(db, sellerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, sellerReviews.ReviewCount)
+	assert.Equal(t, 0, len(sellerReviews.Reviews))
+
+	//Test 3: Hidden review is still shown to the buyer and moderators
+	buyerReviews, resErr := GetBuyerReviews(db, buyerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(buyerReviews.Reviews))
+	hiddenReviews, resErr := GetReviews(db, "hidden")
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(hiddenReviews.Reviews))
+
+	//Test 4: Review is made visible again
+	review, resErr = ModerateReview(db, reviewId, data.ModerateReviewRequestData{Status: "visible"})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "visible", review.Status)
+	assert.Equal(t, "", review.ModerationReason)
+
+	//Test 5: Review does not exist
+	_, resErr = ModerateReview(db, "wrong id", data.ModerateReviewRequestData{Status: "hidden"})
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func createDummyReviewData(t *testing.T, db *sql.DB) (string, string, string, string) {
+	var buyerId, sellerId, orderId, subOrderId string
+
+	query := `INSERT INTO sellers(email, seller_name, password) VALUES ('test@aucto.io','test','test') RETURNING seller_id`
+	err := db.QueryRowContext(context.Background(), query).Scan(&sellerId)
+	assert.NoError(t, err)
+
+	query = `INSERT INTO buyers(email, password) VALUES ('test@aucto.io','test') RETURNING buyer_id;`
+	err = db.QueryRowContext(context.Background(), query).Scan(&buyerId)
+	assert.NoError(t, err)
+
+	query = `INSERT INTO orders(buyer_id, delivery_type, delivery_fee, payment_type, payment_fee, small_order_fee, total_paid,
+			phone_number, order_date, address_line_1, postal_code, payment_status)
+		VALUES ($1, 'standard_delivery', 400, 'paynow_online', 0, 0, 10400, '91234567', NOW(), 'Test', '123456', 'completed')
+		RETURNING order_id;`
+	err = db.QueryRowContext(context.Background(), query, buyerId).Scan(&orderId)
+	assert.NoError(t, err)
+
+	query = `INSERT INTO sub_orders(order_id, seller_id, products_cost, discount, delivery_fee, status, created_date, updated_date)
+		VALUES ($1, $2, 10000, 0, 400, 'shipped', NOW(), NOW()) RETURNING sub_order_id;`
+	err = db.QueryRowContext(context.Background(), query, orderId, sellerId).Scan(&subOrderId)
+	assert.NoError(t, err)
+
+	return buyerId, sellerId, orderId, subOrderId
+}
+
+func createDummyReview(t *testing.T, db *sql.DB, buyerId string, sellerId string, orderId string, subOrderId string) string {
+	var reviewId string
+
+	query := `INSERT INTO reviews(order_id, sub_order_id, seller_id, buyer_id, rating, review_text, created_date, updated_date)
+		VALUES ($1, $2, $3, $4, 5, 'Great', NOW(), NOW()) RETURNING review_id;`
+	err := db.QueryRowContext(context.Background(), query, orderId, subOrderId, sellerId, buyerId).Scan(&reviewId)
+	assert.NoError(t, err)
+
+	return reviewId
+}
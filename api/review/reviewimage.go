@@ -0,0 +1,211 @@
+package review
+
+import (
+	"BackendAPI/api/buyer"
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	maxReviewImages         = 3
+	maxReviewImageSize      = 10 * 1024 * 1024
+	reviewImageUploadExpiry = 15 * time.Minute
+)
+
+var reviewImageContentTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/webp": true,
+}
+
+/*
+Creates upload slots for the photos of a review. Each slot has a presigned url that the client uses to upload
+the photo directly to the image store, the upload ids are then sent with the review.
+*/
+func CreateReviewImageUploads(db *sql.DB, imageStore store.ImageStore, buyerId string,
+	request data.CreateReviewImageUploadsRequestData) (data.CreateReviewImageUploadsResponseData, *utils.ErrorHandler) {
+	response := data.CreateReviewImageUploadsResponseData{Uploads: []data.ProductImageUploadData{}}
+
+	if !buyer.DoesBuyerExist(db, buyerId) {
+		return response, utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	validateErr := validateCreateReviewImageUploads(request)
+
+	if validateErr != nil {
+		return response, validateErr
+	}
+
+	expiresAt := time.Now().Add(reviewImageUploadExpiry)
+
+	for i := 0; i < len(request.Images); i++ {
+		upload := data.ProductImageUploadData{
+			ImageNo:     i + 1,
+			ContentType: request.Images[i].ContentType,
+			Size:        request.Images[i].Size}
+
+		query := `INSERT INTO review_image_uploads(buyer_id, image_no, content_type, size, expires_at)
+			VALUES ($1,$2,$3,$4,$5) RETURNING upload_id, expires_at::TEXT;`
+		err := db.QueryRowContext(
+			context.Background(), query,
+			buyerId, upload.ImageNo, upload.ContentType, upload.Size, expiresAt).Scan(&upload.UploadId, &upload.ExpiresAt)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in Inserting Review Image Upload rows")
+			return response, errResp
+		}
+
+		presigned, err := imageStore.PresignImageUpload(upload.UploadId, upload.ContentType, upload.Size, reviewImageUploadExpiry)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in presigning review image upload")
+			return response, errResp
+		}
+
+		upload.UploadUrl = presigned.Url
+		upload.Method = presigned.Method
+		upload.Headers = presigned.Headers
+		response.Uploads = append(response.Uploads, upload)
+	}
+
+	response.BuyerId = buyerId
+	return response, nil
+}
+
+/*
+Checks that every upload of a review belongs to the buyer, has not expired and was uploaded with the content type
+and size of its slot. Returns the upload ids in the order they were sent in.
+*/
+func getCompletedReviewImageUploads(db *sql.DB, imageStore store.ImageStore, buyerId string, uploadIds []string) ([]string, *utils.ErrorHandler) {
+	var images []string
+
+	if len(uploadIds) == 0 {
+		return images, nil
+	}
+
+	if len(uploadIds) > maxReviewImages {
+		return images, utils.BadRequestError("Too many images uploaded, at most 3 images per review")
+	}
+
+	seen := make(map[string]bool)
+	for i := 0; i < len(uploadIds); i++ {
+		if seen[uploadIds[i]] {
+			return images, utils.BadRequestError("Bad upload_ids data")
+		}
+		seen[uploadIds[i]] = true
+	}
+
+	query := `SELECT upload_id::TEXT, content_type, size FROM review_image_uploads
+		WHERE buyer_id::TEXT = $1 AND upload_id::TEXT = ANY($2) AND status = 'pending' AND expires_at > NOW();`
+	rows, err := db.QueryContext(context.Background(), query, buyerId, pq.Array(uploadIds))
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in Selecting Review Image Upload rows")
+		return images, errResp
+	}
+
+	defer rows.Close()
+
+	uploads := make(map[string]store.ImageObjectInfo)
+
+	for rows.Next() {
+		var uploadId string
+		var upload store.ImageObjectInfo
+		err = rows.Scan(&uploadId, &upload.ContentType, &upload.Size)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in Selecting Review Image Upload rows")
+			return images, errResp
+		}
+
+		uploads[uploadId] = upload
+	}
+
+	for i := 0; i < len(uploadIds); i++ {
+		upload, exists := uploads[uploadIds[i]]
+
+		if !exists {
+			return images, utils.BadRequestError("Upload with given id does not exist or has expired")
+		}
+
+		info, err := imageStore.StatImage(uploadIds[i])
+
+		if err != nil {
+			utils.LogError(err, "Error in fetching uploaded review image")
+			return images, utils.BadRequestError("Image has not been uploaded for upload " + uploadIds[i])
+		}
+
+		if info.Size != upload.Size || info.ContentType != upload.ContentType {
+			utils.LogMessage("Uploaded image does not match the upload content type or size")
+			return images, utils.BadRequestError("Uploaded image does not match upload " + uploadIds[i])
+		}
+
+		images = append(images, uploadIds[i])
+	}
+
+	return images, nil
+}
+
+/*
+Validates a request for review image upload slots
+*/
+func validateCreateReviewImageUploads(request data.CreateReviewImageUploadsRequestData) *utils.ErrorHandler {
+	if len(request.Images) > maxReviewImages {
+		return utils.BadRequestError("Too many images uploaded, at most 3 images per review")
+	}
+
+	if len(request.Images) == 0 {
+		return utils.BadRequestError("No images attached, at least 1 image per upload")
+	}
+
+	for i := 0; i < len(request.Images); i++ {
+		if !reviewImageContentTypes[request.Images[i].ContentType] {
+			utils.LogMessage("Review image content type is not supported")
+			return utils.BadRequestError("Bad content_type data")
+		}
+
+		if request.Images[i].Size <= 0 || request.Images[i].Size > maxReviewImageSize {
+			utils.LogMessage("Review image size is not within the upload limits")
+			return utils.BadRequestError("Bad size data")
+		}
+	}
+
+	return nil
+}
+
+/*
+Transforms the ids of the photos of a review to image paths
+*/
+func makeReviewImagePaths(imageIds []string) ([]string, *utils.ErrorHandler) {
+	paths := []string{}
+
+	if len(imageIds) == 0 {
+		return paths, nil
+	}
+
+	s3Url, doesUrlExist := os.LookupEnv("S3_URL")
+
+	if !doesUrlExist {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(errors.New("Error in loading s3 path"), "Error in creating reading env: no s3 url")
+		return paths, errResp
+	}
+
+	for i := 0; i < len(imageIds); i++ {
+		paths = append(paths, s3Url+"/reviews/images/"+imageIds[i])
+	}
+
+	return paths, nil
+}
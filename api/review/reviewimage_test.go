@@ -0,0 +1,95 @@
+package review
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"bytes"
+	"context"
+	"net/url"
+	"os"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCreateReviewImageUploads(t *testing.T) {
+	//Test 1: Valid upload request
+	req := data.CreateReviewImageUploadsRequestData{Images: []data.ProductImageUploadRequestData{{ContentType: "image/png", Size: 100}}}
+	err := validateCreateReviewImageUploads(req)
+	assert.Empty(t, err)
+
+	//Test 2: Unsupported content type
+	req = data.CreateReviewImageUploadsRequestData{Images: []data.ProductImageUploadRequestData{{ContentType: "text/plain", Size: 100}}}
+	err = validateCreateReviewImageUploads(req)
+	assert.Error(t, err)
+	assert.Equal(t, "Bad content_type data", err.Error())
+
+	//Test 3: Image too large
+	req = data.CreateReviewImageUploadsRequestData{Images: []data.ProductImageUploadRequestData{{ContentType: "image/png", Size: maxReviewImageSize + 1}}}
+	err = validateCreateReviewImageUploads(req)
+	assert.Error(t, err)
+	assert.Equal(t, "Bad size data", err.Error())
+
+	//Test 4: No images
+	err = validateCreateReviewImageUploads(data.CreateReviewImageUploadsRequestData{})
+	assert.Error(t, err)
+	assert.Equal(t, 400, err.ErrorCode())
+}
+
+func TestMakeReviewImagePaths(t *testing.T) {
+	os.Setenv("S3_URL", "https://images.aucto.io")
+
+	//Test 1: Review without photos
+	paths, err := makeReviewImagePaths(nil)
+	assert.Empty(t, err)
+	assert.Equal(t, []string{}, paths)
+
+	//Test 2: Photos keep their order
+	paths, err = makeReviewImagePaths([]string{"b", "a"})
+	assert.Empty(t, err)
+	assert.Equal(t, []string{"https://images.aucto.io/reviews/images/b", "https://images.aucto.io/reviews/images/a"}, paths)
+}
+
+func TestCreateReviewWithImages(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+	imageStore, storeErr := store.NewLocalImageStore(t.TempDir(), "http://localhost:8080", "secret")
+	assert.NoError(t, storeErr)
+
+	buyerId, sellerId, orderId, subOrderId := createDummyReviewData(t, db)
+	_, err = db.ExecContext(context.Background(), `UPDATE sub_orders SET status = 'delivered' WHERE sub_order_id = $1;`, subOrderId)
+	assert.NoError(t, err)
+
+	image := []byte("review image")
+	req := data.CreateReviewImageUploadsRequestData{Images: []data.ProductImageUploadRequestData{{ContentType: "image/png", Size: int64(len(image))}}}
+
+	//Test 1: Upload slots created
+	uploads, resErr := CreateReviewImageUploads(db, imageStore, buyerId, req)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(uploads.Uploads))
+
+	request := data.CreateReviewRequestData{OrderId: orderId, SellerId: sellerId, Rating: 5,
+		UploadIds: []string{uploads.Uploads[0].UploadId}}
+
+	//Test 2: Review cannot be created before the photo is uploaded
+	_, resErr = CreateReview(db, imageStore, buyerId, request)
+	assert.Error(t, resErr)
+	assert.Equal(t, 400, resErr.ErrorCode())
+
+	//Test 3: Review is created with the uploaded photo
+	uploadUrl, _ := url.Parse(uploads.Uploads[0].UploadUrl)
+	saveErr := imageStore.SaveImage(uploads.Uploads[0].UploadId, uploadUrl.Query(), "image/png", bytes.NewReader(image))
+	assert.NoError(t, saveErr)
+
+	review, resErr := CreateReview(db, imageStore, buyerId, request)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(review.Images))
+
+	//Test 4: Buyer does not exist
+	_, resErr = CreateReviewImageUploads(db, imageStore, "wrong id", req)
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
@@ -0,0 +1,251 @@
+package savedsearch
+
+import (
+	"BackendAPI/api/notification"
+	"BackendAPI/api/product"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const (
+	maxMatchedListings = 500
+	// A saved search is notified at most once per interval, listings that match in between are batched together
+	savedSearchNotifyInterval = time.Hour
+)
+
+/*
+Matches the listings created since the last run against every saved search and notifies the buyers of their
+matches. Each saved search is notified at most once an hour, so a bulk import of listings is batched into a
+single notification per saved search.
+*/
+func MatchSavedSearches(db *sql.DB) (data.MatchSavedSearchesResponseData, *utils.ErrorHandler) {
+	return matchSavedSearches(db, notification.Notify)
+}
+
+/*
+Matches the new listings and notifies the buyers with the given notify function
+*/
+func matchSavedSearches(db *sql.DB, notify func(db *sql.DB, buyerId string, notificationType string, title string,
+	message string, productId string) (bool, *utils.ErrorHandler)) (data.MatchSavedSearchesResponseData, *utils.ErrorHandler) {
+	var response data.MatchSavedSearchesResponseData
+
+	listings, listingErr := getNewListings(db)
+
+	if listingErr != nil {
+		return response, listingErr
+	}
+
+	response.Listings = len(listings)
+
+	if len(listings) > 0 {
+		matches, matchErr := addSavedSearchMatches(db, listings)
+
+		if matchErr != nil {
+			return response, matchErr
+		}
+
+		response.Matches = matches
+
+		query := `DELETE FROM new_listings WHERE product_id::TEXT = ANY($1);`
+		_, err := db.ExecContext(context.Background(), query, pq.Array(listings))
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in deleting New Listing rows")
+			return response, errResp
+		}
+	}
+
+	notified, notifyErr := notifySavedSearchMatches(db, notify)
+	response.Notified = notified
+
+	return response, notifyErr
+}
+
+/*
+Gets the oldest listings that have not been matched against the saved searches yet
+*/
+func getNewListings(db *sql.DB) ([]string, *utils.ErrorHandler) {
+	var listings []string
+
+	query := `SELECT product_id::TEXT FROM new_listings ORDER BY created_date ASC LIMIT $1;`
+	rows, err := db.QueryContext(context.Background(), query, maxMatchedListings)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting New Listing rows")
+		return listings, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var productId string
+		err = rows.Scan(&productId)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting New Listing rows")
+			return listings, errResp
+		}
+
+		listings = append(listings, productId)
+	}
+
+	return listings, nil
+}
+
+/*
+Adds the listings that match each saved search to the matches of the saved search. Returns the number of matches.
+*/
+func addSavedSearchMatches(db *sql.DB, listings []string) (int, *utils.ErrorHandler) {
+	query := `SELECT search_id, search_name, search_query, COALESCE(notified_date::TEXT, ''), created_date::TEXT
+		FROM saved_searches;`
+	rows, err := db.QueryContext(context.Background(), query)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Saved Search rows")
+		return 0, errResp
+	}
+
+	var savedSearches []data.SavedSearchData
+
+	for rows.Next() {
+		savedSearch, scanErr := scanSavedSearch(rows)
+
+		if scanErr != nil {
+			rows.Close()
+			return 0, scanErr
+		}
+
+		savedSearches = append(savedSearches, savedSearch)
+	}
+
+	rows.Close()
+
+	matches := 0
+	createdDate := time.Now()
+
+	for i := 0; i < len(savedSearches); i++ {
+		// The products are filtered the same way as the product list so a saved search matches what the buyer saw
+		filtered := product.AddProductFiltering(`SELECT products.product_id
+			FROM products LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id`,
+			savedSearches[i].Query)
+		query = `INSERT INTO saved_search_matches(search_id, product_id, created_date)
+			SELECT $2, filtered.product_id, $3 FROM (` + filtered + `) filtered
+			WHERE filtered.product_id::TEXT = ANY($1)
+			ON CONFLICT (search_id, product_id) DO NOTHING;`
+		res, err := db.ExecContext(context.Background(), query, pq.Array(listings), savedSearches[i].SearchId, createdDate)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in inserting Saved Search Match rows")
+			return matches, errResp
+		}
+
+		count, _ := res.RowsAffected()
+		matches += int(count)
+	}
+
+	return matches, nil
+}
+
+/*
+Notifies the buyers of the matches of their saved searches that have not been notified within the interval.
+Returns the number of saved searches that were notified.
+*/
+func notifySavedSearchMatches(db *sql.DB, notify func(db *sql.DB, buyerId string, notificationType string, title string,
+	message string, productId string) (bool, *utils.ErrorHandler)) (int, *utils.ErrorHandler) {
+	query := `SELECT saved_searches.search_id, saved_searches.buyer_id, saved_searches.search_name,
+			COUNT(*), MIN(saved_search_matches.product_id::TEXT)
+		FROM saved_searches INNER JOIN saved_search_matches ON saved_search_matches.search_id = saved_searches.search_id
+		WHERE saved_searches.notified_date IS NULL OR saved_searches.notified_date <= $1
+		GROUP BY saved_searches.search_id;`
+	rows, err := db.QueryContext(context.Background(), query, time.Now().Add(-savedSearchNotifyInterval))
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Saved Search Match rows")
+		return 0, errResp
+	}
+
+	var pending []savedSearchMatches
+
+	for rows.Next() {
+		var matches savedSearchMatches
+		err = rows.Scan(&matches.searchId, &matches.buyerId, &matches.name, &matches.count, &matches.productId)
+
+		if err != nil {
+			rows.Close()
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Saved Search Match rows")
+			return 0, errResp
+		}
+
+		pending = append(pending, matches)
+	}
+
+	rows.Close()
+
+	notified := 0
+
+	for i := 0; i < len(pending); i++ {
+		title := "New listings for " + pending[i].name
+		message := strconv.Itoa(pending[i].count) + " new listings match your saved search " + pending[i].name + "."
+		productId := ""
+
+		if pending[i].count == 1 {
+			title = "New listing for " + pending[i].name
+			message = "A new listing matches your saved search " + pending[i].name + "."
+			productId = pending[i].productId
+		}
+
+		wasNotified, notifyErr := notify(db, pending[i].buyerId, notification.SavedSearchNotification, title, message, productId)
+
+		if notifyErr != nil {
+			return notified, notifyErr
+		}
+
+		if wasNotified {
+			notified++
+		}
+
+		query = `DELETE FROM saved_search_matches WHERE search_id = $1;`
+		_, err = db.ExecContext(context.Background(), query, pending[i].searchId)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in deleting Saved Search Match rows")
+			return notified, errResp
+		}
+
+		query = `UPDATE saved_searches SET notified_date = $2 WHERE search_id = $1;`
+		_, err = db.ExecContext(context.Background(), query, pending[i].searchId, time.Now())
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in updating Saved Search rows")
+			return notified, errResp
+		}
+	}
+
+	return notified, nil
+}
+
+/*
+The matches of a saved search that the buyer has not been notified of
+*/
+type savedSearchMatches struct {
+	searchId  string
+	buyerId   string
+	name      string
+	count     int
+	productId string
+}
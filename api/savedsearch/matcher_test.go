@@ -0,0 +1,78 @@
+package savedsearch
+
+import (
+	"BackendAPI/api/notification"
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchSavedSearches(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerId, sellerId := createDummySavedSearchData(t, db)
+	CreateSavedSearch(db, buyerId, data.CreateSavedSearchRequestData{
+		Name: "Charizard", Query: data.GetProductListRequestData{Search: "charizard"}})
+
+	type alert struct {
+		notificationType string
+		message          string
+		productId        string
+	}
+	var alerts []alert
+	notify := func(db *sql.DB, buyerId string, notificationType string, title string, message string,
+		productId string) (bool, *utils.ErrorHandler) {
+		alerts = append(alerts, alert{notificationType, message, productId})
+		return true, nil
+	}
+
+	//Test 1: A matching listing is notified
+	productId := createDummySavedSearchListing(t, db, sellerId, "Charizard")
+	createDummySavedSearchListing(t, db, sellerId, "Pikachu")
+	res, resErr := matchSavedSearches(db, notify)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, res.Listings)
+	assert.Equal(t, 1, res.Matches)
+	assert.Equal(t, 1, res.Notified)
+	assert.Equal(t, 1, len(alerts))
+	assert.Equal(t, notification.SavedSearchNotification, alerts[0].notificationType)
+	assert.Equal(t, productId, alerts[0].productId)
+
+	//Test 2: Listings are only matched once
+	alerts = nil
+	res, resErr = matchSavedSearches(db, notify)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, res.Listings)
+	assert.Equal(t, 0, len(alerts))
+
+	//Test 3: Matches within the interval are held back
+	for i := 0; i < 3; i++ {
+		createDummySavedSearchListing(t, db, sellerId, "Charizard")
+	}
+	res, resErr = matchSavedSearches(db, notify)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 3, res.Matches)
+	assert.Equal(t, 0, res.Notified)
+	assert.Equal(t, 0, len(alerts))
+
+	//Test 4: Held back matches are batched into one notification once the interval has passed
+	_, err = db.ExecContext(context.Background(), `UPDATE saved_searches SET notified_date = $1;`,
+		time.Now().Add(-2*savedSearchNotifyInterval))
+	assert.NoError(t, err)
+	res, resErr = matchSavedSearches(db, notify)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, res.Notified)
+	assert.Equal(t, 1, len(alerts))
+	assert.Equal(t, "3 new listings match your saved search Charizard.", alerts[0].message)
+	assert.Equal(t, "", alerts[0].productId)
+
+	store.CloseDB(db)
+}
@@ -0,0 +1,212 @@
+package savedsearch
+
+import (
+	"BackendAPI/api/buyer"
+	"BackendAPI/api/product"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const (
+	maxSavedSearches   = 10
+	maxSavedSearchName = 100
+)
+
+/*
+Saves a product list query of a buyer under a name. New listings that match the query are notified to the buyer.
+The page of the query is not saved, only its filters, search query and sorting.
+*/
+func CreateSavedSearch(db *sql.DB, buyerId string, request data.CreateSavedSearchRequestData) (data.SavedSearchData, *utils.ErrorHandler) {
+	var response data.SavedSearchData
+
+	if !buyer.DoesBuyerExist(db, buyerId) {
+		return response, utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	request.Name = strings.TrimSpace(request.Name)
+	request.Query.Cursor = ""
+	request.Query.Anchor = 0
+	request.Query.Limit = 0
+
+	validateErr := validateCreateSavedSearch(db, buyerId, request)
+
+	if validateErr != nil {
+		return response, validateErr
+	}
+
+	searchQuery, err := json.Marshal(request.Query)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in encoding saved search query")
+		return response, errResp
+	}
+
+	query := `INSERT INTO saved_searches(buyer_id, search_name, search_query, created_date) VALUES ($1,$2,$3,$4)
+		RETURNING search_id;`
+	err = db.QueryRowContext(context.Background(), query, buyerId, request.Name, string(searchQuery), time.Now()).Scan(&response.SearchId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Saved Search rows")
+		return response, errResp
+	}
+
+	return getSavedSearch(db, buyerId, response.SearchId)
+}
+
+/*
+Gets the saved searches of a buyer from the most recently saved search. Returns a 404 Not found error if the buyer
+does not exist.
+*/
+func GetSavedSearches(db *sql.DB, buyerId string) (data.GetSavedSearchesResponseData, *utils.ErrorHandler) {
+	response := data.GetSavedSearchesResponseData{BuyerId: buyerId, SavedSearches: []data.SavedSearchData{}}
+
+	if !buyer.DoesBuyerExist(db, buyerId) {
+		return response, utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	query := `SELECT search_id, search_name, search_query, COALESCE(notified_date::TEXT, ''), created_date::TEXT
+		FROM saved_searches WHERE buyer_id = $1 ORDER BY created_date DESC;`
+	rows, err := db.QueryContext(context.Background(), query, buyerId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Saved Search rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		savedSearch, scanErr := scanSavedSearch(rows)
+
+		if scanErr != nil {
+			return response, scanErr
+		}
+
+		response.SavedSearches = append(response.SavedSearches, savedSearch)
+	}
+
+	return response, nil
+}
+
+/*
+Deletes a saved search of a buyer along with the matches that have not been notified yet
+*/
+func DeleteSavedSearch(db *sql.DB, buyerId string, searchId string) (data.GetSavedSearchesResponseData, *utils.ErrorHandler) {
+	query := `DELETE FROM saved_searches WHERE buyer_id::TEXT = $1 AND search_id::TEXT = $2;`
+	res, err := db.ExecContext(context.Background(), query, buyerId, searchId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in deleting Saved Search rows")
+		return data.GetSavedSearchesResponseData{}, errResp
+	}
+
+	if count, _ := res.RowsAffected(); count == 0 {
+		return data.GetSavedSearchesResponseData{}, utils.NotFoundError("Saved search with given id does not exist")
+	}
+
+	return GetSavedSearches(db, buyerId)
+}
+
+/*
+Runs a saved search of a buyer and gets the page of products that currently match it. If there are more products,
+next_cursor can be passed as the cursor to get the next page.
+*/
+func RunSavedSearch(db *sql.DB, buyerId string, searchId string, cursor string, limit int) (data.GetProductListResponseData, *utils.ErrorHandler) {
+	savedSearch, searchErr := getSavedSearch(db, buyerId, searchId)
+
+	if searchErr != nil {
+		return data.GetProductListResponseData{}, searchErr
+	}
+
+	savedSearch.Query.Cursor = cursor
+	savedSearch.Query.Limit = limit
+
+	return product.GetProductList(db, savedSearch.Query)
+}
+
+/*
+Gets a saved search of a buyer. Returns a 404 Not found error if the buyer has no saved search with the id.
+*/
+func getSavedSearch(db *sql.DB, buyerId string, searchId string) (data.SavedSearchData, *utils.ErrorHandler) {
+	query := `SELECT search_id, search_name, search_query, COALESCE(notified_date::TEXT, ''), created_date::TEXT
+		FROM saved_searches WHERE buyer_id::TEXT = $1 AND search_id::TEXT = $2;`
+	row := db.QueryRowContext(context.Background(), query, buyerId, searchId)
+
+	savedSearch, scanErr := scanSavedSearch(row)
+
+	if scanErr != nil && scanErr.ErrorCode() == 404 {
+		return savedSearch, utils.NotFoundError("Saved search with given id does not exist")
+	}
+
+	return savedSearch, scanErr
+}
+
+/*
+Scans a saved search and decodes its query
+*/
+func scanSavedSearch(row interface{ Scan(...interface{}) error }) (data.SavedSearchData, *utils.ErrorHandler) {
+	var savedSearch data.SavedSearchData
+	var searchQuery string
+
+	err := row.Scan(&savedSearch.SearchId, &savedSearch.Name, &searchQuery, &savedSearch.NotifiedDate, &savedSearch.CreatedDate)
+
+	if err == sql.ErrNoRows {
+		return savedSearch, utils.NotFoundError("Saved search with given id does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Saved Search rows")
+		return savedSearch, errResp
+	}
+
+	err = json.Unmarshal([]byte(searchQuery), &savedSearch.Query)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in decoding saved search query")
+		return savedSearch, errResp
+	}
+
+	return savedSearch, nil
+}
+
+/*
+Validates a new saved search. A saved search needs at least one filter or a search query so that it does not
+match every new listing, and a buyer can only have a limited number of saved searches.
+*/
+func validateCreateSavedSearch(db *sql.DB, buyerId string, request data.CreateSavedSearchRequestData) *utils.ErrorHandler {
+	if request.Name == "" || len(request.Name) > maxSavedSearchName {
+		utils.LogMessage("Saved search name is empty or too long")
+		return utils.BadRequestError("Bad name data")
+	}
+
+	if product.AddProductFiltering("", request.Query) == "" {
+		return utils.BadRequestError("Saved search needs at least one filter or search query")
+	}
+
+	var count int
+	query := `SELECT COUNT(*) FROM saved_searches WHERE buyer_id = $1;`
+	err := db.QueryRowContext(context.Background(), query, buyerId).Scan(&count)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Saved Search rows")
+		return errResp
+	}
+
+	if count >= maxSavedSearches {
+		return utils.BadRequestError("Too many saved searches, at most 10 saved searches per buyer")
+	}
+
+	return nil
+}
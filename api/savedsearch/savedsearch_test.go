@@ -0,0 +1,138 @@
+package savedsearch
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSavedSearch(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerId, _ := createDummySavedSearchData(t, db)
+
+	//Test 1: Save a search, the page is not saved
+	res, resErr := CreateSavedSearch(db, buyerId, data.CreateSavedSearchRequestData{
+		Name:  " Charizard ",
+		Query: data.GetProductListRequestData{Search: "charizard", Languages: []string{"Eng"}, Cursor: "abc", Limit: 5}})
+	assert.Empty(t, resErr)
+	assert.Equal(t, "Charizard", res.Name)
+	assert.Equal(t, "charizard", res.Query.Search)
+	assert.Equal(t, []string{"Eng"}, res.Query.Languages)
+	assert.Equal(t, "", res.Query.Cursor)
+	assert.Equal(t, 0, res.Query.Limit)
+
+	//Test 2: Search without filters
+	_, resErr = CreateSavedSearch(db, buyerId, data.CreateSavedSearchRequestData{Name: "Everything"})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Saved search needs at least one filter or search query", resErr.Error())
+
+	//Test 3: Search without a name
+	_, resErr = CreateSavedSearch(db, buyerId, data.CreateSavedSearchRequestData{
+		Name: " ", Query: data.GetProductListRequestData{Search: "charizard"}})
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad name data", resErr.Error())
+
+	//Test 4: Too many saved searches
+	for i := 1; i < maxSavedSearches; i++ {
+		_, resErr = CreateSavedSearch(db, buyerId, data.CreateSavedSearchRequestData{
+			Name: "Test", Query: data.GetProductListRequestData{Search: "charizard"}})
+		assert.Empty(t, resErr)
+	}
+	_, resErr = CreateSavedSearch(db, buyerId, data.CreateSavedSearchRequestData{
+		Name: "Test", Query: data.GetProductListRequestData{Search: "charizard"}})
+	assert.Error(t, resErr)
+	assert.Equal(t, 400, resErr.ErrorCode())
+
+	//Test 5: Buyer does not exist
+	_, resErr = CreateSavedSearch(db, "wrong id", data.CreateSavedSearchRequestData{
+		Name: "Test", Query: data.GetProductListRequestData{Search: "charizard"}})
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func TestGetSavedSearches(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerId, sellerId := createDummySavedSearchData(t, db)
+	productId := createDummySavedSearchListing(t, db, sellerId, "Charizard")
+	createDummySavedSearchListing(t, db, sellerId, "Pikachu")
+
+	//Test 1: Buyer has no saved searches
+	res, resErr := GetSavedSearches(db, buyerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(res.SavedSearches))
+
+	//Test 2: Saved searches from the most recently saved
+	CreateSavedSearch(db, buyerId, data.CreateSavedSearchRequestData{
+		Name: "First", Query: data.GetProductListRequestData{Search: "pikachu"}})
+	savedSearch, _ := CreateSavedSearch(db, buyerId, data.CreateSavedSearchRequestData{
+		Name: "Second", Query: data.GetProductListRequestData{Search: "charizard"}})
+	res, resErr = GetSavedSearches(db, buyerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, len(res.SavedSearches))
+	assert.Equal(t, "Second", res.SavedSearches[0].Name)
+
+	//Test 3: Run a saved search
+	products, resErr := RunSavedSearch(db, buyerId, savedSearch.SearchId, "", 0)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(products.Products))
+	assert.Equal(t, productId, products.Products[0].ProductId)
+
+	//Test 4: Delete a saved search
+	res, resErr = DeleteSavedSearch(db, buyerId, savedSearch.SearchId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(res.SavedSearches))
+
+	//Test 5: Saved search does not exist
+	_, resErr = RunSavedSearch(db, buyerId, savedSearch.SearchId, "", 0)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Saved search with given id does not exist", resErr.Error())
+	_, resErr = DeleteSavedSearch(db, buyerId, savedSearch.SearchId)
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func createDummySavedSearchData(t *testing.T, db *sql.DB) (string, string) {
+	var buyerId, sellerId string
+
+	query := `INSERT INTO buyers(email, password) VALUES ('test@aucto.io','test') RETURNING buyer_id;`
+	err := db.QueryRowContext(context.Background(), query).Scan(&buyerId)
+	assert.NoError(t, err)
+
+	query = `INSERT INTO sellers(email, seller_name, password) VALUES ('test@aucto.io', 'test', 'test') RETURNING seller_id`
+	err = db.QueryRowContext(context.Background(), query).Scan(&sellerId)
+	assert.NoError(t, err)
+
+	return buyerId, sellerId
+}
+
+func createDummySavedSearchListing(t *testing.T, db *sql.DB, sellerId string, title string) string {
+	var productId string
+
+	query := `INSERT INTO products(title, seller_id, description, product_type, language, expansion, posted_date, price, condition, product_quantity)
+		VALUES ($1, $2, 'Test', 'Buy-Now', 'Eng', 'Test', $3, 1000, 5, 1) RETURNING product_id;`
+	err := db.QueryRowContext(context.Background(), query, title, sellerId, time.Now()).Scan(&productId)
+	assert.NoError(t, err)
+
+	_, err = db.ExecContext(context.Background(), `INSERT INTO product_images(product_id, image_no) VALUES ($1, 1);`, productId)
+	assert.NoError(t, err)
+
+	_, err = db.ExecContext(context.Background(), `INSERT INTO new_listings(product_id, created_date) VALUES ($1, $2);`,
+		productId, time.Now())
+	assert.NoError(t, err)
+
+	return productId
+}
@@ -89,8 +89,11 @@ func GetSellerById(db *sql.DB, sellerId string) (data.GetSellerByIdResponseData,
 		return response, utils.NotFoundError("Seller with given Seller Id does not exist")
 	}
 
-	query := `SELECT seller_name, followers FROM sellers WHERE seller_id = $1;`
-	err := db.QueryRowContext(context.Background(), query, sellerId).Scan(&response.SellerName, &response.Followers)
+	query := `SELECT seller_name, followers, COALESCE(seller_ratings.rating, 0), COALESCE(seller_ratings.review_count, 0)
+		FROM sellers LEFT OUTER JOIN seller_ratings ON seller_ratings.seller_id = sellers.seller_id
+		WHERE sellers.seller_id = $1;`
+	err := db.QueryRowContext(context.Background(), query, sellerId).Scan(&response.SellerName, &response.Followers,
+		&response.Rating, &response.ReviewCount)
 
 	if err != nil {
 		errResp := utils.InternalServerError(err)
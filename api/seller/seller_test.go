@@ -195,6 +195,7 @@ func TestGetSellerById(t *testing.T) {
 	assert.Empty(t, err)
 	assert.Equal(t, "Test1", res.SellerName)
 	assert.Equal(t, 0, res.Followers)
+	assert.Equal(t, 0, res.ReviewCount)
 	assert.Equal(t, sellerIds[0], res.SellerId)
 
 	//Test 2: Seller Id Exists
@@ -0,0 +1,132 @@
+package telegram
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultApiUrl = "https://api.telegram.org"
+
+/*
+Client of the Telegram Bot API for the bot that sends notifications
+*/
+type Client struct {
+	apiUrl      string
+	token       string
+	botUsername string
+	httpClient  *http.Client
+}
+
+var (
+	clientMutex   sync.Mutex
+	defaultClient *Client
+)
+
+/*
+Creates the client of the bot specified by TELEGRAM_BOT_TOKEN and TELEGRAM_BOT_USERNAME. Requests are sent to
+TELEGRAM_API_URL if it is set, such as a fake Telegram API when testing.
+*/
+func CreateClient() (*Client, error) {
+	token, hasToken := os.LookupEnv("TELEGRAM_BOT_TOKEN")
+	botUsername, hasUsername := os.LookupEnv("TELEGRAM_BOT_USERNAME")
+
+	if !(hasToken && hasUsername) {
+		return nil, errors.New("Error in loading environment variables for Telegram")
+	}
+
+	apiUrl := os.Getenv("TELEGRAM_API_URL")
+	if apiUrl == "" {
+		apiUrl = defaultApiUrl
+	}
+
+	return NewClient(apiUrl, token, botUsername), nil
+}
+
+/*
+Creates a client of a bot that sends requests to the given API url
+*/
+func NewClient(apiUrl string, token string, botUsername string) *Client {
+	return &Client{apiUrl: strings.TrimSuffix(apiUrl, "/"), token: token, botUsername: botUsername,
+		httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+/*
+Sets the client that notifications are sent with
+*/
+func UseClient(client *Client) {
+	clientMutex.Lock()
+	defer clientMutex.Unlock()
+
+	defaultClient = client
+}
+
+/*
+Gets the username of the bot, which deep links open a chat with
+*/
+func (client *Client) BotUsername() string {
+	return client.botUsername
+}
+
+/*
+Sends a plain text message to a chat
+*/
+func (client *Client) SendMessage(chatId int64, text string) error {
+	body, err := json.Marshal(map[string]interface{}{"chat_id": chatId, "text": text})
+
+	if err != nil {
+		return err
+	}
+
+	response, err := client.httpClient.Post(client.apiUrl+"/bot"+client.token+"/sendMessage", "application/json",
+		bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	var result struct {
+		Ok          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+
+	err = json.NewDecoder(response.Body).Decode(&result)
+
+	if err != nil {
+		return errors.New("Error in sending Telegram message, status " + response.Status)
+	}
+
+	if !result.Ok {
+		return errors.New("Error in sending Telegram message: " + result.Description)
+	}
+
+	return nil
+}
+
+/*
+Gets the client in use, creating it from the environment variables the first time
+*/
+func getClient() (*Client, error) {
+	clientMutex.Lock()
+	defer clientMutex.Unlock()
+
+	if defaultClient != nil {
+		return defaultClient, nil
+	}
+
+	client, err := CreateClient()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defaultClient = client
+	return defaultClient, nil
+}
@@ -0,0 +1,85 @@
+package telegram
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendMessage(t *testing.T) {
+	api := newFakeTelegramApi()
+	defer api.server.Close()
+
+	client := NewClient(api.server.URL+"/", "token", "AuctoBot")
+
+	//Test 1: Message is sent to the chat with the bot token
+	err := client.SendMessage(42, "Hello")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(api.messages))
+	assert.Equal(t, "/bottoken/sendMessage", api.paths[0])
+	assert.Equal(t, int64(42), api.messages[0].ChatId)
+	assert.Equal(t, "Hello", api.messages[0].Text)
+
+	//Test 2: Error is returned when Telegram does not accept the message
+	api.failing = true
+	err = client.SendMessage(42, "Hello")
+	assert.Error(t, err)
+	assert.Equal(t, "Error in sending Telegram message: Forbidden: bot was blocked by the user", err.Error())
+
+	//Test 3: Bot username is kept for deep links
+	assert.Equal(t, "AuctoBot", client.BotUsername())
+}
+
+func TestCreateClient(t *testing.T) {
+	//Test 1: Client is not created without a bot token
+	t.Setenv("TELEGRAM_BOT_TOKEN", "")
+	os.Unsetenv("TELEGRAM_BOT_TOKEN")
+	t.Setenv("TELEGRAM_BOT_USERNAME", "AuctoBot")
+	_, err := CreateClient()
+	assert.Error(t, err)
+
+	//Test 2: Client uses the Telegram API url when none is set
+	t.Setenv("TELEGRAM_BOT_TOKEN", "token")
+	t.Setenv("TELEGRAM_API_URL", "")
+	client, err := CreateClient()
+	assert.NoError(t, err)
+	assert.Equal(t, defaultApiUrl, client.apiUrl)
+}
+
+type fakeTelegramApi struct {
+	server   *httptest.Server
+	failing  bool
+	paths    []string
+	messages []sentMessage
+}
+
+type sentMessage struct {
+	ChatId int64  `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+/*
+Creates a fake of the Telegram Bot API that keeps the messages it is sent
+*/
+func newFakeTelegramApi() *fakeTelegramApi {
+	api := &fakeTelegramApi{}
+	api.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if api.failing {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"ok": false, "error_code": 403, "description": "Forbidden: bot was blocked by the user"}`))
+			return
+		}
+
+		var message sentMessage
+		json.NewDecoder(r.Body).Decode(&message)
+		api.paths = append(api.paths, r.URL.Path)
+		api.messages = append(api.messages, message)
+		w.Write([]byte(`{"ok": true, "result": {}}`))
+	}))
+
+	return api
+}
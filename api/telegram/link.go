@@ -0,0 +1,250 @@
+package telegram
+
+import (
+	"BackendAPI/api/buyer"
+	"BackendAPI/api/outbox"
+	"BackendAPI/api/seller"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+const (
+	BuyerAccount  = "buyer"
+	SellerAccount = "seller"
+)
+
+const linkCodeExpiry = time.Hour
+
+/*
+Creates the code that links an account to a Telegram chat, replacing any code the account already has. The code is
+sent to the bot by opening the deep link, which is valid for an hour.
+*/
+func CreateLinkCode(db *sql.DB, accountType string, accountId string) (data.TelegramLinkCodeData, *utils.ErrorHandler) {
+	var response data.TelegramLinkCodeData
+
+	accountErr := checkAccount(db, accountType, accountId)
+
+	if accountErr != nil {
+		return response, accountErr
+	}
+
+	client, err := getClient()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in creating Telegram client")
+		return response, errResp
+	}
+
+	codeBytes := make([]byte, 16)
+	_, err = rand.Read(codeBytes)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in generating Telegram link code")
+		return response, errResp
+	}
+
+	response.Code = hex.EncodeToString(codeBytes)
+	response.Url = "https://t.me/" + client.BotUsername() + "?start=" + response.Code
+
+	query := `INSERT INTO telegram_link_codes(code, account_type, account_id, expiry_date) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (account_type, account_id) DO UPDATE SET code = EXCLUDED.code, expiry_date = EXCLUDED.expiry_date,
+			created_date = NOW()
+		RETURNING expiry_date::TEXT;`
+	err = db.QueryRowContext(context.Background(), query, response.Code, accountType, accountId,
+		time.Now().Add(linkCodeExpiry)).Scan(&response.ExpiryDate)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Telegram Link Code rows")
+		return response, errResp
+	}
+
+	return response, nil
+}
+
+/*
+Gets wether an account is linked to a Telegram chat and the username it was linked from
+*/
+func GetTelegramLink(db *sql.DB, accountType string, accountId string) (data.TelegramLinkData, *utils.ErrorHandler) {
+	var response data.TelegramLinkData
+
+	accountErr := checkAccount(db, accountType, accountId)
+
+	if accountErr != nil {
+		return response, accountErr
+	}
+
+	query := `SELECT username, linked_date::TEXT FROM telegram_chats WHERE account_type = $1 AND account_id = $2;`
+	err := db.QueryRowContext(context.Background(), query, accountType, accountId).Scan(&response.Username, &response.LinkedDate)
+
+	if err == sql.ErrNoRows {
+		return response, nil
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Telegram Chat rows")
+		return response, errResp
+	}
+
+	response.Linked = true
+	return response, nil
+}
+
+/*
+Unlinks an account from its Telegram chat, the account no longer gets notifications on Telegram
+*/
+func UnlinkTelegram(db *sql.DB, accountType string, accountId string) (data.TelegramLinkData, *utils.ErrorHandler) {
+	accountErr := checkAccount(db, accountType, accountId)
+
+	if accountErr != nil {
+		return data.TelegramLinkData{}, accountErr
+	}
+
+	query := `DELETE FROM telegram_chats WHERE account_type = $1 AND account_id = $2;`
+	_, err := db.ExecContext(context.Background(), query, accountType, accountId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in deleting Telegram Chat rows")
+		return data.TelegramLinkData{}, errResp
+	}
+
+	return data.TelegramLinkData{}, nil
+}
+
+/*
+Handles an update sent to the bot webhook. Opening a deep link sends /start with the link code, which links the
+account of the code to the chat. /stop unlinks every account from the chat. Only private chats can be linked.
+*/
+func HandleUpdate(db *sql.DB, update data.TelegramUpdateData) *utils.ErrorHandler {
+	if update.Message == nil || update.Message.Chat.Type != "private" {
+		return nil
+	}
+
+	message := update.Message
+	command, argument, _ := strings.Cut(strings.TrimSpace(message.Text), " ")
+
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Telegram transaction")
+		return errResp
+	}
+
+	defer tx.Rollback()
+
+	var reply string
+	var replyErr *utils.ErrorHandler
+
+	switch command {
+	case "/start":
+		reply, replyErr = linkChat(tx, strings.TrimSpace(argument), message)
+	case "/stop":
+		reply, replyErr = unlinkChat(tx, message.Chat.Id)
+	default:
+		reply = "Hi! Open the Telegram link on Aucto to get your notifications here. Send /stop to stop getting them."
+	}
+
+	if replyErr != nil {
+		return replyErr
+	}
+
+	messageId, outboxErr := addChatMessage(tx, message.Chat.Id, reply)
+
+	if outboxErr != nil {
+		return outboxErr
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Telegram transaction")
+		return errResp
+	}
+
+	outbox.DeliverMessages(db, []string{messageId})
+
+	return nil
+}
+
+/*
+Links the account of a link code to the chat the code was sent from and uses up the code. Returns the reply to send.
+*/
+func linkChat(tx *sql.Tx, code string, message *data.TelegramMessageData) (string, *utils.ErrorHandler) {
+	if code == "" {
+		return "Hi! Open the Telegram link on Aucto to link your account.", nil
+	}
+
+	var accountType, accountId string
+	query := `DELETE FROM telegram_link_codes WHERE code = $1 AND expiry_date > NOW() RETURNING account_type, account_id;`
+	err := tx.QueryRowContext(context.Background(), query, code).Scan(&accountType, &accountId)
+
+	if err == sql.ErrNoRows {
+		return "This link has expired. Open the Telegram link on Aucto again to get a new one.", nil
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in deleting Telegram Link Code rows")
+		return "", errResp
+	}
+
+	query = `INSERT INTO telegram_chats(account_type, account_id, chat_id, username) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (account_type, account_id) DO UPDATE SET chat_id = EXCLUDED.chat_id, username = EXCLUDED.username,
+			linked_date = NOW();`
+	_, err = tx.ExecContext(context.Background(), query, accountType, accountId, message.Chat.Id, message.From.Username)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Telegram Chat rows")
+		return "", errResp
+	}
+
+	if accountType == SellerAccount {
+		return "Your Aucto seller account is linked. New orders and offers will show up here. Send /stop to unlink it.", nil
+	}
+
+	return "Your Aucto account is linked. Order, shipping, offer and bid updates will show up here. Send /stop to unlink it.", nil
+}
+
+/*
+Unlinks every account from a chat. Returns the reply to send.
+*/
+func unlinkChat(tx *sql.Tx, chatId int64) (string, *utils.ErrorHandler) {
+	query := `DELETE FROM telegram_chats WHERE chat_id = $1;`
+	_, err := tx.ExecContext(context.Background(), query, chatId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in deleting Telegram Chat rows")
+		return "", errResp
+	}
+
+	return "You will no longer get Aucto notifications here.", nil
+}
+
+/*
+Checks that an account of a type exists, returns a 404 Not found error if it does not
+*/
+func checkAccount(db *sql.DB, accountType string, accountId string) *utils.ErrorHandler {
+	if accountType == SellerAccount && !seller.DoesSellerExist(db, accountId) {
+		return utils.NotFoundError("Seller with given id does not exist")
+	}
+
+	if accountType == BuyerAccount && !buyer.DoesBuyerExist(db, accountId) {
+		return utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package telegram
+
+import (
+	"BackendAPI/api/outbox"
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateLinkCode(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	api := newFakeTelegramApi()
+	defer api.server.Close()
+	UseClient(NewClient(api.server.URL, "token", "AuctoBot"))
+
+	buyerId, sellerId := createDummyAccounts(t, db)
+
+	//Test 1: Link opens a chat with the bot with the code
+	res, resErr := CreateLinkCode(db, BuyerAccount, buyerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 32, len(res.Code))
+	assert.Equal(t, "https://t.me/AuctoBot?start="+res.Code, res.Url)
+	assert.NotEmpty(t, res.ExpiryDate)
+
+	//Test 2: New link replaces the previous code of the account
+	other, resErr := CreateLinkCode(db, BuyerAccount, buyerId)
+	assert.Empty(t, resErr)
+	assert.NotEqual(t, res.Code, other.Code)
+	assert.Equal(t, 1, countRows(t, db, "telegram_link_codes"))
+
+	//Test 3: Buyer and seller accounts have their own codes
+	_, resErr = CreateLinkCode(db, SellerAccount, sellerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, countRows(t, db, "telegram_link_codes"))
+
+	//Test 4: Account does not exist
+	_, resErr = CreateLinkCode(db, SellerAccount, "wrong id")
+	assert.Error(t, resErr)
+	assert.Equal(t, "Seller with given id does not exist", resErr.Error())
+
+	store.CloseDB(db)
+}
+
+func TestHandleUpdate(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	api := newFakeTelegramApi()
+	defer api.server.Close()
+	UseClient(NewClient(api.server.URL, "token", "AuctoBot"))
+	outbox.RegisterHandler(OutboxTopic, DeliverOutboxMessage)
+
+	buyerId, sellerId := createDummyAccounts(t, db)
+	buyerCode, _ := CreateLinkCode(db, BuyerAccount, buyerId)
+	sellerCode, _ := CreateLinkCode(db, SellerAccount, sellerId)
+
+	//Test 1: Start with a code links the account to the chat and replies
+	resErr := HandleUpdate(db, newTestUpdate(100, "private", "/start "+buyerCode.Code))
+	assert.Empty(t, resErr)
+	link, resErr := GetTelegramLink(db, BuyerAccount, buyerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, true, link.Linked)
+	assert.Equal(t, "tester", link.Username)
+	assert.Equal(t, 1, len(api.messages))
+	assert.Equal(t, int64(100), api.messages[0].ChatId)
+	assert.Contains(t, api.messages[0].Text, "Your Aucto account is linked")
+
+	//Test 2: Code can only be used once
+	resErr = HandleUpdate(db, newTestUpdate(200, "private", "/start "+buyerCode.Code))
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, countRows(t, db, "telegram_chats"))
+	assert.Contains(t, api.messages[1].Text, "This link has expired")
+
+	//Test 3: Expired code does not link the account
+	_, err = db.ExecContext(context.Background(), `UPDATE telegram_link_codes SET expiry_date = NOW() - INTERVAL '1 minute';`)
+	assert.NoError(t, err)
+	resErr = HandleUpdate(db, newTestUpdate(100, "private", "/start "+sellerCode.Code))
+	assert.Empty(t, resErr)
+	link, _ = GetTelegramLink(db, SellerAccount, sellerId)
+	assert.Equal(t, false, link.Linked)
+
+	//Test 4: Group chats are ignored
+	sellerCode, _ = CreateLinkCode(db, SellerAccount, sellerId)
+	resErr = HandleUpdate(db, newTestUpdate(300, "group", "/start "+sellerCode.Code))
+	assert.Empty(t, resErr)
+	link, _ = GetTelegramLink(db, SellerAccount, sellerId)
+	assert.Equal(t, false, link.Linked)
+	assert.Equal(t, 3, len(api.messages))
+
+	//Test 5: Buyer and seller can be linked to the same chat
+	resErr = HandleUpdate(db, newTestUpdate(100, "private", "/start "+sellerCode.Code))
+	assert.Empty(t, resErr)
+	link, _ = GetTelegramLink(db, SellerAccount, sellerId)
+	assert.Equal(t, true, link.Linked)
+	assert.Contains(t, api.messages[3].Text, "seller account is linked")
+
+	//Test 6: Stop unlinks every account from the chat
+	resErr = HandleUpdate(db, newTestUpdate(100, "private", "/stop"))
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, countRows(t, db, "telegram_chats"))
+	assert.Equal(t, "You will no longer get Aucto notifications here.", api.messages[4].Text)
+
+	//Test 7: Other messages get the help reply
+	resErr = HandleUpdate(db, newTestUpdate(100, "private", "hello"))
+	assert.Empty(t, resErr)
+	assert.Contains(t, api.messages[5].Text, "Open the Telegram link on Aucto")
+
+	store.CloseDB(db)
+}
+
+func TestUnlinkTelegram(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerId, _ := createDummyAccounts(t, db)
+	linkTestChat(t, db, BuyerAccount, buyerId, 100)
+
+	//Test 1: Account is unlinked
+	res, resErr := UnlinkTelegram(db, BuyerAccount, buyerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, false, res.Linked)
+	link, _ := GetTelegramLink(db, BuyerAccount, buyerId)
+	assert.Equal(t, false, link.Linked)
+
+	//Test 2: Unlinking an account that is not linked does nothing
+	_, resErr = UnlinkTelegram(db, BuyerAccount, buyerId)
+	assert.Empty(t, resErr)
+
+	//Test 3: Account does not exist
+	_, resErr = UnlinkTelegram(db, BuyerAccount, "wrong id")
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func createDummyAccounts(t *testing.T, db *sql.DB) (string, string) {
+	var buyerId, sellerId string
+
+	query := `INSERT INTO buyers(email, password) VALUES ('test@aucto.io','test') RETURNING buyer_id;`
+	err := db.QueryRowContext(context.Background(), query).Scan(&buyerId)
+	assert.NoError(t, err)
+
+	query = `INSERT INTO sellers(email, seller_name, password) VALUES ('test@aucto.io','test','test') RETURNING seller_id`
+	err = db.QueryRowContext(context.Background(), query).Scan(&sellerId)
+	assert.NoError(t, err)
+
+	return buyerId, sellerId
+}
+
+func linkTestChat(t *testing.T, db *sql.DB, accountType string, accountId string, chatId int64) {
+	query := `INSERT INTO telegram_chats(account_type, account_id, chat_id, username) VALUES ($1, $2, $3, 'tester');`
+	_, err := db.ExecContext(context.Background(), query, accountType, accountId, chatId)
+	assert.NoError(t, err)
+}
+
+func newTestUpdate(chatId int64, chatType string, text string) data.TelegramUpdateData {
+	return data.TelegramUpdateData{UpdateId: 1, Message: &data.TelegramMessageData{MessageId: 1,
+		From: data.TelegramUserData{Id: chatId, Username: "tester"}, Chat: data.TelegramChatData{Id: chatId, Type: chatType},
+		Text: text}}
+}
+
+func countRows(t *testing.T, db *sql.DB, table string) int {
+	var count int
+	err := db.QueryRowContext(context.Background(), `SELECT COUNT(*) FROM `+table+`;`).Scan(&count)
+	assert.NoError(t, err)
+	return count
+}
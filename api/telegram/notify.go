@@ -0,0 +1,345 @@
+package telegram
+
+import (
+	"BackendAPI/api/outbox"
+	"BackendAPI/data"
+	"BackendAPI/mailing"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Topic of the outbox messages that are sent to Telegram chats
+const OutboxTopic = "telegram"
+
+/*
+Sends a Telegram message from the outbox with the client in use
+*/
+func DeliverOutboxMessage(payload []byte) error {
+	var message data.TelegramOutboxMessageData
+	err := json.Unmarshal(payload, &message)
+
+	if err != nil {
+		return err
+	}
+
+	client, err := getClient()
+
+	if err != nil {
+		return err
+	}
+
+	return client.SendMessage(message.ChatId, message.Text)
+}
+
+/*
+Adds a message for the chat linked to an account to the outbox as part of a transaction. Accounts that are not
+linked to a chat are skipped. Returns the id of the message in the outbox, if it was added.
+*/
+func AddMessage(tx *sql.Tx, accountType string, accountId string, text string) (string, *utils.ErrorHandler) {
+	var chatId int64
+
+	query := `SELECT chat_id FROM telegram_chats WHERE account_type = $1 AND account_id::TEXT = $2;`
+	err := tx.QueryRowContext(context.Background(), query, accountType, accountId).Scan(&chatId)
+
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Telegram Chat rows")
+		return "", errResp
+	}
+
+	return addChatMessage(tx, chatId, text)
+}
+
+/*
+Adds the messages for an order that has been paid, a confirmation for the buyer and a new order alert for the seller
+of every sub-order. Alerts include the Telegram handle the buyer gave so that sellers can reach them. Returns the ids
+of the messages that were added.
+*/
+func AddOrderPaidMessages(tx *sql.Tx, orderId string, isGuest bool) ([]string, *utils.ErrorHandler) {
+	var messageIds []string
+
+	if !isGuest {
+		var buyerId string
+		var total int
+		query := `SELECT buyer_id, total_paid FROM orders WHERE order_id = $1;`
+		err := tx.QueryRowContext(context.Background(), query, orderId).Scan(&buyerId, &total)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Order rows")
+			return messageIds, errResp
+		}
+
+		text := fmt.Sprintf("Your order %s is confirmed, %s was paid. We will let you know when it ships.", orderId, mailing.FormatPrice(total))
+		messageId, addErr := AddMessage(tx, BuyerAccount, buyerId, text)
+
+		if addErr != nil {
+			return messageIds, addErr
+		}
+
+		messageIds = appendMessageId(messageIds, messageId)
+	}
+
+	type paidSubOrder struct {
+		subOrderId     string
+		sellerId       string
+		total          int
+		telegramHandle string
+	}
+
+	query := `SELECT sub_orders.sub_order_id, sub_orders.seller_id,
+			sub_orders.products_cost - sub_orders.discount + sub_orders.delivery_fee,
+			COALESCE(orders.telegram_handle, guest_orders.telegram_handle, '')
+		FROM (sub_orders LEFT OUTER JOIN orders ON orders.order_id = sub_orders.order_id)
+			LEFT OUTER JOIN guest_orders ON guest_orders.guest_order_id = sub_orders.guest_order_id
+		WHERE (sub_orders.order_id::TEXT = $1 OR sub_orders.guest_order_id::TEXT = $1) AND sub_orders.status = 'paid';`
+	rows, err := tx.QueryContext(context.Background(), query, orderId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Sub Order rows")
+		return messageIds, errResp
+	}
+
+	var subOrders []paidSubOrder
+
+	for rows.Next() {
+		var subOrder paidSubOrder
+		err = rows.Scan(&subOrder.subOrderId, &subOrder.sellerId, &subOrder.total, &subOrder.telegramHandle)
+
+		if err != nil {
+			rows.Close()
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Sub Order rows")
+			return messageIds, errResp
+		}
+
+		subOrders = append(subOrders, subOrder)
+	}
+
+	rows.Close()
+
+	for i := 0; i < len(subOrders); i++ {
+		items, itemErr := getSubOrderItems(tx, subOrders[i].subOrderId)
+
+		if itemErr != nil {
+			return messageIds, itemErr
+		}
+
+		text := fmt.Sprintf("New order! %s for %s is paid and ready to ship, sub-order %s.", items,
+			mailing.FormatPrice(subOrders[i].total), subOrders[i].subOrderId)
+		if handle := strings.TrimPrefix(subOrders[i].telegramHandle, "@"); handle != "" {
+			text += " The buyer is @" + handle + " on Telegram."
+		}
+
+		messageId, addErr := AddMessage(tx, SellerAccount, subOrders[i].sellerId, text)
+
+		if addErr != nil {
+			return messageIds, addErr
+		}
+
+		messageIds = appendMessageId(messageIds, messageId)
+	}
+
+	return messageIds, nil
+}
+
+/*
+Adds the message that lets the buyer of a sub-order know that it has been shipped or delivered. Sub-orders of guest
+orders are skipped since guests have no account to link.
+*/
+func AddSubOrderStatusMessage(tx *sql.Tx, subOrderId string, status string) (string, *utils.ErrorHandler) {
+	var buyerId, orderId, sellerName string
+
+	query := `SELECT orders.buyer_id, orders.order_id, sellers.seller_name
+		FROM (sub_orders INNER JOIN orders ON orders.order_id = sub_orders.order_id)
+			INNER JOIN sellers ON sellers.seller_id = sub_orders.seller_id
+		WHERE sub_orders.sub_order_id = $1;`
+	err := tx.QueryRowContext(context.Background(), query, subOrderId).Scan(&buyerId, &orderId, &sellerName)
+
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Sub Order rows")
+		return "", errResp
+	}
+
+	var text string
+	switch status {
+	case "shipped":
+		text = fmt.Sprintf("%s has shipped their part of your order %s.", sellerName, orderId)
+	case "delivered":
+		text = fmt.Sprintf("Your order %s from %s has been delivered. Let others know how it went by leaving a review.", orderId, sellerName)
+	default:
+		return "", nil
+	}
+
+	return AddMessage(tx, BuyerAccount, buyerId, text)
+}
+
+/*
+Adds the message that lets the buyer or seller of an offer know what the other side did with it, based on the status
+of the offer after the change
+*/
+func AddOfferMessage(tx *sql.Tx, offerId string, recipientType string) (string, *utils.ErrorHandler) {
+	var buyerId, sellerId, title, status string
+	var quantity, amount, counterAmount int
+
+	query := `SELECT offers.buyer_id, products.seller_id, products.title, offers.quantity, offers.amount,
+			COALESCE(offers.counter_amount, 0), offers.status
+		FROM offers INNER JOIN products ON products.product_id = offers.product_id
+		WHERE offers.offer_id = $1;`
+	err := tx.QueryRowContext(context.Background(), query, offerId).Scan(&buyerId, &sellerId, &title, &quantity, &amount,
+		&counterAmount, &status)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Offer rows")
+		return "", errResp
+	}
+
+	var text string
+
+	if recipientType == SellerAccount {
+		switch status {
+		case "pending":
+			text = fmt.Sprintf("New offer of %s for %d x %s.", mailing.FormatPrice(amount), quantity, title)
+		case "accepted":
+			text = fmt.Sprintf("The buyer accepted your counter offer of %s for %s.", mailing.FormatPrice(counterAmount), title)
+		case "rejected":
+			text = fmt.Sprintf("The buyer rejected your counter offer of %s for %s.", mailing.FormatPrice(counterAmount), title)
+		}
+
+		return addOptionalMessage(tx, SellerAccount, sellerId, text)
+	}
+
+	switch status {
+	case "countered":
+		text = fmt.Sprintf("The seller countered your offer on %s with %s.", title, mailing.FormatPrice(counterAmount))
+	case "accepted":
+		text = fmt.Sprintf("Your offer of %s for %s was accepted. Check out soon, the product is only held for you for a while.",
+			mailing.FormatPrice(amount), title)
+	case "rejected":
+		text = fmt.Sprintf("Your offer of %s for %s was rejected.", mailing.FormatPrice(amount), title)
+	}
+
+	return addOptionalMessage(tx, BuyerAccount, buyerId, text)
+}
+
+/*
+Adds the messages for a bid on an auction, the seller is told about the bid and the previous highest bidder is told
+that they have been outbid
+*/
+func AddBidMessages(tx *sql.Tx, productId string, previousBidderId string, amount int) ([]string, *utils.ErrorHandler) {
+	var messageIds []string
+	var sellerId, title string
+
+	query := `SELECT seller_id, title FROM products WHERE product_id = $1;`
+	err := tx.QueryRowContext(context.Background(), query, productId).Scan(&sellerId, &title)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Product rows")
+		return messageIds, errResp
+	}
+
+	messageId, addErr := AddMessage(tx, SellerAccount, sellerId, fmt.Sprintf("New bid of %s on %s.", mailing.FormatPrice(amount), title))
+
+	if addErr != nil {
+		return messageIds, addErr
+	}
+
+	messageIds = appendMessageId(messageIds, messageId)
+
+	if previousBidderId != "" {
+		text := fmt.Sprintf("You have been outbid on %s, the highest bid is now %s.", title, mailing.FormatPrice(amount))
+		messageId, addErr = AddMessage(tx, BuyerAccount, previousBidderId, text)
+
+		if addErr != nil {
+			return messageIds, addErr
+		}
+
+		messageIds = appendMessageId(messageIds, messageId)
+	}
+
+	return messageIds, nil
+}
+
+/*
+Adds a message to the outbox for a chat
+*/
+func addChatMessage(tx *sql.Tx, chatId int64, text string) (string, *utils.ErrorHandler) {
+	return outbox.AddMessage(tx, OutboxTopic, data.TelegramOutboxMessageData{ChatId: chatId, Text: text})
+}
+
+/*
+Adds a message for an account unless there is nothing to say
+*/
+func addOptionalMessage(tx *sql.Tx, accountType string, accountId string, text string) (string, *utils.ErrorHandler) {
+	if text == "" {
+		return "", nil
+	}
+
+	return AddMessage(tx, accountType, accountId, text)
+}
+
+/*
+Gets the products of a sub-order as a list of quantities and titles
+*/
+func getSubOrderItems(tx *sql.Tx, subOrderId string) (string, *utils.ErrorHandler) {
+	query := `SELECT products.title, sub_order_products.quantity
+		FROM (SELECT product_id, quantity FROM order_products WHERE sub_order_id = $1
+			UNION ALL SELECT product_id, quantity FROM guest_order_products WHERE sub_order_id = $1) AS sub_order_products
+			INNER JOIN products ON products.product_id = sub_order_products.product_id
+		ORDER BY products.title;`
+	rows, err := tx.QueryContext(context.Background(), query, subOrderId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Sub Order product rows")
+		return "", errResp
+	}
+
+	defer rows.Close()
+
+	var items []string
+
+	for rows.Next() {
+		var title string
+		var quantity int
+		err = rows.Scan(&title, &quantity)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Sub Order product rows")
+			return "", errResp
+		}
+
+		items = append(items, strconv.Itoa(quantity)+" x "+title)
+	}
+
+	return strings.Join(items, ", "), nil
+}
+
+/*
+Adds the id of an outbox message to the messages to deliver, accounts without a chat have no id
+*/
+func appendMessageId(messageIds []string, messageId string) []string {
+	if messageId == "" {
+		return messageIds
+	}
+
+	return append(messageIds, messageId)
+}
@@ -0,0 +1,223 @@
+package telegram
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddMessage(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerId, sellerId := createDummyAccounts(t, db)
+	linkTestChat(t, db, SellerAccount, sellerId, 100)
+
+	//Test 1: Account that is not linked is skipped
+	tx, err := db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	messageId, resErr := AddMessage(tx, BuyerAccount, buyerId, "Hello")
+	assert.Empty(t, resErr)
+	assert.Empty(t, messageId)
+
+	//Test 2: Message for a linked account is added for its chat
+	messageId, resErr = AddMessage(tx, SellerAccount, sellerId, "Hello")
+	assert.Empty(t, resErr)
+	assert.NotEmpty(t, messageId)
+	assert.NoError(t, tx.Commit())
+	assert.Equal(t, []data.TelegramOutboxMessageData{{ChatId: 100, Text: "Hello"}}, getTestMessages(t, db))
+
+	store.CloseDB(db)
+}
+
+func TestDeliverOutboxMessage(t *testing.T) {
+	api := newFakeTelegramApi()
+	defer api.server.Close()
+	UseClient(NewClient(api.server.URL, "token", "AuctoBot"))
+
+	//Test 1: Message is sent to its chat
+	err := DeliverOutboxMessage([]byte(`{"chat_id": 100, "text": "Hello"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []sentMessage{{ChatId: 100, Text: "Hello"}}, api.messages)
+
+	//Test 2: Failed message returns an error so that it is retried
+	api.failing = true
+	err = DeliverOutboxMessage([]byte(`{"chat_id": 100, "text": "Hello"}`))
+	assert.Error(t, err)
+
+	//Test 3: Bad payload
+	err = DeliverOutboxMessage([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestAddOrderPaidMessages(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerId, sellerId := createDummyAccounts(t, db)
+	productId := createTestProduct(t, db, sellerId)
+	linkTestChat(t, db, BuyerAccount, buyerId, 100)
+	linkTestChat(t, db, SellerAccount, sellerId, 200)
+
+	var orderId, subOrderId string
+	query := `INSERT INTO orders(buyer_id, delivery_type, payment_type, phone_number, order_date, address_line_1,
+			postal_code, telegram_handle, delivery_fee, payment_fee, small_order_fee, total_paid)
+		VALUES ($1, 'normal', 'paynow', '91234567', NOW(), 'Test', '123456', '@tester', 300, 0, 0, 20300) RETURNING order_id;`
+	err = db.QueryRowContext(context.Background(), query, buyerId).Scan(&orderId)
+	assert.NoError(t, err)
+	query = `INSERT INTO sub_orders(order_id, seller_id, products_cost, delivery_fee, status, created_date, updated_date)
+		VALUES ($1, $2, 20000, 300, 'paid', NOW(), NOW()) RETURNING sub_order_id;`
+	err = db.QueryRowContext(context.Background(), query, orderId, sellerId).Scan(&subOrderId)
+	assert.NoError(t, err)
+	query = `INSERT INTO order_products(order_id, product_id, quantity, sub_order_id) VALUES ($1, $2, 2, $3);`
+	_, err = db.ExecContext(context.Background(), query, orderId, productId, subOrderId)
+	assert.NoError(t, err)
+
+	//Test 1: Buyer gets a confirmation and the seller gets an alert with the buyer's handle
+	tx, err := db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	messageIds, resErr := AddOrderPaidMessages(tx, orderId, false)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, len(messageIds))
+	assert.NoError(t, tx.Commit())
+	messages := getTestMessages(t, db)
+	assert.Equal(t, int64(100), messages[0].ChatId)
+	assert.Equal(t, "Your order "+orderId+" is confirmed, $203.00 was paid. We will let you know when it ships.", messages[0].Text)
+	assert.Equal(t, int64(200), messages[1].ChatId)
+	assert.Equal(t, "New order! 2 x Test for $203.00 is paid and ready to ship, sub-order "+subOrderId+
+		". The buyer is @tester on Telegram.", messages[1].Text)
+
+	//Test 2: Shipped sub-order is sent to the buyer
+	tx, err = db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	messageId, resErr := AddSubOrderStatusMessage(tx, subOrderId, "shipped")
+	assert.Empty(t, resErr)
+	assert.NotEmpty(t, messageId)
+	assert.NoError(t, tx.Commit())
+	messages = getTestMessages(t, db)
+	assert.Equal(t, "test has shipped their part of your order "+orderId+".", messages[2].Text)
+
+	store.CloseDB(db)
+}
+
+func TestAddOfferMessage(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerId, sellerId := createDummyAccounts(t, db)
+	productId := createTestProduct(t, db, sellerId)
+	linkTestChat(t, db, BuyerAccount, buyerId, 100)
+	linkTestChat(t, db, SellerAccount, sellerId, 200)
+
+	var offerId string
+	query := `INSERT INTO offers(product_id, buyer_id, amount, created_date, expires_at)
+		VALUES ($1, $2, 8000, NOW(), $3) RETURNING offer_id;`
+	err = db.QueryRowContext(context.Background(), query, productId, buyerId, time.Now().Add(time.Hour)).Scan(&offerId)
+	assert.NoError(t, err)
+
+	//Test 1: Seller is told about a new offer
+	addTestOfferMessage(t, db, offerId, SellerAccount)
+
+	//Test 2: Buyer is told about a counter offer
+	_, err = db.ExecContext(context.Background(), `UPDATE offers SET status = 'countered', counter_amount = 9000;`)
+	assert.NoError(t, err)
+	addTestOfferMessage(t, db, offerId, BuyerAccount)
+
+	//Test 3: Seller is told that the counter offer was accepted
+	_, err = db.ExecContext(context.Background(), `UPDATE offers SET status = 'accepted';`)
+	assert.NoError(t, err)
+	addTestOfferMessage(t, db, offerId, SellerAccount)
+
+	messages := getTestMessages(t, db)
+	assert.Equal(t, []data.TelegramOutboxMessageData{
+		{ChatId: 200, Text: "New offer of $80.00 for 1 x Test."},
+		{ChatId: 100, Text: "The seller countered your offer on Test with $90.00."},
+		{ChatId: 200, Text: "The buyer accepted your counter offer of $90.00 for Test."},
+	}, messages)
+
+	//Test 4: Status without a message is skipped
+	_, err = db.ExecContext(context.Background(), `UPDATE offers SET status = 'expired';`)
+	assert.NoError(t, err)
+	addTestOfferMessage(t, db, offerId, BuyerAccount)
+	assert.Equal(t, 3, len(getTestMessages(t, db)))
+
+	store.CloseDB(db)
+}
+
+func TestAddBidMessages(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerId, sellerId := createDummyAccounts(t, db)
+	productId := createTestProduct(t, db, sellerId)
+	linkTestChat(t, db, BuyerAccount, buyerId, 100)
+	linkTestChat(t, db, SellerAccount, sellerId, 200)
+
+	//Test 1: First bid is only sent to the seller
+	tx, err := db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	messageIds, resErr := AddBidMessages(tx, productId, "", 12000)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(messageIds))
+	assert.NoError(t, tx.Commit())
+
+	//Test 2: Previous highest bidder is told they were outbid
+	tx, err = db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	messageIds, resErr = AddBidMessages(tx, productId, buyerId, 13000)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, len(messageIds))
+	assert.NoError(t, tx.Commit())
+
+	messages := getTestMessages(t, db)
+	assert.Equal(t, []data.TelegramOutboxMessageData{
+		{ChatId: 200, Text: "New bid of $120.00 on Test."},
+		{ChatId: 100, Text: "You have been outbid on Test, the highest bid is now $130.00."},
+		{ChatId: 200, Text: "New bid of $130.00 on Test."},
+	}, messages)
+
+	store.CloseDB(db)
+}
+
+func addTestOfferMessage(t *testing.T, db *sql.DB, offerId string, recipientType string) {
+	tx, err := db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	_, resErr := AddOfferMessage(tx, offerId, recipientType)
+	assert.Empty(t, resErr)
+	assert.NoError(t, tx.Commit())
+}
+
+func createTestProduct(t *testing.T, db *sql.DB, sellerId string) string {
+	var productId string
+	query := `INSERT INTO products(
+		title, seller_id, description, product_type, language, expansion, posted_date, price, condition, product_quantity)
+		VALUES ('Test', $1, 'This is a test description', 'Buy-Now', 'Eng', 'Test', NOW(), 10000, 5, 3) RETURNING product_id;`
+	err := db.QueryRowContext(context.Background(), query, sellerId).Scan(&productId)
+	assert.NoError(t, err)
+	return productId
+}
+
+func getTestMessages(t *testing.T, db *sql.DB) []data.TelegramOutboxMessageData {
+	query := `SELECT payload FROM outbox_messages WHERE topic = $1 ORDER BY created_date, (payload->>'chat_id')::BIGINT;`
+	rows, err := db.QueryContext(context.Background(), query, OutboxTopic)
+	assert.NoError(t, err)
+	defer rows.Close()
+
+	var messages []data.TelegramOutboxMessageData
+	for rows.Next() {
+		var payload []byte
+		var message data.TelegramOutboxMessageData
+		assert.NoError(t, rows.Scan(&payload))
+		assert.NoError(t, json.Unmarshal(payload, &message))
+		messages = append(messages, message)
+	}
+
+	return messages
+}
@@ -0,0 +1,338 @@
+package watchlist
+
+import (
+	"BackendAPI/api/buyer"
+	"BackendAPI/api/notification"
+	"BackendAPI/api/outbox"
+	"BackendAPI/api/product"
+	"BackendAPI/data"
+	"BackendAPI/mailing"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+)
+
+// Buyers are told an auction on their watchlist is ending once it is this close to ending
+const auctionEndingSoonWindow = time.Hour
+
+const watchlistQuery = `SELECT products.product_id, products.title, products.product_type, products.price,
+		products.price - COALESCE(product_effective_discounts.discount, 0),
+		products.sold_quantity >= products.product_quantity, COALESCE(auction_information.ends_at::TEXT, ''),
+		watchlist_products.created_date::TEXT
+	FROM ((watchlist_products INNER JOIN products ON products.product_id = watchlist_products.product_id)
+		LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id)
+		LEFT OUTER JOIN auction_information ON auction_information.product_id = products.product_id`
+
+/*
+Adds a product to the watchlist of a buyer. The buyer is alerted when the effective price of the product drops,
+when it is restocked after selling out and when its auction is ending soon. Adding a product that is already
+on the watchlist does nothing.
+*/
+func AddWatchlistProduct(db *sql.DB, buyerId string, productId string) (data.WatchlistProductData, *utils.ErrorHandler) {
+	if !buyer.DoesBuyerExist(db, buyerId) {
+		return data.WatchlistProductData{}, utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	if !product.DoesProductExist(db, productId) {
+		return data.WatchlistProductData{}, utils.NotFoundError("Product with given id does not exist")
+	}
+
+	query := `INSERT INTO watchlist_products(buyer_id, product_id, alert_price, sold_out, created_date)
+		SELECT $1, products.product_id, products.price - COALESCE(product_effective_discounts.discount, 0),
+			products.sold_quantity >= products.product_quantity, $3
+		FROM products LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id
+		WHERE products.product_id = $2
+		ON CONFLICT (buyer_id, product_id) DO NOTHING;`
+	_, err := db.ExecContext(context.Background(), query, buyerId, productId, time.Now())
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Watchlist Product rows")
+		return data.WatchlistProductData{}, errResp
+	}
+
+	products, watchlistErr := getWatchlistProducts(db, `watchlist_products.buyer_id = $1 AND watchlist_products.product_id = $2`,
+		buyerId, productId)
+
+	if watchlistErr != nil || len(products) == 0 {
+		return data.WatchlistProductData{}, watchlistErr
+	}
+
+	return products[0], nil
+}
+
+/*
+Removes a product from the watchlist of a buyer. Returns a 404 Not found error if the product is not on the watchlist.
+*/
+func RemoveWatchlistProduct(db *sql.DB, buyerId string, productId string) (data.GetWatchlistResponseData, *utils.ErrorHandler) {
+	query := `DELETE FROM watchlist_products WHERE buyer_id::TEXT = $1 AND product_id::TEXT = $2;`
+	res, err := db.ExecContext(context.Background(), query, buyerId, productId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in deleting Watchlist Product rows")
+		return data.GetWatchlistResponseData{}, errResp
+	}
+
+	if count, _ := res.RowsAffected(); count == 0 {
+		return data.GetWatchlistResponseData{}, utils.NotFoundError("Product is not on the watchlist")
+	}
+
+	return GetWatchlist(db, buyerId)
+}
+
+/*
+Gets the products on the watchlist of a buyer from the most recently added product. Returns a 404 Not found error
+if the buyer does not exist.
+*/
+func GetWatchlist(db *sql.DB, buyerId string) (data.GetWatchlistResponseData, *utils.ErrorHandler) {
+	response := data.GetWatchlistResponseData{BuyerId: buyerId, Products: []data.WatchlistProductData{}}
+
+	if !buyer.DoesBuyerExist(db, buyerId) {
+		return response, utils.NotFoundError("Buyer with given id does not exist")
+	}
+
+	products, watchlistErr := getWatchlistProducts(db, `watchlist_products.buyer_id = $1`, buyerId)
+	response.Products = append(response.Products, products...)
+
+	return response, watchlistErr
+}
+
+/*
+Checks every watched product for changes since the last check and notifies the buyers watching them. Buyers are
+alerted when the effective price of a product that is not sold out drops, when a sold out product is restocked and
+when an open auction is ending soon. Each change is only alerted once.
+*/
+func CheckWatchlistAlerts(db *sql.DB) (data.CheckWatchlistAlertsResponseData, *utils.ErrorHandler) {
+	return checkWatchlistAlerts(db, notification.AddNotification)
+}
+
+/*
+Checks the watched products for changes and alerts the buyers with the given function that adds a notification
+*/
+func checkWatchlistAlerts(db *sql.DB, addNotification addNotificationFunc) (data.CheckWatchlistAlertsResponseData, *utils.ErrorHandler) {
+	var response data.CheckWatchlistAlertsResponseData
+
+	query := `SELECT watchlist_products.buyer_id, products.product_id, products.title, products.product_type,
+			watchlist_products.alert_price, products.price - COALESCE(product_effective_discounts.discount, 0),
+			watchlist_products.sold_out, products.sold_quantity >= products.product_quantity,
+			NOT watchlist_products.ending_alerted AND COALESCE(auction_information.status = 'open'
+				AND auction_information.ends_at <= $1, FALSE)
+		FROM ((watchlist_products INNER JOIN products ON products.product_id = watchlist_products.product_id)
+			LEFT OUTER JOIN product_effective_discounts ON product_effective_discounts.product_id = products.product_id)
+			LEFT OUTER JOIN auction_information ON auction_information.product_id = products.product_id
+		WHERE watchlist_products.alert_price <> products.price - COALESCE(product_effective_discounts.discount, 0)
+			OR watchlist_products.sold_out <> (products.sold_quantity >= products.product_quantity)
+			OR (NOT watchlist_products.ending_alerted AND auction_information.status = 'open' AND auction_information.ends_at <= $1);`
+	rows, err := db.QueryContext(context.Background(), query, time.Now().Add(auctionEndingSoonWindow))
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Watchlist Product rows")
+		return response, errResp
+	}
+
+	var changes []watchlistChange
+
+	for rows.Next() {
+		var change watchlistChange
+		err = rows.Scan(&change.buyerId, &change.productId, &change.title, &change.productType, &change.alertPrice,
+			&change.effectivePrice, &change.wasSoldOut, &change.soldOut, &change.endingSoon)
+
+		if err != nil {
+			rows.Close()
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Watchlist Product rows")
+			return response, errResp
+		}
+
+		changes = append(changes, change)
+	}
+
+	rows.Close()
+
+	for i := 0; i < len(changes); i++ {
+		notified, alertErr := alertWatchlistChange(db, changes[i], addNotification)
+
+		if alertErr != nil {
+			return response, alertErr
+		}
+
+		for j := 0; j < len(notified); j++ {
+			switch notified[j] {
+			case notification.PriceDropNotification:
+				response.PriceDrops++
+			case notification.RestockNotification:
+				response.Restocks++
+			case notification.AuctionEndingNotification:
+				response.AuctionsEnding++
+			}
+		}
+	}
+
+	return response, nil
+}
+
+/*
+Adds a notification of a buyer and its mail as part of a transaction, see notification.AddNotification
+*/
+type addNotificationFunc func(tx *sql.Tx, buyerId string, notificationType string, title string, message string,
+	productId string) (bool, string, *utils.ErrorHandler)
+
+/*
+Alerts the buyer of a change to a watched product and records the new state of the product in one transaction, so
+a change is never alerted twice or recorded without its alert. Returns the types of notifications the buyer got.
+*/
+func alertWatchlistChange(db *sql.DB, change watchlistChange, addNotification addNotificationFunc) ([]string, *utils.ErrorHandler) {
+	var notified []string
+	var messageIds []string
+
+	tx, err := db.BeginTx(context.Background(), nil)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in starting Watchlist transaction")
+		return nil, errResp
+	}
+
+	defer tx.Rollback()
+
+	notify := func(notificationType string, title string, message string) *utils.ErrorHandler {
+		isNotified, messageId, notifyErr := addNotification(tx, change.buyerId, notificationType, title, message, change.productId)
+
+		if notifyErr != nil {
+			return notifyErr
+		}
+		if isNotified {
+			notified = append(notified, notificationType)
+		}
+		if messageId != "" {
+			messageIds = append(messageIds, messageId)
+		}
+
+		return nil
+	}
+
+	if change.productType != "Auction" && change.effectivePrice < change.alertPrice && !change.soldOut {
+		notifyErr := notify(notification.PriceDropNotification, "Price drop on "+change.title,
+			change.title+" is now "+mailing.FormatPrice(change.effectivePrice)+", down from "+mailing.FormatPrice(change.alertPrice)+".")
+
+		if notifyErr != nil {
+			return nil, notifyErr
+		}
+	}
+
+	if change.wasSoldOut && !change.soldOut {
+		notifyErr := notify(notification.RestockNotification, change.title+" is back in stock",
+			change.title+" has been restocked.")
+
+		if notifyErr != nil {
+			return nil, notifyErr
+		}
+	}
+
+	if change.endingSoon {
+		notifyErr := notify(notification.AuctionEndingNotification, "Auction for "+change.title+" is ending soon",
+			"The auction for "+change.title+" ends within "+formatDuration(auctionEndingSoonWindow)+".")
+
+		if notifyErr != nil {
+			return nil, notifyErr
+		}
+	}
+
+	query := `UPDATE watchlist_products SET alert_price = $3, sold_out = $4, ending_alerted = ending_alerted OR $5
+		WHERE buyer_id = $1 AND product_id = $2;`
+	_, err = tx.ExecContext(context.Background(), query, change.buyerId, change.productId, change.effectivePrice,
+		change.soldOut, change.endingSoon)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in updating Watchlist Product rows")
+		return nil, errResp
+	}
+
+	err = tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Watchlist transaction")
+		return nil, errResp
+	}
+
+	outbox.DeliverMessages(db, messageIds)
+
+	return notified, nil
+}
+
+/*
+The state of a watched product at the last check and now
+*/
+type watchlistChange struct {
+	buyerId        string
+	productId      string
+	title          string
+	productType    string
+	alertPrice     int
+	effectivePrice int
+	wasSoldOut     bool
+	soldOut        bool
+	endingSoon     bool
+}
+
+/*
+Gets the watched products that match a condition from the most recently added product
+*/
+func getWatchlistProducts(db *sql.DB, condition string, args ...interface{}) ([]data.WatchlistProductData, *utils.ErrorHandler) {
+	var products []data.WatchlistProductData
+
+	query := watchlistQuery + ` WHERE ` + condition + ` ORDER BY watchlist_products.created_date DESC;`
+	rows, err := db.QueryContext(context.Background(), query, args...)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Watchlist Product rows")
+		return products, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var watched data.WatchlistProductData
+		err = rows.Scan(&watched.ProductId, &watched.Title, &watched.ProductType, &watched.Price, &watched.EffectivePrice,
+			&watched.SoldOut, &watched.EndsAt, &watched.CreatedDate)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Watchlist Product rows")
+			return products, errResp
+		}
+
+		products = append(products, watched)
+	}
+
+	return products, nil
+}
+
+/*
+Formats a duration in whole hours, or in minutes if it is not a whole number of hours
+*/
+func formatDuration(duration time.Duration) string {
+	if duration%time.Hour == 0 {
+		return formatCount(int(duration/time.Hour), "hour")
+	}
+
+	return formatCount(int(duration/time.Minute), "minute")
+}
+
+/*
+Formats a count with its unit, adding an s to the unit unless the count is one
+*/
+func formatCount(count int, unit string) string {
+	if count == 1 {
+		return "1 " + unit
+	}
+
+	return strconv.Itoa(count) + " " + unit + "s"
+}
@@ -0,0 +1,184 @@
+package watchlist
+
+import (
+	"BackendAPI/api/notification"
+	"BackendAPI/store"
+	"BackendAPI/utils"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddWatchlistProduct(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerId, sellerId := createDummyWatchlistData(t, db)
+	productId := createDummyWatchlistProduct(t, db, sellerId, "Buy-Now")
+
+	//Test 1: Add a product to the watchlist
+	res, resErr := AddWatchlistProduct(db, buyerId, productId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, productId, res.ProductId)
+	assert.Equal(t, 1000, res.EffectivePrice)
+	assert.Equal(t, false, res.SoldOut)
+
+	//Test 2: Adding the product again does nothing
+	_, resErr = AddWatchlistProduct(db, buyerId, productId)
+	assert.Empty(t, resErr)
+	watchlist, resErr := GetWatchlist(db, buyerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(watchlist.Products))
+
+	//Test 3: Product does not exist
+	_, resErr = AddWatchlistProduct(db, buyerId, "wrong id")
+	assert.Error(t, resErr)
+	assert.Equal(t, "Product with given id does not exist", resErr.Error())
+
+	//Test 4: Remove the product from the watchlist
+	watchlist, resErr = RemoveWatchlistProduct(db, buyerId, productId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(watchlist.Products))
+
+	//Test 5: Product is not on the watchlist
+	_, resErr = RemoveWatchlistProduct(db, buyerId, productId)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Product is not on the watchlist", resErr.Error())
+
+	store.CloseDB(db)
+}
+
+func TestCheckWatchlistAlerts(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	buyerId, sellerId := createDummyWatchlistData(t, db)
+	productId := createDummyWatchlistProduct(t, db, sellerId, "Buy-Now")
+	auctionId := createDummyWatchlistProduct(t, db, sellerId, "Auction")
+	AddWatchlistProduct(db, buyerId, productId)
+	AddWatchlistProduct(db, buyerId, auctionId)
+
+	var alerts []string
+	notify := func(tx *sql.Tx, buyerId string, notificationType string, title string, message string,
+		productId string) (bool, string, *utils.ErrorHandler) {
+		alerts = append(alerts, notificationType)
+		return true, "", nil
+	}
+
+	//Test 1: Nothing has changed
+	res, resErr := checkWatchlistAlerts(db, notify)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(alerts))
+
+	//Test 2: Price drop is alerted once
+	_, err = db.ExecContext(context.Background(), `UPDATE products SET price = 800 WHERE product_id = $1;`, productId)
+	assert.NoError(t, err)
+	res, resErr = checkWatchlistAlerts(db, notify)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, res.PriceDrops)
+	assert.Equal(t, []string{notification.PriceDropNotification}, alerts)
+
+	alerts = nil
+	res, resErr = checkWatchlistAlerts(db, notify)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(alerts))
+
+	//Test 3: Price increase is not alerted
+	_, err = db.ExecContext(context.Background(), `UPDATE products SET price = 1200 WHERE product_id = $1;`, productId)
+	assert.NoError(t, err)
+	res, resErr = checkWatchlistAlerts(db, notify)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(alerts))
+
+	//Test 4: Restock is alerted after the product sells out
+	_, err = db.ExecContext(context.Background(), `UPDATE products SET sold_quantity = 1 WHERE product_id = $1;`, productId)
+	assert.NoError(t, err)
+	res, resErr = checkWatchlistAlerts(db, notify)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(alerts))
+
+	_, err = db.ExecContext(context.Background(), `UPDATE products SET product_quantity = 2 WHERE product_id = $1;`, productId)
+	assert.NoError(t, err)
+	res, resErr = checkWatchlistAlerts(db, notify)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, res.Restocks)
+	assert.Equal(t, []string{notification.RestockNotification}, alerts)
+
+	//Test 5: Auction ending soon is alerted once
+	alerts = nil
+	_, err = db.ExecContext(context.Background(), `UPDATE auction_information SET ends_at = $2 WHERE product_id = $1;`,
+		auctionId, time.Now().Add(30*time.Minute))
+	assert.NoError(t, err)
+	res, resErr = checkWatchlistAlerts(db, notify)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, res.AuctionsEnding)
+	assert.Equal(t, []string{notification.AuctionEndingNotification}, alerts)
+
+	alerts = nil
+	res, resErr = checkWatchlistAlerts(db, notify)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 0, len(alerts))
+
+	//Test 6: Change is not recorded when the notification fails, so it is alerted at the next check
+	_, err = db.ExecContext(context.Background(), `UPDATE products SET price = 900 WHERE product_id = $1;`, productId)
+	assert.NoError(t, err)
+	failingNotify := func(tx *sql.Tx, buyerId string, notificationType string, title string, message string,
+		productId string) (bool, string, *utils.ErrorHandler) {
+		return false, "", utils.InternalServerError(nil)
+	}
+	_, resErr = checkWatchlistAlerts(db, failingNotify)
+	assert.Error(t, resErr)
+
+	res, resErr = checkWatchlistAlerts(db, notify)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, res.PriceDrops)
+	assert.Equal(t, []string{notification.PriceDropNotification}, alerts)
+
+	store.CloseDB(db)
+}
+
+func TestFormatDuration(t *testing.T) {
+	//Test 1: Whole hours are formatted in hours
+	assert.Equal(t, "1 hour", formatDuration(time.Hour))
+	assert.Equal(t, "2 hours", formatDuration(2*time.Hour))
+
+	//Test 2: Other durations are formatted in minutes
+	assert.Equal(t, "30 minutes", formatDuration(30*time.Minute))
+	assert.Equal(t, "90 minutes", formatDuration(90*time.Minute))
+}
+
+func createDummyWatchlistData(t *testing.T, db *sql.DB) (string, string) {
+	var buyerId, sellerId string
+
+	query := `INSERT INTO buyers(email, password) VALUES ('test@aucto.io','test') RETURNING buyer_id;`
+	err := db.QueryRowContext(context.Background(), query).Scan(&buyerId)
+	assert.NoError(t, err)
+
+	query = `INSERT INTO sellers(email, seller_name, password) VALUES ('test@aucto.io', 'test', 'test') RETURNING seller_id`
+	err = db.QueryRowContext(context.Background(), query).Scan(&sellerId)
+	assert.NoError(t, err)
+
+	return buyerId, sellerId
+}
+
+func createDummyWatchlistProduct(t *testing.T, db *sql.DB, sellerId string, productType string) string {
+	var productId string
+
+	query := `INSERT INTO products(title, seller_id, description, product_type, language, expansion, posted_date, price, condition, product_quantity)
+		VALUES ('Test', $1, 'Test', $2, 'Eng', 'Test', $3, 1000, 5, 1) RETURNING product_id;`
+	err := db.QueryRowContext(context.Background(), query, sellerId, productType, time.Now()).Scan(&productId)
+	assert.NoError(t, err)
+
+	if productType == "Auction" {
+		query = `INSERT INTO auction_information(product_id, start_price, starts_at, ends_at, extension_seconds)
+			VALUES ($1, 1000, $2, $3, 0);`
+		_, err = db.ExecContext(context.Background(), query, productId, time.Now(), time.Now().Add(24*time.Hour))
+		assert.NoError(t, err)
+	}
+
+	return productId
+}
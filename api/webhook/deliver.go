@@ -0,0 +1,210 @@
+package webhook
+
+import (
+	"BackendAPI/api/outbox"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// Topic of the outbox messages that send webhook deliveries
+const OutboxTopic = "webhook"
+
+const (
+	EventHeader     = "X-Aucto-Event"
+	DeliveryHeader  = "X-Aucto-Delivery"
+	SignatureHeader = "X-Aucto-Signature"
+)
+
+// Endpoints have to respond in time and redirects are not followed, a redirect counts as a failed attempt. Deliveries
+// are only made to public addresses, which are checked once the host has been resolved so that a host cannot be
+// pointed at the internal network after it has been registered.
+var httpClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: 5 * time.Second, Control: rejectNonPublicAddress}).DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+		MaxIdleConns:        20,
+		IdleConnTimeout:     90 * time.Second,
+		ForceAttemptHTTP2:   true,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// Address ranges that are not reachable on the public internet, on top of the loopback, private, link-local and
+// multicast ranges that net.IP already knows about
+var nonPublicNetworks = parseNetworks("0.0.0.0/8", "100.64.0.0/10", "192.0.0.0/24", "192.0.2.0/24", "198.18.0.0/15",
+	"198.51.100.0/24", "203.0.113.0/24", "240.0.0.0/4", "64:ff9b::/96", "100::/64", "2001:db8::/32")
+
+/*
+Creates the outbox handler that sends webhook deliveries. Each attempt is recorded on the delivery with its response
+code, a failed attempt returns an error so that the outbox retries it with backoff.
+*/
+func DeliveryHandler(db *sql.DB) outbox.Handler {
+	return func(payload []byte) error {
+		var message data.WebhookOutboxMessageData
+		err := json.Unmarshal(payload, &message)
+
+		if err != nil {
+			return err
+		}
+
+		return deliver(db, message.DeliveryId)
+	}
+}
+
+/*
+Signs the body of a delivery with the secret of its endpoint. The signature is the hex HMAC-SHA256 of the timestamp
+and the body joined by a dot, sent as "t=<timestamp>,v1=<signature>" so that endpoints can also reject old deliveries.
+*/
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+
+	return "t=" + strconv.FormatInt(timestamp, 10) + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+/*
+Sends a delivery to its endpoint and records the attempt. Deliveries of endpoints that have been deleted are dropped.
+*/
+func deliver(db *sql.DB, deliveryId string) error {
+	var endpoint, secret, eventType, payload string
+
+	query := `SELECT webhooks.url, webhooks.secret, webhook_deliveries.event_type, webhook_deliveries.payload::TEXT
+		FROM webhook_deliveries INNER JOIN webhooks ON webhooks.webhook_id = webhook_deliveries.webhook_id
+		WHERE webhook_deliveries.delivery_id::TEXT = $1;`
+	err := db.QueryRowContext(context.Background(), query, deliveryId).Scan(&endpoint, &secret, &eventType, &payload)
+
+	if err == sql.ErrNoRows {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	body := []byte(payload)
+	request, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+
+	if err != nil {
+		return recordAttempt(db, deliveryId, 0, err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "Aucto-Webhooks/1.0")
+	request.Header.Set(EventHeader, eventType)
+	request.Header.Set(DeliveryHeader, deliveryId)
+	request.Header.Set(SignatureHeader, Sign(secret, time.Now().Unix(), body))
+
+	response, err := httpClient.Do(request)
+
+	if err != nil {
+		return recordAttempt(db, deliveryId, 0, err)
+	}
+
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+	response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return recordAttempt(db, deliveryId, response.StatusCode, errors.New("Endpoint responded with status "+response.Status))
+	}
+
+	return recordAttempt(db, deliveryId, response.StatusCode, nil)
+}
+
+/*
+Records an attempt at a delivery with the response code of the endpoint, which is 0 if the endpoint could not be
+reached. Returns the error of the attempt.
+*/
+func recordAttempt(db *sql.DB, deliveryId string, responseCode int, deliveryErr error) error {
+	status, lastError := "delivered", ""
+	if deliveryErr != nil {
+		status, lastError = "failed", deliveryErr.Error()
+	}
+
+	query := `UPDATE webhook_deliveries SET status = $2, attempts = attempts + 1, response_code = $3, last_error = $4,
+			last_attempt_date = NOW(), delivered_date = CASE WHEN $2 = 'delivered' THEN NOW() ELSE delivered_date END
+		WHERE delivery_id::TEXT = $1;`
+	_, err := db.ExecContext(context.Background(), query, deliveryId, status, responseCode, lastError)
+
+	if err != nil {
+		utils.LogError(err, "Error in updating Webhook Delivery rows")
+	}
+
+	return deliveryErr
+}
+
+/*
+Refuses connections to addresses that are not public. Runs after the host of an endpoint has been resolved and
+before the connection is made, so it also covers hosts that resolve to an internal address.
+*/
+func rejectNonPublicAddress(network string, address string, conn syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+
+	if ip == nil || !isPublicIP(ip) {
+		return errors.New("Endpoint address " + host + " is not a public address")
+	}
+
+	return nil
+}
+
+/*
+Checks wether an address is reachable on the public internet
+*/
+func isPublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+
+	for i := 0; i < len(nonPublicNetworks); i++ {
+		if nonPublicNetworks[i].Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Parses a list of address ranges in CIDR notation
+*/
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	var networks []*net.IPNet
+
+	for i := 0; i < len(cidrs); i++ {
+		_, network, err := net.ParseCIDR(cidrs[i])
+
+		if err == nil {
+			networks = append(networks, network)
+		}
+	}
+
+	return networks
+}
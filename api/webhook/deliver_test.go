@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":"evt_1","type":"webhook.test"}`)
+
+	//Test 1: Signature has the timestamp and the HMAC of the timestamp and body
+	signature := Sign("whsec_test", 1700000000, body)
+	assert.Equal(t, "t=1700000000,v1=", signature[:16])
+	assert.Equal(t, 16+64, len(signature))
+
+	//Test 2: Signature changes with the secret, the timestamp and the body
+	assert.NotEqual(t, signature, Sign("whsec_other", 1700000000, body))
+	assert.NotEqual(t, signature, Sign("whsec_test", 1700000001, body))
+	assert.NotEqual(t, signature, Sign("whsec_test", 1700000000, []byte(`{}`)))
+
+	//Test 3: Signature is the same for the same delivery
+	assert.Equal(t, signature, Sign("whsec_test", 1700000000, body))
+}
+
+func TestRejectNonPublicAddress(t *testing.T) {
+	//Test 1: Loopback, private, link-local and other internal addresses are refused
+	for _, address := range []string{"127.0.0.1:443", "10.0.0.5:443", "172.16.3.4:443", "192.168.1.1:443", "169.254.169.254:80",
+		"100.64.0.1:443", "0.0.0.0:443", "[::1]:443", "[fd00::1]:443", "[fe80::1]:443", "[::ffff:127.0.0.1]:443"} {
+		assert.Error(t, rejectNonPublicAddress("tcp", address, nil), address)
+	}
+
+	//Test 2: Public addresses are allowed
+	for _, address := range []string{"93.184.216.34:443", "8.8.8.8:443", "[2606:4700:4700::1111]:443"} {
+		assert.NoError(t, rejectNonPublicAddress("tcp", address, nil), address)
+	}
+
+	//Test 3: Deliveries to a server on the internal network are refused when connecting
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	_, err := httpClient.Get(server.URL)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "is not a public address")
+}
+
+type receivedRequest struct {
+	eventType  string
+	deliveryId string
+	signature  string
+	body       []byte
+}
+
+type fakeEndpoint struct {
+	server     *httptest.Server
+	statusCode int
+	requests   []receivedRequest
+	previous   *http.Client
+}
+
+/*
+Creates a fake https endpoint that keeps the deliveries it is sent, deliveries are sent with a client that trusts it
+*/
+func newFakeEndpoint() *fakeEndpoint {
+	endpoint := &fakeEndpoint{statusCode: http.StatusOK, previous: httpClient}
+	endpoint.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		endpoint.requests = append(endpoint.requests, receivedRequest{eventType: r.Header.Get(EventHeader),
+			deliveryId: r.Header.Get(DeliveryHeader), signature: r.Header.Get(SignatureHeader), body: body})
+		w.WriteHeader(endpoint.statusCode)
+	}))
+	httpClient = endpoint.server.Client()
+
+	return endpoint
+}
+
+func (endpoint *fakeEndpoint) close() {
+	httpClient = endpoint.previous
+	endpoint.server.Close()
+}
+
+/*
+Verifies the signature of a delivery the way an endpoint of a seller would
+*/
+func verifyTestSignature(secret string, request receivedRequest) bool {
+	timestamp, _, _ := strings.Cut(strings.TrimPrefix(request.signature, "t="), ",")
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal([]byte(request.signature), []byte(Sign(secret, unix, request.body)))
+}
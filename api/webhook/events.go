@@ -0,0 +1,304 @@
+package webhook
+
+import (
+	"BackendAPI/api/outbox"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// Event types that sellers can subscribe their webhook endpoints to
+const (
+	OrderPaidEvent      = "order.paid"
+	OrderCancelledEvent = "order.cancelled"
+	ProductSoldOutEvent = "product.sold_out"
+	OfferReceivedEvent  = "offer.received"
+)
+
+// Event type of the test events that sellers send to their own endpoints
+const TestEvent = "webhook.test"
+
+var eventTypes = []string{OrderPaidEvent, OrderCancelledEvent, ProductSoldOutEvent, OfferReceivedEvent}
+
+/*
+Adds an event of a seller as part of a transaction, with a delivery to each endpoint of the seller that is subscribed
+to the type of the event. Returns the ids of the outbox messages that send the deliveries.
+*/
+func AddEvent(tx *sql.Tx, sellerId string, eventType string, eventData interface{}) ([]string, *utils.ErrorHandler) {
+	var messageIds []string
+
+	query := `SELECT webhook_id FROM webhooks WHERE seller_id::TEXT = $1 AND $2 = ANY(event_types) ORDER BY created_date;`
+	rows, err := tx.QueryContext(context.Background(), query, sellerId, eventType)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Webhook rows")
+		return messageIds, errResp
+	}
+
+	var webhookIds []string
+
+	for rows.Next() {
+		var webhookId string
+		err = rows.Scan(&webhookId)
+
+		if err != nil {
+			rows.Close()
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Webhook rows")
+			return messageIds, errResp
+		}
+
+		webhookIds = append(webhookIds, webhookId)
+	}
+
+	rows.Close()
+
+	if len(webhookIds) == 0 {
+		return messageIds, nil
+	}
+
+	event, eventErr := newEvent(eventType, eventData)
+
+	if eventErr != nil {
+		return messageIds, eventErr
+	}
+
+	for i := 0; i < len(webhookIds); i++ {
+		_, messageId, addErr := addDelivery(tx, webhookIds[i], event)
+
+		if addErr != nil {
+			return messageIds, addErr
+		}
+
+		messageIds = append(messageIds, messageId)
+	}
+
+	return messageIds, nil
+}
+
+/*
+Adds the order.paid events of an order that has been paid, one for the seller of each sub-order with the products
+they have to ship
+*/
+func AddOrderPaidEvents(tx *sql.Tx, orderId string, isGuest bool) ([]string, *utils.ErrorHandler) {
+	var messageIds []string
+
+	query := `SELECT sub_order_id, seller_id, products_cost - discount + delivery_fee FROM sub_orders
+		WHERE order_id = $1 AND status = 'paid';`
+	if isGuest {
+		query = `SELECT sub_order_id, seller_id, products_cost - discount + delivery_fee FROM sub_orders
+		WHERE guest_order_id = $1 AND status = 'paid';`
+	}
+
+	rows, err := tx.QueryContext(context.Background(), query, orderId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Sub Order rows")
+		return messageIds, errResp
+	}
+
+	var events []data.WebhookOrderEventData
+	var sellerIds []string
+
+	for rows.Next() {
+		var sellerId string
+		event := data.WebhookOrderEventData{OrderId: orderId, Products: []data.WebhookOrderProductData{}}
+		err = rows.Scan(&event.SubOrderId, &sellerId, &event.Total)
+
+		if err != nil {
+			rows.Close()
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Sub Order rows")
+			return messageIds, errResp
+		}
+
+		events = append(events, event)
+		sellerIds = append(sellerIds, sellerId)
+	}
+
+	rows.Close()
+
+	for i := 0; i < len(events); i++ {
+		productErr := addSubOrderProducts(tx, &events[i])
+
+		if productErr != nil {
+			return messageIds, productErr
+		}
+
+		eventIds, eventErr := AddEvent(tx, sellerIds[i], OrderPaidEvent, events[i])
+
+		if eventErr != nil {
+			return messageIds, eventErr
+		}
+
+		messageIds = append(messageIds, eventIds...)
+	}
+
+	return messageIds, nil
+}
+
+/*
+Adds the order.cancelled event of a sub-order that has been cancelled, for the seller of the sub-order
+*/
+func AddOrderCancelledEvent(tx *sql.Tx, subOrderId string, reason string, refundedAmount int) ([]string, *utils.ErrorHandler) {
+	var sellerId string
+	event := data.WebhookOrderCancelledEventData{SubOrderId: subOrderId, Reason: reason, RefundedAmount: refundedAmount}
+
+	query := `SELECT seller_id, COALESCE(order_id, guest_order_id) FROM sub_orders WHERE sub_order_id = $1;`
+	err := tx.QueryRowContext(context.Background(), query, subOrderId).Scan(&sellerId, &event.OrderId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Sub Order rows")
+		return nil, errResp
+	}
+
+	return AddEvent(tx, sellerId, OrderCancelledEvent, event)
+}
+
+/*
+Adds the product.sold_out event of a product that has sold its last unit, for the seller of the product
+*/
+func AddProductSoldOutEvent(tx *sql.Tx, productId string) ([]string, *utils.ErrorHandler) {
+	var sellerId string
+	event := data.WebhookProductEventData{ProductId: productId}
+
+	query := `SELECT seller_id, title, sold_quantity FROM products WHERE product_id = $1;`
+	err := tx.QueryRowContext(context.Background(), query, productId).Scan(&sellerId, &event.Title, &event.SoldQuantity)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Product rows")
+		return nil, errResp
+	}
+
+	return AddEvent(tx, sellerId, ProductSoldOutEvent, event)
+}
+
+/*
+Adds the offer.received event of an offer that a buyer made, for the seller of the product
+*/
+func AddOfferReceivedEvent(tx *sql.Tx, offerId string) ([]string, *utils.ErrorHandler) {
+	var sellerId string
+	event := data.WebhookOfferEventData{OfferId: offerId}
+
+	query := `SELECT products.seller_id, offers.product_id, offers.quantity, offers.amount, offers.expires_at::TEXT
+		FROM offers INNER JOIN products ON products.product_id = offers.product_id
+		WHERE offers.offer_id = $1;`
+	err := tx.QueryRowContext(context.Background(), query, offerId).Scan(&sellerId, &event.ProductId, &event.Quantity,
+		&event.Amount, &event.ExpiresAt)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Offer rows")
+		return nil, errResp
+	}
+
+	return AddEvent(tx, sellerId, OfferReceivedEvent, event)
+}
+
+/*
+Creates the body of an event with a new id. Every endpoint that gets the event gets the same body, so that sellers
+with more than one endpoint can tell that the deliveries are the same event.
+*/
+func newEvent(eventType string, eventData interface{}) (data.WebhookEventData, *utils.ErrorHandler) {
+	event := data.WebhookEventData{Type: eventType, CreatedDate: time.Now().UTC().Format(time.RFC3339), Data: eventData}
+
+	idBytes := make([]byte, 12)
+	_, err := rand.Read(idBytes)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in generating Webhook event id")
+		return event, errResp
+	}
+
+	event.Id = "evt_" + hex.EncodeToString(idBytes)
+	return event, nil
+}
+
+/*
+Adds the delivery of an event to a webhook endpoint to the log and to the outbox. Returns the ids of the delivery
+and of the outbox message that sends it.
+*/
+func addDelivery(tx *sql.Tx, webhookId string, event data.WebhookEventData) (string, string, *utils.ErrorHandler) {
+	var deliveryId string
+
+	payload, err := json.Marshal(event)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in encoding Webhook event")
+		return "", "", errResp
+	}
+
+	query := `INSERT INTO webhook_deliveries(webhook_id, event_id, event_type, payload) VALUES ($1, $2, $3, $4)
+		RETURNING delivery_id;`
+	err = tx.QueryRowContext(context.Background(), query, webhookId, event.Id, event.Type, string(payload)).Scan(&deliveryId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Webhook Delivery rows")
+		return "", "", errResp
+	}
+
+	messageId, outboxErr := outbox.AddMessage(tx, OutboxTopic, data.WebhookOutboxMessageData{DeliveryId: deliveryId})
+
+	if outboxErr != nil {
+		return "", "", outboxErr
+	}
+
+	return deliveryId, messageId, nil
+}
+
+/*
+Adds the products of a sub-order and their quantities to its order.paid event
+*/
+func addSubOrderProducts(tx *sql.Tx, event *data.WebhookOrderEventData) *utils.ErrorHandler {
+	query := `SELECT product_id, quantity FROM order_products WHERE sub_order_id = $1
+		UNION ALL SELECT product_id, quantity FROM guest_order_products WHERE sub_order_id = $1;`
+	rows, err := tx.QueryContext(context.Background(), query, event.SubOrderId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Sub Order product rows")
+		return errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var product data.WebhookOrderProductData
+		err = rows.Scan(&product.ProductId, &product.Quantity)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Sub Order product rows")
+			return errResp
+		}
+
+		event.Products = append(event.Products, product)
+	}
+
+	return nil
+}
+
+/*
+Checks wether an event type is one that sellers can subscribe to
+*/
+func isEventType(eventType string) bool {
+	for i := 0; i < len(eventTypes); i++ {
+		if eventTypes[i] == eventType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddEvent(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerId := createDummySeller(t, db)
+	otherSellerId := createOtherSeller(t, db)
+	first := createTestWebhook(t, db, sellerId, "https://example.com/first", OrderPaidEvent, OrderCancelledEvent)
+	second := createTestWebhook(t, db, sellerId, "https://example.com/second", OrderPaidEvent)
+	createTestWebhook(t, db, otherSellerId, "https://example.com/other", OrderPaidEvent)
+
+	//Test 1: Event is delivered to every webhook of the seller subscribed to it with the same body
+	messageIds := addTestEvent(t, db, sellerId, OrderPaidEvent, data.WebhookTestEventData{Message: "paid"})
+	assert.Equal(t, 2, len(messageIds))
+	firstDeliveries, _ := GetWebhookDeliveries(db, sellerId, first.WebhookId)
+	secondDeliveries, _ := GetWebhookDeliveries(db, sellerId, second.WebhookId)
+	assert.Equal(t, 1, len(firstDeliveries.Deliveries))
+	assert.Equal(t, 1, len(secondDeliveries.Deliveries))
+	assert.Equal(t, "pending", firstDeliveries.Deliveries[0].Status)
+	assert.Equal(t, firstDeliveries.Deliveries[0].EventId, secondDeliveries.Deliveries[0].EventId)
+	assert.JSONEq(t, firstDeliveries.Deliveries[0].Payload, secondDeliveries.Deliveries[0].Payload)
+
+	//Test 2: Event is only delivered to the webhooks subscribed to it
+	messageIds = addTestEvent(t, db, sellerId, OrderCancelledEvent, data.WebhookTestEventData{Message: "cancelled"})
+	assert.Equal(t, 1, len(messageIds))
+	secondDeliveries, _ = GetWebhookDeliveries(db, sellerId, second.WebhookId)
+	assert.Equal(t, 1, len(secondDeliveries.Deliveries))
+
+	//Test 3: Seller without webhooks for the event has no deliveries
+	messageIds = addTestEvent(t, db, otherSellerId, ProductSoldOutEvent, data.WebhookTestEventData{Message: "sold out"})
+	assert.Equal(t, 0, len(messageIds))
+
+	store.CloseDB(db)
+}
+
+func TestAddOfferReceivedEvent(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerId := createDummySeller(t, db)
+	webhook := createTestWebhook(t, db, sellerId, "https://example.com/hook", OfferReceivedEvent, ProductSoldOutEvent)
+
+	var buyerId, productId, offerId string
+	query := `INSERT INTO buyers(email, password) VALUES ('test@aucto.io','test') RETURNING buyer_id;`
+	err = db.QueryRowContext(context.Background(), query).Scan(&buyerId)
+	assert.NoError(t, err)
+	query = `INSERT INTO products(
+		title, seller_id, description, product_type, language, expansion, posted_date, price, condition, product_quantity, sold_quantity)
+		VALUES ('Test', $1, 'This is a test description', 'Buy-Now', 'Eng', 'Test', NOW(), 10000, 5, 3, 3) RETURNING product_id;`
+	err = db.QueryRowContext(context.Background(), query, sellerId).Scan(&productId)
+	assert.NoError(t, err)
+	query = `INSERT INTO offers(product_id, buyer_id, quantity, amount, created_date, expires_at)
+		VALUES ($1, $2, 2, 8000, NOW(), $3) RETURNING offer_id;`
+	err = db.QueryRowContext(context.Background(), query, productId, buyerId, time.Now().Add(time.Hour)).Scan(&offerId)
+	assert.NoError(t, err)
+
+	//Test 1: Offer event has the offer made to the seller
+	tx, err := db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	messageIds, resErr := AddOfferReceivedEvent(tx, offerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(messageIds))
+	assert.NoError(t, tx.Commit())
+
+	deliveries, _ := GetWebhookDeliveries(db, sellerId, webhook.WebhookId)
+	var offerEvent struct {
+		Type string                     `json:"type"`
+		Data data.WebhookOfferEventData `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(deliveries.Deliveries[0].Payload), &offerEvent))
+	assert.Equal(t, OfferReceivedEvent, offerEvent.Type)
+	assert.Equal(t, offerId, offerEvent.Data.OfferId)
+	assert.Equal(t, 2, offerEvent.Data.Quantity)
+	assert.Equal(t, 8000, offerEvent.Data.Amount)
+
+	//Test 2: Sold out event has the product that sold out
+	tx, err = db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	_, resErr = AddProductSoldOutEvent(tx, productId)
+	assert.Empty(t, resErr)
+	assert.NoError(t, tx.Commit())
+
+	deliveries, _ = GetWebhookDeliveries(db, sellerId, webhook.WebhookId)
+	var productEvent struct {
+		Type string                       `json:"type"`
+		Data data.WebhookProductEventData `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(deliveries.Deliveries[0].Payload), &productEvent))
+	assert.Equal(t, ProductSoldOutEvent, productEvent.Type)
+	assert.Equal(t, data.WebhookProductEventData{ProductId: productId, Title: "Test", SoldQuantity: 3}, productEvent.Data)
+
+	store.CloseDB(db)
+}
+
+func addTestEvent(t *testing.T, db *sql.DB, sellerId string, eventType string, eventData interface{}) []string {
+	tx, err := db.BeginTx(context.Background(), nil)
+	assert.NoError(t, err)
+	messageIds, resErr := AddEvent(tx, sellerId, eventType, eventData)
+	assert.Empty(t, resErr)
+	assert.NoError(t, tx.Commit())
+	return messageIds
+}
@@ -0,0 +1,373 @@
+package webhook
+
+import (
+	"BackendAPI/api/outbox"
+	"BackendAPI/api/seller"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+const (
+	maxSellerWebhooks   = 10
+	deliveryListLimit   = 100
+	selectWebhookQuery  = `SELECT webhook_id, seller_id, url, event_types, created_date::TEXT FROM webhooks`
+	selectDeliveryQuery = `SELECT delivery_id, webhook_id, event_id, event_type, payload::TEXT, status, attempts, response_code,
+			last_error, COALESCE(last_attempt_date::TEXT, ''), COALESCE(delivered_date::TEXT, ''), created_date::TEXT
+		FROM webhook_deliveries`
+	testEventDescription = "This is a test event from Aucto"
+)
+
+/*
+Registers a webhook endpoint for a seller that is subscribed to some event types. The endpoint must be an https url
+with a host name, deliveries are only sent if the host resolves to a public address.
+The secret that deliveries are signed with is only returned here, so the seller has to keep it.
+*/
+func CreateWebhook(db *sql.DB, sellerId string, request data.CreateWebhookRequestData) (data.WebhookData, *utils.ErrorHandler) {
+	var webhook data.WebhookData
+
+	if !seller.DoesSellerExist(db, sellerId) {
+		return webhook, utils.NotFoundError("Seller with given id does not exist")
+	}
+
+	eventTypes, validateErr := validateCreateWebhook(db, sellerId, request)
+
+	if validateErr != nil {
+		return webhook, validateErr
+	}
+
+	secretBytes := make([]byte, 24)
+	_, err := rand.Read(secretBytes)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in generating Webhook secret")
+		return webhook, errResp
+	}
+
+	secret := "whsec_" + hex.EncodeToString(secretBytes)
+
+	query := `INSERT INTO webhooks(seller_id, url, secret, event_types) VALUES ($1, $2, $3, $4)
+		RETURNING webhook_id, seller_id, url, event_types, created_date::TEXT;`
+	err = scanWebhook(db.QueryRowContext(context.Background(), query, sellerId, request.Url, secret, pq.Array(eventTypes)), &webhook)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in inserting Webhook rows")
+		return webhook, errResp
+	}
+
+	webhook.Secret = secret
+	return webhook, nil
+}
+
+/*
+Gets the webhook endpoints of a seller, from the newest endpoint. Secrets are not returned.
+*/
+func GetWebhooks(db *sql.DB, sellerId string) (data.GetWebhooksResponseData, *utils.ErrorHandler) {
+	response := data.GetWebhooksResponseData{Webhooks: []data.WebhookData{}}
+
+	if !seller.DoesSellerExist(db, sellerId) {
+		return response, utils.NotFoundError("Seller with given id does not exist")
+	}
+
+	query := selectWebhookQuery + ` WHERE seller_id = $1 ORDER BY created_date DESC;`
+	rows, err := db.QueryContext(context.Background(), query, sellerId)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Webhook rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var webhook data.WebhookData
+		err = scanWebhook(rows, &webhook)
+
+		if err != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(err, "Error in selecting Webhook rows")
+			return response, errResp
+		}
+
+		response.Webhooks = append(response.Webhooks, webhook)
+	}
+
+	return response, nil
+}
+
+/*
+Deletes a webhook endpoint of a seller along with its delivery log, events are no longer sent to it
+*/
+func DeleteWebhook(db *sql.DB, sellerId string, webhookId string) (data.WebhookData, *utils.ErrorHandler) {
+	webhook, err := getWebhook(db, sellerId, webhookId)
+
+	if err != nil {
+		return webhook, err
+	}
+
+	query := `DELETE FROM webhooks WHERE webhook_id = $1;`
+	_, execErr := db.ExecContext(context.Background(), query, webhookId)
+
+	if execErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(execErr, "Error in deleting Webhook rows")
+		return webhook, errResp
+	}
+
+	return webhook, nil
+}
+
+/*
+Gets the latest deliveries to a webhook endpoint of a seller, from the newest delivery, with the response code of
+their latest attempt
+*/
+func GetWebhookDeliveries(db *sql.DB, sellerId string, webhookId string) (data.GetWebhookDeliveriesResponseData, *utils.ErrorHandler) {
+	response := data.GetWebhookDeliveriesResponseData{Deliveries: []data.WebhookDeliveryData{}}
+
+	_, err := getWebhook(db, sellerId, webhookId)
+
+	if err != nil {
+		return response, err
+	}
+
+	query := selectDeliveryQuery + ` WHERE webhook_id = $1 ORDER BY created_date DESC LIMIT $2;`
+	rows, queryErr := db.QueryContext(context.Background(), query, webhookId, deliveryListLimit)
+
+	if queryErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(queryErr, "Error in selecting Webhook Delivery rows")
+		return response, errResp
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var delivery data.WebhookDeliveryData
+		queryErr = scanDelivery(rows, &delivery)
+
+		if queryErr != nil {
+			errResp := utils.InternalServerError(nil)
+			utils.LogError(queryErr, "Error in selecting Webhook Delivery rows")
+			return response, errResp
+		}
+
+		response.Deliveries = append(response.Deliveries, delivery)
+	}
+
+	return response, nil
+}
+
+/*
+Sends a delivery to its webhook endpoint again with the same payload, such as after the seller fixed their endpoint.
+The delivery is attempted right away and is retried with backoff if it fails again.
+*/
+func ReplayDelivery(db *sql.DB, sellerId string, webhookId string, deliveryId string) (data.WebhookDeliveryData, *utils.ErrorHandler) {
+	var delivery data.WebhookDeliveryData
+
+	_, err := getWebhook(db, sellerId, webhookId)
+
+	if err != nil {
+		return delivery, err
+	}
+
+	tx, txErr := db.BeginTx(context.Background(), nil)
+
+	if txErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(txErr, "Error in starting Webhook transaction")
+		return delivery, errResp
+	}
+
+	defer tx.Rollback()
+
+	query := `UPDATE webhook_deliveries SET status = 'pending' WHERE delivery_id::TEXT = $1 AND webhook_id = $2;`
+	result, txErr := tx.ExecContext(context.Background(), query, deliveryId, webhookId)
+
+	if txErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(txErr, "Error in updating Webhook Delivery rows")
+		return delivery, errResp
+	}
+
+	if updated, _ := result.RowsAffected(); updated == 0 {
+		return delivery, utils.NotFoundError("Webhook delivery with given id does not exist")
+	}
+
+	messageId, err := outbox.AddMessage(tx, OutboxTopic, data.WebhookOutboxMessageData{DeliveryId: deliveryId})
+
+	if err != nil {
+		return delivery, err
+	}
+
+	return commitDelivery(db, tx, deliveryId, messageId)
+}
+
+/*
+Sends a test event to a webhook endpoint of a seller so that the seller can check that their endpoint receives and
+verifies deliveries. The test event is attempted right away and logged like any other delivery.
+*/
+func SendTestEvent(db *sql.DB, sellerId string, webhookId string) (data.WebhookDeliveryData, *utils.ErrorHandler) {
+	var delivery data.WebhookDeliveryData
+
+	_, err := getWebhook(db, sellerId, webhookId)
+
+	if err != nil {
+		return delivery, err
+	}
+
+	tx, txErr := db.BeginTx(context.Background(), nil)
+
+	if txErr != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(txErr, "Error in starting Webhook transaction")
+		return delivery, errResp
+	}
+
+	defer tx.Rollback()
+
+	event, err := newEvent(TestEvent, data.WebhookTestEventData{Message: testEventDescription})
+
+	if err != nil {
+		return delivery, err
+	}
+
+	deliveryId, messageId, err := addDelivery(tx, webhookId, event)
+
+	if err != nil {
+		return delivery, err
+	}
+
+	return commitDelivery(db, tx, deliveryId, messageId)
+}
+
+/*
+Commits the transaction that queued a delivery, attempts the delivery and gets it with the result of the attempt
+*/
+func commitDelivery(db *sql.DB, tx *sql.Tx, deliveryId string, messageId string) (data.WebhookDeliveryData, *utils.ErrorHandler) {
+	var delivery data.WebhookDeliveryData
+
+	err := tx.Commit()
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in committing Webhook transaction")
+		return delivery, errResp
+	}
+
+	outbox.DeliverMessages(db, []string{messageId})
+
+	query := selectDeliveryQuery + ` WHERE delivery_id = $1;`
+	err = scanDelivery(db.QueryRowContext(context.Background(), query, deliveryId), &delivery)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Webhook Delivery rows")
+		return delivery, errResp
+	}
+
+	return delivery, nil
+}
+
+/*
+Gets a webhook endpoint of a seller, returns a 404 Not found error if the seller has no endpoint with the given id
+*/
+func getWebhook(db *sql.DB, sellerId string, webhookId string) (data.WebhookData, *utils.ErrorHandler) {
+	var webhook data.WebhookData
+
+	query := selectWebhookQuery + ` WHERE webhook_id::TEXT = $1 AND seller_id::TEXT = $2;`
+	err := scanWebhook(db.QueryRowContext(context.Background(), query, webhookId, sellerId), &webhook)
+
+	if err == sql.ErrNoRows {
+		utils.LogMessage("Webhook with given id does not exist")
+		return webhook, utils.NotFoundError("Webhook with given id does not exist")
+	}
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Webhook rows")
+		return webhook, errResp
+	}
+
+	return webhook, nil
+}
+
+/*
+Scans a row selected with the webhook select query
+*/
+func scanWebhook(row interface{ Scan(...interface{}) error }, webhook *data.WebhookData) error {
+	return row.Scan(&webhook.WebhookId, &webhook.SellerId, &webhook.Url, pq.Array(&webhook.EventTypes), &webhook.CreatedDate)
+}
+
+/*
+Scans a row selected with the delivery select query
+*/
+func scanDelivery(row interface{ Scan(...interface{}) error }, delivery *data.WebhookDeliveryData) error {
+	return row.Scan(&delivery.DeliveryId, &delivery.WebhookId, &delivery.EventId, &delivery.EventType, &delivery.Payload,
+		&delivery.Status, &delivery.Attempts, &delivery.ResponseCode, &delivery.LastError, &delivery.LastAttemptDate,
+		&delivery.DeliveredDate, &delivery.CreatedDate)
+}
+
+/*
+Validates a webhook endpoint and returns its event types without duplicates. The url must be an absolute https url,
+the event types must be ones that sellers can subscribe to and a seller can only have a few endpoints.
+*/
+func validateCreateWebhook(db *sql.DB, sellerId string, request data.CreateWebhookRequestData) ([]string, *utils.ErrorHandler) {
+	endpoint, err := url.Parse(request.Url)
+
+	if err != nil || endpoint.Scheme != "https" || endpoint.Hostname() == "" {
+		utils.LogMessage("Webhook url is not an https url")
+		return nil, utils.BadRequestError("Bad url data")
+	}
+
+	//endpoints have to be registered by their host name, deliveries to the addresses it resolves to are checked when sent
+	if net.ParseIP(endpoint.Hostname()) != nil || strings.EqualFold(endpoint.Hostname(), "localhost") {
+		utils.LogMessage("Webhook url is an address or a local host")
+		return nil, utils.BadRequestError("Bad url data")
+	}
+
+	var eventTypes []string
+	seen := make(map[string]bool)
+
+	for i := 0; i < len(request.EventTypes); i++ {
+		if !isEventType(request.EventTypes[i]) {
+			utils.LogMessage("Webhook event type " + request.EventTypes[i] + " does not exist")
+			return nil, utils.BadRequestError("Bad event_types data")
+		}
+
+		if !seen[request.EventTypes[i]] {
+			seen[request.EventTypes[i]] = true
+			eventTypes = append(eventTypes, request.EventTypes[i])
+		}
+	}
+
+	if len(eventTypes) == 0 {
+		return nil, utils.BadRequestError("Bad event_types data")
+	}
+
+	var count int
+	query := `SELECT COUNT(*) FROM webhooks WHERE seller_id = $1;`
+	err = db.QueryRowContext(context.Background(), query, sellerId).Scan(&count)
+
+	if err != nil {
+		errResp := utils.InternalServerError(nil)
+		utils.LogError(err, "Error in selecting Webhook rows")
+		return nil, errResp
+	}
+
+	if count >= maxSellerWebhooks {
+		return nil, utils.BadRequestError("Seller already has the most webhooks allowed")
+	}
+
+	return eventTypes, nil
+}
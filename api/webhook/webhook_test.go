@@ -0,0 +1,185 @@
+package webhook
+
+import (
+	"BackendAPI/api/outbox"
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerId := createDummySeller(t, db)
+
+	//Test 1: Webhook is created with its secret and without duplicate event types
+	request := data.CreateWebhookRequestData{Url: "https://example.com/hook",
+		EventTypes: []string{OrderPaidEvent, ProductSoldOutEvent, OrderPaidEvent}}
+	res, resErr := CreateWebhook(db, sellerId, request)
+	assert.Empty(t, resErr)
+	assert.Equal(t, sellerId, res.SellerId)
+	assert.Equal(t, []string{OrderPaidEvent, ProductSoldOutEvent}, res.EventTypes)
+	assert.Contains(t, res.Secret, "whsec_")
+
+	//Test 2: Secret is not returned when getting the webhooks
+	webhooks, resErr := GetWebhooks(db, sellerId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 1, len(webhooks.Webhooks))
+	assert.Equal(t, res.WebhookId, webhooks.Webhooks[0].WebhookId)
+	assert.Empty(t, webhooks.Webhooks[0].Secret)
+
+	//Test 3: Url must be an https url
+	request.Url = "http://example.com/hook"
+	_, resErr = CreateWebhook(db, sellerId, request)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad url data", resErr.Error())
+
+	//Test 4: Url cannot be an IP address or a local host
+	for _, url := range []string{"https://127.0.0.1/hook", "https://[::1]:8443/hook", "https://169.254.169.254/", "https://localhost/hook"} {
+		request.Url = url
+		_, resErr = CreateWebhook(db, sellerId, request)
+		assert.Error(t, resErr)
+		assert.Equal(t, "Bad url data", resErr.Error())
+	}
+
+	//Test 5: Event type does not exist
+	request = data.CreateWebhookRequestData{Url: "https://example.com/hook", EventTypes: []string{"order.shipped"}}
+	_, resErr = CreateWebhook(db, sellerId, request)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Bad event_types data", resErr.Error())
+
+	//Test 6: Seller can only have a few webhooks
+	request = data.CreateWebhookRequestData{Url: "https://example.com/hook", EventTypes: []string{OfferReceivedEvent}}
+	for i := 1; i < maxSellerWebhooks; i++ {
+		_, resErr = CreateWebhook(db, sellerId, request)
+		assert.Empty(t, resErr)
+	}
+	_, resErr = CreateWebhook(db, sellerId, request)
+	assert.Error(t, resErr)
+	assert.Equal(t, 400, resErr.ErrorCode())
+
+	//Test 7: Seller does not exist
+	_, resErr = CreateWebhook(db, "wrong id", request)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Seller with given id does not exist", resErr.Error())
+
+	store.CloseDB(db)
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	sellerId := createDummySeller(t, db)
+	otherSellerId := createOtherSeller(t, db)
+	webhook := createTestWebhook(t, db, sellerId, "https://example.com/hook", OrderPaidEvent)
+
+	//Test 1: Webhook of another seller cannot be deleted
+	_, resErr := DeleteWebhook(db, otherSellerId, webhook.WebhookId)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Webhook with given id does not exist", resErr.Error())
+
+	//Test 2: Webhook is deleted
+	_, resErr = DeleteWebhook(db, sellerId, webhook.WebhookId)
+	assert.Empty(t, resErr)
+	webhooks, _ := GetWebhooks(db, sellerId)
+	assert.Equal(t, 0, len(webhooks.Webhooks))
+
+	store.CloseDB(db)
+}
+
+func TestSendTestEvent(t *testing.T) {
+	db, err := store.SetupTestDB("../../.env")
+	assert.NoError(t, err)
+
+	endpoint := newFakeEndpoint()
+	defer endpoint.close()
+	outbox.RegisterHandler(OutboxTopic, DeliveryHandler(db))
+
+	sellerId := createDummySeller(t, db)
+	otherSellerId := createOtherSeller(t, db)
+	webhook := createTestWebhook(t, db, sellerId, endpoint.server.URL, OrderPaidEvent)
+
+	//Test 1: Test event is delivered signed with the secret of the webhook
+	res, resErr := SendTestEvent(db, sellerId, webhook.WebhookId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, TestEvent, res.EventType)
+	assert.Equal(t, "delivered", res.Status)
+	assert.Equal(t, 1, res.Attempts)
+	assert.Equal(t, 200, res.ResponseCode)
+	assert.Equal(t, 1, len(endpoint.requests))
+	assert.Equal(t, TestEvent, endpoint.requests[0].eventType)
+	assert.Equal(t, res.DeliveryId, endpoint.requests[0].deliveryId)
+	assert.True(t, verifyTestSignature(webhook.Secret, endpoint.requests[0]))
+
+	var event data.WebhookEventData
+	assert.NoError(t, json.Unmarshal(endpoint.requests[0].body, &event))
+	assert.Equal(t, TestEvent, event.Type)
+	assert.Equal(t, res.EventId, event.Id)
+
+	//Test 2: Failed delivery is logged with the response code
+	endpoint.statusCode = 500
+	res, resErr = SendTestEvent(db, sellerId, webhook.WebhookId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, "failed", res.Status)
+	assert.Equal(t, 500, res.ResponseCode)
+	assert.Equal(t, "Endpoint responded with status 500 Internal Server Error", res.LastError)
+
+	//Test 3: Deliveries are logged from the newest delivery
+	deliveries, resErr := GetWebhookDeliveries(db, sellerId, webhook.WebhookId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, 2, len(deliveries.Deliveries))
+	assert.Equal(t, res.DeliveryId, deliveries.Deliveries[0].DeliveryId)
+
+	//Test 4: Replayed delivery is sent again with the same payload
+	endpoint.statusCode = 200
+	replayed, resErr := ReplayDelivery(db, sellerId, webhook.WebhookId, res.DeliveryId)
+	assert.Empty(t, resErr)
+	assert.Equal(t, "delivered", replayed.Status)
+	assert.Equal(t, 2, replayed.Attempts)
+	assert.Equal(t, 200, replayed.ResponseCode)
+	assert.NotEmpty(t, replayed.DeliveredDate)
+	assert.Equal(t, string(endpoint.requests[1].body), string(endpoint.requests[2].body))
+
+	//Test 5: Delivery of another webhook cannot be replayed
+	other := createTestWebhook(t, db, sellerId, endpoint.server.URL, OrderPaidEvent)
+	_, resErr = ReplayDelivery(db, sellerId, other.WebhookId, res.DeliveryId)
+	assert.Error(t, resErr)
+	assert.Equal(t, "Webhook delivery with given id does not exist", resErr.Error())
+
+	//Test 6: Webhook of another seller
+	_, resErr = SendTestEvent(db, otherSellerId, webhook.WebhookId)
+	assert.Error(t, resErr)
+	assert.Equal(t, 404, resErr.ErrorCode())
+
+	store.CloseDB(db)
+}
+
+func createDummySeller(t *testing.T, db *sql.DB) string {
+	var sellerId string
+	query := `INSERT INTO sellers(email, seller_name, password) VALUES ('test@aucto.io','test','test') RETURNING seller_id`
+	err := db.QueryRowContext(context.Background(), query).Scan(&sellerId)
+	assert.NoError(t, err)
+	return sellerId
+}
+
+func createOtherSeller(t *testing.T, db *sql.DB) string {
+	var sellerId string
+	query := `INSERT INTO sellers(email, seller_name, password) VALUES ('test2@aucto.io','test2','test') RETURNING seller_id`
+	err := db.QueryRowContext(context.Background(), query).Scan(&sellerId)
+	assert.NoError(t, err)
+	return sellerId
+}
+
+func createTestWebhook(t *testing.T, db *sql.DB, sellerId string, url string, eventTypes ...string) data.WebhookData {
+	webhook, resErr := CreateWebhook(db, sellerId, data.CreateWebhookRequestData{Url: url, EventTypes: eventTypes})
+	assert.Empty(t, resErr)
+	return webhook
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"BackendAPI/api/apikey"
+	"BackendAPI/data"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleCreateApiKey godoc
+// @Summary      Creates an API key for a seller
+// @Description  Creates an API key that a seller's own tools can send as "Authorization: Bearer <key>". Scopes are
+// 'products:write' to create products, add their images and change pre-order dates, and 'orders:read' to read
+// sub-orders. Each key has its own limit of requests per minute, 60 by default and up to 600. The key is only
+// returned when it is created or rotated. A seller can have up to 20 active keys. Needs an API key of the seller
+// or the admin key, so the first key of a seller is created by an admin.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 apiKey body data.CreateApiKeyRequestData true "API key"
+// @Param 		 Authorization header string false "API key of the seller, with any scope, as 'Bearer <key>'"
+// @Param 		 X-Admin-Key header string false "Admin API key, used instead of an API key of the seller"
+// @Success      200  {object}  data.ApiKeyData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      429  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/api-keys [post]
+func handleCreateApiKey(c *gin.Context) {
+	var request data.CreateApiKeyRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := apikey.CreateApiKey(db, c.Param("id"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetApiKeys godoc
+// @Summary      Gets the API keys of a seller
+// @Description  Gets the API keys of a seller from the newest key, including revoked keys, without their secrets.
+// Needs an API key of the seller or the admin key.
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 Authorization header string false "API key of the seller, with any scope, as 'Bearer <key>'"
+// @Param 		 X-Admin-Key header string false "Admin API key, used instead of an API key of the seller"
+// @Success      200  {object}  data.GetApiKeysResponseData
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      429  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/api-keys [get]
+func handleGetApiKeys(c *gin.Context) {
+	response, err := apikey.GetApiKeys(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleRotateApiKey godoc
+// @Summary      Rotates an API key of a seller
+// @Description  Replaces the secret of an API key, keeping its name, scopes and rate limit. The old key stops
+// working immediately and the new key is only returned in this response. Needs an API key of the seller or the
+// admin key.
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 keyId path string true "API key id"
+// @Param 		 Authorization header string false "API key of the seller, with any scope, as 'Bearer <key>'"
+// @Param 		 X-Admin-Key header string false "Admin API key, used instead of an API key of the seller"
+// @Success      200  {object}  data.ApiKeyData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      429  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/api-keys/{keyId}/rotate [post]
+func handleRotateApiKey(c *gin.Context) {
+	response, err := apikey.RotateApiKey(db, c.Param("id"), c.Param("keyId"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleRevokeApiKey godoc
+// @Summary      Revokes an API key of a seller
+// @Description  Revokes an API key of a seller, requests made with it are rejected from then on. Needs an API key
+// of the seller or the admin key.
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 keyId path string true "API key id"
+// @Param 		 Authorization header string false "API key of the seller, with any scope, as 'Bearer <key>'"
+// @Param 		 X-Admin-Key header string false "Admin API key, used instead of an API key of the seller"
+// @Success      200  {object}  data.ApiKeyData
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      429  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/api-keys/{keyId} [delete]
+func handleRevokeApiKey(c *gin.Context) {
+	response, err := apikey.RevokeApiKey(db, c.Param("id"), c.Param("keyId"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"BackendAPI/api/auction"
+	"BackendAPI/api/product"
+	"BackendAPI/data"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handlePlaceBid godoc
+// @Summary      Places a bid on an auction
+// @Description  Places a bid on an auction product. The bid must be at least the minimum bid of the auction, which is the
+// start price for the first bid and the current price plus the bid increment after that. Checkout details are needed with
+// the first bid of a buyer on an auction and are used to create the order if the buyer wins. Bids close to the end of the
+// auction extend the auction.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Product id of the auction"
+// @Param 		 bid body data.PlaceBidRequestData true "Buyer id, bid amount in cents and checkout details"
+// @Success      201  {object}  data.PlaceBidResponseData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /products/{id}/bids [post]
+func handlePlaceBid(c *gin.Context) {
+	var request data.PlaceBidRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := auction.PlaceBid(db, c.Param("id"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	product.PublishProductEvents(db, eventBus, []string{c.Param("id")}, "bid")
+
+	c.JSON(http.StatusCreated, &response)
+}
+
+// handleGetBids godoc
+// @Summary      Gets the bid history of an auction
+// @Description  Gets the bids of an auction from the highest bid, bidders are numbered in the order they first bid.
+// @Produce      json
+// @Param 		 id path string true "Product id of the auction"
+// @Success      200  {object}  data.GetBidsResponseData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /products/{id}/bids [get]
+func handleGetBids(c *gin.Context) {
+	response, err := auction.GetBids(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetAuctionResult godoc
+// @Summary      Gets the result of an auction for a buyer
+// @Description  Gets the status of an auction and the hammer price once sold. The winner of the auction also gets the
+// order created for them and the url to pay for it.
+// @Produce      json
+// @Param 		 id path string true "Product id of the auction"
+// @Param 		 buyer_id query string true "Id of the buyer"
+// @Success      200  {object}  data.AuctionResultData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /products/{id}/auction-result [get]
+func handleGetAuctionResult(c *gin.Context) {
+	response, err := auction.GetAuctionResult(db, c.Param("id"), c.Query("buyer_id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleCloseAuctions godoc
+// @Summary      Closes ended auctions
+// @Description  Closes every auction that has ended and creates the orders for the winners. Meant to be called on a
+// schedule, requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Success      200  {object}  data.CloseAuctionsResponseData
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/tasks/close-auctions [post]
+func handleCloseAuctions(c *gin.Context) {
+	response, err := auction.CloseEndedAuctions(db)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	product.PublishProductEvents(db, eventBus, append(response.Sold, response.Unsold...), "status")
+
+	c.JSON(http.StatusOK, &response)
+}
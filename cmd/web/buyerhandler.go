@@ -2,7 +2,9 @@ package main
 
 import (
 	"BackendAPI/api/buyer"
+	"BackendAPI/api/cart"
 	"BackendAPI/data"
+	"BackendAPI/utils"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -16,6 +18,8 @@ import (
 // @Produce      json
 // @Param 		 email body string true "Buyers email [UNIQUE]"
 // @Param 		 password body string true "Buyers password as plaintext"
+// @Param 		 cart_token body string false "Token of a guest cart to move into the cart of the buyer"
+// @Param 		 locale body string false "Locale that mails to the buyer are written in, defaults to en"
 // @Success      200  {object}  data.BuyerLoginResponseData
 // @Failure      400  {object}  data.Message
 // @Failure      500  {object}  data.Message
@@ -38,6 +42,15 @@ func handleBuyerSignUp(c *gin.Context) {
 		return
 	}
 
+	//A failed merge leaves the guest cart as it is and does not fail the sign up
+	if signUpData.CartToken != "" {
+		_, mergeErr := cart.MergeGuestCart(db, response.BuyerId, signUpData.CartToken)
+
+		if mergeErr != nil {
+			utils.LogMessage("Guest cart could not be merged on sign up: " + mergeErr.Error())
+		}
+	}
+
 	c.JSON(http.StatusCreated, &response)
 }
 
@@ -49,6 +62,7 @@ func handleBuyerSignUp(c *gin.Context) {
 // @Produce      json
 // @Param 		 email body string true "Buyers email"
 // @Param 		 password body string true "Buyers password as plaintext"
+// @Param 		 cart_token body string false "Token of a guest cart to move into the cart of the buyer"
 // @Success      200  {object}  data.BuyerLoginResponseData
 // @Failure      400  {object}  data.Message
 // @Failure      401  {object}  data.Message
@@ -72,6 +86,15 @@ func handleBuyerLogin(c *gin.Context) {
 		return
 	}
 
+	//A failed merge leaves the guest cart as it is and does not fail the login
+	if loginData.CartToken != "" {
+		_, mergeErr := cart.MergeGuestCart(db, response.BuyerId, loginData.CartToken)
+
+		if mergeErr != nil {
+			utils.LogMessage("Guest cart could not be merged on login: " + mergeErr.Error())
+		}
+	}
+
 	c.JSON(http.StatusOK, &response)
 }
 
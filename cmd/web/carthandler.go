@@ -0,0 +1,253 @@
+package main
+
+import (
+	"BackendAPI/api/cart"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleCreateGuestCart godoc
+// @Summary      Creates a cart for a guest
+// @Description  Creates an empty cart for a guest. The cart_token returned identifies the cart in later requests and
+// can be sent when logging in to move the products into the cart of the buyer.
+// @Produce      json
+// @Success      201  {object}  data.CartData
+// @Failure      500  {object}  data.Message
+// @Router       /carts [post]
+func handleCreateGuestCart(c *gin.Context) {
+	response, err := cart.CreateGuestCart(db)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &response)
+}
+
+// handleGetCart godoc
+// @Summary      Gets a cart
+// @Description  Gets the products in the cart of a buyer or a guest, checked against the current price and stock.
+// Products that can no longer be bought as they are have an issue of 'unavailable', 'sold_out', 'insufficient_stock'
+// or 'price_changed'. The subtotal only counts products that are available.
+// @Produce      json
+// @Param 		 id path string false "Buyer id"
+// @Param 		 token path string false "Guest cart token"
+// @Success      200  {object}  data.CartData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/cart [get]
+// @Router       /carts/{token} [get]
+func handleGetCart(c *gin.Context) {
+	cartId, err := getCartId(c)
+
+	if err == nil {
+		var response data.CartData
+		response, err = cart.GetCart(db, cartId)
+
+		if err == nil {
+			c.JSON(http.StatusOK, &response)
+			return
+		}
+	}
+
+	r := data.Message{Message: err.Error()}
+	c.JSON(err.ErrorCode(), r)
+}
+
+// handleAddCartProduct godoc
+// @Summary      Adds a product to a cart
+// @Description  Adds a product to the cart of a buyer or a guest, or adds to its quantity if it is already in the cart.
+// Auctions and closed pre-orders cannot be added and the quantity cannot be more than the stock left.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string false "Buyer id"
+// @Param 		 token path string false "Guest cart token"
+// @Param 		 product body data.AddCartProductRequestData true "Product and quantity to add"
+// @Success      200  {object}  data.CartData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/cart/products [post]
+// @Router       /carts/{token}/products [post]
+func handleAddCartProduct(c *gin.Context) {
+	var request data.AddCartProductRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	cartId, err := getCartId(c)
+
+	if err == nil {
+		var response data.CartData
+		response, err = cart.AddCartProduct(db, cartId, request)
+
+		if err == nil {
+			c.JSON(http.StatusOK, &response)
+			return
+		}
+	}
+
+	r := data.Message{Message: err.Error()}
+	c.JSON(err.ErrorCode(), r)
+}
+
+// handleUpdateCartProduct godoc
+// @Summary      Changes the quantity of a product in a cart
+// @Description  Changes the quantity of a product in the cart of a buyer or a guest. The quantity cannot be more than
+// the stock left and the product is then expected at its current price.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string false "Buyer id"
+// @Param 		 token path string false "Guest cart token"
+// @Param 		 productId path string true "Product id"
+// @Param 		 quantity body data.UpdateCartProductRequestData true "New quantity"
+// @Success      200  {object}  data.CartData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/cart/products/{productId} [put]
+// @Router       /carts/{token}/products/{productId} [put]
+func handleUpdateCartProduct(c *gin.Context) {
+	var request data.UpdateCartProductRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	cartId, err := getCartId(c)
+
+	if err == nil {
+		var response data.CartData
+		response, err = cart.UpdateCartProduct(db, cartId, c.Param("productId"), request)
+
+		if err == nil {
+			c.JSON(http.StatusOK, &response)
+			return
+		}
+	}
+
+	r := data.Message{Message: err.Error()}
+	c.JSON(err.ErrorCode(), r)
+}
+
+// handleRemoveCartProduct godoc
+// @Summary      Removes a product from a cart
+// @Description  Removes a product from the cart of a buyer or a guest
+// @Produce      json
+// @Param 		 id path string false "Buyer id"
+// @Param 		 token path string false "Guest cart token"
+// @Param 		 productId path string true "Product id"
+// @Success      200  {object}  data.CartData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/cart/products/{productId} [delete]
+// @Router       /carts/{token}/products/{productId} [delete]
+func handleRemoveCartProduct(c *gin.Context) {
+	cartId, err := getCartId(c)
+
+	if err == nil {
+		var response data.CartData
+		response, err = cart.RemoveCartProduct(db, cartId, c.Param("productId"))
+
+		if err == nil {
+			c.JSON(http.StatusOK, &response)
+			return
+		}
+	}
+
+	r := data.Message{Message: err.Error()}
+	c.JSON(err.ErrorCode(), r)
+}
+
+// handleCheckoutBuyerCart godoc
+// @Summary      Checks out the cart of a buyer
+// @Description  Creates an order from the products in the cart of a buyer and empties the cart. The checkout fails if
+// the cart is empty or has products that are no longer available, or whose price has changed until they are saved
+// again at their current price. The fees and promo code are checked as for any order.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Param 		 checkout body data.CheckoutCartRequestData true "Delivery and payment details"
+// @Success      201  {object}  data.CreateOrderResponseData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/cart/checkout [post]
+func handleCheckoutBuyerCart(c *gin.Context) {
+	var request data.CheckoutCartRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := cart.CheckoutBuyerCart(db, c.Param("id"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &response)
+}
+
+// handleCheckoutGuestCart godoc
+// @Summary      Checks out the cart of a guest
+// @Description  Creates a guest order from the products in a guest cart and empties the cart. The checkout fails if
+// the cart is empty or has products that are no longer available, or whose price has changed until they are saved
+// again at their current price. The fees and promo code are checked as for any order.
+// @Accept       json
+// @Produce      json
+// @Param 		 token path string true "Guest cart token"
+// @Param 		 checkout body data.CheckoutGuestCartRequestData true "Email, delivery and payment details"
+// @Success      201  {object}  data.CreateGuestOrderResponseData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /carts/{token}/checkout [post]
+func handleCheckoutGuestCart(c *gin.Context) {
+	var request data.CheckoutGuestCartRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := cart.CheckoutGuestCart(db, c.Param("token"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &response)
+}
+
+/*
+Gets the id of the cart a request is for, a guest cart when the path has a token and otherwise the cart of the buyer
+*/
+func getCartId(c *gin.Context) (string, *utils.ErrorHandler) {
+	if token := c.Param("token"); token != "" {
+		return cart.GetGuestCartId(db, token)
+	}
+
+	return cart.GetBuyerCartId(db, c.Param("id"))
+}
@@ -0,0 +1,358 @@
+package main
+
+import (
+	"BackendAPI/api/catalogue"
+	"BackendAPI/data"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleGetGames godoc
+// @Summary      Gets the games in the catalogue
+// @Description  Gets all the trading card games that expansions and products belong to.
+// @Produce      json
+// @Success      200  {array}   data.GameData
+// @Failure      500  {object}  data.Message
+// @Router       /games [get]
+func handleGetGames(c *gin.Context) {
+	response, err := catalogue.GetGames(db)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetLanguages godoc
+// @Summary      Gets the languages in the catalogue
+// @Description  Gets all the languages that products can be listed in.
+// @Produce      json
+// @Success      200  {array}   data.LanguageData
+// @Failure      500  {object}  data.Message
+// @Router       /languages [get]
+func handleGetLanguages(c *gin.Context) {
+	response, err := catalogue.GetLanguages(db)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetExpansions godoc
+// @Summary      Gets the expansions in the catalogue
+// @Description  Gets the expansions in the catalogue with the languages they were released in, newest first.
+// The expansions can be limited to a game or to a language, which is used to build product filters.
+// @Produce      json
+// @Param 		 game query string false "Only expansions of the game with this code"
+// @Param 		 language query string false "Only expansions released in the language with this code"
+// @Success      200  {array}   data.ExpansionData
+// @Failure      400  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /expansions [get]
+func handleGetExpansions(c *gin.Context) {
+	var request data.GetExpansionsRequestData
+	bindErr := c.ShouldBindQuery(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Params"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := catalogue.GetExpansions(db, request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleCreateGame godoc
+// @Summary      Adds a game to the catalogue
+// @Description  Adds a game to the catalogue, the game code must not already be in use. Requires the admin key.
+// @Accept       json
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 game body data.CreateGameData true "Game code and name"
+// @Success      201  {object}  data.GameData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/games [post]
+func handleCreateGame(c *gin.Context) {
+	var request data.CreateGameData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := catalogue.CreateGame(db, request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &response)
+}
+
+// handleUpdateGame godoc
+// @Summary      Updates a game in the catalogue
+// @Description  Updates the name of a game. Requires the admin key.
+// @Accept       json
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 code path string true "Game code"
+// @Param 		 game body data.UpdateGameData true "Game name"
+// @Success      200  {object}  data.GameData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/games/{code} [put]
+func handleUpdateGame(c *gin.Context) {
+	var request data.UpdateGameData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := catalogue.UpdateGame(db, c.Param("code"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleDeleteGame godoc
+// @Summary      Deletes a game from the catalogue
+// @Description  Deletes a game, games that still have expansions cannot be deleted. Requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 code path string true "Game code"
+// @Success      200  {object}  data.Message
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/games/{code} [delete]
+func handleDeleteGame(c *gin.Context) {
+	err := catalogue.DeleteGame(db, c.Param("code"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, data.Message{Message: "Game deleted"})
+}
+
+// handleCreateLanguage godoc
+// @Summary      Adds a language to the catalogue
+// @Description  Adds a language that products can be listed in, the language code must not already be in use.
+// Requires the admin key.
+// @Accept       json
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 language body data.LanguageData true "Language code and name"
+// @Success      201  {object}  data.LanguageData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/languages [post]
+func handleCreateLanguage(c *gin.Context) {
+	var request data.LanguageData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := catalogue.CreateLanguage(db, request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &response)
+}
+
+// handleUpdateLanguage godoc
+// @Summary      Updates a language in the catalogue
+// @Description  Updates the name of a language. Requires the admin key.
+// @Accept       json
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 code path string true "Language code"
+// @Param 		 language body data.UpdateLanguageData true "Language name"
+// @Success      200  {object}  data.LanguageData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/languages/{code} [put]
+func handleUpdateLanguage(c *gin.Context) {
+	var request data.UpdateLanguageData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := catalogue.UpdateLanguage(db, c.Param("code"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleDeleteLanguage godoc
+// @Summary      Deletes a language from the catalogue
+// @Description  Deletes a language, languages used by products or expansions cannot be deleted. Requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 code path string true "Language code"
+// @Success      200  {object}  data.Message
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/languages/{code} [delete]
+func handleDeleteLanguage(c *gin.Context) {
+	err := catalogue.DeleteLanguage(db, c.Param("code"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, data.Message{Message: "Language deleted"})
+}
+
+// handleCreateExpansion godoc
+// @Summary      Adds an expansion to the catalogue
+// @Description  Adds an expansion of a game with the languages it was released in. The expansion code must not
+// already be in use and the game and languages must exist in the catalogue. Requires the admin key.
+// @Accept       json
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 expansion body data.CreateExpansionData true "Expansion information"
+// @Success      201  {object}  data.ExpansionData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/expansions [post]
+func handleCreateExpansion(c *gin.Context) {
+	var request data.CreateExpansionData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := catalogue.CreateExpansion(db, request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &response)
+}
+
+// handleUpdateExpansion godoc
+// @Summary      Updates an expansion in the catalogue
+// @Description  Updates an expansion and replaces the languages it was released in. Languages that are still
+// used by products of the expansion cannot be removed. Requires the admin key.
+// @Accept       json
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 code path string true "Expansion code"
+// @Param 		 expansion body data.UpdateExpansionData true "Expansion information"
+// @Success      200  {object}  data.ExpansionData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/expansions/{code} [put]
+func handleUpdateExpansion(c *gin.Context) {
+	var request data.UpdateExpansionData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := catalogue.UpdateExpansion(db, c.Param("code"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleDeleteExpansion godoc
+// @Summary      Deletes an expansion from the catalogue
+// @Description  Deletes an expansion, expansions that still have products cannot be deleted. Requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 code path string true "Expansion code"
+// @Success      200  {object}  data.Message
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/expansions/{code} [delete]
+func handleDeleteExpansion(c *gin.Context) {
+	err := catalogue.DeleteExpansion(db, c.Param("code"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, data.Message{Message: "Expansion deleted"})
+}
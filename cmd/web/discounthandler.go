@@ -0,0 +1,134 @@
+package main
+
+import (
+	"BackendAPI/api/discount"
+	"BackendAPI/api/product"
+	"BackendAPI/data"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleCreateDiscount godoc
+// @Summary      Creates a sale for a seller
+// @Description  Creates a fixed or percentage sale on one product of a seller, or on every product of the seller when no
+// product is given. Dates are in RFC3339 format, a sale without a start date starts immediately and a sale without an
+// end date runs until it is cancelled. Auctions are never discounted.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 discount body data.CreateDiscountRequestData true "Sale information"
+// @Success      200  {object}  data.DiscountData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/discounts [post]
+func handleCreateDiscount(c *gin.Context) {
+	var request data.CreateDiscountRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := discount.CreateDiscount(db, c.Param("id"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetSellerDiscounts godoc
+// @Summary      Gets the sales of a seller
+// @Description  Gets the sales of a seller, from the newest sale
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Success      200  {object}  data.GetDiscountsResponseData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/discounts [get]
+func handleGetSellerDiscounts(c *gin.Context) {
+	response, err := discount.GetSellerDiscounts(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleCancelDiscount godoc
+// @Summary      Cancels a sale of a seller
+// @Description  Cancels an active sale of a seller so that it no longer applies
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 discountId path string true "Discount id"
+// @Success      200  {object}  data.DiscountData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/discounts/{discountId} [delete]
+func handleCancelDiscount(c *gin.Context) {
+	response, err := discount.CancelDiscount(db, c.Param("id"), c.Param("discountId"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetPriceHistory godoc
+// @Summary      Gets the price history of a product
+// @Description  Gets the current and effective price of a product along with its price history, from the newest price.
+// The was price is the lowest price of the product in the 30 days before its current price, only given when it is
+// higher than the current price.
+// @Produce      json
+// @Param 		 id path string true "Product id"
+// @Success      200  {object}  data.GetPriceHistoryResponseData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /products/{id}/price-history [get]
+func handleGetPriceHistory(c *gin.Context) {
+	response, err := product.GetPriceHistory(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleRecordPrices godoc
+// @Summary      Records the prices of products that have changed
+// @Description  Records the price of every product whose price or effective price has changed, such as when a
+// scheduled sale starts or ends. Meant to be called on a schedule, requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Success      200  {object}  data.RecordPricesResponseData
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/tasks/record-prices [post]
+func handleRecordPrices(c *gin.Context) {
+	response, err := product.RecordPriceHistory(db, nil)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
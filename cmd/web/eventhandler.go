@@ -0,0 +1,116 @@
+package main
+
+import (
+	"BackendAPI/api/product"
+	"BackendAPI/data"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const eventHeartbeatInterval = 15 * time.Second
+
+// handleGetProductEvents godoc
+// @Summary      Streams live updates of a product
+// @Description  Streams the live state of a product as Server-Sent Events. The current state is sent first, followed by an
+// event whenever a bid is placed ('bid'), the remaining quantity changes ('stock') or an auction closes ('status').
+// A 'ping' event is sent periodically to keep the connection open. Only available when the API runs as a server,
+// the route is not registered when the API runs as a lambda.
+// @Produce      text/event-stream
+// @Param 		 id path string true "Product id"
+// @Success      200  {object}  data.ProductEventData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /products/{id}/events [get]
+func handleGetProductEvents(c *gin.Context) {
+	streamProductEvents(c, []string{c.Param("id")})
+}
+
+// handleGetProductListEvents godoc
+// @Summary      Streams live updates of the products on a listing page
+// @Description  Streams the live state of up to 100 products as Server-Sent Events on a single connection. The current
+// state of each product is sent first, followed by the same events as the events of a single product. Only available
+// when the API runs as a server, the route is not registered when the API runs as a lambda.
+// @Produce      text/event-stream
+// @Param 		 ids query []string true "Product ids, at most 100"
+// @Success      200  {object}  data.ProductEventData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /products/events [get]
+func handleGetProductListEvents(c *gin.Context) {
+	streamProductEvents(c, c.QueryArray("ids"))
+}
+
+/*
+Streams the events of the given products to the client until the client disconnects
+*/
+func streamProductEvents(c *gin.Context, productIds []string) {
+	err := product.ValidateProductEventIds(db, productIds)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	if eventBus == nil {
+		r := data.Message{Message: "Internal Server Error"}
+		c.JSON(http.StatusInternalServerError, r)
+		return
+	}
+
+	// Subscribe before reading the current state so that no change is missed in between
+	events, unsubscribe := eventBus.Subscribe(product.ProductEventChannel)
+	defer unsubscribe()
+
+	watched := make(map[string]bool)
+	var snapshots []data.ProductEventData
+
+	for i := 0; i < len(productIds); i++ {
+		snapshot, err := product.GetProductEvent(db, productIds[i], "snapshot")
+
+		if err != nil {
+			r := data.Message{Message: err.Error()}
+			c.JSON(err.ErrorCode(), r)
+			return
+		}
+
+		watched[productIds[i]] = true
+		snapshots = append(snapshots, snapshot)
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	for i := 0; i < len(snapshots); i++ {
+		c.SSEvent(snapshots[i].Event, snapshots[i])
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-heartbeat.C:
+			c.SSEvent("ping", "")
+			return true
+		case payload := <-events:
+			var event data.ProductEventData
+
+			if json.Unmarshal([]byte(payload), &event) != nil || !watched[event.ProductId] {
+				return true
+			}
+
+			c.SSEvent(event.Event, event)
+			return true
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"BackendAPI/api/follow"
+	"BackendAPI/data"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleFollowSeller godoc
+// @Summary      Follows a seller
+// @Description  Makes a buyer follow a seller. Following a seller that is already followed does nothing.
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Param 		 sellerId path string true "Seller id"
+// @Success      200  {object}  data.FollowSellerResponseData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/follows/{sellerId} [put]
+func handleFollowSeller(c *gin.Context) {
+	response, err := follow.FollowSeller(db, c.Param("id"), c.Param("sellerId"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleUnfollowSeller godoc
+// @Summary      Unfollows a seller
+// @Description  Makes a buyer stop following a seller. Unfollowing a seller that is not followed does nothing.
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Param 		 sellerId path string true "Seller id"
+// @Success      200  {object}  data.FollowSellerResponseData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/follows/{sellerId} [delete]
+func handleUnfollowSeller(c *gin.Context) {
+	response, err := follow.UnfollowSeller(db, c.Param("id"), c.Param("sellerId"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetBuyerFollows godoc
+// @Summary      Gets the sellers a buyer follows
+// @Description  Gets the sellers a buyer follows from the most recently followed seller and whether the buyer gets the
+// daily mail of new listings
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Success      200  {object}  data.GetBuyerFollowsResponseData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/follows [get]
+func handleGetBuyerFollows(c *gin.Context) {
+	response, err := follow.GetBuyerFollows(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetBuyerFeed godoc
+// @Summary      Gets the feed of a buyer
+// @Description  Gets the most recent listings of the sellers a buyer follows, from the newest listing. If there are more
+// listings, next_cursor can be passed as the cursor to get the next page.
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Param 		 cursor query string false "Cursor from the next_cursor of the previous page, leave out for the first page"
+// @Param 		 limit query int false "Indicates the number of listings fetched. Default is 20 and at most 100 listings are fetched"
+// @Success      200  {object}  data.GetBuyerFeedResponseData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/feed [get]
+func handleGetBuyerFeed(c *gin.Context) {
+	limit := 0
+
+	if c.Query("limit") != "" {
+		var convErr error
+		limit, convErr = strconv.Atoi(c.Query("limit"))
+
+		if convErr != nil {
+			r := data.Message{Message: "Bad limit param"}
+			c.JSON(http.StatusBadRequest, r)
+			return
+		}
+	}
+
+	response, err := follow.GetBuyerFeed(db, c.Param("id"), c.Query("cursor"), limit)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleUpdateFollowDigest godoc
+// @Summary      Turns the new listing digest on or off
+// @Description  Turns the daily mail of new listings from followed sellers on or off for a buyer
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Param 		 digest body data.UpdateFollowDigestRequestData true "Whether the digest is sent"
+// @Success      200  {object}  data.GetBuyerFollowsResponseData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/feed/digest [put]
+func handleUpdateFollowDigest(c *gin.Context) {
+	var request data.UpdateFollowDigestRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := follow.UpdateFollowDigest(db, c.Param("id"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleSendFollowDigests godoc
+// @Summary      Sends the new listing digests
+// @Description  Mails every buyer who has turned on the digest the new listings of the sellers they follow, at most once
+// a day per buyer. Meant to be called on a schedule, requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Success      200  {object}  data.SendFollowDigestsResponseData
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/tasks/follow-digests [post]
+func handleSendFollowDigests(c *gin.Context) {
+	response, err := follow.SendFollowDigests(db)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"BackendAPI/api/jobs"
+	"BackendAPI/data"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleGetJobs godoc
+// @Summary      Gets the failing background jobs
+// @Description  Gets the background jobs from the newest job. By default only the failing jobs are returned, which
+// are the failed jobs and the pending jobs that have failed at least once. Requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 status query string false "Only jobs with this status, 'pending', 'running', 'completed' or 'failed'"
+// @Success      200  {object}  data.GetJobsResponseData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/jobs [get]
+func handleGetJobs(c *gin.Context) {
+	response, err := jobs.GetJobs(db, c.Query("status"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleEnqueueJob godoc
+// @Summary      Adds a background job
+// @Description  Adds a job of a type the workers can run to the queue, such as record-prices with the product_ids to
+// record. The job runs as soon as possible unless a run date is given. Requires the admin key.
+// @Accept       json
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 type body string true "Type of the job"
+// @Param 		 payload body object false "Payload the job is run with"
+// @Param 		 run_date body string false "When the job should run, in RFC3339"
+// @Param 		 max_attempts body int false "How many times the job is tried, defaults to 5"
+// @Success      200  {object}  data.JobData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/jobs [post]
+func handleEnqueueJob(c *gin.Context) {
+	var request data.EnqueueJobRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := jobQueue.EnqueueJob(request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleRetryJob godoc
+// @Summary      Retries a failed background job
+// @Description  Puts a failed job back in the queue to run as soon as possible with all of its attempts. Requires the
+// admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 id path string true "Job ID"
+// @Success      200  {object}  data.JobData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/jobs/{id}/retry [post]
+func handleRetryJob(c *gin.Context) {
+	response, err := jobs.RetryJob(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleRunDueJobs godoc
+// @Summary      Runs the due background jobs
+// @Description  Schedules the recurring jobs and runs every job that is due, retrying failed jobs with exponential
+// backoff. Meant for deployments without a worker process, requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Success      200  {object}  data.RunJobsResponseData
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/tasks/jobs [post]
+func handleRunDueJobs(c *gin.Context) {
+	response, err := jobQueue.RunDueJobs(c.Request.Context())
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
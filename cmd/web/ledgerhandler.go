@@ -0,0 +1,146 @@
+package main
+
+import (
+	"BackendAPI/api/ledger"
+	"BackendAPI/data"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleGetSellerBalance godoc
+// @Summary      Gets the balance of a seller
+// @Description  Gets the funds of a seller that are held until delivery and the dispute window has passed, the funds
+// available for the next payout and the total paid out. Amounts are after the platform commission.
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Success      200  {object}  data.SellerBalanceData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/balance [get]
+func handleGetSellerBalance(c *gin.Context) {
+	response, err := ledger.GetSellerBalance(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetSellerStatement godoc
+// @Summary      Gets the statement of a seller
+// @Description  Gets the ledger entries of a seller from the oldest entry. Positive amounts are owed to the seller,
+// negative amounts are refunds and payouts.
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 from query string false "Only entries from this RFC3339 date"
+// @Param 		 to query string false "Only entries before this RFC3339 date"
+// @Success      200  {object}  data.GetSellerStatementResponseData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/statement [get]
+func handleGetSellerStatement(c *gin.Context) {
+	response, err := ledger.GetSellerStatement(db, c.Param("id"), c.Query("from"), c.Query("to"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleReleaseSellerFunds godoc
+// @Summary      Releases the funds of delivered sub-orders
+// @Description  Makes the funds of every sub-order that was delivered before the dispute window available for payout.
+// Meant to be called on a schedule, requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Success      200  {object}  data.ReleaseFundsResponseData
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/tasks/release-funds [post]
+func handleReleaseSellerFunds(c *gin.Context) {
+	response, err := ledger.ReleaseSellerFunds(db)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleCreatePayoutBatch godoc
+// @Summary      Creates a payout batch
+// @Description  Creates a payout for every seller with an available balance. The batch is empty if no seller is owed
+// money. Meant to be called on a schedule, requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Success      200  {object}  data.PayoutBatchData
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/tasks/payouts [post]
+func handleCreatePayoutBatch(c *gin.Context) {
+	response, err := ledger.CreatePayoutBatch(db)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetPayoutReport godoc
+// @Summary      Gets the payout report
+// @Description  Gets the funds held and available for sellers, the total paid out, the commission earned and every
+// payout batch from the newest batch. Requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Success      200  {object}  data.PayoutReportData
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/payouts/report [get]
+func handleGetPayoutReport(c *gin.Context) {
+	response, err := ledger.GetPayoutReport(db)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleMarkPayoutPaid godoc
+// @Summary      Marks a payout as paid
+// @Description  Marks a payout as paid once the money has been transferred to the seller. Requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 id path string true "Payout id"
+// @Success      200  {object}  data.PayoutData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/payouts/{id}/paid [post]
+func handleMarkPayoutPaid(c *gin.Context) {
+	response, err := ledger.MarkPayoutPaid(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
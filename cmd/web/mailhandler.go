@@ -0,0 +1,69 @@
+package main
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/mailing"
+	"BackendAPI/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleGetMailTemplates godoc
+// @Summary      Gets the mail templates
+// @Description  Gets the events that mails are sent for and the locales they are written in. Requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Success      200  {object}  data.MailTemplatesResponseData
+// @Failure      401  {object}  data.Message
+// @Router       /admin/mail-templates [get]
+func handleGetMailTemplates(c *gin.Context) {
+	response := data.MailTemplatesResponseData{Events: mailing.Events(), Locales: mailing.Locales()}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handlePreviewMailTemplate godoc
+// @Summary      Previews a mail template
+// @Description  Renders the mail of an event with sample data. Unsupported locales fall back to en. With format html
+// only the HTML body is returned so that it can be viewed in a browser. Requires the admin key.
+// @Produce      json
+// @Produce      html
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 event path string true "Mail event, such as otp or order_confirmation"
+// @Param 		 locale query string false "Locale of the mail, defaults to en"
+// @Param 		 format query string false "Set to html to only get the HTML body"
+// @Success      200  {object}  data.MailPreviewData
+// @Failure      401  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/mail-templates/{event} [get]
+func handlePreviewMailTemplate(c *gin.Context) {
+	event := c.Param("event")
+	locale := mailing.GetLocale(c.Query("locale"))
+
+	if !mailing.IsEvent(event) {
+		r := data.Message{Message: "Mail template does not exist"}
+		c.JSON(http.StatusNotFound, r)
+		return
+	}
+
+	message, err := mailing.Preview(event, locale)
+
+	if err != nil {
+		utils.LogError(err, "Error in rendering mail preview")
+		serverErr := utils.InternalServerError(nil)
+		r := data.Message{Message: serverErr.Error()}
+		c.JSON(serverErr.ErrorCode(), r)
+		return
+	}
+
+	if c.Query("format") == "html" {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(message.Html))
+		return
+	}
+
+	response := data.MailPreviewData{Event: event, Locale: locale, Subject: message.Subject, Text: message.Text, Html: message.Html}
+
+	c.JSON(http.StatusOK, &response)
+}
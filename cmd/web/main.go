@@ -1,10 +1,17 @@
 package main
 
 import (
+	"BackendAPI/api/apikey"
+	"BackendAPI/api/jobs"
+	"BackendAPI/api/outbox"
+	"BackendAPI/api/telegram"
+	"BackendAPI/api/webhook"
 	_ "BackendAPI/docs"
+	"BackendAPI/mailing"
 	"BackendAPI/store"
 	"BackendAPI/utils"
 	"context"
+	"encoding/json"
 	"os"
 
 	"database/sql"
@@ -25,6 +32,9 @@ import (
 var ginLambda *ginadapter.GinLambda
 var db *sql.DB
 var s3Client *s3.Client
+var imageStore store.ImageStore
+var eventBus store.EventBus
+var jobQueue *jobs.Queue
 
 // @title           AUCTO Backend API
 // @version         1.0
@@ -58,6 +68,39 @@ func main() {
 	if err != nil {
 		log.Println("Could not connect to the S3 Instance:", err)
 	}
+	//Setup image store for direct uploads
+	imageStore, err = store.CreateImageStore(s3Client)
+	if err != nil {
+		log.Println("Could not create the image store:", err)
+	}
+	env := os.Getenv("API_ENV")
+	//Run as a long running server instead of a lambda, such as in a container next to the worker
+	runAsServer := env == "local" || (len(os.Args) > 1 && os.Args[1] == "server")
+	//Setup event bus for live updates, only a server has clients subscribed to events
+	eventBus, err = store.CreateEventBus(db, runAsServer)
+	if err != nil {
+		log.Println("Could not create the event bus:", err)
+	}
+	//Setup mailer for transactional mails
+	mailer, err := mailing.CreateMailer()
+	if err != nil {
+		log.Println("Could not create the mailer:", err)
+	} else {
+		mailing.UseMailer(mailer)
+	}
+	//Setup delivery of Telegram notifications from the outbox
+	outbox.RegisterHandler(telegram.OutboxTopic, telegram.DeliverOutboxMessage)
+	//Setup delivery of seller webhooks from the outbox
+	outbox.RegisterHandler(webhook.OutboxTopic, webhook.DeliveryHandler(db))
+	//Setup job queue for background jobs
+	jobQueue = createJobQueue()
+
+	//Run as a worker that only runs background jobs
+	if len(os.Args) > 1 && os.Args[1] == "worker" {
+		runWorker()
+		store.CloseDB(db)
+		return
+	}
 
 	apiGroup := router.Group("/api/v1")
 	{
@@ -67,14 +110,57 @@ func main() {
 			buyerGroup.POST("/signup", handleBuyerSignUp)
 			buyerGroup.POST("/resend-otp", handleResendOtp)
 			buyerGroup.POST("/validate-otp", handleValidateOtp)
+			buyerGroup.GET("/:id/offers", handleGetBuyerOffers)
+			buyerGroup.GET("/:id/cart", handleGetCart)
+			buyerGroup.POST("/:id/cart/products", handleAddCartProduct)
+			buyerGroup.PUT("/:id/cart/products/:productId", handleUpdateCartProduct)
+			buyerGroup.DELETE("/:id/cart/products/:productId", handleRemoveCartProduct)
+			buyerGroup.POST("/:id/cart/checkout", handleCheckoutBuyerCart)
+			buyerGroup.POST("/:id/reviews", handleCreateReview)
+			buyerGroup.GET("/:id/reviews", handleGetBuyerReviews)
+			buyerGroup.POST("/:id/reviews/uploads", handleCreateReviewImageUploads)
+			buyerGroup.GET("/:id/follows", handleGetBuyerFollows)
+			buyerGroup.PUT("/:id/follows/:sellerId", handleFollowSeller)
+			buyerGroup.DELETE("/:id/follows/:sellerId", handleUnfollowSeller)
+			buyerGroup.GET("/:id/feed", handleGetBuyerFeed)
+			buyerGroup.PUT("/:id/feed/digest", handleUpdateFollowDigest)
+			buyerGroup.GET("/:id/watchlist", handleGetWatchlist)
+			buyerGroup.PUT("/:id/watchlist/:productId", handleAddWatchlistProduct)
+			buyerGroup.DELETE("/:id/watchlist/:productId", handleRemoveWatchlistProduct)
+			buyerGroup.GET("/:id/notifications", handleGetNotifications)
+			buyerGroup.POST("/:id/notifications/read", handleMarkNotificationsRead)
+			buyerGroup.GET("/:id/notification-preferences", handleGetNotificationPreferences)
+			buyerGroup.PUT("/:id/notification-preferences", handleUpdateNotificationPreferences)
+			buyerGroup.POST("/:id/saved-searches", handleCreateSavedSearch)
+			buyerGroup.GET("/:id/saved-searches", handleGetSavedSearches)
+			buyerGroup.DELETE("/:id/saved-searches/:searchId", handleDeleteSavedSearch)
+			buyerGroup.GET("/:id/saved-searches/:searchId/products", handleRunSavedSearch)
+			buyerGroup.POST("/:id/telegram", handleCreateBuyerTelegramLink)
+			buyerGroup.GET("/:id/telegram", handleGetBuyerTelegramLink)
+			buyerGroup.DELETE("/:id/telegram", handleUnlinkBuyerTelegram)
 		}
 
 		productGroup := apiGroup.Group("/products")
 		{
 			productGroup.GET("/:id", handleGetProductById)
-			productGroup.POST("", handleCreateProduct)
-			productGroup.POST("/:id/images", handleCreateProductImages)
+			//Lambda returns the response once the handler is done, so the event streams are only served
+			// when the API runs as a server
+			if runAsServer {
+				productGroup.GET("/:id/events", handleGetProductEvents)
+				productGroup.GET("/events", handleGetProductListEvents)
+			}
+			productGroup.POST("", sellerApiKeyMiddleware(apikey.ProductsWriteScope), handleCreateProduct)
+			productGroup.POST("/:id/images", sellerApiKeyMiddleware(apikey.ProductsWriteScope), handleCreateProductImages)
+			productGroup.POST("/:id/images/uploads", sellerApiKeyMiddleware(apikey.ProductsWriteScope), handleCreateProductImageUploads)
+			productGroup.POST("/:id/images/uploads/complete", sellerApiKeyMiddleware(apikey.ProductsWriteScope), handleCompleteProductImageUploads)
 			productGroup.GET("", handleGetProductList)
+			productGroup.POST("/:id/bids", handlePlaceBid)
+			productGroup.GET("/:id/bids", handleGetBids)
+			productGroup.GET("/:id/auction-result", handleGetAuctionResult)
+			productGroup.POST("/:id/offers", handleCreateOffer)
+			productGroup.GET("/:id/price-history", handleGetPriceHistory)
+			productGroup.PUT("/:id/pre-order", sellerApiKeyMiddleware(apikey.ProductsWriteScope), handleUpdatePreOrder)
+			productGroup.POST("/:id/pre-order/convert", sellerApiKeyMiddleware(apikey.ProductsWriteScope), handleConvertPreOrder)
 			//productGroup.GET("/pre-orders", handleGetPreOrderList)
 		}
 
@@ -83,6 +169,34 @@ func main() {
 			sellerGroup.POST("/signup", handleSellerSignUp)
 			sellerGroup.POST("/login", handleSellerLogin)
 			sellerGroup.GET("/:id", handleGetSellerById)
+			sellerGroup.GET("/:id/offers", handleGetSellerOffers)
+			sellerGroup.POST("/:id/discounts", handleCreateDiscount)
+			sellerGroup.GET("/:id/discounts", handleGetSellerDiscounts)
+			sellerGroup.DELETE("/:id/discounts/:discountId", handleCancelDiscount)
+			sellerGroup.POST("/:id/promo-codes", handleCreateSellerPromoCode)
+			sellerGroup.GET("/:id/promo-codes", handleGetSellerPromoCodes)
+			sellerGroup.GET("/:id/sub-orders", sellerApiKeyMiddleware(apikey.OrdersReadScope), handleGetSellerSubOrders)
+			sellerGroup.GET("/:id/sub-orders/:subOrderId", sellerApiKeyMiddleware(apikey.OrdersReadScope), handleGetSellerSubOrder)
+			sellerGroup.PUT("/:id/sub-orders/:subOrderId/status", handleUpdateSubOrderStatus)
+			sellerGroup.POST("/:id/sub-orders/:subOrderId/cancel", handleCancelSellerSubOrder)
+			sellerGroup.POST("/:id/sub-orders/:subOrderId/refunds", handleRefundSubOrder)
+			sellerGroup.GET("/:id/balance", handleGetSellerBalance)
+			sellerGroup.GET("/:id/statement", handleGetSellerStatement)
+			sellerGroup.GET("/:id/reviews", handleGetSellerReviews)
+			sellerGroup.POST("/:id/reviews/:reviewId/reply", handleReplyToReview)
+			sellerGroup.POST("/:id/telegram", handleCreateSellerTelegramLink)
+			sellerGroup.GET("/:id/telegram", handleGetSellerTelegramLink)
+			sellerGroup.DELETE("/:id/telegram", handleUnlinkSellerTelegram)
+			sellerGroup.POST("/:id/webhooks", sellerAuthMiddleware(), handleCreateWebhook)
+			sellerGroup.GET("/:id/webhooks", sellerAuthMiddleware(), handleGetWebhooks)
+			sellerGroup.DELETE("/:id/webhooks/:webhookId", sellerAuthMiddleware(), handleDeleteWebhook)
+			sellerGroup.GET("/:id/webhooks/:webhookId/deliveries", sellerAuthMiddleware(), handleGetWebhookDeliveries)
+			sellerGroup.POST("/:id/webhooks/:webhookId/deliveries/:deliveryId/replay", sellerAuthMiddleware(), handleReplayWebhookDelivery)
+			sellerGroup.POST("/:id/webhooks/:webhookId/test", sellerAuthMiddleware(), handleSendWebhookTestEvent)
+			sellerGroup.POST("/:id/api-keys", sellerAuthMiddleware(), handleCreateApiKey)
+			sellerGroup.GET("/:id/api-keys", sellerAuthMiddleware(), handleGetApiKeys)
+			sellerGroup.POST("/:id/api-keys/:keyId/rotate", sellerAuthMiddleware(), handleRotateApiKey)
+			sellerGroup.DELETE("/:id/api-keys/:keyId", sellerAuthMiddleware(), handleRevokeApiKey)
 
 		}
 
@@ -94,6 +208,75 @@ func main() {
 			orderGroup.GET("/:id/guest", handleGetGuestOrderById)
 			orderGroup.POST("/:id/payment-complete", handlePaymentComplete)
 			orderGroup.POST("/:id/payment-complete/guest", handleGuestPaymentComplete)
+			orderGroup.POST("/:id/sub-orders/:subOrderId/cancel", handleCancelOrderSubOrder)
+		}
+
+		offerGroup := apiGroup.Group("/offers")
+		{
+			offerGroup.GET("/:id", handleGetOfferById)
+			offerGroup.POST("/:id/seller-response", handleSellerOfferResponse)
+			offerGroup.POST("/:id/buyer-response", handleBuyerOfferResponse)
+		}
+
+		cartGroup := apiGroup.Group("/carts")
+		{
+			cartGroup.POST("", handleCreateGuestCart)
+			cartGroup.GET("/:token", handleGetCart)
+			cartGroup.POST("/:token/products", handleAddCartProduct)
+			cartGroup.PUT("/:token/products/:productId", handleUpdateCartProduct)
+			cartGroup.DELETE("/:token/products/:productId", handleRemoveCartProduct)
+			cartGroup.POST("/:token/checkout", handleCheckoutGuestCart)
+		}
+
+		apiGroup.POST("/telegram/webhook", telegramWebhookMiddleware(), handleTelegramWebhook)
+		apiGroup.GET("/promo-codes/:code", handleGetPromoCode)
+		apiGroup.GET("/games", handleGetGames)
+		apiGroup.GET("/languages", handleGetLanguages)
+		apiGroup.GET("/expansions", handleGetExpansions)
+
+		adminGroup := apiGroup.Group("/admin", adminAuthMiddleware())
+		{
+			adminGroup.POST("/games", handleCreateGame)
+			adminGroup.PUT("/games/:code", handleUpdateGame)
+			adminGroup.DELETE("/games/:code", handleDeleteGame)
+			adminGroup.POST("/languages", handleCreateLanguage)
+			adminGroup.PUT("/languages/:code", handleUpdateLanguage)
+			adminGroup.DELETE("/languages/:code", handleDeleteLanguage)
+			adminGroup.POST("/expansions", handleCreateExpansion)
+			adminGroup.PUT("/expansions/:code", handleUpdateExpansion)
+			adminGroup.DELETE("/expansions/:code", handleDeleteExpansion)
+			adminGroup.POST("/promo-codes", handleCreatePlatformPromoCode)
+			adminGroup.POST("/tasks/close-auctions", handleCloseAuctions)
+			adminGroup.POST("/tasks/expire-offers", handleExpireOffers)
+			adminGroup.POST("/tasks/release-pre-orders", handleReleasePreOrders)
+			adminGroup.POST("/tasks/record-prices", handleRecordPrices)
+			adminGroup.POST("/tasks/release-funds", handleReleaseSellerFunds)
+			adminGroup.POST("/tasks/payouts", handleCreatePayoutBatch)
+			adminGroup.POST("/tasks/follow-digests", handleSendFollowDigests)
+			adminGroup.POST("/tasks/watchlist-alerts", handleCheckWatchlistAlerts)
+			adminGroup.POST("/tasks/saved-searches", handleMatchSavedSearches)
+			adminGroup.POST("/tasks/outbox", handleDeliverOutboxMessages)
+			adminGroup.POST("/tasks/jobs", handleRunDueJobs)
+			adminGroup.GET("/payouts/report", handleGetPayoutReport)
+			adminGroup.POST("/payouts/:id/paid", handleMarkPayoutPaid)
+			adminGroup.GET("/reviews", handleGetReviews)
+			adminGroup.PUT("/reviews/:id/status", handleModerateReview)
+			adminGroup.DELETE("/reviews/:id/reply", handleRemoveReviewReply)
+			adminGroup.GET("/mail-templates", handleGetMailTemplates)
+			adminGroup.GET("/mail-templates/:event", handlePreviewMailTemplate)
+			adminGroup.GET("/outbox", handleGetOutboxMessages)
+			adminGroup.POST("/outbox/:id/retry", handleRetryOutboxMessage)
+			adminGroup.GET("/jobs", handleGetJobs)
+			adminGroup.POST("/jobs", handleEnqueueJob)
+			adminGroup.POST("/jobs/:id/retry", handleRetryJob)
+		}
+
+		if _, isLocal := imageStore.(*store.LocalImageStore); isLocal {
+			localStorageGroup := apiGroup.Group("/local-storage")
+			{
+				localStorageGroup.PUT("/:key", handleLocalStorageUpload)
+				localStorageGroup.GET("/:key", handleLocalStorageDownload)
+			}
 		}
 
 		testGroup := apiGroup.Group("/tests")
@@ -107,19 +290,41 @@ func main() {
 		}
 	}
 
-	env := os.Getenv("API_ENV")
-
-	if env != "local" {
+	if !runAsServer {
 		ginLambda = ginadapter.New(router)
 
 		lambda.Start(Handler)
 	} else {
+		if env == "local" {
+			startLocalTasks()
+		}
 		router.Run(":8080")
 	}
 
 	defer store.CloseDB(db)
 }
 
-func Handler(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+/*
+Handles an invocation of the lambda. Scheduled events run the background jobs that are due, any other invocation
+is an API request.
+*/
+func Handler(ctx context.Context, event json.RawMessage) (interface{}, error) {
+	if isScheduledEvent(event) {
+		response, err := jobQueue.RunDueJobs(ctx)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return response, nil
+	}
+
+	var request events.APIGatewayProxyRequest
+	err := json.Unmarshal(event, &request)
+
+	if err != nil {
+		return nil, err
+	}
+
 	return ginLambda.ProxyWithContext(ctx, request)
 }
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"BackendAPI/api/apikey"
+	"BackendAPI/api/product"
+	"BackendAPI/data"
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Key of the seller that an API key belongs to in the context of a request made with an API key
+const apiKeySellerIdKey = "apiKeySellerId"
+
+/*
+Only allows requests that have the admin key from the ADMIN_API_KEY env variable in the X-Admin-Key header.
+If no admin key is configured every request is rejected.
+*/
+func adminAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !hasAdminKey(c) {
+			r := data.Message{Message: "Unauthorized"}
+			c.AbortWithStatusJSON(http.StatusUnauthorized, r)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+/*
+Checks wether a request has the admin key from the ADMIN_API_KEY env variable in the X-Admin-Key header
+*/
+func hasAdminKey(c *gin.Context) bool {
+	adminKey := os.Getenv("ADMIN_API_KEY")
+	requestKey := c.GetHeader("X-Admin-Key")
+
+	return adminKey != "" && subtle.ConstantTimeCompare([]byte(adminKey), []byte(requestKey)) == 1
+}
+
+/*
+Only allows requests for the seller in the path that have an API key of that seller, with any scope, as a bearer
+token in the Authorization header, or that have the admin key in the X-Admin-Key header.
+*/
+func sellerAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if hasAdminKey(c) {
+			c.Next()
+			return
+		}
+
+		key, isBearer := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+		if !isBearer {
+			r := data.Message{Message: "Unauthorized"}
+			c.AbortWithStatusJSON(http.StatusUnauthorized, r)
+			return
+		}
+
+		apiKey, err := apikey.Authenticate(db, strings.TrimSpace(key), "")
+
+		if err != nil {
+			r := data.Message{Message: err.Error()}
+			c.AbortWithStatusJSON(err.ErrorCode(), r)
+			return
+		}
+
+		if c.Param("id") != apiKey.SellerId {
+			r := data.Message{Message: "API key cannot be used for this seller"}
+			c.AbortWithStatusJSON(http.StatusForbidden, r)
+			return
+		}
+
+		c.Set(apiKeySellerIdKey, apiKey.SellerId)
+		c.Next()
+	}
+}
+
+/*
+Only allows webhook requests from Telegram, which send the secret from the TELEGRAM_WEBHOOK_SECRET env variable that
+the webhook was set with in the X-Telegram-Bot-Api-Secret-Token header. If no secret is configured every request is
+rejected.
+*/
+func telegramWebhookMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		secret := os.Getenv("TELEGRAM_WEBHOOK_SECRET")
+		requestSecret := c.GetHeader("X-Telegram-Bot-Api-Secret-Token")
+
+		if secret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(requestSecret)) != 1 {
+			r := data.Message{Message: "Unauthorized"}
+			c.AbortWithStatusJSON(http.StatusUnauthorized, r)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+/*
+Lets sellers call a route from their own tools with an API key that has the given scope, sent as a bearer token in
+the Authorization header. On seller routes the key must belong to the seller in the path. Requests without an
+Authorization header are handled as before.
+*/
+func sellerApiKeyMiddleware(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authorization := c.GetHeader("Authorization")
+
+		if authorization == "" {
+			c.Next()
+			return
+		}
+
+		key, isBearer := strings.CutPrefix(authorization, "Bearer ")
+
+		if !isBearer {
+			r := data.Message{Message: "Invalid API key"}
+			c.AbortWithStatusJSON(http.StatusUnauthorized, r)
+			return
+		}
+
+		apiKey, err := apikey.Authenticate(db, strings.TrimSpace(key), scope)
+
+		if err != nil {
+			r := data.Message{Message: err.Error()}
+			c.AbortWithStatusJSON(err.ErrorCode(), r)
+			return
+		}
+
+		if !strings.HasPrefix(c.FullPath(), "/api/v1/sellers/") || c.Param("id") == apiKey.SellerId {
+			c.Set(apiKeySellerIdKey, apiKey.SellerId)
+			c.Next()
+			return
+		}
+
+		r := data.Message{Message: "API key cannot be used for this seller"}
+		c.AbortWithStatusJSON(http.StatusForbidden, r)
+	}
+}
+
+/*
+Checks wether a request can act for a seller. Requests made with an API key can only act for the seller of the key.
+*/
+func canActForSeller(c *gin.Context, sellerId string) bool {
+	apiKeySellerId, hasApiKey := c.Get(apiKeySellerIdKey)
+	return !hasApiKey || apiKeySellerId == sellerId
+}
+
+/*
+Checks wether a request can act for the seller of a product. Requests made with an API key can only act on the
+products of the seller of the key.
+*/
+func canActForProductSeller(c *gin.Context, productId string) bool {
+	if _, hasApiKey := c.Get(apiKeySellerIdKey); !hasApiKey {
+		return true
+	}
+
+	return canActForSeller(c, product.GetProductSellerId(db, productId))
+}
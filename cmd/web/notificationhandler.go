@@ -0,0 +1,106 @@
+package main
+
+import (
+	"BackendAPI/api/notification"
+	"BackendAPI/data"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleGetNotifications godoc
+// @Summary      Gets the notifications of a buyer
+// @Description  Gets the most recent notifications of a buyer from the newest notification along with the number of
+// unread notifications
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Success      200  {object}  data.GetNotificationsResponseData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/notifications [get]
+func handleGetNotifications(c *gin.Context) {
+	response, err := notification.GetNotifications(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleMarkNotificationsRead godoc
+// @Summary      Marks the notifications of a buyer as read
+// @Description  Marks every notification of a buyer as read
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Success      200  {object}  data.GetNotificationsResponseData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/notifications/read [post]
+func handleMarkNotificationsRead(c *gin.Context) {
+	response, err := notification.MarkNotificationsRead(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetNotificationPreferences godoc
+// @Summary      Gets the notification preferences of a buyer
+// @Description  Gets whether a buyer gets each type of notification, every type is turned on by default
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Success      200  {object}  data.NotificationPreferencesData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/notification-preferences [get]
+func handleGetNotificationPreferences(c *gin.Context) {
+	response, err := notification.GetNotificationPreferences(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleUpdateNotificationPreferences godoc
+// @Summary      Updates the notification preferences of a buyer
+// @Description  Turns types of notifications on or off for a buyer, types that are left out keep their current setting
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Param 		 preferences body data.NotificationPreferencesData true "Notification types to turn on or off"
+// @Success      200  {object}  data.NotificationPreferencesData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/notification-preferences [put]
+func handleUpdateNotificationPreferences(c *gin.Context) {
+	var request data.NotificationPreferencesData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := notification.UpdateNotificationPreferences(db, c.Param("id"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"BackendAPI/api/offer"
+	"BackendAPI/data"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleCreateOffer godoc
+// @Summary      Makes an offer on a product
+// @Description  Makes an offer below the listed price on a Buy-Now product. The seller can accept, reject or counter the
+// offer before it expires. A buyer can only have one open offer on a product.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Product id"
+// @Param 		 offer body data.CreateOfferRequestData true "Buyer id, offered price per item in cents and quantity, the quantity defaults to 1"
+// @Success      201  {object}  data.OfferData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /products/{id}/offers [post]
+func handleCreateOffer(c *gin.Context) {
+	var request data.CreateOfferRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := offer.CreateOffer(db, c.Param("id"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &response)
+}
+
+// handleGetOfferById godoc
+// @Summary      Gets an offer
+// @Description  Gets an offer by its id. Once accepted the offer has the agreed price and the time until which the
+// quantity is reserved for the buyer to check out.
+// @Produce      json
+// @Param 		 id path string true "Offer id"
+// @Success      200  {object}  data.OfferData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /offers/{id} [get]
+func handleGetOfferById(c *gin.Context) {
+	response, err := offer.GetOfferById(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleSellerOfferResponse godoc
+// @Summary      Responds to an offer as the seller
+// @Description  Accepts, rejects or counters an open offer. A counter offer has to be between the offer and the listed
+// price. Accepting reserves the quantity at the agreed price for the buyer to check out with the offer id.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Offer id"
+// @Param 		 response body data.SellerOfferResponseRequestData true "Seller id, action is 'accept', 'reject' or 'counter' and the counter amount in cents when countering"
+// @Success      200  {object}  data.OfferData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /offers/{id}/seller-response [post]
+func handleSellerOfferResponse(c *gin.Context) {
+	var request data.SellerOfferResponseRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := offer.RespondToOfferAsSeller(db, c.Param("id"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleBuyerOfferResponse godoc
+// @Summary      Responds to a counter offer as the buyer
+// @Description  Accepts or rejects the counter offer of the seller. Accepting reserves the quantity at the counter price.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Offer id"
+// @Param 		 response body data.BuyerOfferResponseRequestData true "Buyer id and action, which is 'accept' or 'reject'"
+// @Success      200  {object}  data.OfferData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /offers/{id}/buyer-response [post]
+func handleBuyerOfferResponse(c *gin.Context) {
+	var request data.BuyerOfferResponseRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := offer.RespondToOfferAsBuyer(db, c.Param("id"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetSellerOffers godoc
+// @Summary      Gets the offers on a seller's products
+// @Description  Gets the offers made on the products of a seller, from the newest offer
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Success      200  {object}  data.GetOffersResponseData
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/offers [get]
+func handleGetSellerOffers(c *gin.Context) {
+	response, err := offer.GetSellerOffers(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetBuyerOffers godoc
+// @Summary      Gets the offers of a buyer
+// @Description  Gets the offers made by a buyer, from the newest offer
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Success      200  {object}  data.GetOffersResponseData
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/offers [get]
+func handleGetBuyerOffers(c *gin.Context) {
+	response, err := offer.GetBuyerOffers(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleExpireOffers godoc
+// @Summary      Expires offers that have run out of time
+// @Description  Expires open offers that were not responded to in time and accepted offers that were not checked out
+// before their reservation ended. Meant to be called on a schedule, requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Success      200  {object}  data.ExpireOffersResponseData
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/tasks/expire-offers [post]
+func handleExpireOffers(c *gin.Context) {
+	response, err := offer.ExpireOffers(db)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
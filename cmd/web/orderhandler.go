@@ -2,6 +2,7 @@ package main
 
 import (
 	"BackendAPI/api/order"
+	"BackendAPI/api/product"
 	"BackendAPI/data"
 	"fmt"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 // handleCreateOrder godoc
 // @Summary      Creates a new order
 // @Description  Creates a new order for a specific product. This order is created by an existing buyer with an account.
+// The order is split into a sub-order for each seller, with standard delivery each sub-order has its own delivery fee.
 // @Accept       json
 // @Produce      json
 // @Param 		 products body []data.ProductOrder true "The products for which we are creating an order"
@@ -21,6 +23,7 @@ import (
 // @Param        address_line_2 body string false "Delivery Address 2"
 // @Param        postal_code body string false "Postal code of address"
 // @Param        fees body data.OrderFees false "Delivery Type is either 'self_collection' or 'standard delivery', Payment type is 'card' or 'paynow_online'"
+// @Param        offer_id body string false "Id of an accepted offer of the buyer, the product of the offer is charged at the agreed price"
 // @Success      201  {object}  data.CreateOrderResponseData
 // @Failure      400  {object}  data.Message
 // @Failure      500  {object}  data.Message
@@ -50,6 +53,7 @@ func handleCreateOrder(c *gin.Context) {
 // handleCreateGuestOrder godoc
 // @Summary      Creates a new guest order
 // @Description  Creates a new order for a specific product. This order is created by a guest user.
+// The order is split into a sub-order for each seller, with standard delivery each sub-order has its own delivery fee.
 // @Accept       json
 // @Produce      json
 // @Param 		 products body []data.ProductOrder true "The products for which we are creating an order"
@@ -151,6 +155,11 @@ func handlePaymentComplete(c *gin.Context) {
 		return
 	}
 
+	if req.Status == "completed" {
+		productIds, _ := order.GetOrderProductIds(db, orderId, false)
+		product.PublishProductEvents(db, eventBus, productIds, "stock")
+	}
+
 	c.Status(200)
 	return
 }
@@ -174,6 +183,11 @@ func handleGuestPaymentComplete(c *gin.Context) {
 		return
 	}
 
+	if req.Status == "completed" {
+		productIds, _ := order.GetOrderProductIds(db, orderId, true)
+		product.PublishProductEvents(db, eventBus, productIds, "stock")
+	}
+
 	c.Status(200)
 	return
 }
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"BackendAPI/api/outbox"
+	"BackendAPI/data"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleGetOutboxMessages godoc
+// @Summary      Gets the messages stuck in the outbox
+// @Description  Gets the mails and events in the outbox from the newest message. By default only the stuck messages
+// are returned, which are the dead messages and the pending messages that have failed at least once. Requires the
+// admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 status query string false "Only messages with this status, 'pending', 'delivered' or 'dead'"
+// @Success      200  {object}  data.GetOutboxMessagesResponseData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/outbox [get]
+func handleGetOutboxMessages(c *gin.Context) {
+	response, err := outbox.GetOutboxMessages(db, c.Query("status"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleRetryOutboxMessage godoc
+// @Summary      Retries a dead outbox message
+// @Description  Puts a dead message back in the outbox to be delivered again from its first attempt. Requires the
+// admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 id path string true "Message ID"
+// @Success      200  {object}  data.OutboxMessageData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/outbox/{id}/retry [post]
+func handleRetryOutboxMessage(c *gin.Context) {
+	response, err := outbox.RetryOutboxMessage(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleDeliverOutboxMessages godoc
+// @Summary      Delivers the outbox
+// @Description  Delivers the mails and events in the outbox that are due. Messages that fail are retried with
+// exponential backoff and are marked as dead after 8 attempts. Meant to be called on a schedule, requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Success      200  {object}  data.DeliverOutboxMessagesResponseData
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/tasks/outbox [post]
+func handleDeliverOutboxMessages(c *gin.Context) {
+	response, err := outbox.DeliverOutboxMessages(db)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
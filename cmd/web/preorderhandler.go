@@ -0,0 +1,120 @@
+package main
+
+import (
+	"BackendAPI/api/product"
+	"BackendAPI/data"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleUpdatePreOrder godoc
+// @Summary      Changes the dates of a pre-order
+// @Description  Changes the order by and release dates of a pre-order that has not been released. Buyers who have paid
+// for the pre-order are sent a mail when the release date changes.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Product id of the pre-order"
+// @Param 		 pre-order body data.UpdatePreOrderRequestData true "Seller id and the new dates in RFC3339 format"
+// @Param 		 Authorization header string false "Seller API key with the products:write scope, as 'Bearer <key>'"
+// @Success      200  {object}  data.PreOrderData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      429  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /products/{id}/pre-order [put]
+func handleUpdatePreOrder(c *gin.Context) {
+	var request data.UpdatePreOrderRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	if !canActForSeller(c, request.SellerId) {
+		r := data.Message{Message: "API key cannot be used for this seller"}
+		c.JSON(http.StatusForbidden, r)
+		return
+	}
+
+	response, err := product.UpdatePreOrderDates(db, c.Param("id"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleConvertPreOrder godoc
+// @Summary      Converts a released pre-order into a Buy-Now listing
+// @Description  Converts a pre-order that has been released into a Buy-Now listing so that its remaining stock can be sold.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Product id of the pre-order"
+// @Param 		 seller body data.ConvertPreOrderRequestData true "Seller id"
+// @Param 		 Authorization header string false "Seller API key with the products:write scope, as 'Bearer <key>'"
+// @Success      200  {object}  data.PreOrderData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      429  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /products/{id}/pre-order/convert [post]
+func handleConvertPreOrder(c *gin.Context) {
+	var request data.ConvertPreOrderRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	if !canActForSeller(c, request.SellerId) {
+		r := data.Message{Message: "API key cannot be used for this seller"}
+		c.JSON(http.StatusForbidden, r)
+		return
+	}
+
+	response, err := product.ConvertPreOrderToBuyNow(db, c.Param("id"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	product.PublishProductEvents(db, eventBus, []string{c.Param("id")}, "status")
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleReleasePreOrders godoc
+// @Summary      Releases pre-orders that have reached their release date
+// @Description  Marks every open pre-order that has reached its release date as released and sends a mail to its buyers.
+// Meant to be called on a schedule, requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Success      200  {object}  data.ReleasePreOrdersResponseData
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/tasks/release-pre-orders [post]
+func handleReleasePreOrders(c *gin.Context) {
+	response, err := product.ReleasePreOrders(db)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	product.PublishProductEvents(db, eventBus, response.Released, "status")
+	c.JSON(http.StatusOK, &response)
+}
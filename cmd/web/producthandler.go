@@ -43,12 +43,19 @@ func handleGetProductById(c *gin.Context) {
 // @Param 		 description body string true "Short description of the product"
 // @Param 		 price body int true "Price as an int of the product"
 // @Param 		 condition body int true "Condition of the product from a scale of 0 to 5"
-// @Param 		 product_type body string true "Type of product sale: Buy-Now or Pre-Order"
-// @Param		 language body string true "Language of the product, is either 'Eng' or 'Jap'"
-// @Param 		 expansion body string true "Expansion of the product"
+// @Param 		 product_type body string true "Type of product sale: Buy-Now, Pre-Order or Auction"
+// @Param		 language body string true "Language code of the product, must be a language in the catalogue that the expansion was released in"
+// @Param 		 expansion body string true "Expansion code of the product, must be an expansion in the catalogue"
 // @Param        product_quantity body int true "Quantity of product to be put for sale"
+// @Param 		 card_attributes body data.CardAttributesData false "Attributes of a single card: card number, rarity, finish, edition and grading"
+// @Param 		 sealed_attributes body data.SealedAttributesData false "Attributes of a sealed product, cannot be used with card_attributes"
+// @Param 		 auction body data.CreateAuctionData false "Auction information, needed for Auction products. Auctions have a quantity of 1 and the price starts at the start price"
+// @Param 		 Authorization header string false "Seller API key with the products:write scope, as 'Bearer <key>'"
 // @Success      201  {object}  data.CreateProductResponseData
 // @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      429  {object}  data.Message
 // @Failure      500  {object}  data.Message
 // @Router       /products  [post]
 func handleCreateProduct(c *gin.Context) {
@@ -61,6 +68,12 @@ func handleCreateProduct(c *gin.Context) {
 		return
 	}
 
+	if !canActForSeller(c, createProduct.SellerId) {
+		r := data.Message{Message: "API key cannot be used for this seller"}
+		c.JSON(http.StatusForbidden, r)
+		return
+	}
+
 	product, err := product.CreateProduct(db, createProduct)
 
 	if err != nil {
@@ -80,15 +93,26 @@ func handleCreateProduct(c *gin.Context) {
 // @Produce      json
 // @Param        id path string true "product_id"
 // @Param 		 images formData file true "Array of image files to add to the product post"
+// @Param 		 Authorization header string false "Seller API key with the products:write scope, as 'Bearer <key>'"
 // @Success      201  {object}  data.Message
-// @Failure      415  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
 // @Failure      404  {object}  data.Message
+// @Failure      415  {object}  data.Message
+// @Failure      429  {object}  data.Message
 // @Failure      500  {object}  data.Message
 // @Router       /products/{id}/images  [post]
 func handleCreateProductImages(c *gin.Context) {
-	form, formErr := c.MultipartForm()
 	productId := c.Param("id")
 
+	if !canActForProductSeller(c, productId) {
+		r := data.Message{Message: "API key cannot be used for this seller"}
+		c.JSON(http.StatusForbidden, r)
+		return
+	}
+
+	form, formErr := c.MultipartForm()
+
 	if formErr != nil {
 		r := data.Message{Message: "Bad Content-Type in Request"}
 		c.JSON(http.StatusUnsupportedMediaType, r)
@@ -121,18 +145,120 @@ func handleCreateProductImages(c *gin.Context) {
 
 }
 
+// handleCreateProductImageUploads godoc
+// @Summary      Creates upload urls for product images
+// @Description  Creates an upload slot for each image of an existing product. Each slot has a presigned url that the image
+// must be uploaded to with a PUT request using the given headers, content type and size. Once uploaded the slots have to be
+// confirmed with the complete endpoint. If the product does not exist or already has images returns a 400.
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "product_id"
+// @Param 		 images body []data.ProductImageUploadRequestData true "Content type and size in bytes of each image, at most 5 images"
+// @Param 		 Authorization header string false "Seller API key with the products:write scope, as 'Bearer <key>'"
+// @Success      201  {object}  data.CreateProductImageUploadsResponseData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      429  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /products/{id}/images/uploads  [post]
+func handleCreateProductImageUploads(c *gin.Context) {
+	var request data.CreateProductImageUploadsRequestData
+	productId := c.Param("id")
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	if !canActForProductSeller(c, productId) {
+		r := data.Message{Message: "API key cannot be used for this seller"}
+		c.JSON(http.StatusForbidden, r)
+		return
+	}
+
+	response, err := product.CreateProductImageUploads(db, imageStore, productId, request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// handleCompleteProductImageUploads godoc
+// @Summary      Completes the upload of product images
+// @Description  Checks that the images for the given upload slots have been uploaded with the content type and size of the slot
+// and adds them to the product. If an image is missing, does not match its slot or the slot has expired returns a 400.
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "product_id"
+// @Param 		 upload_ids body []string true "Upload ids of the uploaded images, in the order of the images"
+// @Param 		 Authorization header string false "Seller API key with the products:write scope, as 'Bearer <key>'"
+// @Success      201  {object}  data.CreateProductImageData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      429  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /products/{id}/images/uploads/complete  [post]
+func handleCompleteProductImageUploads(c *gin.Context) {
+	var request data.CompleteProductImageUploadsRequestData
+	productId := c.Param("id")
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	if !canActForProductSeller(c, productId) {
+		r := data.Message{Message: "API key cannot be used for this seller"}
+		c.JSON(http.StatusForbidden, r)
+		return
+	}
+
+	response, err := product.CompleteProductImageUploads(db, imageStore, productId, request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
 // handleGetProducts godoc
 // @Summary      Gets Products with given query parameters
-// @Description  Gets product information of products given query parameters provided in the Request
+// @Description  Gets product information of products given query parameters provided in the Request. The response also has
+// the number of products for each product type, language, expansion, condition and price range, where the counts of each
+// filter are calculated with every other filter applied. If there are more products, next_cursor can be passed as the cursor
+// to get the next page.
 // @Accept       json
 // @Produce      json
-// @Param        sort_by query string false  "Sort By a specific attribute of the product. Default is posted_date"
-// @Param 		 product_types query []string false "Get products by a specific product type, the types are 'Pre-Order' or 'Buy-Now'. Default is both will be selected"
-// @Param 		 languages query []string false "Get products filtered by the language of the expansion. The choices are 'Eng' or 'Jap' and default is both."
+// @Param        q query string false "Keyword search over the title and description of the product, partial words are matched"
+// @Param        sort_by query string false  "Sort By a specific attribute of the product: 'price-low', 'price-high', 'name-asc', 'name-desc', 'grade-high', 'grade-low' or 'relevance'. Default is posted_date, or relevance when searching"
+// @Param 		 product_types query []string false "Get products by a specific product type, the types are 'Pre-Order', 'Buy-Now' or 'Auction'. Default is both will be selected"
+// @Param 		 languages query []string false "Get products filtered by the language of the expansion. The choices are the language codes in the catalogue and default is all languages."
 // @Param 		 expansions query []string false "Get products filtered by the expansion of the product. Default is all expansions"
-// @Param		 prices  query []string false "Gets products filtered by prices ranges, the ranges are '0-20', '20-50', '50-100', '100-200', '200'"
-// @Param 		 anchor query int true "Indicates the offset for the products"
-// @Param 		 limit query int true "Indicates the number of products fetched"
+// @Param 		 conditions query []string false "Gets products filtered by condition, from '0' to '5'. Default is all conditions"
+// @Param		 prices  query []string false "Gets products filtered by price ranges of the price after discounts, the ranges are '0-20', '20-50', '50-100', '100-200', '200' and include their minimum but not their maximum"
+// @Param 		 card_numbers query []string false "Gets cards filtered by their card number"
+// @Param 		 rarities query []string false "Gets cards filtered by their rarity"
+// @Param 		 finishes query []string false "Gets cards filtered by finish, the finishes are 'Non-Holo', 'Holo' or 'Reverse-Holo'"
+// @Param 		 editions query []string false "Gets cards filtered by edition, the editions are 'Unlimited' or '1st-Edition'"
+// @Param 		 grading_companies query []string false "Gets graded cards filtered by grading company, the companies are 'PSA', 'BGS' or 'CGC'"
+// @Param 		 min_grade query number false "Gets graded cards with at least this grade"
+// @Param 		 sealed_types query []string false "Gets sealed products filtered by type, the types are 'Booster-Box', 'Booster-Pack' or 'ETB'"
+// @Param 		 cursor query string false "Cursor from the next_cursor of the previous page, leave out for the first page"
+// @Param 		 anchor query int false "Deprecated, use cursor instead. Indicates the offset for the products"
+// @Param 		 limit query int false "Indicates the number of products fetched. Default is 20 and at most 100 products are fetched"
 // @Success      200  {object}  data.GetProductListResponseData
 // @Failure      500  {object}  data.Message
 // @Router       /products  [get]
@@ -143,10 +269,28 @@ func handleGetProductList(c *gin.Context) {
 	productTypes := c.QueryArray("product_types")
 	languages := c.QueryArray("languages")
 	expansions := c.QueryArray("expansions")
+	conditions := c.QueryArray("conditions")
+	cardNumbers := c.QueryArray("card_numbers")
+	rarities := c.QueryArray("rarities")
+	finishes := c.QueryArray("finishes")
+	editions := c.QueryArray("editions")
+	gradingCompanies := c.QueryArray("grading_companies")
+	minGrade := c.DefaultQuery("min_grade", "None")
+	sealedTypes := c.QueryArray("sealed_types")
+	search := c.Query("q")
+	cursor := c.Query("cursor")
 	anchor := c.DefaultQuery("anchor", "None")
 	limit := c.DefaultQuery("limit", "None")
 
-	err := request.GetProductListDataRequestFromParams(sortBy, productTypes, languages, prices, expansions, anchor, limit)
+	err := request.GetProductListDataRequestFromParams(sortBy, productTypes, languages, prices, expansions, conditions, search, cursor, anchor, limit)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	err = request.GetProductAttributeFiltersFromParams(cardNumbers, rarities, finishes, editions, gradingCompanies, minGrade, sealedTypes)
 
 	if err != nil {
 		r := data.Message{Message: err.Error()}
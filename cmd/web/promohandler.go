@@ -0,0 +1,110 @@
+package main
+
+import (
+	"BackendAPI/api/promo"
+	"BackendAPI/data"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleCreateSellerPromoCode godoc
+// @Summary      Creates a promo code for a seller
+// @Description  Creates a promo code that only applies to the products of the seller. Codes are 3 to 20 letters and digits
+// and are not case sensitive. A percentage or fixed discount can have a minimum spend, an expiry date in RFC3339 format,
+// a usage limit over all buyers and per buyer, be limited to first orders and to specific expansions.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 promo-code body data.CreatePromoCodeRequestData true "Promo code rules"
+// @Success      200  {object}  data.PromoCodeData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/promo-codes [post]
+func handleCreateSellerPromoCode(c *gin.Context) {
+	createPromoCode(c, c.Param("id"))
+}
+
+// handleGetSellerPromoCodes godoc
+// @Summary      Gets the promo codes of a seller
+// @Description  Gets the promo codes created by a seller, from the newest code
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Success      200  {object}  data.GetPromoCodesResponseData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/promo-codes [get]
+func handleGetSellerPromoCodes(c *gin.Context) {
+	response, err := promo.GetSellerPromoCodes(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetPromoCode godoc
+// @Summary      Gets a promo code
+// @Description  Gets the rules of a promo code so that the discount can be shown at checkout. Codes are not case sensitive.
+// @Produce      json
+// @Param 		 code path string true "Promo code"
+// @Success      200  {object}  data.PromoCodeData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /promo-codes/{code} [get]
+func handleGetPromoCode(c *gin.Context) {
+	response, err := promo.GetPromoCode(db, c.Param("code"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleCreatePlatformPromoCode godoc
+// @Summary      Creates a platform promo code
+// @Description  Creates a promo code run by the platform, which applies to every seller unless it is limited to specific
+// sellers. Takes the same rules as a seller promo code, requires the admin key.
+// @Accept       json
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 promo-code body data.CreatePromoCodeRequestData true "Promo code rules"
+// @Success      200  {object}  data.PromoCodeData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/promo-codes [post]
+func handleCreatePlatformPromoCode(c *gin.Context) {
+	createPromoCode(c, "")
+}
+
+/*
+Creates a promo code from the request body, for the platform when the seller id is empty
+*/
+func createPromoCode(c *gin.Context, sellerId string) {
+	var request data.CreatePromoCodeRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := promo.CreatePromoCode(db, sellerId, request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"BackendAPI/api/review"
+	"BackendAPI/data"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleCreateReviewImageUploads godoc
+// @Summary      Creates upload urls for review photos
+// @Description  Creates an upload slot for each photo of a review. Each slot has a presigned url that the photo must be
+// uploaded to with a PUT request using the given headers, content type and size. The upload ids are then sent with the review.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Param 		 images body data.CreateReviewImageUploadsRequestData true "Content type and size in bytes of each photo, at most 3 photos"
+// @Success      201  {object}  data.CreateReviewImageUploadsResponseData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/reviews/uploads [post]
+func handleCreateReviewImageUploads(c *gin.Context) {
+	var request data.CreateReviewImageUploadsRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := review.CreateReviewImageUploads(db, imageStore, c.Param("id"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &response)
+}
+
+// handleCreateReview godoc
+// @Summary      Reviews the seller of an order
+// @Description  Creates a review of a seller with a rating from 1 to 5, an optional text and optional photos. A buyer can
+// review each seller of their order once, after the seller's part of the order has been delivered.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Param 		 review body data.CreateReviewRequestData true "Review"
+// @Success      201  {object}  data.ReviewData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/reviews [post]
+func handleCreateReview(c *gin.Context) {
+	var request data.CreateReviewRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := review.CreateReview(db, imageStore, c.Param("id"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusCreated, &response)
+}
+
+// handleGetBuyerReviews godoc
+// @Summary      Gets the reviews of a buyer
+// @Description  Gets the reviews written by a buyer from the newest review, including reviews hidden by a moderator
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Success      200  {object}  data.GetReviewsResponseData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/reviews [get]
+func handleGetBuyerReviews(c *gin.Context) {
+	response, err := review.GetBuyerReviews(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetSellerReviews godoc
+// @Summary      Gets the reviews of a seller
+// @Description  Gets the rating of a seller and their reviews from the newest review
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Success      200  {object}  data.GetSellerReviewsResponseData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/reviews [get]
+func handleGetSellerReviews(c *gin.Context) {
+	response, err := review.GetSellerReviews(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleReplyToReview godoc
+// @Summary      Replies to a review
+// @Description  Replies to a review of the seller. A seller can only reply to a review once.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 reviewId path string true "Review id"
+// @Param 		 reply body data.ReplyReviewRequestData true "Reply"
+// @Success      200  {object}  data.ReviewData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/reviews/{reviewId}/reply [post]
+func handleReplyToReview(c *gin.Context) {
+	var request data.ReplyReviewRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := review.ReplyToReview(db, c.Param("id"), c.Param("reviewId"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetReviews godoc
+// @Summary      Gets reviews for moderation
+// @Description  Gets every review from the newest review, including hidden reviews. Requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 status query string false "Only reviews with this status, 'visible' or 'hidden'"
+// @Success      200  {object}  data.GetReviewsResponseData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/reviews [get]
+func handleGetReviews(c *gin.Context) {
+	response, err := review.GetReviews(db, c.Query("status"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleModerateReview godoc
+// @Summary      Hides or restores a review
+// @Description  Sets the status of a review to 'hidden' with an optional reason, or back to 'visible'. Hidden reviews are
+// only shown to their buyer and do not count towards the rating of the seller. Requires the admin key.
+// @Accept       json
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 id path string true "Review id"
+// @Param 		 status body data.ModerateReviewRequestData true "New status"
+// @Success      200  {object}  data.ReviewData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/reviews/{id}/status [put]
+func handleModerateReview(c *gin.Context) {
+	var request data.ModerateReviewRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := review.ModerateReview(db, c.Param("id"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleRemoveReviewReply godoc
+// @Summary      Removes the reply to a review
+// @Description  Removes the reply of the seller from a review. The seller cannot reply to the review again. Requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Param 		 id path string true "Review id"
+// @Success      200  {object}  data.ReviewData
+// @Failure      401  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/reviews/{id}/reply [delete]
+func handleRemoveReviewReply(c *gin.Context) {
+	response, err := review.RemoveReviewReply(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
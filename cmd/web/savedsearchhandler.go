@@ -0,0 +1,149 @@
+package main
+
+import (
+	"BackendAPI/api/savedsearch"
+	"BackendAPI/data"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleCreateSavedSearch godoc
+// @Summary      Saves a search
+// @Description  Saves a product list query of a buyer under a name, the buyer is notified of new listings that match
+// it. The query needs at least one filter or search query and a buyer can have at most 10 saved searches.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Param 		 search body data.CreateSavedSearchRequestData true "Name and product list query of the search"
+// @Success      200  {object}  data.SavedSearchData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/saved-searches [post]
+func handleCreateSavedSearch(c *gin.Context) {
+	var request data.CreateSavedSearchRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := savedsearch.CreateSavedSearch(db, c.Param("id"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetSavedSearches godoc
+// @Summary      Gets the saved searches of a buyer
+// @Description  Gets the saved searches of a buyer from the most recently saved search
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Success      200  {object}  data.GetSavedSearchesResponseData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/saved-searches [get]
+func handleGetSavedSearches(c *gin.Context) {
+	response, err := savedsearch.GetSavedSearches(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleDeleteSavedSearch godoc
+// @Summary      Deletes a saved search
+// @Description  Deletes a saved search of a buyer, the buyer is no longer notified of its matches
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Param 		 searchId path string true "Saved search id"
+// @Success      200  {object}  data.GetSavedSearchesResponseData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/saved-searches/{searchId} [delete]
+func handleDeleteSavedSearch(c *gin.Context) {
+	response, err := savedsearch.DeleteSavedSearch(db, c.Param("id"), c.Param("searchId"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleRunSavedSearch godoc
+// @Summary      Runs a saved search
+// @Description  Gets the products that currently match a saved search of a buyer. If there are more products,
+// next_cursor can be passed as the cursor to get the next page.
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Param 		 searchId path string true "Saved search id"
+// @Param 		 cursor query string false "Cursor from the next_cursor of the previous page, leave out for the first page"
+// @Param 		 limit query int false "Indicates the number of products fetched. Default is 20 and at most 100 products are fetched"
+// @Success      200  {object}  data.GetProductListResponseData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/saved-searches/{searchId}/products [get]
+func handleRunSavedSearch(c *gin.Context) {
+	limit := 0
+
+	if c.Query("limit") != "" {
+		var convErr error
+		limit, convErr = strconv.Atoi(c.Query("limit"))
+
+		if convErr != nil {
+			r := data.Message{Message: "Bad limit param"}
+			c.JSON(http.StatusBadRequest, r)
+			return
+		}
+	}
+
+	response, err := savedsearch.RunSavedSearch(db, c.Param("id"), c.Param("searchId"), c.Query("cursor"), limit)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleMatchSavedSearches godoc
+// @Summary      Matches new listings against saved searches
+// @Description  Matches the listings created since the last run against every saved search and notifies the buyers.
+// Each saved search is notified at most once an hour, matches in between are batched into one notification. Meant to
+// be called on a schedule, requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Success      200  {object}  data.MatchSavedSearchesResponseData
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/tasks/saved-searches [post]
+func handleMatchSavedSearches(c *gin.Context) {
+	response, err := savedsearch.MatchSavedSearches(db)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
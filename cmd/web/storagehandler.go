@@ -0,0 +1,60 @@
+package main
+
+import (
+	"BackendAPI/data"
+	"BackendAPI/store"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+Receives an image uploaded to a signed local storage url. Only used when images are stored locally,
+where it stands in for a presigned S3 upload.
+*/
+func handleLocalStorageUpload(c *gin.Context) {
+	localStore := imageStore.(*store.LocalImageStore)
+	key := c.Param("key")
+
+	err := localStore.SaveImage(key, c.Request.URL.Query(), c.ContentType(), c.Request.Body)
+
+	if errors.Is(err, store.ErrBadUploadSignature) || errors.Is(err, store.ErrUploadExpired) {
+		r := data.Message{Message: err.Error()}
+		c.JSON(http.StatusForbidden, r)
+		return
+	}
+
+	if errors.Is(err, store.ErrBadUploadContent) {
+		r := data.Message{Message: err.Error()}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	if err != nil {
+		r := data.Message{Message: "Something went wrong"}
+		c.JSON(http.StatusInternalServerError, r)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+/*
+Serves an image from local storage
+*/
+func handleLocalStorageDownload(c *gin.Context) {
+	localStore := imageStore.(*store.LocalImageStore)
+
+	file, info, err := localStore.OpenImage(c.Param("key"))
+
+	if err != nil {
+		r := data.Message{Message: "Resource not found"}
+		c.JSON(http.StatusNotFound, r)
+		return
+	}
+
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, info.Size, info.ContentType, file, nil)
+}
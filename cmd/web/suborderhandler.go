@@ -0,0 +1,197 @@
+package main
+
+import (
+	"BackendAPI/api/order"
+	"BackendAPI/api/product"
+	"BackendAPI/data"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleGetSellerSubOrders godoc
+// @Summary      Gets the sub-orders of a seller
+// @Description  Gets the part of each order that a seller has to fulfil, with the delivery details of the order,
+// from the newest sub-order. Sellers only see their own products and share of the order.
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 Authorization header string false "Seller API key with the orders:read scope, as 'Bearer <key>'"
+// @Success      200  {object}  data.GetSellerSubOrdersResponseData
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      429  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/sub-orders [get]
+func handleGetSellerSubOrders(c *gin.Context) {
+	response, err := order.GetSellerSubOrders(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetSellerSubOrder godoc
+// @Summary      Gets a sub-order of a seller
+// @Description  Gets a sub-order of a seller with the delivery details of its order
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 subOrderId path string true "Sub-order id"
+// @Param 		 Authorization header string false "Seller API key with the orders:read scope, as 'Bearer <key>'"
+// @Success      200  {object}  data.SellerSubOrderData
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      429  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/sub-orders/{subOrderId} [get]
+func handleGetSellerSubOrder(c *gin.Context) {
+	response, err := order.GetSellerSubOrder(db, c.Param("id"), c.Param("subOrderId"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleUpdateSubOrderStatus godoc
+// @Summary      Updates the fulfilment status of a sub-order
+// @Description  Marks a paid sub-order as 'shipped' or a shipped sub-order as 'delivered'
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 subOrderId path string true "Sub-order id"
+// @Param 		 status body data.UpdateSubOrderStatusRequestData true "New status"
+// @Success      200  {object}  data.SellerSubOrderData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/sub-orders/{subOrderId}/status [put]
+func handleUpdateSubOrderStatus(c *gin.Context) {
+	var request data.UpdateSubOrderStatusRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := order.UpdateSubOrderStatus(db, c.Param("id"), c.Param("subOrderId"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleCancelSellerSubOrder godoc
+// @Summary      Cancels a sub-order of a seller
+// @Description  Cancels a paid sub-order that has not been shipped, refunding what is left of its total and returning
+// its products to stock. The other sub-orders of the order are not affected.
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 subOrderId path string true "Sub-order id"
+// @Success      200  {object}  data.SellerSubOrderData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/sub-orders/{subOrderId}/cancel [post]
+func handleCancelSellerSubOrder(c *gin.Context) {
+	response, err := order.CancelSellerSubOrder(db, c.Param("id"), c.Param("subOrderId"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	publishSubOrderStock(response.SubOrderData)
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleRefundSubOrder godoc
+// @Summary      Refunds a sub-order
+// @Description  Refunds part or all of a paid sub-order. The refunds of a sub-order cannot add up to more than its
+// total, a sub-order refunded in full is marked as 'refunded'.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 subOrderId path string true "Sub-order id"
+// @Param 		 refund body data.RefundSubOrderRequestData true "Amount to refund in cents and the reason"
+// @Success      200  {object}  data.SellerSubOrderData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/sub-orders/{subOrderId}/refunds [post]
+func handleRefundSubOrder(c *gin.Context) {
+	var request data.RefundSubOrderRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := order.RefundSubOrder(db, c.Param("id"), c.Param("subOrderId"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	publishSubOrderStock(response.SubOrderData)
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleCancelOrderSubOrder godoc
+// @Summary      Cancels a sub-order of an order
+// @Description  Cancels the part of an order or guest order from one seller, which can be done until the seller ships
+// it. What is left of its total is refunded and the rest of the order is not affected.
+// @Produce      json
+// @Param 		 id path string true "Order or guest order id"
+// @Param 		 subOrderId path string true "Sub-order id"
+// @Success      200  {object}  data.SubOrderData
+// @Failure      400  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /orders/{id}/sub-orders/{subOrderId}/cancel [post]
+func handleCancelOrderSubOrder(c *gin.Context) {
+	response, err := order.CancelOrderSubOrder(db, c.Param("id"), c.Param("subOrderId"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	publishSubOrderStock(response)
+
+	c.JSON(http.StatusOK, &response)
+}
+
+/*
+Publishes the remaining quantity of the products of a sub-order to the clients watching them
+*/
+func publishSubOrderStock(subOrder data.SubOrderData) {
+	var productIds []string
+	for i := 0; i < len(subOrder.Products); i++ {
+		productIds = append(productIds, subOrder.Products[i].ProductId)
+	}
+
+	product.PublishProductEvents(db, eventBus, productIds, "stock")
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"BackendAPI/api/auction"
+	"BackendAPI/api/follow"
+	"BackendAPI/api/jobs"
+	"BackendAPI/api/ledger"
+	"BackendAPI/api/offer"
+	"BackendAPI/api/outbox"
+	"BackendAPI/api/product"
+	"BackendAPI/api/promo"
+	"BackendAPI/api/savedsearch"
+	"BackendAPI/api/watchlist"
+	"BackendAPI/data"
+	"BackendAPI/utils"
+	"context"
+	"encoding/json"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const (
+	closeAuctionsJob      = "close-auctions"
+	expireOffersJob       = "expire-offers"
+	releasePreOrdersJob   = "release-pre-orders"
+	recordPricesJob       = "record-prices"
+	releaseFundsJob       = "release-funds"
+	createPayoutBatchJob  = "create-payout-batch"
+	sendFollowDigestsJob  = "send-follow-digests"
+	watchlistAlertsJob    = "watchlist-alerts"
+	matchSavedSearchesJob = "match-saved-searches"
+	deliverOutboxJob      = "deliver-outbox"
+	releasePromoCodesJob  = "release-promo-codes"
+)
+
+/*
+Creates the job queue with a handler for every type of background job. The scheduled tasks recur every minute, the
+outbox more often since buyers wait on some of its mails and payouts once a day.
+*/
+func createJobQueue() *jobs.Queue {
+	queue := jobs.NewQueue(db)
+
+	jobs.Handle(queue, closeAuctionsJob, func(ctx context.Context, payload struct{}) error {
+		response, err := auction.CloseEndedAuctions(db)
+		if err != nil {
+			return err
+		}
+
+		product.PublishProductEvents(db, eventBus, append(response.Sold, response.Unsold...), "status")
+		return nil
+	})
+	jobs.Handle(queue, expireOffersJob, func(ctx context.Context, payload struct{}) error {
+		_, err := offer.ExpireOffers(db)
+		return asError(err)
+	})
+	jobs.Handle(queue, releasePreOrdersJob, func(ctx context.Context, payload struct{}) error {
+		response, err := product.ReleasePreOrders(db)
+		if err != nil {
+			return err
+		}
+
+		product.PublishProductEvents(db, eventBus, response.Released, "status")
+		return nil
+	})
+	jobs.Handle(queue, recordPricesJob, func(ctx context.Context, payload data.RecordPricesJobData) error {
+		_, err := product.RecordPriceHistory(db, payload.ProductIds)
+		return asError(err)
+	})
+	jobs.Handle(queue, releaseFundsJob, func(ctx context.Context, payload struct{}) error {
+		_, err := ledger.ReleaseSellerFunds(db)
+		return asError(err)
+	})
+	jobs.Handle(queue, createPayoutBatchJob, func(ctx context.Context, payload struct{}) error {
+		_, err := ledger.CreatePayoutBatch(db)
+		return asError(err)
+	})
+	jobs.Handle(queue, sendFollowDigestsJob, func(ctx context.Context, payload struct{}) error {
+		_, err := follow.SendFollowDigests(db)
+		return asError(err)
+	})
+	jobs.Handle(queue, watchlistAlertsJob, func(ctx context.Context, payload struct{}) error {
+		_, err := watchlist.CheckWatchlistAlerts(db)
+		return asError(err)
+	})
+	jobs.Handle(queue, matchSavedSearchesJob, func(ctx context.Context, payload struct{}) error {
+		_, err := savedsearch.MatchSavedSearches(db)
+		return asError(err)
+	})
+	jobs.Handle(queue, releasePromoCodesJob, func(ctx context.Context, payload struct{}) error {
+		_, err := promo.ReleaseUnpaidRedemptions(db)
+		return asError(err)
+	})
+	jobs.Handle(queue, deliverOutboxJob, func(ctx context.Context, payload struct{}) error {
+		_, err := outbox.DeliverOutboxMessages(db)
+		return asError(err)
+	})
+
+	queue.Every(closeAuctionsJob, time.Minute)
+	queue.Every(expireOffersJob, time.Minute)
+	queue.Every(releasePreOrdersJob, time.Minute)
+	queue.Every(recordPricesJob, time.Minute)
+	queue.Every(releaseFundsJob, time.Minute)
+	queue.Every(createPayoutBatchJob, 24*time.Hour)
+	queue.Every(sendFollowDigestsJob, time.Minute)
+	queue.Every(watchlistAlertsJob, time.Minute)
+	queue.Every(matchSavedSearchesJob, time.Minute)
+	queue.Every(releasePromoCodesJob, time.Minute)
+	queue.Every(deliverOutboxJob, 10*time.Second)
+
+	return queue
+}
+
+/*
+Runs the job queue in the background when the API is run locally. When deployed the jobs are run by a worker
+process or by scheduled invocations of the lambda instead.
+*/
+func startLocalTasks() {
+	go jobQueue.Work(context.Background(), 5*time.Second)
+}
+
+/*
+Runs the job queue until the process is interrupted or terminated, a job that is running when the worker stops is
+cancelled and claimed again by another worker once its visibility timeout runs out
+*/
+func runWorker() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	utils.LogMessage("Worker started")
+	jobQueue.Work(ctx, 5*time.Second)
+	utils.LogMessage("Worker stopped")
+}
+
+/*
+Checks wether a lambda invocation is a scheduled event, such as an EventBridge rule, rather than an API request
+*/
+func isScheduledEvent(event json.RawMessage) bool {
+	var scheduledEvent struct {
+		Source     string `json:"source"`
+		DetailType string `json:"detail-type"`
+	}
+
+	if json.Unmarshal(event, &scheduledEvent) != nil {
+		return false
+	}
+
+	return scheduledEvent.Source == "aws.events" || scheduledEvent.DetailType == "Scheduled Event"
+}
+
+/*
+Converts the error of a task to an error, a nil error handler has to become a nil error
+*/
+func asError(err *utils.ErrorHandler) error {
+	if err == nil {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"BackendAPI/api/telegram"
+	"BackendAPI/data"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleCreateBuyerTelegramLink godoc
+// @Summary      Creates a Telegram link for a buyer
+// @Description  Creates the deep link that links a buyer to a Telegram chat with the bot. Opening the link and
+// pressing Start links the chat, after which order, shipping, offer and bid updates are sent to it. The link is
+// valid for an hour and creating a new link replaces the previous one.
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Success      200  {object}  data.TelegramLinkCodeData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/telegram [post]
+func handleCreateBuyerTelegramLink(c *gin.Context) {
+	response, err := telegram.CreateLinkCode(db, telegram.BuyerAccount, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetBuyerTelegramLink godoc
+// @Summary      Gets the Telegram link of a buyer
+// @Description  Gets whether a buyer is linked to a Telegram chat and the Telegram username it was linked from
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Success      200  {object}  data.TelegramLinkData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/telegram [get]
+func handleGetBuyerTelegramLink(c *gin.Context) {
+	response, err := telegram.GetTelegramLink(db, telegram.BuyerAccount, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleUnlinkBuyerTelegram godoc
+// @Summary      Unlinks a buyer from Telegram
+// @Description  Unlinks a buyer from its Telegram chat so that no more updates are sent to it. Unlinking a buyer that
+// is not linked does nothing.
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Success      200  {object}  data.TelegramLinkData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/telegram [delete]
+func handleUnlinkBuyerTelegram(c *gin.Context) {
+	response, err := telegram.UnlinkTelegram(db, telegram.BuyerAccount, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleCreateSellerTelegramLink godoc
+// @Summary      Creates a Telegram link for a seller
+// @Description  Creates the deep link that links a seller to a Telegram chat with the bot. Opening the link and
+// pressing Start links the chat, after which new orders, offers and bids are sent to it along with the Telegram
+// handle of the buyer. The link is valid for an hour and creating a new link replaces the previous one.
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Success      200  {object}  data.TelegramLinkCodeData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/telegram [post]
+func handleCreateSellerTelegramLink(c *gin.Context) {
+	response, err := telegram.CreateLinkCode(db, telegram.SellerAccount, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetSellerTelegramLink godoc
+// @Summary      Gets the Telegram link of a seller
+// @Description  Gets whether a seller is linked to a Telegram chat and the Telegram username it was linked from
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Success      200  {object}  data.TelegramLinkData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/telegram [get]
+func handleGetSellerTelegramLink(c *gin.Context) {
+	response, err := telegram.GetTelegramLink(db, telegram.SellerAccount, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleUnlinkSellerTelegram godoc
+// @Summary      Unlinks a seller from Telegram
+// @Description  Unlinks a seller from its Telegram chat so that no more updates are sent to it. Unlinking a seller
+// that is not linked does nothing.
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Success      200  {object}  data.TelegramLinkData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/telegram [delete]
+func handleUnlinkSellerTelegram(c *gin.Context) {
+	response, err := telegram.UnlinkTelegram(db, telegram.SellerAccount, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleTelegramWebhook godoc
+// @Summary      Receives updates from the Telegram bot
+// @Description  Receives the updates Telegram sends to the webhook of the bot. /start with a link code links the
+// chat, /stop unlinks it. Requests must have the webhook secret in the X-Telegram-Bot-Api-Secret-Token header.
+// @Accept       json
+// @Produce      json
+// @Param 		 X-Telegram-Bot-Api-Secret-Token header string true "Webhook secret"
+// @Param 		 update body data.TelegramUpdateData true "Telegram update"
+// @Success      200  {object}  data.Message
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /telegram/webhook [post]
+func handleTelegramWebhook(c *gin.Context) {
+	var request data.TelegramUpdateData
+
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	err := telegram.HandleUpdate(db, request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, data.Message{Message: "OK"})
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"BackendAPI/api/watchlist"
+	"BackendAPI/data"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleGetWatchlist godoc
+// @Summary      Gets the watchlist of a buyer
+// @Description  Gets the products on the watchlist of a buyer from the most recently added product
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Success      200  {object}  data.GetWatchlistResponseData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/watchlist [get]
+func handleGetWatchlist(c *gin.Context) {
+	response, err := watchlist.GetWatchlist(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleAddWatchlistProduct godoc
+// @Summary      Adds a product to the watchlist
+// @Description  Adds a product to the watchlist of a buyer. The buyer is alerted when the effective price drops, when
+// the product is restocked after selling out and when its auction is ending soon. Adding a product that is already on
+// the watchlist does nothing.
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Param 		 productId path string true "Product id"
+// @Success      200  {object}  data.WatchlistProductData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/watchlist/{productId} [put]
+func handleAddWatchlistProduct(c *gin.Context) {
+	response, err := watchlist.AddWatchlistProduct(db, c.Param("id"), c.Param("productId"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleRemoveWatchlistProduct godoc
+// @Summary      Removes a product from the watchlist
+// @Description  Removes a product from the watchlist of a buyer
+// @Produce      json
+// @Param 		 id path string true "Buyer id"
+// @Param 		 productId path string true "Product id"
+// @Success      200  {object}  data.GetWatchlistResponseData
+// @Failure      404  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /buyers/{id}/watchlist/{productId} [delete]
+func handleRemoveWatchlistProduct(c *gin.Context) {
+	response, err := watchlist.RemoveWatchlistProduct(db, c.Param("id"), c.Param("productId"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleCheckWatchlistAlerts godoc
+// @Summary      Checks the watchlists for alerts
+// @Description  Notifies buyers of price drops, restocks and auctions ending soon on the products they watch. Each change
+// is only alerted once. Meant to be called on a schedule, requires the admin key.
+// @Produce      json
+// @Param 		 X-Admin-Key header string true "Admin API key"
+// @Success      200  {object}  data.CheckWatchlistAlertsResponseData
+// @Failure      401  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /admin/tasks/watchlist-alerts [post]
+func handleCheckWatchlistAlerts(c *gin.Context) {
+	response, err := watchlist.CheckWatchlistAlerts(db)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"BackendAPI/api/webhook"
+	"BackendAPI/data"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// handleCreateWebhook godoc
+// @Summary      Registers a webhook endpoint for a seller
+// @Description  Registers an https endpoint, by host name rather than IP address, that is sent the events it is subscribed to, 'order.paid',
+// 'order.cancelled', 'product.sold_out' and 'offer.received'. Deliveries are POSTed as JSON and signed with the secret
+// of the endpoint in the X-Aucto-Signature header as "t=<unix time>,v1=<hex HMAC-SHA256 of the time, a dot and the
+// body>". The secret is only returned when the endpoint is registered. A seller can have up to 10 endpoints.
+// @Accept       json
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 webhook body data.CreateWebhookRequestData true "Webhook endpoint"
+// @Param 		 Authorization header string false "API key of the seller, with any scope, as 'Bearer <key>'"
+// @Param 		 X-Admin-Key header string false "Admin API key, used instead of an API key of the seller"
+// @Success      200  {object}  data.WebhookData
+// @Failure      400  {object}  data.Message
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      429  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/webhooks [post]
+func handleCreateWebhook(c *gin.Context) {
+	var request data.CreateWebhookRequestData
+	bindErr := c.ShouldBindJSON(&request)
+
+	if bindErr != nil {
+		r := data.Message{Message: "Bad Request Body"}
+		c.JSON(http.StatusBadRequest, r)
+		return
+	}
+
+	response, err := webhook.CreateWebhook(db, c.Param("id"), request)
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetWebhooks godoc
+// @Summary      Gets the webhook endpoints of a seller
+// @Description  Gets the webhook endpoints of a seller from the newest endpoint, without their secrets
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 Authorization header string false "API key of the seller, with any scope, as 'Bearer <key>'"
+// @Param 		 X-Admin-Key header string false "Admin API key, used instead of an API key of the seller"
+// @Success      200  {object}  data.GetWebhooksResponseData
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      429  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/webhooks [get]
+func handleGetWebhooks(c *gin.Context) {
+	response, err := webhook.GetWebhooks(db, c.Param("id"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleDeleteWebhook godoc
+// @Summary      Deletes a webhook endpoint of a seller
+// @Description  Deletes a webhook endpoint of a seller along with its delivery log, no more events are sent to it
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 webhookId path string true "Webhook id"
+// @Param 		 Authorization header string false "API key of the seller, with any scope, as 'Bearer <key>'"
+// @Param 		 X-Admin-Key header string false "Admin API key, used instead of an API key of the seller"
+// @Success      200  {object}  data.WebhookData
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      429  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/webhooks/{webhookId} [delete]
+func handleDeleteWebhook(c *gin.Context) {
+	response, err := webhook.DeleteWebhook(db, c.Param("id"), c.Param("webhookId"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleGetWebhookDeliveries godoc
+// @Summary      Gets the delivery log of a webhook endpoint
+// @Description  Gets the latest 100 deliveries to a webhook endpoint of a seller from the newest delivery, with the
+// payload that was sent and the response code of the latest attempt. Failed deliveries are retried with exponential
+// backoff.
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 webhookId path string true "Webhook id"
+// @Param 		 Authorization header string false "API key of the seller, with any scope, as 'Bearer <key>'"
+// @Param 		 X-Admin-Key header string false "Admin API key, used instead of an API key of the seller"
+// @Success      200  {object}  data.GetWebhookDeliveriesResponseData
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      429  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/webhooks/{webhookId}/deliveries [get]
+func handleGetWebhookDeliveries(c *gin.Context) {
+	response, err := webhook.GetWebhookDeliveries(db, c.Param("id"), c.Param("webhookId"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleReplayWebhookDelivery godoc
+// @Summary      Replays a webhook delivery
+// @Description  Sends a delivery to its webhook endpoint again with the same payload and returns the result of the
+// attempt. A replay that fails is retried with exponential backoff.
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 webhookId path string true "Webhook id"
+// @Param 		 deliveryId path string true "Delivery id"
+// @Param 		 Authorization header string false "API key of the seller, with any scope, as 'Bearer <key>'"
+// @Param 		 X-Admin-Key header string false "Admin API key, used instead of an API key of the seller"
+// @Success      200  {object}  data.WebhookDeliveryData
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      429  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/webhooks/{webhookId}/deliveries/{deliveryId}/replay [post]
+func handleReplayWebhookDelivery(c *gin.Context) {
+	response, err := webhook.ReplayDelivery(db, c.Param("id"), c.Param("webhookId"), c.Param("deliveryId"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
+
+// handleSendWebhookTestEvent godoc
+// @Summary      Sends a test event to a webhook endpoint
+// @Description  Sends a 'webhook.test' event to a webhook endpoint of a seller and returns the result of the attempt,
+// so that the seller can check that their endpoint receives deliveries and verifies their signature
+// @Produce      json
+// @Param 		 id path string true "Seller id"
+// @Param 		 webhookId path string true "Webhook id"
+// @Param 		 Authorization header string false "API key of the seller, with any scope, as 'Bearer <key>'"
+// @Param 		 X-Admin-Key header string false "Admin API key, used instead of an API key of the seller"
+// @Success      200  {object}  data.WebhookDeliveryData
+// @Failure      401  {object}  data.Message
+// @Failure      403  {object}  data.Message
+// @Failure      404  {object}  data.Message
+// @Failure      429  {object}  data.Message
+// @Failure      500  {object}  data.Message
+// @Router       /sellers/{id}/webhooks/{webhookId}/test [post]
+func handleSendWebhookTestEvent(c *gin.Context) {
+	response, err := webhook.SendTestEvent(db, c.Param("id"), c.Param("webhookId"))
+
+	if err != nil {
+		r := data.Message{Message: err.Error()}
+		c.JSON(err.ErrorCode(), r)
+		return
+	}
+
+	c.JSON(http.StatusOK, &response)
+}
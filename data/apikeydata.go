@@ -0,0 +1,26 @@
+package data
+
+type CreateApiKeyRequestData struct {
+	Name      string   `json:"name" binding:"required" example:"Inventory sheet"`
+	Scopes    []string `json:"scopes" binding:"required" example:"products:write,orders:read"`
+	RateLimit int      `json:"rate_limit" example:"60"`
+}
+
+type ApiKeyData struct {
+	KeyId        string   `json:"key_id" binding:"required"`
+	SellerId     string   `json:"seller_id" binding:"required"`
+	Name         string   `json:"name" binding:"required"`
+	Prefix       string   `json:"prefix" binding:"required"`
+	Key          string   `json:"key,omitempty"`
+	Scopes       []string `json:"scopes" binding:"required"`
+	RateLimit    int      `json:"rate_limit" binding:"required"`
+	Status       string   `json:"status" binding:"required" example:"active"`
+	LastUsedDate string   `json:"last_used_date,omitempty"`
+	RotatedDate  string   `json:"rotated_date,omitempty"`
+	RevokedDate  string   `json:"revoked_date,omitempty"`
+	CreatedDate  string   `json:"created_date" binding:"required"`
+}
+
+type GetApiKeysResponseData struct {
+	ApiKeys []ApiKeyData `json:"api_keys" binding:"required"`
+}
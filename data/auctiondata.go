@@ -0,0 +1,72 @@
+package data
+
+type CreateAuctionData struct {
+	StartPrice       int    `json:"start_price" binding:"required"`
+	ReservePrice     int    `json:"reserve_price"`
+	StartsAt         string `json:"starts_at" example:"2023-10-02T15:00:00+08:00"`
+	EndsAt           string `json:"ends_at" binding:"required" example:"2023-10-09T21:00:00+08:00"`
+	ExtensionSeconds int    `json:"extension_seconds" example:"120"`
+}
+
+type AuctionData struct {
+	StartPrice       int    `json:"start_price" binding:"required"`
+	CurrentPrice     int    `json:"current_price" binding:"required"`
+	MinimumBid       int    `json:"minimum_bid" binding:"required"`
+	ReserveMet       bool   `json:"reserve_met" binding:"required"`
+	BidCount         int    `json:"bid_count" binding:"required"`
+	StartsAt         string `json:"starts_at" binding:"required"`
+	EndsAt           string `json:"ends_at" binding:"required"`
+	ExtensionSeconds int    `json:"extension_seconds" binding:"required"`
+	Status           string `json:"status" binding:"required" example:"open"`
+}
+
+type PlaceBidRequestData struct {
+	BuyerId  string               `json:"buyer_id" binding:"required"`
+	Amount   int                  `json:"amount" binding:"required"`
+	Checkout *AuctionCheckoutData `json:"checkout"`
+}
+
+type AuctionCheckoutData struct {
+	PhoneNumber    string `json:"phone_number" binding:"required"`
+	AddressLine1   string `json:"address_line_1" binding:"required"`
+	AddressLine2   string `json:"address_line_2"`
+	PostalCode     string `json:"postal_code" binding:"required"`
+	TelegramHandle string `json:"telegram_handle"`
+	DeliveryType   string `json:"delivery_type" binding:"required" example:"standard_delivery"`
+	PaymentType    string `json:"payment_type" binding:"required" example:"paynow_online"`
+}
+
+type PlaceBidResponseData struct {
+	BidId      string `json:"bid_id" binding:"required"`
+	ProductId  string `json:"product_id" binding:"required"`
+	Amount     int    `json:"amount" binding:"required"`
+	BidDate    string `json:"bid_date" binding:"required"`
+	MinimumBid int    `json:"minimum_bid" binding:"required"`
+	EndsAt     string `json:"ends_at" binding:"required"`
+}
+
+type BidData struct {
+	BidId    string `json:"bid_id" binding:"required"`
+	BidderNo int    `json:"bidder_no" binding:"required"`
+	Amount   int    `json:"amount" binding:"required"`
+	BidDate  string `json:"bid_date" binding:"required"`
+}
+
+type GetBidsResponseData struct {
+	ProductId string    `json:"product_id" binding:"required"`
+	Bids      []BidData `json:"bids" binding:"required"`
+}
+
+type CloseAuctionsResponseData struct {
+	Sold   []string `json:"sold" binding:"required"`
+	Unsold []string `json:"unsold" binding:"required"`
+}
+
+type AuctionResultData struct {
+	ProductId   string `json:"product_id" binding:"required"`
+	Status      string `json:"status" binding:"required" example:"sold"`
+	Won         bool   `json:"won" binding:"required"`
+	HammerPrice int    `json:"hammer_price"`
+	OrderId     string `json:"order_id"`
+	PaymentUrl  string `json:"payment_url"`
+}
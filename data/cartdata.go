@@ -0,0 +1,51 @@
+package data
+
+type AddCartProductRequestData struct {
+	ProductId string `json:"product_id" binding:"required"`
+	Quantity  int    `json:"quantity" binding:"required"`
+}
+
+type UpdateCartProductRequestData struct {
+	Quantity int `json:"quantity" binding:"required"`
+}
+
+type CartProductData struct {
+	ProductId         string `json:"product_id" binding:"required"`
+	Title             string `json:"title" binding:"required"`
+	ProductType       string `json:"product_type" binding:"required"`
+	Quantity          int    `json:"quantity" binding:"required"`
+	Price             int    `json:"price" binding:"required"`
+	AddedPrice        int    `json:"added_price" binding:"required"`
+	RemainingQuantity int    `json:"remaining_quantity" binding:"required"`
+	Available         bool   `json:"available" binding:"required"`
+	Issue             string `json:"issue,omitempty" example:"price_changed"`
+}
+
+type CartData struct {
+	CartId    string            `json:"cart_id" binding:"required"`
+	BuyerId   string            `json:"buyer_id,omitempty"`
+	CartToken string            `json:"cart_token,omitempty"`
+	Products  []CartProductData `json:"products" binding:"required"`
+	Subtotal  int               `json:"subtotal" binding:"required"`
+}
+
+type CheckoutCartRequestData struct {
+	PhoneNumber    string    `json:"phone_number" binding:"required"`
+	AddressLine1   string    `json:"address_line_1" binding:"required"`
+	AddressLine2   string    `json:"address_line_2"`
+	PostalCode     string    `json:"postal_code" binding:"required"`
+	TelegramHandle string    `json:"telegram_handle"`
+	PromoCode      string    `json:"promo_code"`
+	Fees           OrderFees `json:"fees" binding:"required"`
+}
+
+type CheckoutGuestCartRequestData struct {
+	Email          string    `json:"email" binding:"required,email"`
+	PhoneNumber    string    `json:"phone_number" binding:"required"`
+	AddressLine1   string    `json:"address_line_1" binding:"required"`
+	AddressLine2   string    `json:"address_line_2"`
+	PostalCode     string    `json:"postal_code" binding:"required"`
+	TelegramHandle string    `json:"telegram_handle"`
+	PromoCode      string    `json:"promo_code"`
+	Fees           OrderFees `json:"fees" binding:"required"`
+}
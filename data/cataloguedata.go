@@ -0,0 +1,54 @@
+package data
+
+type GameData struct {
+	GameId string `json:"game_id" binding:"required"`
+	Code   string `json:"code" binding:"required" example:"pokemon"`
+	Name   string `json:"name" binding:"required" example:"Pokemon TCG"`
+}
+
+type CreateGameData struct {
+	Code string `json:"code" binding:"required"`
+	Name string `json:"name" binding:"required"`
+}
+
+type UpdateGameData struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type LanguageData struct {
+	Code string `json:"code" binding:"required" example:"Eng"`
+	Name string `json:"name" binding:"required" example:"English"`
+}
+
+type UpdateLanguageData struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type ExpansionData struct {
+	ExpansionId string   `json:"expansion_id" binding:"required"`
+	GameCode    string   `json:"game_code" binding:"required" example:"pokemon"`
+	Code        string   `json:"code" binding:"required" example:"SV03"`
+	Name        string   `json:"name" binding:"required" example:"Obsidian Flames"`
+	ReleaseDate string   `json:"release_date" example:"2023-08-11"`
+	Languages   []string `json:"languages" binding:"required"`
+}
+
+type CreateExpansionData struct {
+	GameCode    string   `json:"game_code" binding:"required"`
+	Code        string   `json:"code" binding:"required"`
+	Name        string   `json:"name" binding:"required"`
+	ReleaseDate string   `json:"release_date"`
+	Languages   []string `json:"languages" binding:"required"`
+}
+
+type UpdateExpansionData struct {
+	GameCode    string   `json:"game_code" binding:"required"`
+	Name        string   `json:"name" binding:"required"`
+	ReleaseDate string   `json:"release_date"`
+	Languages   []string `json:"languages" binding:"required"`
+}
+
+type GetExpansionsRequestData struct {
+	GameCode string `form:"game"`
+	Language string `form:"language"`
+}
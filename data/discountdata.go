@@ -0,0 +1,25 @@
+package data
+
+type CreateDiscountRequestData struct {
+	ProductId    string `json:"product_id"`
+	DiscountType string `json:"discount_type" binding:"required" example:"percentage"`
+	Amount       int    `json:"amount" binding:"required"`
+	StartsAt     string `json:"starts_at"`
+	EndsAt       string `json:"ends_at"`
+}
+
+type DiscountData struct {
+	DiscountId   string `json:"discount_id" binding:"required"`
+	SellerId     string `json:"seller_id" binding:"required"`
+	ProductId    string `json:"product_id,omitempty"`
+	DiscountType string `json:"discount_type" binding:"required" example:"percentage"`
+	Amount       int    `json:"amount" binding:"required"`
+	StartsAt     string `json:"starts_at" binding:"required"`
+	EndsAt       string `json:"ends_at,omitempty"`
+	Status       string `json:"status" binding:"required" example:"active"`
+	CreatedDate  string `json:"created_date" binding:"required"`
+}
+
+type GetDiscountsResponseData struct {
+	Discounts []DiscountData `json:"discounts" binding:"required"`
+}
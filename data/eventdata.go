@@ -0,0 +1,10 @@
+package data
+
+type ProductEventData struct {
+	ProductId         string       `json:"product_id" binding:"required"`
+	Event             string       `json:"event" binding:"required" example:"bid"`
+	Status            string       `json:"status" binding:"required" example:"available"`
+	Price             int          `json:"price" binding:"required"`
+	RemainingQuantity int          `json:"remaining_quantity" binding:"required"`
+	Auction           *AuctionData `json:"auction,omitempty"`
+}
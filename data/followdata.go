@@ -0,0 +1,28 @@
+package data
+
+type FollowSellerResponseData struct {
+	BuyerId   string `json:"buyer_id" binding:"required"`
+	SellerId  string `json:"seller_id" binding:"required"`
+	Following bool   `json:"following"`
+	Followers int    `json:"followers"`
+}
+
+type GetBuyerFollowsResponseData struct {
+	BuyerId      string                      `json:"buyer_id" binding:"required"`
+	FollowDigest bool                        `json:"follow_digest"`
+	Sellers      []GetSellerByIdResponseData `json:"sellers" binding:"required"`
+}
+
+type UpdateFollowDigestRequestData struct {
+	Enabled bool `json:"enabled"`
+}
+
+type GetBuyerFeedResponseData struct {
+	BuyerId    string                   `json:"buyer_id" binding:"required"`
+	Products   []GetProductResponseData `json:"products" binding:"required"`
+	NextCursor string                   `json:"next_cursor"`
+}
+
+type SendFollowDigestsResponseData struct {
+	Sent []string `json:"sent" binding:"required"`
+}
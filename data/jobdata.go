@@ -0,0 +1,37 @@
+package data
+
+type JobData struct {
+	JobId         string `json:"job_id" binding:"required"`
+	Type          string `json:"type" binding:"required" example:"close-auctions"`
+	Payload       string `json:"payload" binding:"required"`
+	Status        string `json:"status" binding:"required" example:"failed"`
+	Attempts      int    `json:"attempts" binding:"required"`
+	MaxAttempts   int    `json:"max_attempts" binding:"required"`
+	LastError     string `json:"last_error"`
+	RunDate       string `json:"run_date" binding:"required"`
+	LockedUntil   string `json:"locked_until"`
+	CreatedDate   string `json:"created_date" binding:"required"`
+	CompletedDate string `json:"completed_date"`
+}
+
+type GetJobsResponseData struct {
+	Jobs []JobData `json:"jobs" binding:"required"`
+}
+
+type EnqueueJobRequestData struct {
+	Type        string                 `json:"type" binding:"required" example:"record-prices"`
+	Payload     map[string]interface{} `json:"payload"`
+	RunDate     string                 `json:"run_date" example:"2026-01-02T15:04:05Z"`
+	MaxAttempts int                    `json:"max_attempts" example:"5"`
+}
+
+type RunJobsResponseData struct {
+	Scheduled int `json:"scheduled"`
+	Completed int `json:"completed"`
+	Retried   int `json:"retried"`
+	Failed    int `json:"failed"`
+}
+
+type RecordPricesJobData struct {
+	ProductIds []string `json:"product_ids"`
+}
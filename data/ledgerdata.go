@@ -0,0 +1,55 @@
+package data
+
+type SellerBalanceData struct {
+	SellerId  string `json:"seller_id" binding:"required"`
+	Pending   int    `json:"pending"`
+	Available int    `json:"available"`
+	PaidOut   int    `json:"paid_out"`
+}
+
+type StatementEntryData struct {
+	TransactionId   string `json:"transaction_id" binding:"required"`
+	TransactionType string `json:"transaction_type" binding:"required"`
+	SubOrderId      string `json:"sub_order_id"`
+	PayoutId        string `json:"payout_id"`
+	Account         string `json:"account" binding:"required"`
+	Amount          int    `json:"amount" binding:"required"`
+	CreatedDate     string `json:"created_date" binding:"required"`
+}
+
+type GetSellerStatementResponseData struct {
+	SellerId string               `json:"seller_id" binding:"required"`
+	From     string               `json:"from"`
+	To       string               `json:"to"`
+	Entries  []StatementEntryData `json:"entries" binding:"required"`
+}
+
+type ReleaseFundsResponseData struct {
+	Released []string `json:"released" binding:"required"`
+}
+
+type PayoutData struct {
+	PayoutId    string `json:"payout_id" binding:"required"`
+	BatchId     string `json:"batch_id" binding:"required"`
+	SellerId    string `json:"seller_id" binding:"required"`
+	SellerName  string `json:"seller_name" binding:"required"`
+	Amount      int    `json:"amount" binding:"required"`
+	Status      string `json:"status" binding:"required"`
+	CreatedDate string `json:"created_date" binding:"required"`
+	PaidDate    string `json:"paid_date"`
+}
+
+type PayoutBatchData struct {
+	BatchId     string       `json:"batch_id"`
+	Total       int          `json:"total"`
+	CreatedDate string       `json:"created_date"`
+	Payouts     []PayoutData `json:"payouts" binding:"required"`
+}
+
+type PayoutReportData struct {
+	Pending    int               `json:"pending"`
+	Available  int               `json:"available"`
+	PaidOut    int               `json:"paid_out"`
+	Commission int               `json:"commission"`
+	Batches    []PayoutBatchData `json:"batches" binding:"required"`
+}
@@ -0,0 +1,14 @@
+package data
+
+type MailTemplatesResponseData struct {
+	Events  []string `json:"events" binding:"required"`
+	Locales []string `json:"locales" binding:"required"`
+}
+
+type MailPreviewData struct {
+	Event   string `json:"event" binding:"required" example:"otp"`
+	Locale  string `json:"locale" binding:"required" example:"en"`
+	Subject string `json:"subject" binding:"required"`
+	Text    string `json:"text" binding:"required"`
+	Html    string `json:"html" binding:"required"`
+}
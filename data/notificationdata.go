@@ -0,0 +1,27 @@
+package data
+
+type NotificationData struct {
+	NotificationId   string `json:"notification_id" binding:"required"`
+	NotificationType string `json:"notification_type" binding:"required" example:"price_drop"`
+	Title            string `json:"title" binding:"required"`
+	Message          string `json:"message" binding:"required"`
+	ProductId        string `json:"product_id"`
+	IsRead           bool   `json:"is_read"`
+	CreatedDate      string `json:"created_date" binding:"required"`
+}
+
+type GetNotificationsResponseData struct {
+	BuyerId       string             `json:"buyer_id" binding:"required"`
+	UnreadCount   int                `json:"unread_count"`
+	Notifications []NotificationData `json:"notifications" binding:"required"`
+}
+
+type NotificationPreferenceData struct {
+	NotificationType string `json:"notification_type" binding:"required" example:"price_drop"`
+	Enabled          bool   `json:"enabled"`
+}
+
+type NotificationPreferencesData struct {
+	BuyerId     string                       `json:"buyer_id"`
+	Preferences []NotificationPreferenceData `json:"preferences" binding:"required"`
+}
@@ -0,0 +1,42 @@
+package data
+
+type CreateOfferRequestData struct {
+	BuyerId  string `json:"buyer_id" binding:"required"`
+	Amount   int    `json:"amount" binding:"required"`
+	Quantity int    `json:"quantity"`
+}
+
+type SellerOfferResponseRequestData struct {
+	SellerId      string `json:"seller_id" binding:"required"`
+	Action        string `json:"action" binding:"required" example:"counter"`
+	CounterAmount int    `json:"counter_amount"`
+}
+
+type BuyerOfferResponseRequestData struct {
+	BuyerId string `json:"buyer_id" binding:"required"`
+	Action  string `json:"action" binding:"required" example:"accept"`
+}
+
+type OfferData struct {
+	OfferId       string `json:"offer_id" binding:"required"`
+	ProductId     string `json:"product_id" binding:"required"`
+	BuyerId       string `json:"buyer_id" binding:"required"`
+	SellerId      string `json:"seller_id" binding:"required"`
+	Quantity      int    `json:"quantity" binding:"required"`
+	Amount        int    `json:"amount" binding:"required"`
+	CounterAmount int    `json:"counter_amount,omitempty"`
+	AgreedAmount  int    `json:"agreed_amount,omitempty"`
+	Status        string `json:"status" binding:"required" example:"pending"`
+	CreatedDate   string `json:"created_date" binding:"required"`
+	ExpiresAt     string `json:"expires_at" binding:"required"`
+	ReservedUntil string `json:"reserved_until,omitempty"`
+	OrderId       string `json:"order_id,omitempty"`
+}
+
+type GetOffersResponseData struct {
+	Offers []OfferData `json:"offers" binding:"required"`
+}
+
+type ExpireOffersResponseData struct {
+	Expired []string `json:"expired" binding:"required"`
+}
@@ -8,6 +8,8 @@ type CreateOrderRequestData struct {
 	AddressLine2   string         `json:"address_line_2"`
 	PostalCode     string         `json:"postal_code" binding:"required"`
 	TelegramHandle string         `json:"telegram_handle"`
+	OfferId        string         `json:"offer_id"`
+	PromoCode      string         `json:"promo_code"`
 	Fees           OrderFees      `json:"fees" binding:"required"`
 }
 
@@ -24,6 +26,7 @@ type CreateGuestOrderRequestData struct {
 	AddressLine2   string         `json:"address_line_2"`
 	PostalCode     string         `json:"postal_code" binding:"required"`
 	TelegramHandle string         `json:"telegram_handle"`
+	PromoCode      string         `json:"promo_code"`
 	Fees           OrderFees      `json:"fees" binding:"required"`
 }
 
@@ -49,6 +52,7 @@ type GetOrderByIdResponseData struct {
 	PaymentStatus  string         `json:"payment_status" binding:"required"`
 	OrderDate      string         `json:"order_date" binding:"required"`
 	Fees           OrderFees      `json:"fees" binding:"required"`
+	SubOrders      []SubOrderData `json:"sub_orders" binding:"required"`
 }
 
 type GetGuestOrderByIdResponseData struct {
@@ -63,6 +67,7 @@ type GetGuestOrderByIdResponseData struct {
 	PaymentStatus  string         `json:"payment_status" binding:"required"`
 	OrderDate      string         `json:"order_date" binding:"required"`
 	Fees           OrderFees      `json:"fees" binding:"required"`
+	SubOrders      []SubOrderData `json:"sub_orders" binding:"required"`
 }
 
 type OrderFees struct {
@@ -72,4 +77,5 @@ type OrderFees struct {
 	DeliveryFee   int    `json:"delivery_fee"`
 	TotalPaid     int    `json:"total_paid" binding:"required"`
 	SmallOrderFee int    `json:"small_order_fee"`
+	Discount      int    `json:"discount"`
 }
@@ -0,0 +1,38 @@
+package data
+
+type OutboxMessageData struct {
+	MessageId       string `json:"message_id" binding:"required"`
+	Topic           string `json:"topic" binding:"required" example:"mail"`
+	Payload         string `json:"payload" binding:"required"`
+	Status          string `json:"status" binding:"required" example:"dead"`
+	Attempts        int    `json:"attempts" binding:"required"`
+	LastError       string `json:"last_error"`
+	NextAttemptDate string `json:"next_attempt_date" binding:"required"`
+	CreatedDate     string `json:"created_date" binding:"required"`
+	DeliveredDate   string `json:"delivered_date"`
+}
+
+type GetOutboxMessagesResponseData struct {
+	Messages []OutboxMessageData `json:"messages" binding:"required"`
+}
+
+type DeliverOutboxMessagesResponseData struct {
+	Delivered    int `json:"delivered"`
+	Retried      int `json:"retried"`
+	DeadLettered int `json:"dead_lettered"`
+}
+
+type BuyerEventData struct {
+	BuyerId string `json:"buyer_id"`
+}
+
+type OrderEventData struct {
+	OrderId string `json:"order_id"`
+	IsGuest bool   `json:"is_guest"`
+}
+
+type SubOrderEventData struct {
+	SubOrderId string `json:"sub_order_id"`
+	Amount     int    `json:"amount,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+}
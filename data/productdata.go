@@ -6,40 +6,60 @@ import (
 )
 
 type CreateProductResponseData struct {
-	ProductId    string `json:"product_id" binding:"required"`
-	SellerId     string `json:"seller_id" binding:"required"`
-	Title        string `json:"title" binding:"required"`
-	Description  string `json:"desc" binding:"required"`
-	ProductType  string `json:"product_type" binding:"required"`
-	Language     string `json:"language" binding:"required"`
-	Expansion    string `json:"expansion" binding:"required"`
-	PostedDate   string `json:"posted_date" binding:"required" example:"2023-08-03 02:50:26.034552906 +0000 UTC m=+192.307467936"`
-	Price        int    `json:"price" binding:"required"`
-	Condition    int8   `json:"condition" binding:"required"`
-	Quantity     int    `json:"product_quantity" binding:"required"`
-	SoldQuantity int    `json:"sold_quantity" binding:"required"`
-	OrderBy      string `json:"order_by"`
-	ReleasesOn   string `json:"releases_on"`
-	Discount     int    `json:"discount"`
+	ProductId        string                `json:"product_id" binding:"required"`
+	SellerId         string                `json:"seller_id" binding:"required"`
+	Title            string                `json:"title" binding:"required"`
+	Description      string                `json:"desc" binding:"required"`
+	ProductType      string                `json:"product_type" binding:"required"`
+	Language         string                `json:"language" binding:"required"`
+	Expansion        string                `json:"expansion" binding:"required"`
+	PostedDate       string                `json:"posted_date" binding:"required" example:"2023-08-03 02:50:26.034552906 +0000 UTC m=+192.307467936"`
+	Price            int                   `json:"price" binding:"required"`
+	Condition        int8                  `json:"condition" binding:"required"`
+	Quantity         int                   `json:"product_quantity" binding:"required"`
+	SoldQuantity     int                   `json:"sold_quantity" binding:"required"`
+	OrderBy          string                `json:"order_by"`
+	ReleasesOn       string                `json:"releases_on"`
+	Discount         int                   `json:"discount"`
+	CardAttributes   *CardAttributesData   `json:"card_attributes,omitempty"`
+	SealedAttributes *SealedAttributesData `json:"sealed_attributes,omitempty"`
 }
 
 type GetProductResponseData struct {
-	ProductId     string                    `json:"product_id" binding:"required"`
-	SellerInfo    GetSellerByIdResponseData `json:"seller_info" binding:"required"`
-	Title         string                    `json:"title" binding:"required"`
-	Description   string                    `json:"desc" binding:"required"`
-	ProductType   string                    `json:"product_type" binding:"required"`
-	Language      string                    `json:"language" binding:"required"`
-	Expansion     string                    `json:"expansion" binding:"required"`
-	PostedDate    string                    `json:"posted_date" binding:"required" example:"2023-08-03 02:50:26.034552906 +0000 UTC m=+192.307467936"`
-	Price         int                       `json:"price" binding:"required"`
-	Condition     int8                      `json:"condition" binding:"required"`
-	Quantity      int                       `json:"product_quantity" binding:"required"`
-	SoldQuantity  int                       `json:"sold_quantity" binding:"required"`
-	OrderBy       string                    `json:"order_by"`
-	ReleasesOn    string                    `json:"releases_on"`
-	Discount      int                       `json:"discount"`
-	ProductImages []ProductImageData        `json:"images" binding:"required"`
+	ProductId        string                    `json:"product_id" binding:"required"`
+	SellerInfo       GetSellerByIdResponseData `json:"seller_info" binding:"required"`
+	Title            string                    `json:"title" binding:"required"`
+	Description      string                    `json:"desc" binding:"required"`
+	ProductType      string                    `json:"product_type" binding:"required"`
+	Language         string                    `json:"language" binding:"required"`
+	Expansion        string                    `json:"expansion" binding:"required"`
+	PostedDate       string                    `json:"posted_date" binding:"required" example:"2023-08-03 02:50:26.034552906 +0000 UTC m=+192.307467936"`
+	Price            int                       `json:"price" binding:"required"`
+	Condition        int8                      `json:"condition" binding:"required"`
+	Quantity         int                       `json:"product_quantity" binding:"required"`
+	SoldQuantity     int                       `json:"sold_quantity" binding:"required"`
+	OrderBy          string                    `json:"order_by"`
+	ReleasesOn       string                    `json:"releases_on"`
+	ReleaseStatus    string                    `json:"release_status,omitempty" example:"open"`
+	Discount         int                       `json:"discount"`
+	ProductImages    []ProductImageData        `json:"images" binding:"required"`
+	CardAttributes   *CardAttributesData       `json:"card_attributes,omitempty"`
+	SealedAttributes *SealedAttributesData     `json:"sealed_attributes,omitempty"`
+	Auction          *AuctionData              `json:"auction,omitempty"`
+}
+
+type CardAttributesData struct {
+	CardNumber     string  `json:"card_number" binding:"required" example:"4/102"`
+	Rarity         string  `json:"rarity" example:"Holo Rare"`
+	Finish         string  `json:"finish" binding:"required" example:"Holo"`
+	Edition        string  `json:"edition" binding:"required" example:"1st-Edition"`
+	GradingCompany string  `json:"grading_company" example:"PSA"`
+	Grade          float64 `json:"grade" example:"10"`
+}
+
+type SealedAttributesData struct {
+	SealedType string `json:"sealed_type" binding:"required" example:"Booster-Box"`
+	PackCount  int    `json:"pack_count" example:"36"`
 }
 
 type ProductImageData struct {
@@ -48,18 +68,21 @@ type ProductImageData struct {
 }
 
 type CreateProductData struct {
-	Title       string `json:"title" binding:"required"`
-	SellerId    string `json:"seller_id" binding:"required"`
-	Description string `json:"description" binding:"required"`
-	ProductType string `json:"product_type" binding:"required"`
-	Language    string `json:"language" binding:"required"`
-	Expansion   string `json:"expansion" binding:"required"`
-	Price       int    `json:"price"`
-	Condition   int8   `json:"condition" `
-	Quantity    int    `json:"product_quantity"`
-	OrderBy     string `json:"order_by"`
-	ReleasesOn  string `json:"releases_on"`
-	Discount    int    `json:"discount"`
+	Title            string                `json:"title" binding:"required"`
+	SellerId         string                `json:"seller_id" binding:"required"`
+	Description      string                `json:"description" binding:"required"`
+	ProductType      string                `json:"product_type" binding:"required"`
+	Language         string                `json:"language" binding:"required"`
+	Expansion        string                `json:"expansion" binding:"required"`
+	Price            int                   `json:"price"`
+	Condition        int8                  `json:"condition" `
+	Quantity         int                   `json:"product_quantity"`
+	OrderBy          string                `json:"order_by"`
+	ReleasesOn       string                `json:"releases_on"`
+	Discount         int                   `json:"discount"`
+	CardAttributes   *CardAttributesData   `json:"card_attributes"`
+	SealedAttributes *SealedAttributesData `json:"sealed_attributes"`
+	Auction          *CreateAuctionData    `json:"auction"`
 }
 
 type CreateProductImageData struct {
@@ -67,19 +90,74 @@ type CreateProductImageData struct {
 	Images    []string `json:"images" binding:"required"`
 }
 
+type CreateProductImageUploadsRequestData struct {
+	Images []ProductImageUploadRequestData `json:"images" binding:"required"`
+}
+
+type ProductImageUploadRequestData struct {
+	ContentType string `json:"content_type" binding:"required" example:"image/png"`
+	Size        int64  `json:"size" binding:"required"`
+}
+
+type CreateProductImageUploadsResponseData struct {
+	ProductId string                   `json:"product_id" binding:"required"`
+	Uploads   []ProductImageUploadData `json:"uploads" binding:"required"`
+}
+
+type ProductImageUploadData struct {
+	UploadId    string            `json:"upload_id" binding:"required"`
+	ImageNo     int               `json:"image_no" binding:"required"`
+	UploadUrl   string            `json:"upload_url" binding:"required"`
+	Method      string            `json:"method" binding:"required"`
+	Headers     map[string]string `json:"headers" binding:"required"`
+	ContentType string            `json:"content_type" binding:"required"`
+	Size        int64             `json:"size" binding:"required"`
+	ExpiresAt   string            `json:"expires_at" binding:"required"`
+}
+
+type CompleteProductImageUploadsRequestData struct {
+	UploadIds []string `json:"upload_ids" binding:"required"`
+}
+
 type GetProductListRequestData struct {
-	SortBy       string   `json:"sort"`
-	Prices       []string `json:"prices"`
-	ProductTypes []string `json:"product_type"`
-	Languages    []string `json:"language"`
-	Expansions   []string `json:"expansion"`
-	Anchor       int      `json:"anchor"`
-	Limit        int      `json:"limit"`
+	SortBy           string   `json:"sort"`
+	Prices           []string `json:"prices"`
+	ProductTypes     []string `json:"product_type"`
+	Languages        []string `json:"language"`
+	Expansions       []string `json:"expansion"`
+	Conditions       []string `json:"condition"`
+	CardNumbers      []string `json:"card_number"`
+	Rarities         []string `json:"rarity"`
+	Finishes         []string `json:"finish"`
+	Editions         []string `json:"edition"`
+	GradingCompanies []string `json:"grading_company"`
+	MinGrade         float64  `json:"min_grade"`
+	SealedTypes      []string `json:"sealed_type"`
+	SellerIds        []string `json:"seller_ids"`
+	Search           string   `json:"q"`
+	Cursor           string   `json:"cursor"`
+	Anchor           int      `json:"anchor"`
+	Limit            int      `json:"limit"`
 }
 
 type GetProductListResponseData struct {
 	ProductCount int                      `json:"product_count" binding:"required"`
 	Products     []GetProductResponseData `json:"products" binding:"required"`
+	Facets       ProductFacetsData        `json:"facets" binding:"required"`
+	NextCursor   string                   `json:"next_cursor"`
+}
+
+type ProductFacetsData struct {
+	ProductTypes []FacetCountData `json:"product_types" binding:"required"`
+	Languages    []FacetCountData `json:"languages" binding:"required"`
+	Expansions   []FacetCountData `json:"expansions" binding:"required"`
+	Conditions   []FacetCountData `json:"conditions" binding:"required"`
+	Prices       []FacetCountData `json:"prices" binding:"required"`
+}
+
+type FacetCountData struct {
+	Value string `json:"value" binding:"required"`
+	Count int    `json:"count" binding:"required"`
 }
 
 /*
@@ -99,15 +177,24 @@ func (request *CreateProductData) ProductCreateResponseFromRequest(response *Cre
 	response.Discount = request.Discount
 	response.Language = request.Language
 	response.Expansion = request.Expansion
+	response.CardAttributes = request.CardAttributes
+	response.SealedAttributes = request.SealedAttributes
 }
 
 func (request *GetProductListRequestData) GetProductListDataRequestFromParams(sortBy string, productTypes []string, languages []string,
-	prices []string, expansions []string, anchor string, limit string) *utils.ErrorHandler {
+	prices []string, expansions []string, conditions []string, search string, cursor string, anchor string, limit string) *utils.ErrorHandler {
+	if len(search) > 200 {
+		return utils.BadRequestError("Bad q param")
+	}
+
 	request.SortBy = sortBy
+	request.Search = search
+	request.Cursor = cursor
 	request.ProductTypes = productTypes
 	request.Languages = languages
 	request.Expansions = expansions
 	request.Prices = prices
+	request.Conditions = conditions
 
 	if anchor != "None" {
 		anch, err := strconv.Atoi(anchor)
@@ -120,7 +207,7 @@ func (request *GetProductListRequestData) GetProductListDataRequestFromParams(so
 
 	if limit != "None" {
 		lim, err := strconv.Atoi(limit)
-		if err != nil || lim < 0 {
+		if err != nil || lim <= 0 {
 			return utils.BadRequestError("Bad limit param")
 		}
 
@@ -129,3 +216,68 @@ func (request *GetProductListRequestData) GetProductListDataRequestFromParams(so
 
 	return nil
 }
+
+/*
+Adds the card and sealed product attribute filters to a product list request
+*/
+func (request *GetProductListRequestData) GetProductAttributeFiltersFromParams(cardNumbers []string, rarities []string, finishes []string,
+	editions []string, gradingCompanies []string, minGrade string, sealedTypes []string) *utils.ErrorHandler {
+	request.CardNumbers = cardNumbers
+	request.Rarities = rarities
+	request.Finishes = finishes
+	request.Editions = editions
+	request.GradingCompanies = gradingCompanies
+	request.SealedTypes = sealedTypes
+
+	if minGrade != "None" {
+		grade, err := strconv.ParseFloat(minGrade, 64)
+		if err != nil || grade < 0 || grade > 10 {
+			return utils.BadRequestError("Bad min_grade param")
+		}
+
+		request.MinGrade = grade
+	}
+
+	return nil
+}
+
+type UpdatePreOrderRequestData struct {
+	SellerId   string `json:"seller_id" binding:"required"`
+	OrderBy    string `json:"order_by" binding:"required" example:"2023-11-01T23:59:59+08:00"`
+	ReleasesOn string `json:"releases_on" binding:"required" example:"2023-11-10T00:00:00+08:00"`
+}
+
+type ConvertPreOrderRequestData struct {
+	SellerId string `json:"seller_id" binding:"required"`
+}
+
+type PreOrderData struct {
+	ProductId      string `json:"product_id" binding:"required"`
+	ProductType    string `json:"product_type" binding:"required" example:"Pre-Order"`
+	OrderBy        string `json:"order_by" binding:"required"`
+	ReleasesOn     string `json:"releases_on" binding:"required"`
+	Status         string `json:"status" binding:"required" example:"open"`
+	NotifiedBuyers int    `json:"notified_buyers"`
+}
+
+type ReleasePreOrdersResponseData struct {
+	Released []string `json:"released" binding:"required"`
+}
+
+type PriceHistoryData struct {
+	Price          int    `json:"price" binding:"required"`
+	EffectivePrice int    `json:"effective_price" binding:"required"`
+	RecordedAt     string `json:"recorded_at" binding:"required"`
+}
+
+type GetPriceHistoryResponseData struct {
+	ProductId      string             `json:"product_id" binding:"required"`
+	Price          int                `json:"price" binding:"required"`
+	EffectivePrice int                `json:"effective_price" binding:"required"`
+	WasPrice       int                `json:"was_price,omitempty"`
+	History        []PriceHistoryData `json:"history" binding:"required"`
+}
+
+type RecordPricesResponseData struct {
+	Recorded []string `json:"recorded" binding:"required"`
+}
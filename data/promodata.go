@@ -0,0 +1,46 @@
+package data
+
+type CreatePromoCodeRequestData struct {
+	Code            string   `json:"code" binding:"required" example:"WELCOME10"`
+	DiscountType    string   `json:"discount_type" binding:"required" example:"percentage"`
+	Amount          int      `json:"amount" binding:"required"`
+	MinSpend        int      `json:"min_spend"`
+	ExpiresAt       string   `json:"expires_at"`
+	MaxUses         int      `json:"max_uses"`
+	MaxUsesPerBuyer int      `json:"max_uses_per_buyer"`
+	FirstOrderOnly  bool     `json:"first_order_only"`
+	SellerIds       []string `json:"seller_ids"`
+	Expansions      []string `json:"expansions"`
+}
+
+type PromoCodeData struct {
+	PromoCodeId     string   `json:"promo_code_id" binding:"required"`
+	Code            string   `json:"code" binding:"required" example:"WELCOME10"`
+	SellerId        string   `json:"seller_id,omitempty"`
+	DiscountType    string   `json:"discount_type" binding:"required" example:"percentage"`
+	Amount          int      `json:"amount" binding:"required"`
+	MinSpend        int      `json:"min_spend"`
+	ExpiresAt       string   `json:"expires_at,omitempty"`
+	MaxUses         int      `json:"max_uses,omitempty"`
+	MaxUsesPerBuyer int      `json:"max_uses_per_buyer,omitempty"`
+	FirstOrderOnly  bool     `json:"first_order_only"`
+	SellerIds       []string `json:"seller_ids" binding:"required"`
+	Expansions      []string `json:"expansions" binding:"required"`
+	UsedCount       int      `json:"used_count"`
+	Status          string   `json:"status" binding:"required" example:"active"`
+	CreatedDate     string   `json:"created_date" binding:"required"`
+}
+
+type GetPromoCodesResponseData struct {
+	PromoCodes []PromoCodeData `json:"promo_codes" binding:"required"`
+}
+
+type PromoOrderItemData struct {
+	SellerId  string
+	Expansion string
+	Cost      int
+}
+
+type ReleaseRedemptionsResponseData struct {
+	Released []string `json:"released" binding:"required"`
+}
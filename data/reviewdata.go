@@ -0,0 +1,53 @@
+package data
+
+type CreateReviewRequestData struct {
+	OrderId   string   `json:"order_id" binding:"required"`
+	SellerId  string   `json:"seller_id" binding:"required"`
+	Rating    int      `json:"rating" binding:"required" example:"5"`
+	Text      string   `json:"text"`
+	UploadIds []string `json:"upload_ids"`
+}
+
+type ReviewData struct {
+	ReviewId         string   `json:"review_id" binding:"required"`
+	OrderId          string   `json:"order_id" binding:"required"`
+	SellerId         string   `json:"seller_id" binding:"required"`
+	BuyerId          string   `json:"buyer_id" binding:"required"`
+	Rating           int      `json:"rating" binding:"required" example:"5"`
+	Text             string   `json:"text"`
+	Images           []string `json:"images" binding:"required"`
+	Reply            string   `json:"reply"`
+	RepliedDate      string   `json:"replied_date"`
+	Status           string   `json:"status" binding:"required" example:"visible"`
+	ModerationReason string   `json:"moderation_reason,omitempty"`
+	CreatedDate      string   `json:"created_date" binding:"required"`
+}
+
+type GetSellerReviewsResponseData struct {
+	SellerId    string       `json:"seller_id" binding:"required"`
+	Rating      float64      `json:"rating" example:"4.5"`
+	ReviewCount int          `json:"review_count"`
+	Reviews     []ReviewData `json:"reviews" binding:"required"`
+}
+
+type GetReviewsResponseData struct {
+	Reviews []ReviewData `json:"reviews" binding:"required"`
+}
+
+type ReplyReviewRequestData struct {
+	Reply string `json:"reply" binding:"required"`
+}
+
+type ModerateReviewRequestData struct {
+	Status string `json:"status" binding:"required" example:"hidden"`
+	Reason string `json:"reason"`
+}
+
+type CreateReviewImageUploadsRequestData struct {
+	Images []ProductImageUploadRequestData `json:"images" binding:"required"`
+}
+
+type CreateReviewImageUploadsResponseData struct {
+	BuyerId string                   `json:"buyer_id" binding:"required"`
+	Uploads []ProductImageUploadData `json:"uploads" binding:"required"`
+}
@@ -0,0 +1,25 @@
+package data
+
+type CreateSavedSearchRequestData struct {
+	Name  string                    `json:"name" binding:"required" example:"Charizard holo"`
+	Query GetProductListRequestData `json:"query" binding:"required"`
+}
+
+type SavedSearchData struct {
+	SearchId     string                    `json:"search_id" binding:"required"`
+	Name         string                    `json:"name" binding:"required"`
+	Query        GetProductListRequestData `json:"query" binding:"required"`
+	NotifiedDate string                    `json:"notified_date"`
+	CreatedDate  string                    `json:"created_date" binding:"required"`
+}
+
+type GetSavedSearchesResponseData struct {
+	BuyerId       string            `json:"buyer_id" binding:"required"`
+	SavedSearches []SavedSearchData `json:"saved_searches" binding:"required"`
+}
+
+type MatchSavedSearchesResponseData struct {
+	Listings int `json:"listings"`
+	Matches  int `json:"matches"`
+	Notified int `json:"notified"`
+}
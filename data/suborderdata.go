@@ -0,0 +1,41 @@
+package data
+
+type SubOrderData struct {
+	SubOrderId     string         `json:"sub_order_id" binding:"required"`
+	OrderId        string         `json:"order_id"`
+	GuestOrderId   string         `json:"guest_order_id"`
+	SellerId       string         `json:"seller_id" binding:"required"`
+	Products       []ProductOrder `json:"products" binding:"required"`
+	ProductsCost   int            `json:"products_cost" binding:"required"`
+	Discount       int            `json:"discount"`
+	DeliveryFee    int            `json:"delivery_fee"`
+	Total          int            `json:"total" binding:"required"`
+	RefundedAmount int            `json:"refunded_amount"`
+	Status         string         `json:"status" binding:"required"`
+	CreatedDate    string         `json:"created_date" binding:"required"`
+	UpdatedDate    string         `json:"updated_date" binding:"required"`
+}
+
+type SellerSubOrderData struct {
+	SubOrderData
+	DeliveryType   string `json:"delivery_type" binding:"required"`
+	PaymentStatus  string `json:"payment_status" binding:"required"`
+	PhoneNumber    string `json:"phone_number" binding:"required"`
+	AddressLine1   string `json:"address_line_1" binding:"required"`
+	AddressLine2   string `json:"address_line_2"`
+	PostalCode     string `json:"postal_code" binding:"required"`
+	TelegramHandle string `json:"telegram_handle"`
+}
+
+type GetSellerSubOrdersResponseData struct {
+	SubOrders []SellerSubOrderData `json:"sub_orders" binding:"required"`
+}
+
+type UpdateSubOrderStatusRequestData struct {
+	Status string `json:"status" binding:"required"`
+}
+
+type RefundSubOrderRequestData struct {
+	Amount int    `json:"amount" binding:"required"`
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,40 @@
+package data
+
+type TelegramLinkCodeData struct {
+	Code       string `json:"code" binding:"required"`
+	Url        string `json:"url" binding:"required" example:"https://t.me/AuctoBot?start=3f2a9c"`
+	ExpiryDate string `json:"expiry_date" binding:"required"`
+}
+
+type TelegramLinkData struct {
+	Linked     bool   `json:"linked"`
+	Username   string `json:"username"`
+	LinkedDate string `json:"linked_date"`
+}
+
+type TelegramUpdateData struct {
+	UpdateId int64                `json:"update_id"`
+	Message  *TelegramMessageData `json:"message"`
+}
+
+type TelegramMessageData struct {
+	MessageId int64            `json:"message_id"`
+	From      TelegramUserData `json:"from"`
+	Chat      TelegramChatData `json:"chat"`
+	Text      string           `json:"text"`
+}
+
+type TelegramUserData struct {
+	Id       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+type TelegramChatData struct {
+	Id   int64  `json:"id"`
+	Type string `json:"type"`
+}
+
+type TelegramOutboxMessageData struct {
+	ChatId int64  `json:"chat_id"`
+	Text   string `json:"text"`
+}
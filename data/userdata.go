@@ -1,8 +1,9 @@
 package data
 
 type UserLoginData struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
+	Email     string `json:"email" binding:"required,email"`
+	Password  string `json:"password" binding:"required"`
+	CartToken string `json:"cart_token"`
 }
 
 type BuyerResendOtpData struct {
@@ -15,8 +16,10 @@ type BuyerValidateOtpData struct {
 }
 
 type BuyerSignUpData struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
+	Email     string `json:"email" binding:"required,email"`
+	Password  string `json:"password" binding:"required"`
+	Locale    string `json:"locale" example:"en"`
+	CartToken string `json:"cart_token"`
 }
 
 type BuyerLoginResponseData struct {
@@ -39,7 +42,9 @@ type SellerLoginResponseData struct {
 }
 
 type GetSellerByIdResponseData struct {
-	SellerId   string `json:"seller_id" binding:"required"`
-	SellerName string `json:"seller_name" binding:"required"`
-	Followers  int    `json:"followers" binding:"required"`
+	SellerId    string  `json:"seller_id" binding:"required"`
+	SellerName  string  `json:"seller_name" binding:"required"`
+	Followers   int     `json:"followers" binding:"required"`
+	Rating      float64 `json:"rating" example:"4.5"`
+	ReviewCount int     `json:"review_count"`
 }
@@ -0,0 +1,23 @@
+package data
+
+type WatchlistProductData struct {
+	ProductId      string `json:"product_id" binding:"required"`
+	Title          string `json:"title" binding:"required"`
+	ProductType    string `json:"product_type" binding:"required"`
+	Price          int    `json:"price" binding:"required"`
+	EffectivePrice int    `json:"effective_price" binding:"required"`
+	SoldOut        bool   `json:"sold_out"`
+	EndsAt         string `json:"ends_at,omitempty"`
+	CreatedDate    string `json:"created_date" binding:"required"`
+}
+
+type GetWatchlistResponseData struct {
+	BuyerId  string                 `json:"buyer_id" binding:"required"`
+	Products []WatchlistProductData `json:"products" binding:"required"`
+}
+
+type CheckWatchlistAlertsResponseData struct {
+	PriceDrops     int `json:"price_drops"`
+	Restocks       int `json:"restocks"`
+	AuctionsEnding int `json:"auctions_ending"`
+}
@@ -0,0 +1,86 @@
+package data
+
+type CreateWebhookRequestData struct {
+	Url        string   `json:"url" binding:"required" example:"https://example.com/aucto/webhook"`
+	EventTypes []string `json:"event_types" binding:"required" example:"order.paid,product.sold_out"`
+}
+
+type WebhookData struct {
+	WebhookId   string   `json:"webhook_id" binding:"required"`
+	SellerId    string   `json:"seller_id" binding:"required"`
+	Url         string   `json:"url" binding:"required"`
+	EventTypes  []string `json:"event_types" binding:"required"`
+	Secret      string   `json:"secret,omitempty"`
+	CreatedDate string   `json:"created_date" binding:"required"`
+}
+
+type GetWebhooksResponseData struct {
+	Webhooks []WebhookData `json:"webhooks" binding:"required"`
+}
+
+type WebhookDeliveryData struct {
+	DeliveryId      string `json:"delivery_id" binding:"required"`
+	WebhookId       string `json:"webhook_id" binding:"required"`
+	EventId         string `json:"event_id" binding:"required"`
+	EventType       string `json:"event_type" binding:"required" example:"order.paid"`
+	Payload         string `json:"payload" binding:"required"`
+	Status          string `json:"status" binding:"required" example:"delivered"`
+	Attempts        int    `json:"attempts" binding:"required"`
+	ResponseCode    int    `json:"response_code"`
+	LastError       string `json:"last_error"`
+	LastAttemptDate string `json:"last_attempt_date,omitempty"`
+	DeliveredDate   string `json:"delivered_date,omitempty"`
+	CreatedDate     string `json:"created_date" binding:"required"`
+}
+
+type GetWebhookDeliveriesResponseData struct {
+	Deliveries []WebhookDeliveryData `json:"deliveries" binding:"required"`
+}
+
+type WebhookEventData struct {
+	Id          string      `json:"id"`
+	Type        string      `json:"type"`
+	CreatedDate string      `json:"created_date"`
+	Data        interface{} `json:"data"`
+}
+
+type WebhookOrderEventData struct {
+	SubOrderId string                    `json:"sub_order_id"`
+	OrderId    string                    `json:"order_id"`
+	Total      int                       `json:"total"`
+	Products   []WebhookOrderProductData `json:"products"`
+}
+
+type WebhookOrderProductData struct {
+	ProductId string `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+}
+
+type WebhookOrderCancelledEventData struct {
+	SubOrderId     string `json:"sub_order_id"`
+	OrderId        string `json:"order_id"`
+	Reason         string `json:"reason"`
+	RefundedAmount int    `json:"refunded_amount"`
+}
+
+type WebhookProductEventData struct {
+	ProductId    string `json:"product_id"`
+	Title        string `json:"title"`
+	SoldQuantity int    `json:"sold_quantity"`
+}
+
+type WebhookOfferEventData struct {
+	OfferId   string `json:"offer_id"`
+	ProductId string `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+	Amount    int    `json:"amount"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+type WebhookTestEventData struct {
+	Message string `json:"message"`
+}
+
+type WebhookOutboxMessageData struct {
+	DeliveryId string `json:"delivery_id"`
+}
@@ -16,41 +16,39 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/buyers/login": {
+        "/admin/expansions": {
             "post": {
-                "description": "Checks to see if a buyer email exists and if supplied password matches the stored password",
+                "description": "Adds an expansion of a game with the languages it was released in. The expansion code must not",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Logs a buyer into their account",
+                "summary": "Adds an expansion to the catalogue",
                 "parameters": [
                     {
-                        "description": "Buyers email",
-                        "name": "email",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "description": "Buyers password as plaintext",
-                        "name": "password",
+                        "description": "Expansion information",
+                        "name": "expansion",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.CreateExpansionData"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/data.BuyerLoginResponseData"
+                            "$ref": "#/definitions/data.ExpansionData"
                         }
                     },
                     "400": {
@@ -74,24 +72,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/buyers/resend-otp": {
-            "post": {
-                "description": "Checks to see if the provided buyer_id exists and sends a email to the specific buy_ids email with a newly",
+        "/admin/expansions/{code}": {
+            "put": {
+                "description": "Updates an expansion and replaces the languages it was released in. Languages that are still",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Sends a new Otp to the provided email",
+                "summary": "Updates an expansion in the catalogue",
                 "parameters": [
                     {
-                        "description": "Buyer Id",
-                        "name": "buyer_id",
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expansion code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Expansion information",
+                        "name": "expansion",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.UpdateExpansionData"
                         }
                     }
                 ],
@@ -99,7 +111,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/data.Message"
+                            "$ref": "#/definitions/data.ExpansionData"
                         }
                     },
                     "400": {
@@ -107,45 +119,54 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/data.Message"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
                     }
                 }
-            }
-        },
-        "/buyers/signup": {
-            "post": {
-                "description": "Checks to see if a buyer email exists and if not creates a new account with supplied email and password",
-                "consumes": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Deletes an expansion, expansions that still have products cannot be deleted. Requires the admin key.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Signs a new buyer up",
+                "summary": "Deletes an expansion from the catalogue",
                 "parameters": [
                     {
-                        "description": "Buyers email [UNIQUE]",
-                        "name": "email",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "description": "Buyers password as plaintext",
-                        "name": "password",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "string",
+                        "description": "Expansion code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/data.BuyerLoginResponseData"
+                            "$ref": "#/definitions/data.Message"
                         }
                     },
                     "400": {
@@ -154,6 +175,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/data.Message"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -163,41 +196,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/buyers/validate-otp": {
+        "/admin/games": {
             "post": {
-                "description": "Checks to see if the provided buyer exists, if not returns a 400. Otherwise it checks to see if the otps match. If not it",
+                "description": "Adds a game to the catalogue, the game code must not already be in use. Requires the admin key.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Validates a given otp from a specific buyer",
+                "summary": "Adds a game to the catalogue",
                 "parameters": [
                     {
-                        "description": "Buyer Id",
-                        "name": "buyer_id",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "description": "Otp",
-                        "name": "otp",
+                        "description": "Game code and name",
+                        "name": "game",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.CreateGameData"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/data.BuyerLoginResponseData"
+                            "$ref": "#/definitions/data.GameData"
                         }
                     },
                     "400": {
@@ -211,90 +242,111 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/data.Message"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
                     }
                 }
             }
         },
-        "/orders": {
-            "post": {
-                "description": "Creates a new order for a specific product. This order is created by an existing buyer with an account.",
+        "/admin/games/{code}": {
+            "put": {
+                "description": "Updates the name of a game. Requires the admin key.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Creates a new order",
+                "summary": "Updates a game in the catalogue",
                 "parameters": [
                     {
-                        "description": "The products for which we are creating an order",
-                        "name": "products",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/data.ProductOrder"
-                            }
-                        }
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "description": "The id of the buyer who is creating the order",
-                        "name": "buyer_id",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "string",
+                        "description": "Game code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "description": "Phone number of buyer",
-                        "name": "phone_number",
+                        "description": "Game name",
+                        "name": "game",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.UpdateGameData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GameData"
                         }
                     },
-                    {
-                        "description": "Delivery Address",
-                        "name": "address_line_1",
-                        "in": "body",
-                        "required": true,
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.Message"
                         }
                     },
-                    {
-                        "description": "Delivery Address 2",
-                        "name": "address_line_2",
-                        "in": "body",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.Message"
                         }
                     },
-                    {
-                        "description": "Postal code of address",
-                        "name": "postal_code",
-                        "in": "body",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.Message"
                         }
                     },
-                    {
-                        "description": "Delivery Type is either 'self_collection' or 'standard delivery', Payment type is 'card' or 'paynow_online'",
-                        "name": "fees",
-                        "in": "body",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/data.OrderFees"
+                            "$ref": "#/definitions/data.Message"
                         }
                     }
+                }
+            },
+            "delete": {
+                "description": "Deletes a game, games that still have expansions cannot be deleted. Requires the admin key.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Deletes a game from the catalogue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Game code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/data.CreateOrderResponseData"
+                            "$ref": "#/definitions/data.Message"
                         }
                     },
                     "400": {
@@ -303,6 +355,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/data.Message"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -312,50 +376,75 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/guest": {
-            "post": {
-                "description": "Creates a new order for a specific product. This order is created by a guest user.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/jobs": {
+            "get": {
+                "description": "Gets the background jobs from the newest job. By default only the failing jobs are returned, which",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Creates a new guest order",
+                "summary": "Gets the failing background jobs",
                 "parameters": [
                     {
-                        "description": "The products for which we are creating an order",
-                        "name": "products",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only jobs with this status, 'pending', 'running', 'completed' or 'failed'",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/data.ProductOrder"
-                            }
+                            "$ref": "#/definitions/data.GetJobsResponseData"
                         }
                     },
-                    {
-                        "description": "The email of the guest user",
-                        "name": "email",
-                        "in": "body",
-                        "required": true,
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.Message"
                         }
                     },
-                    {
-                        "description": "Phone number of buyer",
-                        "name": "phone_number",
-                        "in": "body",
-                        "required": true,
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
                         }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a job of a type the workers can run to the queue, such as record-prices with the product_ids to",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Adds a background job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "description": "Delivery Address",
-                        "name": "address_line_1",
+                        "description": "Type of the job",
+                        "name": "type",
                         "in": "body",
                         "required": true,
                         "schema": {
@@ -363,35 +452,35 @@ const docTemplate = `{
                         }
                     },
                     {
-                        "description": "Delivery Address 2",
-                        "name": "address_line_2",
+                        "description": "Payload the job is run with",
+                        "name": "payload",
                         "in": "body",
                         "schema": {
-                            "type": "string"
+                            "type": "object"
                         }
                     },
                     {
-                        "description": "Postal code of address",
-                        "name": "postal_code",
+                        "description": "When the job should run, in RFC3339",
+                        "name": "run_date",
                         "in": "body",
                         "schema": {
                             "type": "string"
                         }
                     },
                     {
-                        "description": "Delivery Type is either 'self_collection' or 'standard delivery', Payment type is 'card' or 'paynow_online'",
-                        "name": "fees",
+                        "description": "How many times the job is tried, defaults to 5",
+                        "name": "max_attempts",
                         "in": "body",
                         "schema": {
-                            "$ref": "#/definitions/data.OrderFees"
+                            "type": "integer"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/data.CreateGuestOrderResponseData"
+                            "$ref": "#/definitions/data.JobData"
                         }
                     },
                     "400": {
@@ -400,6 +489,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/data.Message"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -409,21 +504,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}": {
-            "get": {
-                "description": "Returns the order details of an order with a given order id. If the order id does not exists, returns a 404 error.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/jobs/{id}/retry": {
+            "post": {
+                "description": "Puts a failed job back in the queue to run as soon as possible with all of its attempts. Requires the",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Fetched order details for an order with a specific order id",
+                "summary": "Retries a failed background job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/data.GetOrderByIdResponseData"
+                            "$ref": "#/definitions/data.JobData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
                         }
                     },
                     "404": {
@@ -441,25 +561,49 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}/guest": {
-            "get": {
-                "description": "Returns the order details of an guest order with a given guest order id. If the order id does not exists, returns a 404 error.",
+        "/admin/languages": {
+            "post": {
+                "description": "Adds a language that products can be listed in, the language code must not already be in use.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Fetched order details for an guest order with a specific guest order id",
+                "summary": "Adds a language to the catalogue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Language code and name",
+                        "name": "language",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.LanguageData"
+                        }
+                    }
+                ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/data.GetGuestOrderByIdResponseData"
+                            "$ref": "#/definitions/data.LanguageData"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/data.Message"
                         }
@@ -473,83 +617,64 @@ const docTemplate = `{
                 }
             }
         },
-        "/products": {
-            "get": {
-                "description": "Gets product information of products given query parameters provided in the Request",
+        "/admin/languages/{code}": {
+            "put": {
+                "description": "Updates the name of a language. Requires the admin key.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Gets Products with given query parameters",
+                "summary": "Updates a language in the catalogue",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sort By a specific attribute of the product. Default is posted_date",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Get products by a specific product type, the types are 'Pre-Order' or 'Buy-Now'. Default is both will be selected",
-                        "name": "product_types",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Get products filtered by the language of the expansion. The choices are 'Eng' or 'Jap' and default is both.",
-                        "name": "languages",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Get products filtered by the expansion of the product. Default is all expansions",
-                        "name": "expansions",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Gets products filtered by prices ranges, the ranges are '0-20', '20-50', '50-100', '100-200', '200'",
-                        "name": "prices",
-                        "in": "query"
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Indicates the offset for the products",
-                        "name": "anchor",
-                        "in": "query",
+                        "type": "string",
+                        "description": "Language code",
+                        "name": "code",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Indicates the number of products fetched",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "description": "Language name",
+                        "name": "language",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.UpdateLanguageData"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/data.GetProductListResponseData"
+                            "$ref": "#/definitions/data.LanguageData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
                         }
                     },
                     "500": {
@@ -560,104 +685,145 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Creates a new product post with the supplied data, if the data is not valid it throws and error",
+            "delete": {
+                "description": "Deletes a language, languages used by products or expansions cannot be deleted. Requires the admin key.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Creates a new product post",
+                "summary": "Deletes a language from the catalogue",
                 "parameters": [
                     {
-                        "description": "The Seller who posted the product's seller_id",
-                        "name": "seller_id",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "description": "Title of the product",
-                        "name": "title",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "Language code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.Message"
                         }
                     },
-                    {
-                        "description": "Short description of the product",
-                        "name": "description",
-                        "in": "body",
-                        "required": true,
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.Message"
                         }
                     },
-                    {
-                        "description": "Price as an int of the product",
-                        "name": "price",
-                        "in": "body",
-                        "required": true,
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "integer"
+                            "$ref": "#/definitions/data.Message"
                         }
                     },
-                    {
-                        "description": "Condition of the product from a scale of 0 to 5",
-                        "name": "condition",
-                        "in": "body",
-                        "required": true,
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "integer"
+                            "$ref": "#/definitions/data.Message"
                         }
                     },
-                    {
-                        "description": "Type of product sale: Buy-Now or Pre-Order",
-                        "name": "product_type",
-                        "in": "body",
-                        "required": true,
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.Message"
                         }
-                    },
+                    }
+                }
+            }
+        },
+        "/admin/mail-templates": {
+            "get": {
+                "description": "Gets the events that mails are sent for and the locales they are written in. Requires the admin key.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the mail templates",
+                "parameters": [
                     {
-                        "description": "Language of the product, is either 'Eng' or 'Jap'",
-                        "name": "language",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.MailTemplatesResponseData"
                         }
                     },
-                    {
-                        "description": "Expansion of the product",
-                        "name": "expansion",
-                        "in": "body",
-                        "required": true,
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.Message"
                         }
+                    }
+                }
+            }
+        },
+        "/admin/mail-templates/{event}": {
+            "get": {
+                "description": "Renders the mail of an event with sample data. Unsupported locales fall back to en. With format html",
+                "produces": [
+                    "application/json",
+                    "text/html"
+                ],
+                "summary": "Previews a mail template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "description": "Quantity of product to be put for sale",
-                        "name": "product_quantity",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
+                        "type": "string",
+                        "description": "Mail event, such as otp or order_confirmation",
+                        "name": "event",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Locale of the mail, defaults to en",
+                        "name": "locale",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to html to only get the HTML body",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/data.CreateProductResponseData"
+                            "$ref": "#/definitions/data.MailPreviewData"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/data.Message"
                         }
@@ -671,27 +837,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}": {
+        "/admin/outbox": {
             "get": {
-                "description": "Checks to see if a product with a given id exists and returns its product information if it does.",
+                "description": "Gets the mails and events in the outbox from the newest message. By default only the stuck messages",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Gets a Product by its Product ID",
+                "summary": "Gets the messages stuck in the outbox",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "product_id",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only messages with this status, 'pending', 'delivered' or 'dead'",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/data.GetProductResponseData"
+                            "$ref": "#/definitions/data.GetOutboxMessagesResponseData"
                         }
                     },
                     "400": {
@@ -700,8 +872,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/data.Message"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/data.Message"
                         }
@@ -715,47 +887,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}/images": {
+        "/admin/outbox/{id}/retry": {
             "post": {
-                "description": "Adds images to an existing product with supplied product id. If product with product id does not exist returns a",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Puts a dead message back in the outbox to be delivered again from its first attempt. Requires the",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Adds images to products",
+                "summary": "Retries a dead outbox message",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "product_id",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
                         "required": true
                     },
                     {
-                        "type": "file",
-                        "description": "Array of image files to add to the product post",
-                        "name": "images",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.OutboxMessageData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/data.Message"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/data.Message"
                         }
                     },
-                    "415": {
-                        "description": "Unsupported Media Type",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/data.Message"
                         }
@@ -769,41 +944,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/sellers/login": {
-            "post": {
-                "description": "Checks to see if a sellers email exists and if supplied password matches the stored password",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/payouts/report": {
+            "get": {
+                "description": "Gets the funds held and available for sellers, the total paid out, the commission earned and every",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Logs a seller into their account",
+                "summary": "Gets the payout report",
                 "parameters": [
                     {
-                        "description": "Sellers email",
-                        "name": "email",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.PayoutReportData"
                         }
                     },
-                    {
-                        "description": "Sellers password as plaintext",
-                        "name": "password",
-                        "in": "body",
-                        "required": true,
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
                         }
                     }
+                }
+            }
+        },
+        "/admin/payouts/{id}/paid": {
+            "post": {
+                "description": "Marks a payout as paid once the money has been transferred to the seller. Requires the admin key.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Marks a payout as paid",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Payout id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/data.SellerLoginResponseData"
+                            "$ref": "#/definitions/data.PayoutData"
                         }
                     },
                     "400": {
@@ -818,6 +1024,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/data.Message"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -827,42 +1039,31 @@ const docTemplate = `{
                 }
             }
         },
-        "/sellers/signup": {
+        "/admin/promo-codes": {
             "post": {
-                "description": "Checks to see if a seller email does not already exists if so creates a new",
+                "description": "Creates a promo code run by the platform, which applies to every seller unless it is limited to specific",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Signs a new seller up",
+                "summary": "Creates a platform promo code",
                 "parameters": [
                     {
-                        "description": "Sellers email [UNIQUE]",
-                        "name": "email",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Sellers password as plaintext",
-                        "name": "password",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "description": "Sellers seller alias that is displayed as their seller name [UNIQUE]",
-                        "name": "seller_name",
+                        "description": "Promo code rules",
+                        "name": "promo-code",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/data.CreatePromoCodeRequestData"
                         }
                     }
                 ],
@@ -870,7 +1071,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/data.SellerLoginResponseData"
+                            "$ref": "#/definitions/data.PromoCodeData"
                         }
                     },
                     "400": {
@@ -879,6 +1080,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/data.Message"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -888,34 +1095,43 @@ const docTemplate = `{
                 }
             }
         },
-        "/sellers/{id}": {
+        "/admin/reviews": {
             "get": {
-                "description": "Checks to see if a sellers id exists and if it does returns the specified sellers public information,",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Gets every review from the newest review, including hidden reviews. Requires the admin key.",
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Gets seller info based on seller id",
+                "summary": "Gets reviews for moderation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "seller_id",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only reviews with this status, 'visible' or 'hidden'",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/data.GetSellerByIdResponseData"
+                            "$ref": "#/definitions/data.GetReviewsResponseData"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/data.Message"
                         }
@@ -928,135 +1144,8676 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "data.BuyerLoginResponseData": {
-            "type": "object",
-            "required": [
-                "buyer_id",
-                "email",
-                "verification"
-            ],
-            "properties": {
-                "buyer_id": {
-                    "type": "string"
-                },
+        },
+        "/admin/reviews/{id}/reply": {
+            "delete": {
+                "description": "Removes the reply of the seller from a review. The seller cannot reply to the review again. Requires the admin key.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Removes the reply to a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Review id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.ReviewData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reviews/{id}/status": {
+            "put": {
+                "description": "Sets the status of a review to 'hidden' with an optional reason, or back to 'visible'. Hidden reviews are",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Hides or restores a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Review id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.ModerateReviewRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.ReviewData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tasks/close-auctions": {
+            "post": {
+                "description": "Closes every auction that has ended and creates the orders for the winners. Meant to be called on a",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Closes ended auctions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.CloseAuctionsResponseData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tasks/expire-offers": {
+            "post": {
+                "description": "Expires open offers that were not responded to in time and accepted offers that were not checked out",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Expires offers that have run out of time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.ExpireOffersResponseData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tasks/follow-digests": {
+            "post": {
+                "description": "Mails every buyer who has turned on the digest the new listings of the sellers they follow, at most once",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Sends the new listing digests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.SendFollowDigestsResponseData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tasks/jobs": {
+            "post": {
+                "description": "Schedules the recurring jobs and runs every job that is due, retrying failed jobs with exponential",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Runs the due background jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.RunJobsResponseData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tasks/outbox": {
+            "post": {
+                "description": "Delivers the mails and events in the outbox that are due. Messages that fail are retried with",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Delivers the outbox",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.DeliverOutboxMessagesResponseData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tasks/payouts": {
+            "post": {
+                "description": "Creates a payout for every seller with an available balance. The batch is empty if no seller is owed",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Creates a payout batch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.PayoutBatchData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tasks/record-prices": {
+            "post": {
+                "description": "Records the price of every product whose price or effective price has changed, such as when a",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Records the prices of products that have changed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.RecordPricesResponseData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tasks/release-funds": {
+            "post": {
+                "description": "Makes the funds of every sub-order that was delivered before the dispute window available for payout.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Releases the funds of delivered sub-orders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.ReleaseFundsResponseData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tasks/release-pre-orders": {
+            "post": {
+                "description": "Marks every open pre-order that has reached its release date as released and sends a mail to its buyers.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Releases pre-orders that have reached their release date",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.ReleasePreOrdersResponseData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tasks/saved-searches": {
+            "post": {
+                "description": "Matches the listings created since the last run against every saved search and notifies the buyers.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Matches new listings against saved searches",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.MatchSavedSearchesResponseData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/tasks/watchlist-alerts": {
+            "post": {
+                "description": "Notifies buyers of price drops, restocks and auctions ending soon on the products they watch. Each change",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Checks the watchlists for alerts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Admin API key",
+                        "name": "X-Admin-Key",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.CheckWatchlistAlertsResponseData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/login": {
+            "post": {
+                "description": "Checks to see if a buyer email exists and if supplied password matches the stored password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Logs a buyer into their account",
+                "parameters": [
+                    {
+                        "description": "Buyers email",
+                        "name": "email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Buyers password as plaintext",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Token of a guest cart to move into the cart of the buyer",
+                        "name": "cart_token",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.BuyerLoginResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/resend-otp": {
+            "post": {
+                "description": "Checks to see if the provided buyer_id exists and sends a email to the specific buy_ids email with a newly",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Sends a new Otp to the provided email",
+                "parameters": [
+                    {
+                        "description": "Buyer Id",
+                        "name": "buyer_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/signup": {
+            "post": {
+                "description": "Checks to see if a buyer email exists and if not creates a new account with supplied email and password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Signs a new buyer up",
+                "parameters": [
+                    {
+                        "description": "Buyers email [UNIQUE]",
+                        "name": "email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Buyers password as plaintext",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Token of a guest cart to move into the cart of the buyer",
+                        "name": "cart_token",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Locale that mails to the buyer are written in, defaults to en",
+                        "name": "locale",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.BuyerLoginResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/validate-otp": {
+            "post": {
+                "description": "Checks to see if the provided buyer exists, if not returns a 400. Otherwise it checks to see if the otps match. If not it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Validates a given otp from a specific buyer",
+                "parameters": [
+                    {
+                        "description": "Buyer Id",
+                        "name": "buyer_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Otp",
+                        "name": "otp",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.BuyerLoginResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/cart": {
+            "get": {
+                "description": "Gets the products in the cart of a buyer or a guest, checked against the current price and stock.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets a cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "token",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.CartData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/cart/checkout": {
+            "post": {
+                "description": "Creates an order from the products in the cart of a buyer and empties the cart. The checkout fails if",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Checks out the cart of a buyer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delivery and payment details",
+                        "name": "checkout",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.CheckoutCartRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/data.CreateOrderResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/cart/products": {
+            "post": {
+                "description": "Adds a product to the cart of a buyer or a guest, or adds to its quantity if it is already in the cart.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Adds a product to a cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "token",
+                        "in": "path"
+                    },
+                    {
+                        "description": "Product and quantity to add",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.AddCartProductRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.CartData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/cart/products/{productId}": {
+            "put": {
+                "description": "Changes the quantity of a product in the cart of a buyer or a guest. The quantity cannot be more than",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Changes the quantity of a product in a cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "token",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New quantity",
+                        "name": "quantity",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.UpdateCartProductRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.CartData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a product from the cart of a buyer or a guest",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Removes a product from a cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "token",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.CartData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/feed": {
+            "get": {
+                "description": "Gets the most recent listings of the sellers a buyer follows, from the newest listing. If there are more",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the feed of a buyer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the next_cursor of the previous page, leave out for the first page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Indicates the number of listings fetched. Default is 20 and at most 100 listings are fetched",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetBuyerFeedResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/feed/digest": {
+            "put": {
+                "description": "Turns the daily mail of new listings from followed sellers on or off for a buyer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Turns the new listing digest on or off",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Whether the digest is sent",
+                        "name": "digest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.UpdateFollowDigestRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetBuyerFollowsResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/follows": {
+            "get": {
+                "description": "Gets the sellers a buyer follows from the most recently followed seller and whether the buyer gets the",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the sellers a buyer follows",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetBuyerFollowsResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/follows/{sellerId}": {
+            "put": {
+                "description": "Makes a buyer follow a seller. Following a seller that is already followed does nothing.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Follows a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "sellerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.FollowSellerResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Makes a buyer stop following a seller. Unfollowing a seller that is not followed does nothing.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Unfollows a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "sellerId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.FollowSellerResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/notification-preferences": {
+            "get": {
+                "description": "Gets whether a buyer gets each type of notification, every type is turned on by default",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the notification preferences of a buyer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.NotificationPreferencesData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Turns types of notifications on or off for a buyer, types that are left out keep their current setting",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Updates the notification preferences of a buyer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Notification types to turn on or off",
+                        "name": "preferences",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.NotificationPreferencesData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.NotificationPreferencesData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/notifications": {
+            "get": {
+                "description": "Gets the most recent notifications of a buyer from the newest notification along with the number of",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the notifications of a buyer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetNotificationsResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/notifications/read": {
+            "post": {
+                "description": "Marks every notification of a buyer as read",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Marks the notifications of a buyer as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetNotificationsResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/offers": {
+            "get": {
+                "description": "Gets the offers made by a buyer, from the newest offer",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the offers of a buyer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetOffersResponseData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/reviews": {
+            "get": {
+                "description": "Gets the reviews written by a buyer from the newest review, including reviews hidden by a moderator",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the reviews of a buyer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetReviewsResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a review of a seller with a rating from 1 to 5, an optional text and optional photos. A buyer can",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Reviews the seller of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.CreateReviewRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/data.ReviewData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/reviews/uploads": {
+            "post": {
+                "description": "Creates an upload slot for each photo of a review. Each slot has a presigned url that the photo must be",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Creates upload urls for review photos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Content type and size in bytes of each photo, at most 3 photos",
+                        "name": "images",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.CreateReviewImageUploadsRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/data.CreateReviewImageUploadsResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/saved-searches": {
+            "get": {
+                "description": "Gets the saved searches of a buyer from the most recently saved search",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the saved searches of a buyer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetSavedSearchesResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Saves a product list query of a buyer under a name, the buyer is notified of new listings that match",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Saves a search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Name and product list query of the search",
+                        "name": "search",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.CreateSavedSearchRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.SavedSearchData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/saved-searches/{searchId}": {
+            "delete": {
+                "description": "Deletes a saved search of a buyer, the buyer is no longer notified of its matches",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Deletes a saved search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Saved search id",
+                        "name": "searchId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetSavedSearchesResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/saved-searches/{searchId}/products": {
+            "get": {
+                "description": "Gets the products that currently match a saved search of a buyer. If there are more products,",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Runs a saved search",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Saved search id",
+                        "name": "searchId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the next_cursor of the previous page, leave out for the first page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Indicates the number of products fetched. Default is 20 and at most 100 products are fetched",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetProductListResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/telegram": {
+            "get": {
+                "description": "Gets whether a buyer is linked to a Telegram chat and the Telegram username it was linked from",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the Telegram link of a buyer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.TelegramLinkData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates the deep link that links a buyer to a Telegram chat with the bot. Opening the link and",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Creates a Telegram link for a buyer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.TelegramLinkCodeData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Unlinks a buyer from its Telegram chat so that no more updates are sent to it. Unlinking a buyer that",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Unlinks a buyer from Telegram",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.TelegramLinkData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/watchlist": {
+            "get": {
+                "description": "Gets the products on the watchlist of a buyer from the most recently added product",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the watchlist of a buyer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetWatchlistResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/buyers/{id}/watchlist/{productId}": {
+            "put": {
+                "description": "Adds a product to the watchlist of a buyer. The buyer is alerted when the effective price drops, when",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Adds a product to the watchlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.WatchlistProductData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a product from the watchlist of a buyer",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Removes a product from the watchlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetWatchlistResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/carts": {
+            "post": {
+                "description": "Creates an empty cart for a guest. The cart_token returned identifies the cart in later requests and",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Creates a cart for a guest",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/data.CartData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/carts/{token}": {
+            "get": {
+                "description": "Gets the products in the cart of a buyer or a guest, checked against the current price and stock.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets a cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "token",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.CartData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/carts/{token}/checkout": {
+            "post": {
+                "description": "Creates a guest order from the products in a guest cart and empties the cart. The checkout fails if",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Checks out the cart of a guest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Email, delivery and payment details",
+                        "name": "checkout",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.CheckoutGuestCartRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/data.CreateGuestOrderResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/carts/{token}/products": {
+            "post": {
+                "description": "Adds a product to the cart of a buyer or a guest, or adds to its quantity if it is already in the cart.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Adds a product to a cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "token",
+                        "in": "path"
+                    },
+                    {
+                        "description": "Product and quantity to add",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.AddCartProductRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.CartData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/carts/{token}/products/{productId}": {
+            "put": {
+                "description": "Changes the quantity of a product in the cart of a buyer or a guest. The quantity cannot be more than",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Changes the quantity of a product in a cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "token",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New quantity",
+                        "name": "quantity",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.UpdateCartProductRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.CartData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a product from the cart of a buyer or a guest",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Removes a product from a cart",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Buyer id",
+                        "name": "id",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Guest cart token",
+                        "name": "token",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product id",
+                        "name": "productId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.CartData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/expansions": {
+            "get": {
+                "description": "Gets the expansions in the catalogue with the languages they were released in, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the expansions in the catalogue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only expansions of the game with this code",
+                        "name": "game",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only expansions released in the language with this code",
+                        "name": "language",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/data.ExpansionData"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/games": {
+            "get": {
+                "description": "Gets all the trading card games that expansions and products belong to.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the games in the catalogue",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/data.GameData"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/languages": {
+            "get": {
+                "description": "Gets all the languages that products can be listed in.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the languages in the catalogue",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/data.LanguageData"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/offers/{id}": {
+            "get": {
+                "description": "Gets an offer by its id. Once accepted the offer has the agreed price and the time until which the",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets an offer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Offer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.OfferData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/offers/{id}/buyer-response": {
+            "post": {
+                "description": "Accepts or rejects the counter offer of the seller. Accepting reserves the quantity at the counter price.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Responds to a counter offer as the buyer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Offer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Buyer id and action, which is 'accept' or 'reject'",
+                        "name": "response",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.BuyerOfferResponseRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.OfferData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/offers/{id}/seller-response": {
+            "post": {
+                "description": "Accepts, rejects or counters an open offer. A counter offer has to be between the offer and the listed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Responds to an offer as the seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Offer id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Seller id, action is 'accept', 'reject' or 'counter' and the counter amount in cents when countering",
+                        "name": "response",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.SellerOfferResponseRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.OfferData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "post": {
+                "description": "Creates a new order for a specific product. This order is created by an existing buyer with an account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Creates a new order",
+                "parameters": [
+                    {
+                        "description": "The products for which we are creating an order",
+                        "name": "products",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/data.ProductOrder"
+                            }
+                        }
+                    },
+                    {
+                        "description": "The id of the buyer who is creating the order",
+                        "name": "buyer_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Phone number of buyer",
+                        "name": "phone_number",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Delivery Address",
+                        "name": "address_line_1",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Delivery Address 2",
+                        "name": "address_line_2",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Postal code of address",
+                        "name": "postal_code",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Delivery Type is either 'self_collection' or 'standard delivery', Payment type is 'card' or 'paynow_online'",
+                        "name": "fees",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/data.OrderFees"
+                        }
+                    },
+                    {
+                        "description": "Id of an accepted offer of the buyer, the product of the offer is charged at the agreed price",
+                        "name": "offer_id",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/data.CreateOrderResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/guest": {
+            "post": {
+                "description": "Creates a new order for a specific product. This order is created by a guest user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Creates a new guest order",
+                "parameters": [
+                    {
+                        "description": "The products for which we are creating an order",
+                        "name": "products",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/data.ProductOrder"
+                            }
+                        }
+                    },
+                    {
+                        "description": "The email of the guest user",
+                        "name": "email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Phone number of buyer",
+                        "name": "phone_number",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Delivery Address",
+                        "name": "address_line_1",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Delivery Address 2",
+                        "name": "address_line_2",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Postal code of address",
+                        "name": "postal_code",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Delivery Type is either 'self_collection' or 'standard delivery', Payment type is 'card' or 'paynow_online'",
+                        "name": "fees",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/data.OrderFees"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/data.CreateGuestOrderResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "description": "Returns the order details of an order with a given order id. If the order id does not exists, returns a 404 error.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Fetched order details for an order with a specific order id",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetOrderByIdResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/guest": {
+            "get": {
+                "description": "Returns the order details of an guest order with a given guest order id. If the order id does not exists, returns a 404 error.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Fetched order details for an guest order with a specific guest order id",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetGuestOrderByIdResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/sub-orders/{subOrderId}/cancel": {
+            "post": {
+                "description": "Cancels the part of an order or guest order from one seller, which can be done until the seller ships",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Cancels a sub-order of an order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Order or guest order id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sub-order id",
+                        "name": "subOrderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.SubOrderData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Gets product information of products given query parameters provided in the Request. The response also has",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets Products with given query parameters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Keyword search over the title and description of the product, partial words are matched",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort By a specific attribute of the product: 'price-low', 'price-high', 'name-asc', 'name-desc', 'grade-high', 'grade-low' or 'relevance'. Default is posted_date, or relevance when searching",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Get products by a specific product type, the types are 'Pre-Order', 'Buy-Now' or 'Auction'. Default is both will be selected",
+                        "name": "product_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Get products filtered by the language of the expansion. The choices are the language codes in the catalogue and default is all languages.",
+                        "name": "languages",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Get products filtered by the expansion of the product. Default is all expansions",
+                        "name": "expansions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Gets products filtered by condition, from '0' to '5'. Default is all conditions",
+                        "name": "conditions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Gets products filtered by price ranges of the price after discounts, the ranges are '0-20', '20-50', '50-100', '100-200', '200' and include their minimum but not their maximum",
+                        "name": "prices",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Gets cards filtered by their card number",
+                        "name": "card_numbers",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Gets cards filtered by their rarity",
+                        "name": "rarities",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Gets cards filtered by finish, the finishes are 'Non-Holo', 'Holo' or 'Reverse-Holo'",
+                        "name": "finishes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Gets cards filtered by edition, the editions are 'Unlimited' or '1st-Edition'",
+                        "name": "editions",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Gets graded cards filtered by grading company, the companies are 'PSA', 'BGS' or 'CGC'",
+                        "name": "grading_companies",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Gets graded cards with at least this grade",
+                        "name": "min_grade",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Gets sealed products filtered by type, the types are 'Booster-Box', 'Booster-Pack' or 'ETB'",
+                        "name": "sealed_types",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from the next_cursor of the previous page, leave out for the first page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Deprecated, use cursor instead. Indicates the offset for the products",
+                        "name": "anchor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Indicates the number of products fetched. Default is 20 and at most 100 products are fetched",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetProductListResponseData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a new product post with the supplied data, if the data is not valid it throws and error",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Creates a new product post",
+                "parameters": [
+                    {
+                        "description": "The Seller who posted the product's seller_id",
+                        "name": "seller_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Title of the product",
+                        "name": "title",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Short description of the product",
+                        "name": "description",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Price as an int of the product",
+                        "name": "price",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Condition of the product from a scale of 0 to 5",
+                        "name": "condition",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Type of product sale: Buy-Now, Pre-Order or Auction",
+                        "name": "product_type",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Language code of the product, must be a language in the catalogue that the expansion was released in",
+                        "name": "language",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Expansion code of the product, must be an expansion in the catalogue",
+                        "name": "expansion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Quantity of product to be put for sale",
+                        "name": "product_quantity",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Attributes of a single card: card number, rarity, finish, edition and grading",
+                        "name": "card_attributes",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/data.CardAttributesData"
+                        }
+                    },
+                    {
+                        "description": "Attributes of a sealed product, cannot be used with card_attributes",
+                        "name": "sealed_attributes",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/data.SealedAttributesData"
+                        }
+                    },
+                    {
+                        "description": "Auction information, needed for Auction products. Auctions have a quantity of 1 and the price starts at the start price",
+                        "name": "auction",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/data.CreateAuctionData"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Seller API key with the products:write scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/data.CreateProductResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/events": {
+            "get": {
+                "description": "Streams the live state of up to 100 products as Server-Sent Events on a single connection. The current",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "summary": "Streams live updates of the products on a listing page",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Product ids, at most 100",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.ProductEventData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Checks to see if a product with a given id exists and returns its product information if it does.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets a Product by its Product ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "product_id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetProductResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/auction-result": {
+            "get": {
+                "description": "Gets the status of an auction and the hammer price once sold. The winner of the auction also gets the",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the result of an auction for a buyer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product id of the auction",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Id of the buyer",
+                        "name": "buyer_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.AuctionResultData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/bids": {
+            "get": {
+                "description": "Gets the bids of an auction from the highest bid, bidders are numbered in the order they first bid.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the bid history of an auction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product id of the auction",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetBidsResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Places a bid on an auction product. The bid must be at least the minimum bid of the auction, which is the",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Places a bid on an auction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product id of the auction",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Buyer id, bid amount in cents and checkout details",
+                        "name": "bid",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.PlaceBidRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/data.PlaceBidResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/events": {
+            "get": {
+                "description": "Streams the live state of a product as Server-Sent Events. The current state is sent first, followed by an",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "summary": "Streams live updates of a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.ProductEventData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images": {
+            "post": {
+                "description": "Adds images to an existing product with supplied product id. If product with product id does not exist returns a",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Adds images to products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "product_id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Array of image files to add to the product post",
+                        "name": "images",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Seller API key with the products:write scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images/uploads": {
+            "post": {
+                "description": "Creates an upload slot for each image of an existing product. Each slot has a presigned url that the image",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Creates upload urls for product images",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "product_id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Content type and size in bytes of each image, at most 5 images",
+                        "name": "images",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/data.ProductImageUploadRequestData"
+                            }
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Seller API key with the products:write scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/data.CreateProductImageUploadsResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images/uploads/complete": {
+            "post": {
+                "description": "Checks that the images for the given upload slots have been uploaded with the content type and size of the slot",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Completes the upload of product images",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "product_id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Upload ids of the uploaded images, in the order of the images",
+                        "name": "upload_ids",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Seller API key with the products:write scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/data.CreateProductImageData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/offers": {
+            "post": {
+                "description": "Makes an offer below the listed price on a Buy-Now product. The seller can accept, reject or counter the",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Makes an offer on a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Buyer id, offered price per item in cents and quantity, the quantity defaults to 1",
+                        "name": "offer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.CreateOfferRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/data.OfferData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/pre-order": {
+            "put": {
+                "description": "Changes the order by and release dates of a pre-order that has not been released. Buyers who have paid",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Changes the dates of a pre-order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product id of the pre-order",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Seller id and the new dates in RFC3339 format",
+                        "name": "pre-order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.UpdatePreOrderRequestData"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Seller API key with the products:write scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.PreOrderData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/pre-order/convert": {
+            "post": {
+                "description": "Converts a pre-order that has been released into a Buy-Now listing so that its remaining stock can be sold.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Converts a released pre-order into a Buy-Now listing",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product id of the pre-order",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Seller id",
+                        "name": "seller",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.ConvertPreOrderRequestData"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Seller API key with the products:write scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.PreOrderData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/price-history": {
+            "get": {
+                "description": "Gets the current and effective price of a product along with its price history, from the newest price.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the price history of a product",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Product id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetPriceHistoryResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/promo-codes/{code}": {
+            "get": {
+                "description": "Gets the rules of a promo code so that the discount can be shown at checkout. Codes are not case sensitive.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets a promo code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Promo code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.PromoCodeData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/login": {
+            "post": {
+                "description": "Checks to see if a sellers email exists and if supplied password matches the stored password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Logs a seller into their account",
+                "parameters": [
+                    {
+                        "description": "Sellers email",
+                        "name": "email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Sellers password as plaintext",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.SellerLoginResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/signup": {
+            "post": {
+                "description": "Checks to see if a seller email does not already exists if so creates a new",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Signs a new seller up",
+                "parameters": [
+                    {
+                        "description": "Sellers email [UNIQUE]",
+                        "name": "email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Sellers password as plaintext",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Sellers seller alias that is displayed as their seller name [UNIQUE]",
+                        "name": "seller_name",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.SellerLoginResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}": {
+            "get": {
+                "description": "Checks to see if a sellers id exists and if it does returns the specified sellers public information,",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets seller info based on seller id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "seller_id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetSellerByIdResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/api-keys": {
+            "get": {
+                "description": "Gets the API keys of a seller from the newest key, including revoked keys, without their secrets.",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the API keys of a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key of the seller, with any scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key, used instead of an API key of the seller",
+                        "name": "X-Admin-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetApiKeysResponseData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates an API key that a seller's own tools can send as \"Authorization: Bearer \u003ckey\u003e\". Scopes are",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Creates an API key for a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "API key",
+                        "name": "apiKey",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.CreateApiKeyRequestData"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key of the seller, with any scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key, used instead of an API key of the seller",
+                        "name": "X-Admin-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.ApiKeyData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/api-keys/{keyId}": {
+            "delete": {
+                "description": "Revokes an API key of a seller, requests made with it are rejected from then on. Needs an API key",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Revokes an API key of a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key id",
+                        "name": "keyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key of the seller, with any scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key, used instead of an API key of the seller",
+                        "name": "X-Admin-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.ApiKeyData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/api-keys/{keyId}/rotate": {
+            "post": {
+                "description": "Replaces the secret of an API key, keeping its name, scopes and rate limit. The old key stops",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Rotates an API key of a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key id",
+                        "name": "keyId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key of the seller, with any scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key, used instead of an API key of the seller",
+                        "name": "X-Admin-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.ApiKeyData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/balance": {
+            "get": {
+                "description": "Gets the funds of a seller that are held until delivery and the dispute window has passed, the funds",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the balance of a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.SellerBalanceData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/discounts": {
+            "get": {
+                "description": "Gets the sales of a seller, from the newest sale",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the sales of a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetDiscountsResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a fixed or percentage sale on one product of a seller, or on every product of the seller when no",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Creates a sale for a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sale information",
+                        "name": "discount",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.CreateDiscountRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.DiscountData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/discounts/{discountId}": {
+            "delete": {
+                "description": "Cancels an active sale of a seller so that it no longer applies",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Cancels a sale of a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Discount id",
+                        "name": "discountId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.DiscountData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/offers": {
+            "get": {
+                "description": "Gets the offers made on the products of a seller, from the newest offer",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the offers on a seller's products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetOffersResponseData"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/promo-codes": {
+            "get": {
+                "description": "Gets the promo codes created by a seller, from the newest code",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the promo codes of a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetPromoCodesResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates a promo code that only applies to the products of the seller. Codes are 3 to 20 letters and digits",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Creates a promo code for a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Promo code rules",
+                        "name": "promo-code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.CreatePromoCodeRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.PromoCodeData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/reviews": {
+            "get": {
+                "description": "Gets the rating of a seller and their reviews from the newest review",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the reviews of a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetSellerReviewsResponseData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/reviews/{reviewId}/reply": {
+            "post": {
+                "description": "Replies to a review of the seller. A seller can only reply to a review once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Replies to a review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Review id",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reply",
+                        "name": "reply",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.ReplyReviewRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.ReviewData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/statement": {
+            "get": {
+                "description": "Gets the ledger entries of a seller from the oldest entry. Positive amounts are owed to the seller,",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the statement of a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries from this RFC3339 date",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only entries before this RFC3339 date",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetSellerStatementResponseData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/sub-orders": {
+            "get": {
+                "description": "Gets the part of each order that a seller has to fulfil, with the delivery details of the order,",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the sub-orders of a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Seller API key with the orders:read scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetSellerSubOrdersResponseData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/sub-orders/{subOrderId}": {
+            "get": {
+                "description": "Gets a sub-order of a seller with the delivery details of its order",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets a sub-order of a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sub-order id",
+                        "name": "subOrderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Seller API key with the orders:read scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.SellerSubOrderData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/sub-orders/{subOrderId}/cancel": {
+            "post": {
+                "description": "Cancels a paid sub-order that has not been shipped, refunding what is left of its total and returning",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Cancels a sub-order of a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sub-order id",
+                        "name": "subOrderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.SellerSubOrderData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/sub-orders/{subOrderId}/refunds": {
+            "post": {
+                "description": "Refunds part or all of a paid sub-order. The refunds of a sub-order cannot add up to more than its",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Refunds a sub-order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sub-order id",
+                        "name": "subOrderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amount to refund in cents and the reason",
+                        "name": "refund",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.RefundSubOrderRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.SellerSubOrderData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/sub-orders/{subOrderId}/status": {
+            "put": {
+                "description": "Marks a paid sub-order as 'shipped' or a shipped sub-order as 'delivered'",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Updates the fulfilment status of a sub-order",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sub-order id",
+                        "name": "subOrderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.UpdateSubOrderStatusRequestData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.SellerSubOrderData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/telegram": {
+            "get": {
+                "description": "Gets whether a seller is linked to a Telegram chat and the Telegram username it was linked from",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the Telegram link of a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.TelegramLinkData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Creates the deep link that links a seller to a Telegram chat with the bot. Opening the link and",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Creates a Telegram link for a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.TelegramLinkCodeData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Unlinks a seller from its Telegram chat so that no more updates are sent to it. Unlinking a seller",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Unlinks a seller from Telegram",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.TelegramLinkData"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/webhooks": {
+            "get": {
+                "description": "Gets the webhook endpoints of a seller from the newest endpoint, without their secrets",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the webhook endpoints of a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key of the seller, with any scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key, used instead of an API key of the seller",
+                        "name": "X-Admin-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetWebhooksResponseData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers an https endpoint, by host name rather than IP address, that is sent the events it is subscribed to, 'order.paid',",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Registers a webhook endpoint for a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook endpoint",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.CreateWebhookRequestData"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key of the seller, with any scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key, used instead of an API key of the seller",
+                        "name": "X-Admin-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.WebhookData"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/webhooks/{webhookId}": {
+            "delete": {
+                "description": "Deletes a webhook endpoint of a seller along with its delivery log, no more events are sent to it",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Deletes a webhook endpoint of a seller",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook id",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key of the seller, with any scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key, used instead of an API key of the seller",
+                        "name": "X-Admin-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.WebhookData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/webhooks/{webhookId}/deliveries": {
+            "get": {
+                "description": "Gets the latest 100 deliveries to a webhook endpoint of a seller from the newest delivery, with the",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets the delivery log of a webhook endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook id",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key of the seller, with any scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key, used instead of an API key of the seller",
+                        "name": "X-Admin-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.GetWebhookDeliveriesResponseData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/webhooks/{webhookId}/deliveries/{deliveryId}/replay": {
+            "post": {
+                "description": "Sends a delivery to its webhook endpoint again with the same payload and returns the result of the",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Replays a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook id",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Delivery id",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key of the seller, with any scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key, used instead of an API key of the seller",
+                        "name": "X-Admin-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.WebhookDeliveryData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/sellers/{id}/webhooks/{webhookId}/test": {
+            "post": {
+                "description": "Sends a 'webhook.test' event to a webhook endpoint of a seller and returns the result of the attempt,",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Sends a test event to a webhook endpoint",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Webhook id",
+                        "name": "webhookId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key of the seller, with any scope, as 'Bearer \u003ckey\u003e'",
+                        "name": "Authorization",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admin API key, used instead of an API key of the seller",
+                        "name": "X-Admin-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.WebhookDeliveryData"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        },
+        "/telegram/webhook": {
+            "post": {
+                "description": "Receives the updates Telegram sends to the webhook of the bot. /start with a link code links the",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Receives updates from the Telegram bot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook secret",
+                        "name": "X-Telegram-Bot-Api-Secret-Token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Telegram update",
+                        "name": "update",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/data.TelegramUpdateData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/data.Message"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "data.AddCartProductRequestData": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.ApiKeyData": {
+            "type": "object",
+            "required": [
+                "created_date",
+                "key_id",
+                "name",
+                "prefix",
+                "rate_limit",
+                "scopes",
+                "seller_id",
+                "status"
+            ],
+            "properties": {
+                "created_date": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "key_id": {
+                    "type": "string"
+                },
+                "last_used_date": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "rate_limit": {
+                    "type": "integer"
+                },
+                "revoked_date": {
+                    "type": "string"
+                },
+                "rotated_date": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                }
+            }
+        },
+        "data.AuctionCheckoutData": {
+            "type": "object",
+            "required": [
+                "address_line_1",
+                "delivery_type",
+                "payment_type",
+                "phone_number",
+                "postal_code"
+            ],
+            "properties": {
+                "address_line_1": {
+                    "type": "string"
+                },
+                "address_line_2": {
+                    "type": "string"
+                },
+                "delivery_type": {
+                    "type": "string",
+                    "example": "standard_delivery"
+                },
+                "payment_type": {
+                    "type": "string",
+                    "example": "paynow_online"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "telegram_handle": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.AuctionData": {
+            "type": "object",
+            "required": [
+                "bid_count",
+                "current_price",
+                "ends_at",
+                "extension_seconds",
+                "minimum_bid",
+                "reserve_met",
+                "start_price",
+                "starts_at",
+                "status"
+            ],
+            "properties": {
+                "bid_count": {
+                    "type": "integer"
+                },
+                "current_price": {
+                    "type": "integer"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "extension_seconds": {
+                    "type": "integer"
+                },
+                "minimum_bid": {
+                    "type": "integer"
+                },
+                "reserve_met": {
+                    "type": "boolean"
+                },
+                "start_price": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "open"
+                }
+            }
+        },
+        "data.AuctionResultData": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "status",
+                "won"
+            ],
+            "properties": {
+                "hammer_price": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "payment_url": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "sold"
+                },
+                "won": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "data.BidData": {
+            "type": "object",
+            "required": [
+                "amount",
+                "bid_date",
+                "bid_id",
+                "bidder_no"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "bid_date": {
+                    "type": "string"
+                },
+                "bid_id": {
+                    "type": "string"
+                },
+                "bidder_no": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.BuyerLoginResponseData": {
+            "type": "object",
+            "required": [
+                "buyer_id",
+                "email",
+                "verification"
+            ],
+            "properties": {
+                "buyer_id": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "verification": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.BuyerOfferResponseRequestData": {
+            "type": "object",
+            "required": [
+                "action",
+                "buyer_id"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "accept"
+                },
+                "buyer_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.CardAttributesData": {
+            "type": "object",
+            "required": [
+                "card_number",
+                "edition",
+                "finish"
+            ],
+            "properties": {
+                "card_number": {
+                    "type": "string",
+                    "example": "4/102"
+                },
+                "edition": {
+                    "type": "string",
+                    "example": "1st-Edition"
+                },
+                "finish": {
+                    "type": "string",
+                    "example": "Holo"
+                },
+                "grade": {
+                    "type": "number",
+                    "example": 10
+                },
+                "grading_company": {
+                    "type": "string",
+                    "example": "PSA"
+                },
+                "rarity": {
+                    "type": "string",
+                    "example": "Holo Rare"
+                }
+            }
+        },
+        "data.CartData": {
+            "type": "object",
+            "required": [
+                "cart_id",
+                "products",
+                "subtotal"
+            ],
+            "properties": {
+                "buyer_id": {
+                    "type": "string"
+                },
+                "cart_id": {
+                    "type": "string"
+                },
+                "cart_token": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.CartProductData"
+                    }
+                },
+                "subtotal": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.CartProductData": {
+            "type": "object",
+            "required": [
+                "added_price",
+                "available",
+                "price",
+                "product_id",
+                "product_type",
+                "quantity",
+                "remaining_quantity",
+                "title"
+            ],
+            "properties": {
+                "added_price": {
+                    "type": "integer"
+                },
+                "available": {
+                    "type": "boolean"
+                },
+                "issue": {
+                    "type": "string",
+                    "example": "price_changed"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_type": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "remaining_quantity": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.CheckWatchlistAlertsResponseData": {
+            "type": "object",
+            "properties": {
+                "auctions_ending": {
+                    "type": "integer"
+                },
+                "price_drops": {
+                    "type": "integer"
+                },
+                "restocks": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.CheckoutCartRequestData": {
+            "type": "object",
+            "required": [
+                "address_line_1",
+                "fees",
+                "phone_number",
+                "postal_code"
+            ],
+            "properties": {
+                "address_line_1": {
+                    "type": "string"
+                },
+                "address_line_2": {
+                    "type": "string"
+                },
+                "fees": {
+                    "$ref": "#/definitions/data.OrderFees"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "promo_code": {
+                    "type": "string"
+                },
+                "telegram_handle": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.CheckoutGuestCartRequestData": {
+            "type": "object",
+            "required": [
+                "address_line_1",
+                "email",
+                "fees",
+                "phone_number",
+                "postal_code"
+            ],
+            "properties": {
+                "address_line_1": {
+                    "type": "string"
+                },
+                "address_line_2": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "fees": {
+                    "$ref": "#/definitions/data.OrderFees"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "promo_code": {
+                    "type": "string"
+                },
+                "telegram_handle": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.CloseAuctionsResponseData": {
+            "type": "object",
+            "required": [
+                "sold",
+                "unsold"
+            ],
+            "properties": {
+                "sold": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "unsold": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "data.ConvertPreOrderRequestData": {
+            "type": "object",
+            "required": [
+                "seller_id"
+            ],
+            "properties": {
+                "seller_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.CreateApiKeyRequestData": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Inventory sheet"
+                },
+                "rate_limit": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "products:write",
+                        "orders:read"
+                    ]
+                }
+            }
+        },
+        "data.CreateAuctionData": {
+            "type": "object",
+            "required": [
+                "ends_at",
+                "start_price"
+            ],
+            "properties": {
+                "ends_at": {
+                    "type": "string",
+                    "example": "2023-10-09T21:00:00+08:00"
+                },
+                "extension_seconds": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "reserve_price": {
+                    "type": "integer"
+                },
+                "start_price": {
+                    "type": "integer"
+                },
+                "starts_at": {
+                    "type": "string",
+                    "example": "2023-10-02T15:00:00+08:00"
+                }
+            }
+        },
+        "data.CreateDiscountRequestData": {
+            "type": "object",
+            "required": [
+                "amount",
+                "discount_type"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "example": "percentage"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.CreateExpansionData": {
+            "type": "object",
+            "required": [
+                "code",
+                "game_code",
+                "languages",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "game_code": {
+                    "type": "string"
+                },
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "release_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.CreateGameData": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.CreateGuestOrderResponseData": {
+            "type": "object",
+            "required": [
+                "guest_order_id",
+                "redirect_url"
+            ],
+            "properties": {
+                "guest_order_id": {
+                    "type": "string"
+                },
+                "redirect_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.CreateOfferRequestData": {
+            "type": "object",
+            "required": [
+                "amount",
+                "buyer_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "buyer_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.CreateOrderResponseData": {
+            "type": "object",
+            "required": [
+                "order_id",
+                "redirect_url"
+            ],
+            "properties": {
+                "order_id": {
+                    "type": "string"
+                },
+                "redirect_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.CreateProductImageData": {
+            "type": "object",
+            "required": [
+                "images",
+                "product_id"
+            ],
+            "properties": {
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.CreateProductImageUploadsResponseData": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "uploads"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "string"
+                },
+                "uploads": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.ProductImageUploadData"
+                    }
+                }
+            }
+        },
+        "data.CreateProductResponseData": {
+            "type": "object",
+            "required": [
+                "condition",
+                "desc",
+                "expansion",
+                "language",
+                "posted_date",
+                "price",
+                "product_id",
+                "product_quantity",
+                "product_type",
+                "seller_id",
+                "sold_quantity",
+                "title"
+            ],
+            "properties": {
+                "card_attributes": {
+                    "$ref": "#/definitions/data.CardAttributesData"
+                },
+                "condition": {
+                    "type": "integer"
+                },
+                "desc": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "expansion": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "order_by": {
+                    "type": "string"
+                },
+                "posted_date": {
+                    "type": "string",
+                    "example": "2023-08-03 02:50:26.034552906 +0000 UTC m=+192.307467936"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_quantity": {
+                    "type": "integer"
+                },
+                "product_type": {
+                    "type": "string"
+                },
+                "releases_on": {
+                    "type": "string"
+                },
+                "sealed_attributes": {
+                    "$ref": "#/definitions/data.SealedAttributesData"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "sold_quantity": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.CreatePromoCodeRequestData": {
+            "type": "object",
+            "required": [
+                "amount",
+                "code",
+                "discount_type"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "WELCOME10"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "example": "percentage"
+                },
+                "expansions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "first_order_only": {
+                    "type": "boolean"
+                },
+                "max_uses": {
+                    "type": "integer"
+                },
+                "max_uses_per_buyer": {
+                    "type": "integer"
+                },
+                "min_spend": {
+                    "type": "integer"
+                },
+                "seller_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "data.CreateReviewImageUploadsRequestData": {
+            "type": "object",
+            "required": [
+                "images"
+            ],
+            "properties": {
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.ProductImageUploadRequestData"
+                    }
+                }
+            }
+        },
+        "data.CreateReviewImageUploadsResponseData": {
+            "type": "object",
+            "required": [
+                "buyer_id",
+                "uploads"
+            ],
+            "properties": {
+                "buyer_id": {
+                    "type": "string"
+                },
+                "uploads": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.ProductImageUploadData"
+                    }
+                }
+            }
+        },
+        "data.CreateReviewRequestData": {
+            "type": "object",
+            "required": [
+                "order_id",
+                "rating",
+                "seller_id"
+            ],
+            "properties": {
+                "order_id": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "upload_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "data.CreateSavedSearchRequestData": {
+            "type": "object",
+            "required": [
+                "name",
+                "query"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Charizard holo"
+                },
+                "query": {
+                    "$ref": "#/definitions/data.GetProductListRequestData"
+                }
+            }
+        },
+        "data.CreateWebhookRequestData": {
+            "type": "object",
+            "required": [
+                "event_types",
+                "url"
+            ],
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "order.paid",
+                        "product.sold_out"
+                    ]
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://example.com/aucto/webhook"
+                }
+            }
+        },
+        "data.DeliverOutboxMessagesResponseData": {
+            "type": "object",
+            "properties": {
+                "dead_lettered": {
+                    "type": "integer"
+                },
+                "delivered": {
+                    "type": "integer"
+                },
+                "retried": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.DiscountData": {
+            "type": "object",
+            "required": [
+                "amount",
+                "created_date",
+                "discount_id",
+                "discount_type",
+                "seller_id",
+                "starts_at",
+                "status"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "created_date": {
+                    "type": "string"
+                },
+                "discount_id": {
+                    "type": "string"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "example": "percentage"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                }
+            }
+        },
+        "data.ExpansionData": {
+            "type": "object",
+            "required": [
+                "code",
+                "expansion_id",
+                "game_code",
+                "languages",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "SV03"
+                },
+                "expansion_id": {
+                    "type": "string"
+                },
+                "game_code": {
+                    "type": "string",
+                    "example": "pokemon"
+                },
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Obsidian Flames"
+                },
+                "release_date": {
+                    "type": "string",
+                    "example": "2023-08-11"
+                }
+            }
+        },
+        "data.ExpireOffersResponseData": {
+            "type": "object",
+            "required": [
+                "expired"
+            ],
+            "properties": {
+                "expired": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "data.FacetCountData": {
+            "type": "object",
+            "required": [
+                "count",
+                "value"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.FollowSellerResponseData": {
+            "type": "object",
+            "required": [
+                "buyer_id",
+                "seller_id"
+            ],
+            "properties": {
+                "buyer_id": {
+                    "type": "string"
+                },
+                "followers": {
+                    "type": "integer"
+                },
+                "following": {
+                    "type": "boolean"
+                },
+                "seller_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.GameData": {
+            "type": "object",
+            "required": [
+                "code",
+                "game_id",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "pokemon"
+                },
+                "game_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Pokemon TCG"
+                }
+            }
+        },
+        "data.GetApiKeysResponseData": {
+            "type": "object",
+            "required": [
+                "api_keys"
+            ],
+            "properties": {
+                "api_keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.ApiKeyData"
+                    }
+                }
+            }
+        },
+        "data.GetBidsResponseData": {
+            "type": "object",
+            "required": [
+                "bids",
+                "product_id"
+            ],
+            "properties": {
+                "bids": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.BidData"
+                    }
+                },
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.GetBuyerFeedResponseData": {
+            "type": "object",
+            "required": [
+                "buyer_id",
+                "products"
+            ],
+            "properties": {
+                "buyer_id": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.GetProductResponseData"
+                    }
+                }
+            }
+        },
+        "data.GetBuyerFollowsResponseData": {
+            "type": "object",
+            "required": [
+                "buyer_id",
+                "sellers"
+            ],
+            "properties": {
+                "buyer_id": {
+                    "type": "string"
+                },
+                "follow_digest": {
+                    "type": "boolean"
+                },
+                "sellers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.GetSellerByIdResponseData"
+                    }
+                }
+            }
+        },
+        "data.GetDiscountsResponseData": {
+            "type": "object",
+            "required": [
+                "discounts"
+            ],
+            "properties": {
+                "discounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.DiscountData"
+                    }
+                }
+            }
+        },
+        "data.GetGuestOrderByIdResponseData": {
+            "type": "object",
+            "required": [
+                "address_line_1",
+                "email",
+                "fees",
+                "guest_order_id",
+                "order_date",
+                "payment_status",
+                "phone_number",
+                "postal_code",
+                "products",
+                "sub_orders"
+            ],
+            "properties": {
+                "address_line_1": {
+                    "type": "string"
+                },
+                "address_line_2": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
-                "verification": {
+                "fees": {
+                    "$ref": "#/definitions/data.OrderFees"
+                },
+                "guest_order_id": {
+                    "type": "string"
+                },
+                "order_date": {
+                    "type": "string"
+                },
+                "payment_status": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.ProductOrder"
+                    }
+                },
+                "sub_orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.SubOrderData"
+                    }
+                },
+                "telegram_handle": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.GetJobsResponseData": {
+            "type": "object",
+            "required": [
+                "jobs"
+            ],
+            "properties": {
+                "jobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.JobData"
+                    }
+                }
+            }
+        },
+        "data.GetNotificationsResponseData": {
+            "type": "object",
+            "required": [
+                "buyer_id",
+                "notifications"
+            ],
+            "properties": {
+                "buyer_id": {
+                    "type": "string"
+                },
+                "notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.NotificationData"
+                    }
+                },
+                "unread_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.GetOffersResponseData": {
+            "type": "object",
+            "required": [
+                "offers"
+            ],
+            "properties": {
+                "offers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.OfferData"
+                    }
+                }
+            }
+        },
+        "data.GetOrderByIdResponseData": {
+            "type": "object",
+            "required": [
+                "address_line_1",
+                "buyer_id",
+                "fees",
+                "order_date",
+                "order_id",
+                "payment_status",
+                "phone_number",
+                "postal_code",
+                "products",
+                "sub_orders"
+            ],
+            "properties": {
+                "address_line_1": {
+                    "type": "string"
+                },
+                "address_line_2": {
+                    "type": "string"
+                },
+                "buyer_id": {
+                    "type": "string"
+                },
+                "fees": {
+                    "$ref": "#/definitions/data.OrderFees"
+                },
+                "order_date": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "payment_status": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.ProductOrder"
+                    }
+                },
+                "sub_orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.SubOrderData"
+                    }
+                },
+                "telegram_handle": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.GetOutboxMessagesResponseData": {
+            "type": "object",
+            "required": [
+                "messages"
+            ],
+            "properties": {
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.OutboxMessageData"
+                    }
+                }
+            }
+        },
+        "data.GetPriceHistoryResponseData": {
+            "type": "object",
+            "required": [
+                "effective_price",
+                "history",
+                "price",
+                "product_id"
+            ],
+            "properties": {
+                "effective_price": {
+                    "type": "integer"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.PriceHistoryData"
+                    }
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "was_price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.GetProductListRequestData": {
+            "type": "object",
+            "properties": {
+                "anchor": {
+                    "type": "integer"
+                },
+                "card_number": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "condition": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cursor": {
+                    "type": "string"
+                },
+                "edition": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expansion": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "finish": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "grading_company": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "language": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "min_grade": {
+                    "type": "number"
+                },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "product_type": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "q": {
+                    "type": "string"
+                },
+                "rarity": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sealed_type": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "seller_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "sort": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.GetProductListResponseData": {
+            "type": "object",
+            "required": [
+                "facets",
+                "product_count",
+                "products"
+            ],
+            "properties": {
+                "facets": {
+                    "$ref": "#/definitions/data.ProductFacetsData"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "product_count": {
+                    "type": "integer"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.GetProductResponseData"
+                    }
+                }
+            }
+        },
+        "data.GetProductResponseData": {
+            "type": "object",
+            "required": [
+                "condition",
+                "desc",
+                "expansion",
+                "images",
+                "language",
+                "posted_date",
+                "price",
+                "product_id",
+                "product_quantity",
+                "product_type",
+                "seller_info",
+                "sold_quantity",
+                "title"
+            ],
+            "properties": {
+                "auction": {
+                    "$ref": "#/definitions/data.AuctionData"
+                },
+                "card_attributes": {
+                    "$ref": "#/definitions/data.CardAttributesData"
+                },
+                "condition": {
+                    "type": "integer"
+                },
+                "desc": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "expansion": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.ProductImageData"
+                    }
+                },
+                "language": {
+                    "type": "string"
+                },
+                "order_by": {
+                    "type": "string"
+                },
+                "posted_date": {
+                    "type": "string",
+                    "example": "2023-08-03 02:50:26.034552906 +0000 UTC m=+192.307467936"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_quantity": {
+                    "type": "integer"
+                },
+                "product_type": {
+                    "type": "string"
+                },
+                "release_status": {
+                    "type": "string",
+                    "example": "open"
+                },
+                "releases_on": {
+                    "type": "string"
+                },
+                "sealed_attributes": {
+                    "$ref": "#/definitions/data.SealedAttributesData"
+                },
+                "seller_info": {
+                    "$ref": "#/definitions/data.GetSellerByIdResponseData"
+                },
+                "sold_quantity": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.GetPromoCodesResponseData": {
+            "type": "object",
+            "required": [
+                "promo_codes"
+            ],
+            "properties": {
+                "promo_codes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.PromoCodeData"
+                    }
+                }
+            }
+        },
+        "data.GetReviewsResponseData": {
+            "type": "object",
+            "required": [
+                "reviews"
+            ],
+            "properties": {
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.ReviewData"
+                    }
+                }
+            }
+        },
+        "data.GetSavedSearchesResponseData": {
+            "type": "object",
+            "required": [
+                "buyer_id",
+                "saved_searches"
+            ],
+            "properties": {
+                "buyer_id": {
+                    "type": "string"
+                },
+                "saved_searches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.SavedSearchData"
+                    }
+                }
+            }
+        },
+        "data.GetSellerByIdResponseData": {
+            "type": "object",
+            "required": [
+                "followers",
+                "seller_id",
+                "seller_name"
+            ],
+            "properties": {
+                "followers": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "review_count": {
+                    "type": "integer"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "seller_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.GetSellerReviewsResponseData": {
+            "type": "object",
+            "required": [
+                "reviews",
+                "seller_id"
+            ],
+            "properties": {
+                "rating": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "review_count": {
+                    "type": "integer"
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.ReviewData"
+                    }
+                },
+                "seller_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.GetSellerStatementResponseData": {
+            "type": "object",
+            "required": [
+                "entries",
+                "seller_id"
+            ],
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.StatementEntryData"
+                    }
+                },
+                "from": {
+                    "type": "string"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.GetSellerSubOrdersResponseData": {
+            "type": "object",
+            "required": [
+                "sub_orders"
+            ],
+            "properties": {
+                "sub_orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.SellerSubOrderData"
+                    }
+                }
+            }
+        },
+        "data.GetWatchlistResponseData": {
+            "type": "object",
+            "required": [
+                "buyer_id",
+                "products"
+            ],
+            "properties": {
+                "buyer_id": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.WatchlistProductData"
+                    }
+                }
+            }
+        },
+        "data.GetWebhookDeliveriesResponseData": {
+            "type": "object",
+            "required": [
+                "deliveries"
+            ],
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.WebhookDeliveryData"
+                    }
+                }
+            }
+        },
+        "data.GetWebhooksResponseData": {
+            "type": "object",
+            "required": [
+                "webhooks"
+            ],
+            "properties": {
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.WebhookData"
+                    }
+                }
+            }
+        },
+        "data.JobData": {
+            "type": "object",
+            "required": [
+                "attempts",
+                "created_date",
+                "job_id",
+                "max_attempts",
+                "payload",
+                "run_date",
+                "status",
+                "type"
+            ],
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "completed_date": {
+                    "type": "string"
+                },
+                "created_date": {
+                    "type": "string"
+                },
+                "job_id": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "locked_until": {
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "run_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "failed"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "close-auctions"
+                }
+            }
+        },
+        "data.LanguageData": {
+            "type": "object",
+            "required": [
+                "code",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "Eng"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "English"
+                }
+            }
+        },
+        "data.MailPreviewData": {
+            "type": "object",
+            "required": [
+                "event",
+                "html",
+                "locale",
+                "subject",
+                "text"
+            ],
+            "properties": {
+                "event": {
+                    "type": "string",
+                    "example": "otp"
+                },
+                "html": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.MailTemplatesResponseData": {
+            "type": "object",
+            "required": [
+                "events",
+                "locales"
+            ],
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "locales": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "data.MatchSavedSearchesResponseData": {
+            "type": "object",
+            "properties": {
+                "listings": {
+                    "type": "integer"
+                },
+                "matches": {
+                    "type": "integer"
+                },
+                "notified": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.Message": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.ModerateReviewRequestData": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "hidden"
+                }
+            }
+        },
+        "data.NotificationData": {
+            "type": "object",
+            "required": [
+                "created_date",
+                "message",
+                "notification_id",
+                "notification_type",
+                "title"
+            ],
+            "properties": {
+                "created_date": {
+                    "type": "string"
+                },
+                "is_read": {
+                    "type": "boolean"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "notification_id": {
+                    "type": "string"
+                },
+                "notification_type": {
+                    "type": "string",
+                    "example": "price_drop"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.NotificationPreferenceData": {
+            "type": "object",
+            "required": [
+                "notification_type"
+            ],
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "notification_type": {
+                    "type": "string",
+                    "example": "price_drop"
+                }
+            }
+        },
+        "data.NotificationPreferencesData": {
+            "type": "object",
+            "required": [
+                "preferences"
+            ],
+            "properties": {
+                "buyer_id": {
+                    "type": "string"
+                },
+                "preferences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.NotificationPreferenceData"
+                    }
+                }
+            }
+        },
+        "data.OfferData": {
+            "type": "object",
+            "required": [
+                "amount",
+                "buyer_id",
+                "created_date",
+                "expires_at",
+                "offer_id",
+                "product_id",
+                "quantity",
+                "seller_id",
+                "status"
+            ],
+            "properties": {
+                "agreed_amount": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "type": "integer"
+                },
+                "buyer_id": {
+                    "type": "string"
+                },
+                "counter_amount": {
+                    "type": "integer"
+                },
+                "created_date": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "offer_id": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "reserved_until": {
+                    "type": "string"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
+        "data.OrderFees": {
+            "type": "object",
+            "required": [
+                "delivery_type",
+                "payment_type",
+                "total_paid"
+            ],
+            "properties": {
+                "delivery_fee": {
+                    "type": "integer"
+                },
+                "delivery_type": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "payment_fee": {
+                    "type": "integer"
+                },
+                "payment_type": {
+                    "type": "string"
+                },
+                "small_order_fee": {
+                    "type": "integer"
+                },
+                "total_paid": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.OutboxMessageData": {
+            "type": "object",
+            "required": [
+                "attempts",
+                "created_date",
+                "message_id",
+                "next_attempt_date",
+                "payload",
+                "status",
+                "topic"
+            ],
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_date": {
+                    "type": "string"
+                },
+                "delivered_date": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "message_id": {
+                    "type": "string"
+                },
+                "next_attempt_date": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "dead"
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "mail"
+                }
+            }
+        },
+        "data.PayoutBatchData": {
+            "type": "object",
+            "required": [
+                "payouts"
+            ],
+            "properties": {
+                "batch_id": {
+                    "type": "string"
+                },
+                "created_date": {
+                    "type": "string"
+                },
+                "payouts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.PayoutData"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.PayoutData": {
+            "type": "object",
+            "required": [
+                "amount",
+                "batch_id",
+                "created_date",
+                "payout_id",
+                "seller_id",
+                "seller_name",
+                "status"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "batch_id": {
+                    "type": "string"
+                },
+                "created_date": {
+                    "type": "string"
+                },
+                "paid_date": {
+                    "type": "string"
+                },
+                "payout_id": {
+                    "type": "string"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "seller_name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.PayoutReportData": {
+            "type": "object",
+            "required": [
+                "batches"
+            ],
+            "properties": {
+                "available": {
+                    "type": "integer"
+                },
+                "batches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.PayoutBatchData"
+                    }
+                },
+                "commission": {
+                    "type": "integer"
+                },
+                "paid_out": {
+                    "type": "integer"
+                },
+                "pending": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.PlaceBidRequestData": {
+            "type": "object",
+            "required": [
+                "amount",
+                "buyer_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "buyer_id": {
+                    "type": "string"
+                },
+                "checkout": {
+                    "$ref": "#/definitions/data.AuctionCheckoutData"
+                }
+            }
+        },
+        "data.PlaceBidResponseData": {
+            "type": "object",
+            "required": [
+                "amount",
+                "bid_date",
+                "bid_id",
+                "ends_at",
+                "minimum_bid",
+                "product_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "bid_date": {
+                    "type": "string"
+                },
+                "bid_id": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "minimum_bid": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.PreOrderData": {
+            "type": "object",
+            "required": [
+                "order_by",
+                "product_id",
+                "product_type",
+                "releases_on",
+                "status"
+            ],
+            "properties": {
+                "notified_buyers": {
+                    "type": "integer"
+                },
+                "order_by": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_type": {
+                    "type": "string",
+                    "example": "Pre-Order"
+                },
+                "releases_on": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "open"
+                }
+            }
+        },
+        "data.PriceHistoryData": {
+            "type": "object",
+            "required": [
+                "effective_price",
+                "price",
+                "recorded_at"
+            ],
+            "properties": {
+                "effective_price": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "recorded_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.ProductEventData": {
+            "type": "object",
+            "required": [
+                "event",
+                "price",
+                "product_id",
+                "remaining_quantity",
+                "status"
+            ],
+            "properties": {
+                "auction": {
+                    "$ref": "#/definitions/data.AuctionData"
+                },
+                "event": {
+                    "type": "string",
+                    "example": "bid"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "remaining_quantity": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "available"
+                }
+            }
+        },
+        "data.ProductFacetsData": {
+            "type": "object",
+            "required": [
+                "conditions",
+                "expansions",
+                "languages",
+                "prices",
+                "product_types"
+            ],
+            "properties": {
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.FacetCountData"
+                    }
+                },
+                "expansions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.FacetCountData"
+                    }
+                },
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.FacetCountData"
+                    }
+                },
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.FacetCountData"
+                    }
+                },
+                "product_types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/data.FacetCountData"
+                    }
+                }
+            }
+        },
+        "data.ProductImageData": {
+            "type": "object",
+            "required": [
+                "image_no",
+                "image_path"
+            ],
+            "properties": {
+                "image_no": {
+                    "type": "integer"
+                },
+                "image_path": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.ProductImageUploadData": {
+            "type": "object",
+            "required": [
+                "content_type",
+                "expires_at",
+                "headers",
+                "image_no",
+                "method",
+                "size",
+                "upload_id",
+                "upload_url"
+            ],
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "image_no": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "upload_id": {
+                    "type": "string"
+                },
+                "upload_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.ProductImageUploadRequestData": {
+            "type": "object",
+            "required": [
+                "content_type",
+                "size"
+            ],
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "example": "image/png"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.ProductOrder": {
+            "type": "object",
+            "required": [
+                "order_quantity",
+                "product_id"
+            ],
+            "properties": {
+                "order_quantity": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.PromoCodeData": {
+            "type": "object",
+            "required": [
+                "amount",
+                "code",
+                "created_date",
+                "discount_type",
+                "expansions",
+                "promo_code_id",
+                "seller_ids",
+                "status"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "WELCOME10"
+                },
+                "created_date": {
+                    "type": "string"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "example": "percentage"
+                },
+                "expansions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "first_order_only": {
+                    "type": "boolean"
+                },
+                "max_uses": {
+                    "type": "integer"
+                },
+                "max_uses_per_buyer": {
+                    "type": "integer"
+                },
+                "min_spend": {
+                    "type": "integer"
+                },
+                "promo_code_id": {
                     "type": "string"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "seller_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "active"
+                },
+                "used_count": {
+                    "type": "integer"
                 }
             }
         },
-        "data.CreateGuestOrderResponseData": {
+        "data.RecordPricesResponseData": {
             "type": "object",
             "required": [
-                "guest_order_id",
-                "redirect_url"
+                "recorded"
             ],
             "properties": {
-                "guest_order_id": {
-                    "type": "string"
+                "recorded": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "data.RefundSubOrderRequestData": {
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "integer"
                 },
-                "redirect_url": {
+                "reason": {
                     "type": "string"
                 }
             }
         },
-        "data.CreateOrderResponseData": {
+        "data.ReleaseFundsResponseData": {
             "type": "object",
             "required": [
-                "order_id",
-                "redirect_url"
+                "released"
             ],
             "properties": {
-                "order_id": {
-                    "type": "string"
-                },
-                "redirect_url": {
+                "released": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "data.ReleasePreOrdersResponseData": {
+            "type": "object",
+            "required": [
+                "released"
+            ],
+            "properties": {
+                "released": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "data.ReplyReviewRequestData": {
+            "type": "object",
+            "required": [
+                "reply"
+            ],
+            "properties": {
+                "reply": {
                     "type": "string"
                 }
             }
         },
-        "data.CreateProductResponseData": {
+        "data.ReviewData": {
             "type": "object",
             "required": [
-                "condition",
-                "desc",
-                "expansion",
-                "language",
-                "posted_date",
-                "price",
-                "product_id",
-                "product_quantity",
-                "product_type",
+                "buyer_id",
+                "created_date",
+                "images",
+                "order_id",
+                "rating",
+                "review_id",
                 "seller_id",
-                "sold_quantity",
-                "title"
+                "status"
             ],
             "properties": {
-                "condition": {
-                    "type": "integer"
+                "buyer_id": {
+                    "type": "string"
                 },
-                "desc": {
+                "created_date": {
                     "type": "string"
                 },
-                "discount": {
-                    "type": "integer"
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "expansion": {
+                "moderation_reason": {
                     "type": "string"
                 },
-                "language": {
+                "order_id": {
                     "type": "string"
                 },
-                "order_by": {
+                "rating": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "replied_date": {
                     "type": "string"
                 },
-                "posted_date": {
+                "reply": {
+                    "type": "string"
+                },
+                "review_id": {
+                    "type": "string"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string",
-                    "example": "2023-08-03 02:50:26.034552906 +0000 UTC m=+192.307467936"
+                    "example": "visible"
                 },
-                "price": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.RunJobsResponseData": {
+            "type": "object",
+            "properties": {
+                "completed": {
                     "type": "integer"
                 },
-                "product_id": {
-                    "type": "string"
+                "failed": {
+                    "type": "integer"
                 },
-                "product_quantity": {
+                "retried": {
                     "type": "integer"
                 },
-                "product_type": {
+                "scheduled": {
+                    "type": "integer"
+                }
+            }
+        },
+        "data.SavedSearchData": {
+            "type": "object",
+            "required": [
+                "created_date",
+                "name",
+                "query",
+                "search_id"
+            ],
+            "properties": {
+                "created_date": {
                     "type": "string"
                 },
-                "releases_on": {
+                "name": {
+                    "type": "string"
+                },
+                "notified_date": {
+                    "type": "string"
+                },
+                "query": {
+                    "$ref": "#/definitions/data.GetProductListRequestData"
+                },
+                "search_id": {
                     "type": "string"
+                }
+            }
+        },
+        "data.SealedAttributesData": {
+            "type": "object",
+            "required": [
+                "sealed_type"
+            ],
+            "properties": {
+                "pack_count": {
+                    "type": "integer",
+                    "example": 36
+                },
+                "sealed_type": {
+                    "type": "string",
+                    "example": "Booster-Box"
+                }
+            }
+        },
+        "data.SellerBalanceData": {
+            "type": "object",
+            "required": [
+                "seller_id"
+            ],
+            "properties": {
+                "available": {
+                    "type": "integer"
+                },
+                "paid_out": {
+                    "type": "integer"
+                },
+                "pending": {
+                    "type": "integer"
                 },
                 "seller_id": {
                     "type": "string"
+                }
+            }
+        },
+        "data.SellerLoginResponseData": {
+            "type": "object",
+            "required": [
+                "email",
+                "followers",
+                "seller_id",
+                "seller_name"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
                 },
-                "sold_quantity": {
+                "followers": {
                     "type": "integer"
                 },
-                "title": {
+                "seller_id": {
+                    "type": "string"
+                },
+                "seller_name": {
                     "type": "string"
                 }
             }
         },
-        "data.GetGuestOrderByIdResponseData": {
+        "data.SellerOfferResponseRequestData": {
+            "type": "object",
+            "required": [
+                "action",
+                "seller_id"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "counter"
+                },
+                "counter_amount": {
+                    "type": "integer"
+                },
+                "seller_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.SellerSubOrderData": {
             "type": "object",
             "required": [
                 "address_line_1",
-                "email",
-                "fees",
-                "guest_order_id",
-                "order_date",
+                "created_date",
+                "delivery_type",
                 "payment_status",
                 "phone_number",
                 "postal_code",
-                "products"
+                "products",
+                "products_cost",
+                "seller_id",
+                "status",
+                "sub_order_id",
+                "total",
+                "updated_date"
             ],
             "properties": {
                 "address_line_1": {
@@ -1065,16 +9822,22 @@ const docTemplate = `{
                 "address_line_2": {
                     "type": "string"
                 },
-                "email": {
+                "created_date": {
                     "type": "string"
                 },
-                "fees": {
-                    "$ref": "#/definitions/data.OrderFees"
+                "delivery_fee": {
+                    "type": "integer"
+                },
+                "delivery_type": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "integer"
                 },
                 "guest_order_id": {
                     "type": "string"
                 },
-                "order_date": {
+                "order_id": {
                     "type": "string"
                 },
                 "payment_status": {
@@ -1092,50 +9855,105 @@ const docTemplate = `{
                         "$ref": "#/definitions/data.ProductOrder"
                     }
                 },
+                "products_cost": {
+                    "type": "integer"
+                },
+                "refunded_amount": {
+                    "type": "integer"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "sub_order_id": {
+                    "type": "string"
+                },
                 "telegram_handle": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updated_date": {
+                    "type": "string"
                 }
             }
         },
-        "data.GetOrderByIdResponseData": {
+        "data.SendFollowDigestsResponseData": {
             "type": "object",
             "required": [
-                "address_line_1",
-                "buyer_id",
-                "fees",
-                "order_date",
-                "order_id",
-                "payment_status",
-                "phone_number",
-                "postal_code",
-                "products"
+                "sent"
+            ],
+            "properties": {
+                "sent": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "data.StatementEntryData": {
+            "type": "object",
+            "required": [
+                "account",
+                "amount",
+                "created_date",
+                "transaction_id",
+                "transaction_type"
             ],
             "properties": {
-                "address_line_1": {
+                "account": {
                     "type": "string"
                 },
-                "address_line_2": {
-                    "type": "string"
+                "amount": {
+                    "type": "integer"
                 },
-                "buyer_id": {
+                "created_date": {
                     "type": "string"
                 },
-                "fees": {
-                    "$ref": "#/definitions/data.OrderFees"
+                "payout_id": {
+                    "type": "string"
                 },
-                "order_date": {
+                "sub_order_id": {
                     "type": "string"
                 },
-                "order_id": {
+                "transaction_id": {
                     "type": "string"
                 },
-                "payment_status": {
+                "transaction_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.SubOrderData": {
+            "type": "object",
+            "required": [
+                "created_date",
+                "products",
+                "products_cost",
+                "seller_id",
+                "status",
+                "sub_order_id",
+                "total",
+                "updated_date"
+            ],
+            "properties": {
+                "created_date": {
                     "type": "string"
                 },
-                "phone_number": {
+                "delivery_fee": {
+                    "type": "integer"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "guest_order_id": {
                     "type": "string"
                 },
-                "postal_code": {
+                "order_id": {
                     "type": "string"
                 },
                 "products": {
@@ -1144,205 +9962,330 @@ const docTemplate = `{
                         "$ref": "#/definitions/data.ProductOrder"
                     }
                 },
-                "telegram_handle": {
+                "products_cost": {
+                    "type": "integer"
+                },
+                "refunded_amount": {
+                    "type": "integer"
+                },
+                "seller_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "sub_order_id": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updated_date": {
                     "type": "string"
                 }
             }
         },
-        "data.GetProductListResponseData": {
+        "data.TelegramChatData": {
             "type": "object",
-            "required": [
-                "product_count",
-                "products"
-            ],
             "properties": {
-                "product_count": {
+                "id": {
                     "type": "integer"
                 },
-                "products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/data.GetProductResponseData"
-                    }
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "data.GetProductResponseData": {
+        "data.TelegramLinkCodeData": {
             "type": "object",
             "required": [
-                "condition",
-                "desc",
-                "expansion",
-                "images",
-                "language",
-                "posted_date",
-                "price",
-                "product_id",
-                "product_quantity",
-                "product_type",
-                "seller_info",
-                "sold_quantity",
-                "title"
+                "code",
+                "expiry_date",
+                "url"
             ],
             "properties": {
-                "condition": {
-                    "type": "integer"
-                },
-                "desc": {
+                "code": {
                     "type": "string"
                 },
-                "discount": {
-                    "type": "integer"
-                },
-                "expansion": {
+                "expiry_date": {
                     "type": "string"
                 },
-                "images": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/data.ProductImageData"
-                    }
+                "url": {
+                    "type": "string",
+                    "example": "https://t.me/AuctoBot?start=3f2a9c"
+                }
+            }
+        },
+        "data.TelegramLinkData": {
+            "type": "object",
+            "properties": {
+                "linked": {
+                    "type": "boolean"
                 },
-                "language": {
+                "linked_date": {
                     "type": "string"
                 },
-                "order_by": {
+                "username": {
                     "type": "string"
+                }
+            }
+        },
+        "data.TelegramMessageData": {
+            "type": "object",
+            "properties": {
+                "chat": {
+                    "$ref": "#/definitions/data.TelegramChatData"
                 },
-                "posted_date": {
-                    "type": "string",
-                    "example": "2023-08-03 02:50:26.034552906 +0000 UTC m=+192.307467936"
+                "from": {
+                    "$ref": "#/definitions/data.TelegramUserData"
                 },
-                "price": {
+                "message_id": {
                     "type": "integer"
                 },
-                "product_id": {
+                "text": {
                     "type": "string"
+                }
+            }
+        },
+        "data.TelegramUpdateData": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "$ref": "#/definitions/data.TelegramMessageData"
                 },
-                "product_quantity": {
+                "update_id": {
                     "type": "integer"
-                },
-                "product_type": {
-                    "type": "string"
-                },
-                "releases_on": {
-                    "type": "string"
-                },
-                "seller_info": {
-                    "$ref": "#/definitions/data.GetSellerByIdResponseData"
-                },
-                "sold_quantity": {
+                }
+            }
+        },
+        "data.TelegramUserData": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "integer"
                 },
-                "title": {
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "data.GetSellerByIdResponseData": {
+        "data.UpdateCartProductRequestData": {
             "type": "object",
             "required": [
-                "followers",
-                "seller_id",
-                "seller_name"
+                "quantity"
             ],
             "properties": {
-                "followers": {
+                "quantity": {
                     "type": "integer"
+                }
+            }
+        },
+        "data.UpdateExpansionData": {
+            "type": "object",
+            "required": [
+                "game_code",
+                "languages",
+                "name"
+            ],
+            "properties": {
+                "game_code": {
+                    "type": "string"
                 },
-                "seller_id": {
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
                     "type": "string"
                 },
-                "seller_name": {
+                "release_date": {
                     "type": "string"
                 }
             }
         },
-        "data.Message": {
+        "data.UpdateFollowDigestRequestData": {
             "type": "object",
             "properties": {
-                "message": {
+                "enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "data.UpdateGameData": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "data.OrderFees": {
+        "data.UpdateLanguageData": {
             "type": "object",
             "required": [
-                "delivery_type",
-                "payment_type",
-                "total_paid"
+                "name"
             ],
             "properties": {
-                "delivery_fee": {
-                    "type": "integer"
-                },
-                "delivery_type": {
+                "name": {
                     "type": "string"
+                }
+            }
+        },
+        "data.UpdatePreOrderRequestData": {
+            "type": "object",
+            "required": [
+                "order_by",
+                "releases_on",
+                "seller_id"
+            ],
+            "properties": {
+                "order_by": {
+                    "type": "string",
+                    "example": "2023-11-01T23:59:59+08:00"
                 },
-                "payment_fee": {
-                    "type": "integer"
+                "releases_on": {
+                    "type": "string",
+                    "example": "2023-11-10T00:00:00+08:00"
                 },
-                "payment_type": {
+                "seller_id": {
                     "type": "string"
-                },
-                "small_order_fee": {
-                    "type": "integer"
-                },
-                "total_paid": {
-                    "type": "integer"
                 }
             }
         },
-        "data.ProductImageData": {
+        "data.UpdateSubOrderStatusRequestData": {
             "type": "object",
             "required": [
-                "image_no",
-                "image_path"
+                "status"
             ],
             "properties": {
-                "image_no": {
-                    "type": "integer"
-                },
-                "image_path": {
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "data.ProductOrder": {
+        "data.WatchlistProductData": {
             "type": "object",
             "required": [
-                "order_quantity",
-                "product_id"
+                "created_date",
+                "effective_price",
+                "price",
+                "product_id",
+                "product_type",
+                "title"
             ],
             "properties": {
-                "order_quantity": {
+                "created_date": {
+                    "type": "string"
+                },
+                "effective_price": {
+                    "type": "integer"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "price": {
                     "type": "integer"
                 },
                 "product_id": {
                     "type": "string"
+                },
+                "product_type": {
+                    "type": "string"
+                },
+                "sold_out": {
+                    "type": "boolean"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "data.SellerLoginResponseData": {
+        "data.WebhookData": {
             "type": "object",
             "required": [
-                "email",
-                "followers",
+                "created_date",
+                "event_types",
                 "seller_id",
-                "seller_name"
+                "url",
+                "webhook_id"
             ],
             "properties": {
-                "email": {
+                "created_date": {
                     "type": "string"
                 },
-                "followers": {
-                    "type": "integer"
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "type": "string"
                 },
                 "seller_id": {
                     "type": "string"
                 },
-                "seller_name": {
+                "url": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "data.WebhookDeliveryData": {
+            "type": "object",
+            "required": [
+                "attempts",
+                "created_date",
+                "delivery_id",
+                "event_id",
+                "event_type",
+                "payload",
+                "status",
+                "webhook_id"
+            ],
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_date": {
+                    "type": "string"
+                },
+                "delivered_date": {
+                    "type": "string"
+                },
+                "delivery_id": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string",
+                    "example": "order.paid"
+                },
+                "last_attempt_date": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "string"
+                },
+                "response_code": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "delivered"
+                },
+                "webhook_id": {
                     "type": "string"
                 }
             }
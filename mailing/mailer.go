@@ -0,0 +1,135 @@
+package mailing
+
+import (
+	"errors"
+	"os"
+	"sync"
+)
+
+const (
+	fromName  = "Aucto Admin"
+	fromEmail = "admin@aucto.io"
+)
+
+/*
+A mail with both a plain text and a HTML body, mail clients show the HTML body when they can
+*/
+type Message struct {
+	ToName  string
+	ToEmail string
+	Subject string
+	Text    string
+	Html    string
+}
+
+/*
+Delivers mails to their recipients
+*/
+type Mailer interface {
+	Send(message Message) error
+}
+
+var (
+	mailerMutex   sync.Mutex
+	defaultMailer Mailer
+)
+
+/*
+Creates the mailer selected by MAIL_PROVIDER. Mails are sent through SendGrid by default, smtp sends them through
+the SMTP server specified by the environment variables, file writes them to MAIL_SINK_DIR and memory keeps them
+in memory.
+*/
+func CreateMailer() (Mailer, error) {
+	switch os.Getenv("MAIL_PROVIDER") {
+	case "", "sendgrid":
+		return NewSendGridMailer(os.Getenv("SENDGRID_API_KEY")), nil
+	case "smtp":
+		return CreateSMTPMailer()
+	case "file":
+		dir, hasDir := os.LookupEnv("MAIL_SINK_DIR")
+
+		if !hasDir {
+			return nil, errors.New("Error in loading environment variables, Mail sink directory does not exist")
+		}
+
+		return NewFileMailer(dir), nil
+	case "memory":
+		return NewMemoryMailer(), nil
+	}
+
+	return nil, errors.New("Error in creating mailer, unknown mail provider " + os.Getenv("MAIL_PROVIDER"))
+}
+
+/*
+Sets the mailer that mails are sent with
+*/
+func UseMailer(mailer Mailer) {
+	mailerMutex.Lock()
+	defer mailerMutex.Unlock()
+
+	defaultMailer = mailer
+}
+
+/*
+Renders the template of an event in the locale of the recipient and sends it with the mailer in use
+*/
+func Send(event string, locale string, name string, email string, data interface{}) error {
+	message, err := Compose(event, locale, name, email, data)
+
+	if err != nil {
+		return err
+	}
+
+	return Deliver(message)
+}
+
+/*
+Renders the template of an event in the locale of the recipient and addresses it, without sending it
+*/
+func Compose(event string, locale string, name string, email string, data interface{}) (Message, error) {
+	message, err := Render(event, locale, data)
+
+	if err != nil {
+		return message, err
+	}
+
+	message.ToName = name
+	message.ToEmail = email
+
+	return message, nil
+}
+
+/*
+Sends a mail that has already been rendered with the mailer in use. If no mailer has been set, the mailer selected
+by the environment variables is used.
+*/
+func Deliver(message Message) error {
+	mailer, err := getMailer()
+
+	if err != nil {
+		return err
+	}
+
+	return mailer.Send(message)
+}
+
+/*
+Gets the mailer in use, creating it from the environment variables the first time
+*/
+func getMailer() (Mailer, error) {
+	mailerMutex.Lock()
+	defer mailerMutex.Unlock()
+
+	if defaultMailer != nil {
+		return defaultMailer, nil
+	}
+
+	mailer, err := CreateMailer()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defaultMailer = mailer
+	return defaultMailer, nil
+}
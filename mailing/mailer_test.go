@@ -0,0 +1,109 @@
+package mailing
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSend(t *testing.T) {
+	mailer := NewMemoryMailer()
+	UseMailer(mailer)
+	defer UseMailer(nil)
+
+	//Test 1: Mail is rendered in the locale of the recipient and sent with the mailer in use
+	err := Send(OtpMail, "es", "New collector", "test@aucto.io", OtpMailData{Otp: "000000"})
+	assert.NoError(t, err)
+	messages := mailer.Messages()
+	assert.Equal(t, 1, len(messages))
+	assert.Equal(t, "test@aucto.io", messages[0].ToEmail)
+	assert.Equal(t, "Verifica tu correo electrónico", messages[0].Subject)
+	assert.Contains(t, messages[0].Text, "000000")
+
+	//Test 2: Unknown event is not sent
+	err = Send("wrong", "en", "Collector", "test@aucto.io", nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, len(mailer.Messages()))
+
+	//Test 3: Composed mail is addressed but not sent until it is delivered
+	message, err := ComposeOtpMail("test2@aucto.io", "en", "111111")
+	assert.NoError(t, err)
+	assert.Equal(t, "New collector", message.ToName)
+	assert.Equal(t, "test2@aucto.io", message.ToEmail)
+	assert.Equal(t, 1, len(mailer.Messages()))
+	err = Deliver(message)
+	assert.NoError(t, err)
+	messages = mailer.Messages()
+	assert.Equal(t, 2, len(messages))
+	assert.Contains(t, messages[1].Text, "111111")
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := t.TempDir()
+	mailer := NewFileMailer(filepath.Join(dir, "mail"))
+
+	//Test 1: Every mail is written to its own file
+	err := mailer.Send(Message{ToEmail: "test@aucto.io", Subject: "First"})
+	assert.NoError(t, err)
+	err = mailer.Send(Message{ToEmail: "test@aucto.io", Subject: "Second"})
+	assert.NoError(t, err)
+
+	files, err := os.ReadDir(filepath.Join(dir, "mail"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(files))
+
+	//Test 2: Files hold the mail
+	content, err := os.ReadFile(filepath.Join(dir, "mail", files[0].Name()))
+	assert.NoError(t, err)
+	var message Message
+	assert.NoError(t, json.Unmarshal(content, &message))
+	assert.Equal(t, "First", message.Subject)
+}
+
+func TestCreateMailer(t *testing.T) {
+	//Test 1: SendGrid is used by default
+	t.Setenv("MAIL_PROVIDER", "")
+	mailer, err := CreateMailer()
+	assert.NoError(t, err)
+	assert.IsType(t, &SendGridMailer{}, mailer)
+
+	//Test 2: Memory sink
+	t.Setenv("MAIL_PROVIDER", "memory")
+	mailer, err = CreateMailer()
+	assert.NoError(t, err)
+	assert.IsType(t, &MemoryMailer{}, mailer)
+
+	//Test 3: SMTP needs a host and port
+	t.Setenv("MAIL_PROVIDER", "smtp")
+	t.Setenv("SMTP_HOST", "")
+	os.Unsetenv("SMTP_HOST")
+	_, err = CreateMailer()
+	assert.Error(t, err)
+	t.Setenv("SMTP_HOST", "localhost")
+	t.Setenv("SMTP_PORT", "1025")
+	mailer, err = CreateMailer()
+	assert.NoError(t, err)
+	assert.IsType(t, &SMTPMailer{}, mailer)
+
+	//Test 4: Unknown provider
+	t.Setenv("MAIL_PROVIDER", "wrong")
+	_, err = CreateMailer()
+	assert.Error(t, err)
+}
+
+func TestBuildMimeMessage(t *testing.T) {
+	//Test 1: Mail has a text and a HTML part
+	body, err := buildMimeMessage(Message{ToName: "Collector", ToEmail: "test@aucto.io", Subject: "Réservation",
+		Text: "Hello", Html: "<p>Hello</p>"})
+	assert.NoError(t, err)
+	mail := string(body)
+	assert.Contains(t, mail, "To: Collector <test@aucto.io>\r\n")
+	assert.Contains(t, mail, "Subject: =?utf-8?q?R=C3=A9servation?=\r\n")
+	assert.Contains(t, mail, "Content-Type: text/plain; charset=utf-8")
+	assert.Contains(t, mail, "Content-Type: text/html; charset=utf-8")
+	assert.True(t, strings.HasSuffix(mail, "--\r\n"))
+}
@@ -0,0 +1,129 @@
+package mailing
+
+type OtpMailData struct {
+	Otp string
+}
+
+type OrderMailItem struct {
+	Title    string
+	Quantity int
+}
+
+type OrderConfirmationMailData struct {
+	OrderId string
+	Items   []OrderMailItem
+	Total   int
+}
+
+type OrderShippedMailData struct {
+	OrderId    string
+	SellerName string
+	Items      []OrderMailItem
+}
+
+type RefundMailData struct {
+	OrderId string
+	Amount  int
+	Reason  string
+}
+
+type PasswordResetMailData struct {
+	ResetUrl  string
+	ExpiresIn string
+}
+
+type PreOrderMailData struct {
+	Title      string
+	ReleasesOn string
+}
+
+type FollowDigestMailData struct {
+	Titles []string
+}
+
+type NotificationMailData struct {
+	Title   string
+	Message string
+}
+
+// Data that every template is previewed with
+var sampleData = map[string]interface{}{
+	OtpMail: OtpMailData{Otp: "123456"},
+	OrderConfirmationMail: OrderConfirmationMailData{
+		OrderId: "7c1d6f2e-0000-0000-0000-000000000000",
+		Items:   []OrderMailItem{{Title: "Charizard Holo", Quantity: 1}, {Title: "Booster Box", Quantity: 2}},
+		Total:   25999},
+	OrderShippedMail: OrderShippedMailData{
+		OrderId:    "7c1d6f2e-0000-0000-0000-000000000000",
+		SellerName: "Card Corner",
+		Items:      []OrderMailItem{{Title: "Charizard Holo", Quantity: 1}}},
+	RefundMail:              RefundMailData{OrderId: "7c1d6f2e-0000-0000-0000-000000000000", Amount: 1250, Reason: "Damaged in transit"},
+	PasswordResetMail:       PasswordResetMailData{ResetUrl: "https://aucto.io/reset-password?token=sample", ExpiresIn: "1 hour"},
+	PreOrderReleasedMail:    PreOrderMailData{Title: "Booster Box"},
+	PreOrderReleaseDateMail: PreOrderMailData{Title: "Booster Box", ReleasesOn: "2 Jan 2026"},
+	FollowDigestMail:        FollowDigestMailData{Titles: []string{"Charizard Holo", "Booster Box"}},
+	NotificationMail:        NotificationMailData{Title: "Price drop on Charizard Holo", Message: "Charizard Holo is now $99.00."},
+}
+
+/*
+Composes the mail that sends a buyer the OTP that verifies their email address
+*/
+func ComposeOtpMail(email string, locale string, otp string) (Message, error) {
+	return Compose(OtpMail, locale, "New collector", email, OtpMailData{Otp: otp})
+}
+
+/*
+Composes the mail that lets a buyer know that the payment of their order went through
+*/
+func ComposeOrderConfirmationMail(email string, locale string, order OrderConfirmationMailData) (Message, error) {
+	return Compose(OrderConfirmationMail, locale, "Collector", email, order)
+}
+
+/*
+Composes the mail that lets a buyer know that a seller has shipped their part of an order
+*/
+func ComposeOrderShippedMail(email string, locale string, shipment OrderShippedMailData) (Message, error) {
+	return Compose(OrderShippedMail, locale, "Collector", email, shipment)
+}
+
+/*
+Composes the mail that lets a buyer know that part of an order has been refunded
+*/
+func ComposeRefundMail(email string, locale string, refund RefundMailData) (Message, error) {
+	return Compose(RefundMail, locale, "Collector", email, refund)
+}
+
+/*
+Sends a link that resets the password of an account
+*/
+func SendPasswordResetMail(email string, locale string, reset PasswordResetMailData) error {
+	return Send(PasswordResetMail, locale, "Collector", email, reset)
+}
+
+/*
+Composes the mail that lets a buyer of a pre-order know that the product has been released
+*/
+func ComposePreOrderReleasedMail(email string, locale string, title string) (Message, error) {
+	return Compose(PreOrderReleasedMail, locale, "Collector", email, PreOrderMailData{Title: title})
+}
+
+/*
+Composes the mail that lets a buyer of a pre-order know that the seller has changed the release date of the product
+*/
+func ComposePreOrderReleaseDateMail(email string, locale string, title string, releasesOn string) (Message, error) {
+	return Compose(PreOrderReleaseDateMail, locale, "Collector", email, PreOrderMailData{Title: title, ReleasesOn: releasesOn})
+}
+
+/*
+Composes the mail that lets a buyer know about the new listings of the sellers they follow
+*/
+func ComposeFollowDigestMail(email string, locale string, titles []string) (Message, error) {
+	return Compose(FollowDigestMail, locale, "Collector", email, FollowDigestMailData{Titles: titles})
+}
+
+/*
+Composes the mail that sends a buyer a copy of a notification they were shown in the app
+*/
+func ComposeNotificationMail(email string, locale string, title string, message string) (Message, error) {
+	return Compose(NotificationMail, locale, "Collector", email, NotificationMailData{Title: title, Message: message})
+}
@@ -0,0 +1,45 @@
+package mailing
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/sendgrid/sendgrid-go"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+/*
+Mailer that sends mails through the SendGrid API
+*/
+type SendGridMailer struct {
+	apiKey string
+}
+
+/*
+Creates a mailer that sends mails through SendGrid with the given api key
+*/
+func NewSendGridMailer(apiKey string) *SendGridMailer {
+	return &SendGridMailer{apiKey: apiKey}
+}
+
+/*
+Sends a mail through SendGrid. SendGrid answers rejected mails with an error status rather than an error,
+so those are turned into errors as well.
+*/
+func (mailer *SendGridMailer) Send(message Message) error {
+	from := mail.NewEmail(fromName, fromEmail)
+	to := mail.NewEmail(message.ToName, message.ToEmail)
+	client := sendgrid.NewSendClient(mailer.apiKey)
+
+	response, err := client.Send(mail.NewSingleEmail(from, message.Subject, to, message.Text, message.Html))
+
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode >= 300 {
+		return errors.New("Error in sending mail, SendGrid responded with status " + strconv.Itoa(response.StatusCode))
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package mailing
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+/*
+Mailer that writes every mail to a JSON file in a directory instead of sending it. Used when running locally
+so that mails can be read without a mail provider.
+*/
+type FileMailer struct {
+	dir   string
+	mutex sync.Mutex
+	count int
+}
+
+/*
+Mailer that keeps every mail in memory instead of sending it. Used in tests to check the mails that were sent.
+*/
+type MemoryMailer struct {
+	mutex    sync.Mutex
+	messages []Message
+}
+
+/*
+Creates a mailer that writes mails to the given directory
+*/
+func NewFileMailer(dir string) *FileMailer {
+	return &FileMailer{dir: dir}
+}
+
+/*
+Writes a mail to a new file in the directory, files are named by the time they were written
+*/
+func (mailer *FileMailer) Send(message Message) error {
+	mailer.mutex.Lock()
+	defer mailer.mutex.Unlock()
+
+	err := os.MkdirAll(mailer.dir, 0o755)
+
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(message, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	mailer.count++
+	name := time.Now().Format("20060102T150405.000000000") + "-" + strconv.Itoa(mailer.count) + ".json"
+
+	return os.WriteFile(filepath.Join(mailer.dir, name), content, 0o644)
+}
+
+/*
+Creates a mailer that keeps mails in memory
+*/
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+/*
+Keeps a mail in memory
+*/
+func (mailer *MemoryMailer) Send(message Message) error {
+	mailer.mutex.Lock()
+	defer mailer.mutex.Unlock()
+
+	mailer.messages = append(mailer.messages, message)
+	return nil
+}
+
+/*
+Gets the mails that were sent, from the first mail sent
+*/
+func (mailer *MemoryMailer) Messages() []Message {
+	mailer.mutex.Lock()
+	defer mailer.mutex.Unlock()
+
+	return append([]Message{}, mailer.messages...)
+}
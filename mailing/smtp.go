@@ -0,0 +1,115 @@
+package mailing
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"os"
+)
+
+/*
+Mailer that sends mails through a SMTP server
+*/
+type SMTPMailer struct {
+	addr string
+	auth smtp.Auth
+}
+
+/*
+Creates a mailer for the SMTP server specified by SMTP_HOST and SMTP_PORT. The server is logged into with
+SMTP_USERNAME and SMTP_PASSWORD when they are set.
+*/
+func CreateSMTPMailer() (*SMTPMailer, error) {
+	host, hasHost := os.LookupEnv("SMTP_HOST")
+	port, hasPort := os.LookupEnv("SMTP_PORT")
+
+	if !(hasHost && hasPort) {
+		return nil, errors.New("Error in loading environment variables for SMTP")
+	}
+
+	return NewSMTPMailer(host, port, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD")), nil
+}
+
+/*
+Creates a mailer for a SMTP server, the server is only logged into if a username is given
+*/
+func NewSMTPMailer(host string, port string, username string, password string) *SMTPMailer {
+	mailer := &SMTPMailer{addr: net.JoinHostPort(host, port)}
+
+	if username != "" {
+		mailer.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return mailer
+}
+
+/*
+Sends a mail through the SMTP server
+*/
+func (mailer *SMTPMailer) Send(message Message) error {
+	body, err := buildMimeMessage(message)
+
+	if err != nil {
+		return err
+	}
+
+	return smtp.SendMail(mailer.addr, mailer.auth, fromEmail, []string{message.ToEmail}, body)
+}
+
+/*
+Builds a MIME mail with a plain text and a HTML part
+*/
+func buildMimeMessage(message Message) ([]byte, error) {
+	var body bytes.Buffer
+
+	boundaryBytes := make([]byte, 16)
+	_, err := rand.Read(boundaryBytes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	boundary := hex.EncodeToString(boundaryBytes)
+	to := mime.QEncoding.Encode("utf-8", message.ToName) + " <" + message.ToEmail + ">"
+
+	body.WriteString("From: " + fromName + " <" + fromEmail + ">\r\n")
+	body.WriteString("To: " + to + "\r\n")
+	body.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", message.Subject) + "\r\n")
+	body.WriteString("MIME-Version: 1.0\r\n")
+	body.WriteString("Content-Type: multipart/alternative; boundary=" + boundary + "\r\n\r\n")
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{{"text/plain", message.Text}, {"text/html", message.Html}}
+
+	for i := 0; i < len(parts); i++ {
+		body.WriteString("--" + boundary + "\r\n")
+		body.WriteString("Content-Type: " + parts[i].contentType + "; charset=utf-8\r\n")
+		body.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+		writer := quotedprintable.NewWriter(&body)
+		_, err = writer.Write([]byte(parts[i].content))
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = writer.Close()
+
+		if err != nil {
+			return nil, err
+		}
+
+		body.WriteString("\r\n")
+	}
+
+	body.WriteString("--" + boundary + "--\r\n")
+
+	return body.Bytes(), nil
+}
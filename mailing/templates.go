@@ -0,0 +1,144 @@
+package mailing
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+const (
+	OtpMail                 = "otp"
+	OrderConfirmationMail   = "order_confirmation"
+	OrderShippedMail        = "order_shipped"
+	RefundMail              = "refund"
+	PasswordResetMail       = "password_reset"
+	PreOrderReleasedMail    = "preorder_released"
+	PreOrderReleaseDateMail = "preorder_release_date"
+	FollowDigestMail        = "follow_digest"
+	NotificationMail        = "notification"
+	DefaultLocale           = "en"
+)
+
+// Every locale has a text and a HTML template for every event, and a layout that wraps the HTML templates
+//
+//go:embed templates
+var templateFiles embed.FS
+
+var events = []string{OtpMail, OrderConfirmationMail, OrderShippedMail, RefundMail, PasswordResetMail,
+	PreOrderReleasedMail, PreOrderReleaseDateMail, FollowDigestMail, NotificationMail}
+
+var locales = []string{"en", "es"}
+
+var templateFuncs = map[string]interface{}{"price": FormatPrice}
+
+/*
+Gets the events that mails are sent for
+*/
+func Events() []string {
+	return append([]string{}, events...)
+}
+
+/*
+Gets the locales that mails are written in
+*/
+func Locales() []string {
+	return append([]string{}, locales...)
+}
+
+/*
+Gets the supported locale closest to the given locale. Regional locales such as es-MX fall back to their language
+and unsupported locales fall back to the default locale.
+*/
+func GetLocale(locale string) string {
+	language := strings.ToLower(strings.SplitN(strings.ReplaceAll(locale, "_", "-"), "-", 2)[0])
+
+	for i := 0; i < len(locales); i++ {
+		if locales[i] == language {
+			return language
+		}
+	}
+
+	return DefaultLocale
+}
+
+/*
+Renders the subject, text and HTML of the mail for an event in a locale with the given data
+*/
+func Render(event string, locale string, data interface{}) (Message, error) {
+	var message Message
+
+	if !IsEvent(event) {
+		return message, errors.New("Error in rendering mail, unknown mail event " + event)
+	}
+
+	dir := "templates/" + GetLocale(locale) + "/"
+
+	textTemplate, err := texttemplate.New(event).Funcs(templateFuncs).ParseFS(templateFiles, dir+event+".txt")
+
+	if err != nil {
+		return message, err
+	}
+
+	htmlTemplate, err := htmltemplate.New(event).Funcs(templateFuncs).ParseFS(templateFiles, dir+"layout.html", dir+event+".html")
+
+	if err != nil {
+		return message, err
+	}
+
+	var subject, text, html bytes.Buffer
+
+	err = textTemplate.ExecuteTemplate(&subject, "subject", data)
+
+	if err != nil {
+		return message, err
+	}
+
+	err = textTemplate.ExecuteTemplate(&text, "text", data)
+
+	if err != nil {
+		return message, err
+	}
+
+	err = htmlTemplate.ExecuteTemplate(&html, "layout", data)
+
+	if err != nil {
+		return message, err
+	}
+
+	message.Subject = strings.TrimSpace(subject.String())
+	message.Text = strings.TrimSpace(text.String())
+	message.Html = strings.TrimSpace(html.String())
+
+	return message, nil
+}
+
+/*
+Renders the mail for an event in a locale with sample data, so that admins can see what buyers receive
+*/
+func Preview(event string, locale string) (Message, error) {
+	return Render(event, locale, sampleData[event])
+}
+
+/*
+Checks wether mails are sent for an event
+*/
+func IsEvent(event string) bool {
+	for i := 0; i < len(events); i++ {
+		if events[i] == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+Formats a price in cents as dollars
+*/
+func FormatPrice(price int) string {
+	return fmt.Sprintf("$%d.%02d", price/100, price%100)
+}
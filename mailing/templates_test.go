@@ -0,0 +1,67 @@
+package mailing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	//Test 1: Every event renders in every locale
+	for _, locale := range Locales() {
+		for _, event := range Events() {
+			message, err := Preview(event, locale)
+			assert.NoError(t, err, event+" "+locale)
+			assert.NotEmpty(t, message.Subject, event+" "+locale)
+			assert.NotEmpty(t, message.Text, event+" "+locale)
+			assert.Contains(t, message.Html, "<html lang=\""+locale+"\">", event+" "+locale)
+		}
+	}
+
+	//Test 2: Data is filled in and prices are formatted
+	message, err := Render(OrderConfirmationMail, "en", OrderConfirmationMailData{
+		OrderId: "test", Items: []OrderMailItem{{Title: "Charizard", Quantity: 2}}, Total: 1050})
+	assert.NoError(t, err)
+	assert.Equal(t, "Your order has been confirmed", message.Subject)
+	assert.Contains(t, message.Text, "- 2 x Charizard")
+	assert.Contains(t, message.Text, "Total paid: $10.50")
+	assert.Contains(t, message.Html, "<li>2 x Charizard</li>")
+
+	//Test 3: HTML is escaped in the HTML body only
+	message, err = Render(NotificationMail, "en", NotificationMailData{Title: "Test", Message: "<b>Sale</b>"})
+	assert.NoError(t, err)
+	assert.Equal(t, "<b>Sale</b>", message.Text)
+	assert.Contains(t, message.Html, "&lt;b&gt;Sale&lt;/b&gt;")
+
+	//Test 4: Regional and unsupported locales fall back
+	message, err = Render(OtpMail, "es-MX", OtpMailData{Otp: "000000"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Verifica tu correo electrónico", message.Subject)
+	message, err = Render(OtpMail, "fr", OtpMailData{Otp: "000000"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Verify your email address", message.Subject)
+
+	//Test 5: Unknown event
+	_, err = Render("wrong", "en", nil)
+	assert.Error(t, err)
+}
+
+func TestGetLocale(t *testing.T) {
+	//Test 1: Supported locale
+	assert.Equal(t, "es", GetLocale("es"))
+
+	//Test 2: Regional locale
+	assert.Equal(t, "es", GetLocale("ES_es"))
+
+	//Test 3: Unsupported or missing locale
+	assert.Equal(t, DefaultLocale, GetLocale("fr"))
+	assert.Equal(t, DefaultLocale, GetLocale(""))
+}
+
+func TestFormatPrice(t *testing.T) {
+	//Test 1: Cents are padded
+	assert.Equal(t, "$12.05", FormatPrice(1205))
+
+	//Test 2: Prices under a dollar
+	assert.Equal(t, "$0.99", FormatPrice(99))
+}
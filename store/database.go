@@ -37,6 +37,31 @@ func SetupDB() (*sql.DB, error) {
 Function to initiate the DB connection and returns the DB connection
 */
 func initDB(isTest bool) (*sql.DB, error) {
+	postgresqlDbInfo, err := getDBConnectionInfo(isTest)
+
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("postgres", postgresqlDbInfo)
+	if err != nil {
+		return db, errors.New("The database connection could not be reated:" + err.Error())
+	}
+
+	err = db.Ping()
+	if err != nil {
+		return db, errors.New("Could not ping the database:" + err.Error())
+	}
+
+	log.Println("Established a successful connection!")
+
+	return db, nil
+}
+
+/*
+Function to get the connection string of the DB from the .env variables
+*/
+func getDBConnectionInfo(isTest bool) (string, error) {
 	var (
 		env      string
 		host     string
@@ -71,26 +96,14 @@ func initDB(isTest bool) (*sql.DB, error) {
 	}
 
 	if err != nil {
-		return nil, errors.New("The .env environments could not be loaded:" + err.Error())
+		return "", errors.New("The .env environments could not be loaded:" + err.Error())
 	}
 
 	postgresqlDbInfo := fmt.Sprintf("host=%s port=%d user=%s "+
 		"password=%s dbname=%s sslmode=%s",
 		host, port, user, password, dbname, sslmode)
 
-	db, err := sql.Open("postgres", postgresqlDbInfo)
-	if err != nil {
-		return db, errors.New("The database connection could not be reated:" + err.Error())
-	}
-
-	err = db.Ping()
-	if err != nil {
-		return db, errors.New("Could not ping the database:" + err.Error())
-	}
-
-	log.Println("Established a successful connection!")
-
-	return db, nil
+	return postgresqlDbInfo, nil
 }
 
 /*
@@ -135,6 +148,44 @@ func resetDB(db *sql.DB) {
 	queryResetSellers := `TRUNCATE sellers CASCADE;`
 	queryResetProducts := `TRUNCATE products CASCADE;`
 	queryResetProductImages := `TRUNCATE product_images CASCADE;`
+	queryResetProductImageUploads := `TRUNCATE product_image_uploads CASCADE;`
+	queryResetProductCardAttributes := `TRUNCATE product_card_attributes CASCADE;`
+	queryResetProductSealedAttributes := `TRUNCATE product_sealed_attributes CASCADE;`
+	queryResetAuctionInformation := `TRUNCATE auction_information CASCADE;`
+	queryResetAuctionBidders := `TRUNCATE auction_bidders CASCADE;`
+	queryResetAuctionBids := `TRUNCATE auction_bids CASCADE;`
+	queryResetOffers := `TRUNCATE offers CASCADE;`
+	queryResetDiscounts := `TRUNCATE discounts CASCADE;`
+	queryResetProductPriceHistory := `TRUNCATE product_price_history CASCADE;`
+	queryResetPromoCodes := `TRUNCATE promo_codes CASCADE;`
+	queryResetPromoCodeRedemptions := `TRUNCATE promo_code_redemptions CASCADE;`
+	queryResetCarts := `TRUNCATE carts CASCADE;`
+	queryResetCartProducts := `TRUNCATE cart_products CASCADE;`
+	queryResetSubOrders := `TRUNCATE sub_orders CASCADE;`
+	queryResetSubOrderRefunds := `TRUNCATE sub_order_refunds CASCADE;`
+	queryResetPayoutBatches := `TRUNCATE payout_batches CASCADE;`
+	queryResetPayouts := `TRUNCATE payouts CASCADE;`
+	queryResetLedgerTransactions := `TRUNCATE ledger_transactions CASCADE;`
+	queryResetLedgerEntries := `TRUNCATE ledger_entries CASCADE;`
+	queryResetReviews := `TRUNCATE reviews CASCADE;`
+	queryResetReviewImageUploads := `TRUNCATE review_image_uploads CASCADE;`
+	queryResetReviewImages := `TRUNCATE review_images CASCADE;`
+	queryResetSellerFollows := `TRUNCATE seller_follows CASCADE;`
+	queryResetNotifications := `TRUNCATE notifications CASCADE;`
+	queryResetNotificationPreferences := `TRUNCATE notification_preferences CASCADE;`
+	queryResetWatchlistProducts := `TRUNCATE watchlist_products CASCADE;`
+	queryResetSavedSearches := `TRUNCATE saved_searches CASCADE;`
+	queryResetSavedSearchMatches := `TRUNCATE saved_search_matches CASCADE;`
+	queryResetNewListings := `TRUNCATE new_listings CASCADE;`
+	queryResetOutboxMessages := `TRUNCATE outbox_messages CASCADE;`
+	queryResetJobs := `TRUNCATE jobs CASCADE;`
+	queryResetTelegramChats := `TRUNCATE telegram_chats CASCADE;`
+	queryResetTelegramLinkCodes := `TRUNCATE telegram_link_codes CASCADE;`
+	queryResetWebhooks := `TRUNCATE webhooks CASCADE;`
+	queryResetWebhookDeliveries := `TRUNCATE webhook_deliveries CASCADE;`
+	queryResetSellerApiKeys := `TRUNCATE seller_api_keys CASCADE;`
+	queryResetExpansions := `TRUNCATE expansions CASCADE;`
+	queryResetGames := `TRUNCATE games CASCADE;`
 
 	db.Exec(queryResetBuyerOtps)
 	db.Exec(queryResetGuestOrders)
@@ -143,6 +194,44 @@ func resetDB(db *sql.DB) {
 	db.Exec(queryResetSellers)
 	db.Exec(queryResetProducts)
 	db.Exec(queryResetProductImages)
+	db.Exec(queryResetProductImageUploads)
+	db.Exec(queryResetProductCardAttributes)
+	db.Exec(queryResetProductSealedAttributes)
+	db.Exec(queryResetAuctionInformation)
+	db.Exec(queryResetAuctionBidders)
+	db.Exec(queryResetAuctionBids)
+	db.Exec(queryResetOffers)
+	db.Exec(queryResetDiscounts)
+	db.Exec(queryResetProductPriceHistory)
+	db.Exec(queryResetPromoCodes)
+	db.Exec(queryResetPromoCodeRedemptions)
+	db.Exec(queryResetCarts)
+	db.Exec(queryResetCartProducts)
+	db.Exec(queryResetSubOrders)
+	db.Exec(queryResetSubOrderRefunds)
+	db.Exec(queryResetPayoutBatches)
+	db.Exec(queryResetPayouts)
+	db.Exec(queryResetLedgerTransactions)
+	db.Exec(queryResetLedgerEntries)
+	db.Exec(queryResetReviews)
+	db.Exec(queryResetReviewImageUploads)
+	db.Exec(queryResetReviewImages)
+	db.Exec(queryResetSellerFollows)
+	db.Exec(queryResetNotifications)
+	db.Exec(queryResetNotificationPreferences)
+	db.Exec(queryResetWatchlistProducts)
+	db.Exec(queryResetSavedSearches)
+	db.Exec(queryResetSavedSearchMatches)
+	db.Exec(queryResetNewListings)
+	db.Exec(queryResetOutboxMessages)
+	db.Exec(queryResetJobs)
+	db.Exec(queryResetTelegramChats)
+	db.Exec(queryResetTelegramLinkCodes)
+	db.Exec(queryResetWebhooks)
+	db.Exec(queryResetWebhookDeliveries)
+	db.Exec(queryResetSellerApiKeys)
+	db.Exec(queryResetExpansions)
+	db.Exec(queryResetGames)
 }
 
 /*
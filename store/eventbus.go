@@ -0,0 +1,210 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+const eventSubscriberBuffer = 16
+
+/*
+A publish and subscribe bus for events that are pushed to clients, such as live product updates
+*/
+type EventBus interface {
+	Publish(channel string, payload string) error
+	Subscribe(channel string) (<-chan string, func())
+}
+
+/*
+Event bus that only delivers events to subscribers in the same process. Used when running locally
+where there is a single API instance.
+*/
+type LocalEventBus struct {
+	mutex       sync.Mutex
+	subscribers map[string]map[chan string]bool
+}
+
+/*
+Event bus backed by Postgres LISTEN/NOTIFY, so that events published by any API instance are
+delivered to the subscribers of every instance
+*/
+type PostgresEventBus struct {
+	db       *sql.DB
+	listener *pq.Listener
+	local    *LocalEventBus
+	mutex    sync.Mutex
+	channels map[string]bool
+}
+
+/*
+Event bus that only publishes events through Postgres NOTIFY, used by instances that have no subscribers such as
+lambdas and workers, so that they do not hold a connection open to listen
+*/
+type NotifyEventBus struct {
+	db *sql.DB
+}
+
+/*
+Creates the event bus used for live updates. If API_ENV is local events are delivered in process, otherwise events
+are sent through Postgres. Only instances that have subscribers listen for events, the others just publish them.
+*/
+func CreateEventBus(db *sql.DB, hasSubscribers bool) (EventBus, error) {
+	if os.Getenv("API_ENV") == "local" {
+		return NewLocalEventBus(), nil
+	}
+
+	if !hasSubscribers {
+		return NewNotifyEventBus(db), nil
+	}
+
+	connectionInfo, err := getDBConnectionInfo(false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPostgresEventBus(db, connectionInfo), nil
+}
+
+/*
+Creates an in process event bus
+*/
+func NewLocalEventBus() *LocalEventBus {
+	return &LocalEventBus{subscribers: make(map[string]map[chan string]bool)}
+}
+
+/*
+Sends an event to every subscriber of the channel. Subscribers that are not keeping up miss the event
+instead of blocking the publisher.
+*/
+func (bus *LocalEventBus) Publish(channel string, payload string) error {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	for subscriber := range bus.subscribers[channel] {
+		select {
+		case subscriber <- payload:
+		default:
+			log.Println("Dropped event for slow subscriber on channel:", channel)
+		}
+	}
+
+	return nil
+}
+
+/*
+Subscribes to the events of a channel. The returned function removes the subscription and must be
+called once the subscriber is done.
+*/
+func (bus *LocalEventBus) Subscribe(channel string) (<-chan string, func()) {
+	subscriber := make(chan string, eventSubscriberBuffer)
+
+	bus.mutex.Lock()
+	if bus.subscribers[channel] == nil {
+		bus.subscribers[channel] = make(map[chan string]bool)
+	}
+	bus.subscribers[channel][subscriber] = true
+	bus.mutex.Unlock()
+
+	unsubscribe := func() {
+		bus.mutex.Lock()
+		defer bus.mutex.Unlock()
+		delete(bus.subscribers[channel], subscriber)
+	}
+
+	return subscriber, unsubscribe
+}
+
+/*
+Creates an event bus that only publishes events
+*/
+func NewNotifyEventBus(db *sql.DB) *NotifyEventBus {
+	return &NotifyEventBus{db: db}
+}
+
+/*
+Sends an event to the subscribers of every API instance through NOTIFY
+*/
+func (bus *NotifyEventBus) Publish(channel string, payload string) error {
+	return notifyEvent(bus.db, channel, payload)
+}
+
+/*
+Subscribing is not supported as the instance does not listen for events, the returned channel never gets an event
+*/
+func (bus *NotifyEventBus) Subscribe(channel string) (<-chan string, func()) {
+	log.Println("Cannot subscribe to event channel on a publish only event bus:", channel)
+	return make(chan string), func() {}
+}
+
+/*
+Creates an event bus that listens for notifications on a dedicated connection to the DB
+*/
+func NewPostgresEventBus(db *sql.DB, connectionInfo string) *PostgresEventBus {
+	bus := &PostgresEventBus{db: db, local: NewLocalEventBus(), channels: make(map[string]bool)}
+
+	bus.listener = pq.NewListener(connectionInfo, 10*time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Println("Event listener connection error:", err)
+		}
+	})
+
+	go bus.dispatch()
+
+	return bus
+}
+
+/*
+Sends an event to the subscribers of every API instance through NOTIFY
+*/
+func (bus *PostgresEventBus) Publish(channel string, payload string) error {
+	return notifyEvent(bus.db, channel, payload)
+}
+
+/*
+Subscribes to the events of a channel, the instance starts listening on the channel with its first subscriber
+*/
+func (bus *PostgresEventBus) Subscribe(channel string) (<-chan string, func()) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	if !bus.channels[channel] {
+		err := bus.listener.Listen(channel)
+
+		if err != nil && err != pq.ErrChannelAlreadyOpen {
+			log.Println("Could not listen on event channel:", err)
+		} else {
+			bus.channels[channel] = true
+		}
+	}
+
+	return bus.local.Subscribe(channel)
+}
+
+/*
+Delivers the notifications received by the listener to the subscribers of this instance
+*/
+func (bus *PostgresEventBus) dispatch() {
+	for notification := range bus.listener.Notify {
+		// A nil notification means the connection was re-established and events may have been missed
+		if notification == nil {
+			continue
+		}
+
+		bus.local.Publish(notification.Channel, notification.Extra)
+	}
+}
+
+/*
+Sends an event on a channel through NOTIFY
+*/
+func notifyEvent(db *sql.DB, channel string, payload string) error {
+	_, err := db.ExecContext(context.Background(), `SELECT pg_notify($1, $2);`, channel, payload)
+	return err
+}
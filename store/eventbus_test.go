@@ -0,0 +1,40 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalEventBus(t *testing.T) {
+	bus := NewLocalEventBus()
+
+	events1, unsubscribe1 := bus.Subscribe("products")
+	events2, unsubscribe2 := bus.Subscribe("products")
+	otherEvents, unsubscribeOther := bus.Subscribe("orders")
+	defer unsubscribeOther()
+
+	//Test 1: Event is delivered to every subscriber of the channel
+	err := bus.Publish("products", "event1")
+	assert.NoError(t, err)
+	assert.Equal(t, "event1", <-events1)
+	assert.Equal(t, "event1", <-events2)
+
+	//Test 2: Subscribers of other channels do not get the event
+	assert.Equal(t, 0, len(otherEvents))
+
+	//Test 3: Unsubscribed subscribers do not get later events
+	unsubscribe1()
+	err = bus.Publish("products", "event2")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(events1))
+	assert.Equal(t, "event2", <-events2)
+
+	//Test 4: Slow subscribers miss events instead of blocking the publisher
+	for i := 0; i < eventSubscriberBuffer+5; i++ {
+		err = bus.Publish("products", "event")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, eventSubscriberBuffer, len(events2))
+	unsubscribe2()
+}
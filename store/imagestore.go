@@ -0,0 +1,113 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+/*
+Information about an uploaded image object that is used to verify a direct upload
+*/
+type ImageObjectInfo struct {
+	Size        int64
+	ContentType string
+}
+
+/*
+Presigned upload that a client can use to upload an image directly to the image store
+without sending the image through the API
+*/
+type PresignedUpload struct {
+	Url     string
+	Method  string
+	Headers map[string]string
+}
+
+/*
+A store for product images that allows clients to upload images directly to it
+*/
+type ImageStore interface {
+	PresignImageUpload(key string, contentType string, size int64, expires time.Duration) (PresignedUpload, error)
+	StatImage(key string) (ImageObjectInfo, error)
+}
+
+/*
+Image store backed by the S3 bucket specified by the environment variables
+*/
+type S3ImageStore struct {
+	client *s3.Client
+	bucket string
+}
+
+/*
+Creates the image store used for direct uploads. If STORAGE_ENV is local images are stored
+on the local disk, otherwise images are stored in the S3 bucket.
+*/
+func CreateImageStore(client *s3.Client) (ImageStore, error) {
+	if os.Getenv("STORAGE_ENV") == "local" {
+		return CreateLocalImageStore()
+	}
+
+	bucket, hasBucket := os.LookupEnv("S3_BUCKET_NAME")
+
+	if !hasBucket {
+		return nil, errors.New("Error in loading environment variables, Bucket name does not exist:")
+	}
+
+	if client == nil {
+		return nil, errors.New("Error in creating image store, no S3 client")
+	}
+
+	return &S3ImageStore{client: client, bucket: bucket}, nil
+}
+
+/*
+Creates a presigned PUT url for an image that is restricted to the given content type and size
+*/
+func (store *S3ImageStore) PresignImageUpload(key string, contentType string, size int64, expires time.Duration) (PresignedUpload, error) {
+	var upload PresignedUpload
+	presignClient := s3.NewPresignClient(store.client, s3.WithPresignExpires(expires))
+
+	req, err := presignClient.PresignPutObject(context.Background(), &s3.PutObjectInput{
+		Bucket:        aws.String(store.bucket),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: size,
+	})
+
+	if err != nil {
+		return upload, errors.New("Error in presigning S3 upload:" + err.Error())
+	}
+
+	upload.Url = req.URL
+	upload.Method = req.Method
+	upload.Headers = map[string]string{"Content-Type": contentType}
+
+	return upload, nil
+}
+
+/*
+Gets the size and content type of an image in the S3 bucket
+*/
+func (store *S3ImageStore) StatImage(key string) (ImageObjectInfo, error) {
+	var info ImageObjectInfo
+
+	res, err := store.client.HeadObject(context.Background(), &s3.HeadObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(key),
+	})
+
+	if err != nil {
+		return info, errors.New("Error in fetching S3 object:" + err.Error())
+	}
+
+	info.Size = res.ContentLength
+	info.ContentType = aws.ToString(res.ContentType)
+
+	return info, nil
+}
@@ -0,0 +1,226 @@
+package store
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrBadUploadSignature = errors.New("Upload signature is invalid")
+	ErrUploadExpired      = errors.New("Upload url has expired")
+	ErrBadUploadContent   = errors.New("Uploaded content does not match the upload constraints")
+)
+
+/*
+Image store that keeps images on the local disk. Uploads are sent to the API's local storage
+endpoint with a signed url, which mirrors how presigned S3 urls work.
+*/
+type LocalImageStore struct {
+	dir     string
+	baseUrl string
+	secret  string
+}
+
+/*
+Metadata stored next to each locally stored image
+*/
+type localImageMeta struct {
+	ContentType string `json:"content_type"`
+}
+
+/*
+Creates a local image store using the LOCAL_STORAGE_PATH, LOCAL_STORAGE_SECRET and
+API_BASE_URL environment variables
+*/
+func CreateLocalImageStore() (*LocalImageStore, error) {
+	var (
+		dir, hasDir         = os.LookupEnv("LOCAL_STORAGE_PATH")
+		secret, hasSecret   = os.LookupEnv("LOCAL_STORAGE_SECRET")
+		baseUrl, hasBaseUrl = os.LookupEnv("API_BASE_URL")
+	)
+
+	if !(hasDir && hasSecret && hasBaseUrl) {
+		return nil, errors.New("Error in loading environment variables for local storage")
+	}
+
+	return NewLocalImageStore(dir, baseUrl, secret)
+}
+
+/*
+Creates a local image store that stores images in dir and signs upload urls that point to baseUrl
+*/
+func NewLocalImageStore(dir string, baseUrl string, secret string) (*LocalImageStore, error) {
+	err := os.MkdirAll(dir, 0755)
+
+	if err != nil {
+		return nil, errors.New("Error in creating local storage directory:" + err.Error())
+	}
+
+	return &LocalImageStore{dir: dir, baseUrl: strings.TrimSuffix(baseUrl, "/"), secret: secret}, nil
+}
+
+/*
+Creates a signed PUT url to the local storage endpoint that is restricted to the given content type and size
+*/
+func (store *LocalImageStore) PresignImageUpload(key string, contentType string, size int64, expires time.Duration) (PresignedUpload, error) {
+	var upload PresignedUpload
+
+	if !isValidLocalKey(key) {
+		return upload, errors.New("Error in presigning local upload: bad key")
+	}
+
+	expiresAt := strconv.FormatInt(time.Now().Add(expires).Unix(), 10)
+	sizeStr := strconv.FormatInt(size, 10)
+
+	params := url.Values{}
+	params.Set("content_type", contentType)
+	params.Set("size", sizeStr)
+	params.Set("expires", expiresAt)
+	params.Set("signature", store.sign(key, contentType, sizeStr, expiresAt))
+
+	upload.Url = store.baseUrl + "/api/v1/local-storage/" + key + "?" + params.Encode()
+	upload.Method = "PUT"
+	upload.Headers = map[string]string{"Content-Type": contentType}
+
+	return upload, nil
+}
+
+/*
+Gets the size and content type of a locally stored image
+*/
+func (store *LocalImageStore) StatImage(key string) (ImageObjectInfo, error) {
+	var info ImageObjectInfo
+	var meta localImageMeta
+
+	if !isValidLocalKey(key) {
+		return info, errors.New("Error in fetching local object: bad key")
+	}
+
+	stat, err := os.Stat(store.path(key))
+
+	if err != nil {
+		return info, errors.New("Error in fetching local object:" + err.Error())
+	}
+
+	metaBytes, err := os.ReadFile(store.path(key) + ".meta")
+
+	if err != nil {
+		return info, errors.New("Error in fetching local object metadata:" + err.Error())
+	}
+
+	err = json.Unmarshal(metaBytes, &meta)
+
+	if err != nil {
+		return info, errors.New("Error in fetching local object metadata:" + err.Error())
+	}
+
+	info.Size = stat.Size()
+	info.ContentType = meta.ContentType
+
+	return info, nil
+}
+
+/*
+Saves an image uploaded to a signed url. The signature, expiry, content type and size of the
+upload are checked against the values in the signed url.
+*/
+func (store *LocalImageStore) SaveImage(key string, params url.Values, contentType string, body io.Reader) error {
+	if !isValidLocalKey(key) {
+		return ErrBadUploadSignature
+	}
+
+	signedType := params.Get("content_type")
+	sizeStr := params.Get("size")
+	expiresAt := params.Get("expires")
+	signature := params.Get("signature")
+
+	expected := store.sign(key, signedType, sizeStr, expiresAt)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrBadUploadSignature
+	}
+
+	expiry, err := strconv.ParseInt(expiresAt, 10, 64)
+	if err != nil || time.Now().Unix() > expiry {
+		return ErrUploadExpired
+	}
+
+	size, err := strconv.ParseInt(sizeStr, 10, 64)
+	if err != nil || contentType != signedType {
+		return ErrBadUploadContent
+	}
+
+	// Read one byte past the signed size so oversized uploads can be rejected
+	content, err := io.ReadAll(io.LimitReader(body, size+1))
+	if err != nil {
+		return errors.New("Error in reading upload:" + err.Error())
+	}
+
+	if int64(len(content)) != size {
+		return ErrBadUploadContent
+	}
+
+	err = os.WriteFile(store.path(key), content, 0644)
+	if err != nil {
+		return errors.New("Error in writing local object:" + err.Error())
+	}
+
+	metaBytes, _ := json.Marshal(localImageMeta{ContentType: contentType})
+	err = os.WriteFile(store.path(key)+".meta", metaBytes, 0644)
+	if err != nil {
+		return errors.New("Error in writing local object metadata:" + err.Error())
+	}
+
+	return nil
+}
+
+/*
+Opens a locally stored image so that it can be served
+*/
+func (store *LocalImageStore) OpenImage(key string) (*os.File, ImageObjectInfo, error) {
+	info, err := store.StatImage(key)
+
+	if err != nil {
+		return nil, info, err
+	}
+
+	file, err := os.Open(store.path(key))
+
+	if err != nil {
+		return nil, info, errors.New("Error in opening local object:" + err.Error())
+	}
+
+	return file, info, nil
+}
+
+/*
+Signs the constraints of an upload with the local storage secret
+*/
+func (store *LocalImageStore) sign(key string, contentType string, size string, expires string) string {
+	mac := hmac.New(sha256.New, []byte(store.secret))
+	mac.Write([]byte(key + "\n" + contentType + "\n" + size + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+/*
+Gets the path on disk of a locally stored image
+*/
+func (store *LocalImageStore) path(key string) string {
+	return filepath.Join(store.dir, key)
+}
+
+/*
+Checks that a key is a plain file name so uploads cannot escape the storage directory
+*/
+func isValidLocalKey(key string) bool {
+	return key != "" && key != "." && key != ".." && !strings.ContainsAny(key, `/\`) && filepath.Base(key) == key
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalImageStoreUpload(t *testing.T) {
+	imageStore, err := NewLocalImageStore(t.TempDir(), "http://localhost:8080", "secret")
+	assert.NoError(t, err)
+
+	content := []byte("fake png content")
+	size := int64(len(content))
+
+	//Test 1: Presigned upload points to the local storage endpoint
+	upload, err := imageStore.PresignImageUpload("image1", "image/png", size, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, "PUT", upload.Method)
+	assert.Equal(t, "image/png", upload.Headers["Content-Type"])
+	uploadUrl, err := url.Parse(upload.Url)
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/v1/local-storage/image1", uploadUrl.Path)
+
+	//Test 2: Image does not exist before upload
+	_, err = imageStore.StatImage("image1")
+	assert.Error(t, err)
+
+	//Test 3: Wrong content type is rejected
+	err = imageStore.SaveImage("image1", uploadUrl.Query(), "image/jpeg", bytes.NewReader(content))
+	assert.Equal(t, ErrBadUploadContent, err)
+
+	//Test 4: Wrong size is rejected
+	err = imageStore.SaveImage("image1", uploadUrl.Query(), "image/png", bytes.NewReader(append(content, 'x')))
+	assert.Equal(t, ErrBadUploadContent, err)
+
+	//Test 5: Upload to a different key is rejected
+	err = imageStore.SaveImage("image2", uploadUrl.Query(), "image/png", bytes.NewReader(content))
+	assert.Equal(t, ErrBadUploadSignature, err)
+
+	//Test 6: Tampered size is rejected
+	params := uploadUrl.Query()
+	params.Set("size", "100")
+	err = imageStore.SaveImage("image1", params, "image/png", bytes.NewReader(content))
+	assert.Equal(t, ErrBadUploadSignature, err)
+
+	//Test 7: Upload successful and can be verified
+	err = imageStore.SaveImage("image1", uploadUrl.Query(), "image/png", bytes.NewReader(content))
+	assert.NoError(t, err)
+	info, err := imageStore.StatImage("image1")
+	assert.NoError(t, err)
+	assert.Equal(t, size, info.Size)
+	assert.Equal(t, "image/png", info.ContentType)
+
+	//Test 8: Expired upload is rejected
+	upload, err = imageStore.PresignImageUpload("image3", "image/png", size, -time.Minute)
+	assert.NoError(t, err)
+	uploadUrl, _ = url.Parse(upload.Url)
+	err = imageStore.SaveImage("image3", uploadUrl.Query(), "image/png", bytes.NewReader(content))
+	assert.Equal(t, ErrUploadExpired, err)
+
+	//Test 9: Keys that escape the storage directory are rejected
+	_, err = imageStore.PresignImageUpload("../image1", "image/png", size, time.Minute)
+	assert.Error(t, err)
+}
@@ -33,128 +33,1474 @@ func createTables(db *sql.DB) error {
 		return err
 	}
 
+	err = createGamesTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createLanguagesTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createExpansionsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createExpansionLanguagesTable(db)
+
+	if err != nil {
+		return err
+	}
+
 	err = createProductsTable(db)
 
 	if err != nil {
 		return err
 	}
 
-	err = createPreorderInformationTable(db)
+	err = createProductsSearchIndex(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createPreorderInformationTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createProductDiscountsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createProductCardAttributesTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createProductSealedAttributesTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createProductImagesTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createProductImageUploadsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createOrdersTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createOrderProductsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createGuestOrdersTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createGuestOrderProductsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createAuctionInformationTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createAuctionBiddersTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createAuctionBidsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createOffersTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createDiscountsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createProductEffectiveDiscountsView(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createProductPriceHistoryTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createPromoCodesTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createPromoCodeSellersTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createPromoCodeExpansionsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createPromoCodeRedemptionsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = addOrderDiscountColumns(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createCartsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createCartProductsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createSubOrdersTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = addOrderProductSubOrderColumns(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createSubOrderRefundsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = addSubOrderDeliveredDateColumn(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createPayoutBatchesTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createPayoutsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createLedgerTransactionsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createLedgerEntriesTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createReviewsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createReviewImageUploadsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createReviewImagesTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createSellerRatingsView(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createSellerFollowsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = addBuyerFollowDigestColumns(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createNotificationsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createNotificationPreferencesTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createWatchlistProductsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createSavedSearchesTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createSavedSearchMatchesTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createNewListingsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = addBuyerLocaleColumn(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createOutboxMessagesTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createJobsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createTelegramChatsTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createTelegramLinkCodesTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createWebhooksTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createWebhookDeliveriesTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = createSellerApiKeysTable(db)
+
+	if err != nil {
+		return err
+	}
+
+	err = seedCatalogue(db)
+
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+/*
+Install and Postgres Extensions
+*/
+func installExtensions(db *sql.DB) error {
+	uuidExtensionQuery := `CREATE EXTENSION IF NOT EXISTS "uuid-ossp";`
+	_, err := db.ExecContext(context.Background(), uuidExtensionQuery)
+	return err
+}
+
+/*
+Create the table for Buyers
+*/
+func createBuyersTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS buyers(
+		buyer_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		email VARCHAR NOT NULL UNIQUE,
+		password VARCHAR NOT NULL,
+		verification VARCHAR NOT NULL DEFAULT 'pending',
+		PRIMARY KEY(buyer_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Buyer Otps
+*/
+func createBuyerOtpsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS buyer_otps(
+		buyer_id uuid REFERENCES buyers(buyer_id) NOT NULL,
+		email_otp VARCHAR NOT NULL,
+		PRIMARY KEY(buyer_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Sellers
+*/
+func createSellersTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS sellers(
+		seller_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		email VARCHAR NOT NULL UNIQUE,
+		password VARCHAR NOT NULL,
+		seller_name VARCHAR NOT NULL UNIQUE,
+		followers INT DEFAULT 0 NOT NULL,
+		PRIMARY KEY(seller_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Games, the trading card games that expansions belong to
+*/
+func createGamesTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS games(
+		game_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		code VARCHAR NOT NULL UNIQUE,
+		name VARCHAR NOT NULL,
+		PRIMARY KEY(game_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Languages that products can be listed in, the languages
+that were previously hardcoded are added if they do not exist
+*/
+func createLanguagesTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS languages(
+		language_code VARCHAR NOT NULL,
+		name VARCHAR NOT NULL,
+		PRIMARY KEY(language_code));`
+
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `INSERT INTO languages(language_code, name)
+		VALUES ('Eng', 'English'), ('Jap', 'Japanese')
+		ON CONFLICT DO NOTHING;`
+
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Expansions, the sets that products are from
+*/
+func createExpansionsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS expansions(
+		expansion_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		game_id uuid REFERENCES games(game_id) NOT NULL,
+		code VARCHAR NOT NULL UNIQUE,
+		name VARCHAR NOT NULL,
+		release_date DATE,
+		PRIMARY KEY(expansion_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for the languages an expansion was released in
+*/
+func createExpansionLanguagesTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS expansion_languages(
+		expansion_id uuid REFERENCES expansions(expansion_id) ON DELETE CASCADE NOT NULL,
+		language_code VARCHAR REFERENCES languages(language_code) NOT NULL,
+		PRIMARY KEY(expansion_id, language_code));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Adds the expansions of existing products to the catalogue so that products listed before the
+catalogue existed stay valid. They are added to a default game until they are updated by an admin.
+*/
+func seedCatalogue(db *sql.DB) error {
+	query := `INSERT INTO games(code, name)
+		SELECT 'pokemon', 'Pokemon TCG'
+		WHERE EXISTS(SELECT * FROM products)
+		ON CONFLICT DO NOTHING;`
+
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `INSERT INTO expansions(game_id, code, name)
+		SELECT games.game_id, products.expansion, products.expansion
+		FROM (SELECT DISTINCT expansion FROM products) products, games
+		WHERE games.code = 'pokemon'
+		ON CONFLICT DO NOTHING;`
+
+	_, err = db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `INSERT INTO expansion_languages(expansion_id, language_code)
+		SELECT DISTINCT expansions.expansion_id, products.language
+		FROM (products INNER JOIN expansions ON expansions.code = products.expansion)
+			INNER JOIN languages ON languages.language_code = products.language
+		ON CONFLICT DO NOTHING;`
+
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Products
+*/
+func createProductsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS products(
+		product_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		seller_id uuid REFERENCES sellers(seller_id),
+		title TEXT NOT NULL,
+		description TEXT NOT NULL,
+		image_count INT NOT NULL DEFAULT 0,
+		condition INT NOT NULL CONSTRAINT isOutOfFive CHECK (condition >= 0 AND condition <= 5),
+		price INT NOT NULL CONSTRAINT isPositive CHECK (price >= 0),
+		product_type VARCHAR NOT NULL,
+		posted_date TIMESTAMPTZ NOT NULL,
+		product_quantity INT NOT NULL,
+		sold_quantity INT DEFAULT 0 NOT NULL,
+		language VARCHAR DEFAULT 'ENG' NOT NULL,
+		expansion VARCHAR NOT NULL,
+		PRIMARY KEY(product_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Add the full text search column and index for Products. Titles are weighted above descriptions
+when ranking search results.
+*/
+func createProductsSearchIndex(db *sql.DB) error {
+	query := `ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('english', title), 'A') ||
+			setweight(to_tsvector('english', description), 'B')) STORED;`
+
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE INDEX IF NOT EXISTS products_search_vector_idx ON products USING GIN(search_vector);`
+
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create Pre Order Information table. The status of a pre-order is 'open' until its release date,
+'released' after it and 'converted' once the seller turns it into a Buy-Now listing.
+*/
+func createPreorderInformationTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS preorder_information(
+		product_id uuid REFERENCES products(product_id) NOT NULL,
+		order_by TIMESTAMPTZ NOT NULL,
+		releases_on TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(product_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `ALTER TABLE preorder_information
+		ADD COLUMN IF NOT EXISTS status VARCHAR NOT NULL DEFAULT 'open',
+		ADD COLUMN IF NOT EXISTS released_at TIMESTAMPTZ;`
+
+	_, err = db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE INDEX IF NOT EXISTS preorder_information_release_idx ON preorder_information(releases_on)
+		WHERE status = 'open';`
+
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create Product Discounts table
+*/
+func createProductDiscountsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS product_discounts(
+		product_id uuid REFERENCES products(product_id) NOT NULL,
+		discount INT NOT NULL,
+		PRIMARY KEY(product_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for the attributes of single card products
+*/
+func createProductCardAttributesTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS product_card_attributes(
+		product_id uuid REFERENCES products(product_id) NOT NULL,
+		card_number VARCHAR NOT NULL,
+		rarity VARCHAR NOT NULL DEFAULT '',
+		finish VARCHAR NOT NULL,
+		edition VARCHAR NOT NULL,
+		grading_company VARCHAR,
+		grade NUMERIC(3,1) CONSTRAINT isValidGrade CHECK (grade >= 1 AND grade <= 10),
+		PRIMARY KEY(product_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE INDEX IF NOT EXISTS product_card_attributes_grade_idx ON product_card_attributes(grading_company, grade);`
+
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for the attributes of sealed products such as booster boxes and packs
+*/
+func createProductSealedAttributesTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS product_sealed_attributes(
+		product_id uuid REFERENCES products(product_id) NOT NULL,
+		sealed_type VARCHAR NOT NULL,
+		pack_count INT NOT NULL DEFAULT 0,
+		PRIMARY KEY(product_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Product Images
+*/
+func createProductImagesTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS product_images(
+		product_image_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		product_id uuid REFERENCES products(product_id),
+		image_no INT NOT NULL,
+		PRIMARY KEY(product_image_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Product Image Uploads, which tracks direct uploads of product images
+until they are confirmed
+*/
+func createProductImageUploadsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS product_image_uploads(
+		upload_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		product_id uuid REFERENCES products(product_id) NOT NULL,
+		image_no INT NOT NULL,
+		content_type VARCHAR NOT NULL,
+		size BIGINT NOT NULL,
+		status VARCHAR DEFAULT 'pending' NOT NULL,
+		expires_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(upload_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Orders
+*/
+func createOrdersTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS orders(
+		order_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		buyer_id uuid REFERENCES buyers(buyer_id) NOT NULL,
+		delivery_type VARCHAR NOT NULL,
+		payment_type VARCHAR NOT NULL,
+		payment_status VARCHAR DEFAULT 'pending' NOT NULL,
+		phone_number VARCHAR NOT NULL,
+		order_date TIMESTAMPTZ NOT NULL,
+		address_line_1 VARCHAR NOT NULL,
+		address_line_2 VARCHAR,
+		postal_code VARCHAR NOT NULL,
+		telegram_handle VARCHAR,
+		delivery_fee INT NOT NULL,
+		payment_fee INT NOT NULL,
+		small_order_fee INT NOT NULL,
+		total_paid INT NOT NULL,
+		PRIMARY KEY(order_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create table for products in a order
+*/
+func createOrderProductsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS order_products(
+			order_id uuid REFERENCES orders(order_id),
+			product_id uuid REFERENCES products(product_id),
+			quantity INT NOT NULL,
+			PRIMARY KEY(order_id, product_id)
+		);`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Guest Orders
+*/
+func createGuestOrdersTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS guest_orders(
+		guest_order_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		delivery_type VARCHAR NOT NULL,
+		payment_type VARCHAR NOT NULL,
+		payment_status VARCHAR DEFAULT 'pending' NOT NULL,
+		phone_number VARCHAR NOT NULL,
+		email VARCHAR NOT NULL,
+		order_date TIMESTAMPTZ NOT NULL,
+		address_line_1 VARCHAR NOT NULL,
+		address_line_2 VARCHAR,
+		postal_code VARCHAR NOT NULL,
+		telegram_handle VARCHAR,
+		delivery_fee INT NOT NULL,
+		payment_fee INT NOT NULL,
+		small_order_fee INT NOT NULL,
+		total_paid INT NOT NULL,
+		PRIMARY KEY(guest_order_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create table for products in a guest order
+*/
+func createGuestOrderProductsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS guest_order_products(
+			guest_order_id uuid REFERENCES guest_orders(guest_order_id),
+			product_id uuid REFERENCES products(product_id),
+			quantity INT NOT NULL,
+			PRIMARY KEY(guest_order_id, product_id)
+		);`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for the information of auction products. The current price of an auction is kept in the
+price of the product so that auctions are sorted and filtered by their current price.
+*/
+func createAuctionInformationTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS auction_information(
+		product_id uuid REFERENCES products(product_id) NOT NULL,
+		start_price INT NOT NULL CONSTRAINT isPositive CHECK (start_price >= 0),
+		reserve_price INT NOT NULL DEFAULT 0,
+		starts_at TIMESTAMPTZ NOT NULL,
+		ends_at TIMESTAMPTZ NOT NULL,
+		extension_seconds INT NOT NULL,
+		bid_count INT NOT NULL DEFAULT 0,
+		high_bidder_id uuid REFERENCES buyers(buyer_id),
+		status VARCHAR NOT NULL DEFAULT 'open',
+		order_id uuid REFERENCES orders(order_id),
+		payment_url VARCHAR,
+		PRIMARY KEY(product_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE INDEX IF NOT EXISTS auction_information_ends_at_idx ON auction_information(ends_at) WHERE status = 'open';`
+
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for the checkout details that buyers register before bidding on an auction,
+which are used for the order of the winning bidder
+*/
+func createAuctionBiddersTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS auction_bidders(
+		product_id uuid REFERENCES products(product_id) NOT NULL,
+		buyer_id uuid REFERENCES buyers(buyer_id) NOT NULL,
+		phone_number VARCHAR NOT NULL,
+		address_line_1 VARCHAR NOT NULL,
+		address_line_2 VARCHAR,
+		postal_code VARCHAR NOT NULL,
+		telegram_handle VARCHAR,
+		delivery_type VARCHAR NOT NULL,
+		payment_type VARCHAR NOT NULL,
+		PRIMARY KEY(product_id, buyer_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for the bids on auctions
+*/
+func createAuctionBidsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS auction_bids(
+		bid_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		product_id uuid REFERENCES products(product_id) NOT NULL,
+		buyer_id uuid REFERENCES buyers(buyer_id) NOT NULL,
+		amount INT NOT NULL,
+		bid_date TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(bid_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE INDEX IF NOT EXISTS auction_bids_product_idx ON auction_bids(product_id, amount DESC);`
+
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Offers, the prices that buyers propose on Buy-Now products. An accepted offer
+reserves its quantity at the agreed price for the buyer until reserved_until. A buyer can only have
+one open offer on a product.
+*/
+func createOffersTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS offers(
+		offer_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		product_id uuid REFERENCES products(product_id) NOT NULL,
+		buyer_id uuid REFERENCES buyers(buyer_id) NOT NULL,
+		quantity INT NOT NULL DEFAULT 1 CONSTRAINT isPositiveQuantity CHECK (quantity > 0),
+		amount INT NOT NULL CONSTRAINT isPositiveAmount CHECK (amount > 0),
+		counter_amount INT,
+		status VARCHAR NOT NULL DEFAULT 'pending',
+		created_date TIMESTAMPTZ NOT NULL,
+		expires_at TIMESTAMPTZ NOT NULL,
+		reserved_until TIMESTAMPTZ,
+		order_id uuid REFERENCES orders(order_id),
+		PRIMARY KEY(offer_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE UNIQUE INDEX IF NOT EXISTS offers_open_idx ON offers(product_id, buyer_id)
+		WHERE status IN ('pending', 'countered', 'accepted');`
+
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Discounts, the sales that sellers run on a product or, without a product, on
+every product they sell. Discounts are either a fixed amount in cents or a percentage of the price and
+apply between starts_at and ends_at, a discount without an end runs until it is cancelled.
+*/
+func createDiscountsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS discounts(
+		discount_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		seller_id uuid REFERENCES sellers(seller_id) NOT NULL,
+		product_id uuid REFERENCES products(product_id),
+		discount_type VARCHAR NOT NULL,
+		amount INT NOT NULL CONSTRAINT isPositiveDiscount CHECK (amount > 0),
+		starts_at TIMESTAMPTZ NOT NULL,
+		ends_at TIMESTAMPTZ,
+		status VARCHAR NOT NULL DEFAULT 'active',
+		created_date TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(discount_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE INDEX IF NOT EXISTS discounts_seller_idx ON discounts(seller_id) WHERE status = 'active';`
+
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the view of the discount that currently applies to each product. The best of the listing discount
+and the running sales of the product's seller applies, and a discount is never more than the price.
+Auctions are never discounted.
+*/
+func createProductEffectiveDiscountsView(db *sql.DB) error {
+	query := `CREATE OR REPLACE VIEW product_effective_discounts AS
+		SELECT products.product_id, LEAST(products.price, GREATEST(
+			COALESCE((
+				SELECT product_discounts.discount FROM product_discounts
+				WHERE product_discounts.product_id = products.product_id), 0),
+			COALESCE((
+				SELECT MAX(CASE WHEN discounts.discount_type = 'percentage'
+					THEN products.price * discounts.amount / 100 ELSE discounts.amount END)
+				FROM discounts
+				WHERE discounts.seller_id = products.seller_id
+					AND (discounts.product_id IS NULL OR discounts.product_id = products.product_id)
+					AND discounts.status = 'active' AND discounts.starts_at <= NOW()
+					AND (discounts.ends_at IS NULL OR discounts.ends_at > NOW())), 0))) AS discount
+		FROM products
+		WHERE products.product_type <> 'Auction';`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Product Price History, a row is recorded whenever the price or effective
+price of a product changes
+*/
+func createProductPriceHistoryTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS product_price_history(
+		product_id uuid REFERENCES products(product_id) NOT NULL,
+		price INT NOT NULL,
+		effective_price INT NOT NULL,
+		recorded_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(product_id, recorded_at));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Promo Codes, the voucher codes that buyers enter at checkout. Codes without a seller are
+run by the platform, codes with a seller only apply to the products of that seller. used_count holds the
+number of orders that currently hold a redemption of the code.
+*/
+func createPromoCodesTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS promo_codes(
+		promo_code_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		code VARCHAR NOT NULL UNIQUE,
+		seller_id uuid REFERENCES sellers(seller_id),
+		discount_type VARCHAR NOT NULL,
+		amount INT NOT NULL CONSTRAINT isPositivePromoAmount CHECK (amount > 0),
+		min_spend INT NOT NULL DEFAULT 0,
+		expires_at TIMESTAMPTZ,
+		max_uses INT,
+		max_uses_per_buyer INT,
+		first_order_only BOOLEAN NOT NULL DEFAULT FALSE,
+		used_count INT NOT NULL DEFAULT 0,
+		status VARCHAR NOT NULL DEFAULT 'active',
+		created_date TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(promo_code_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for the sellers a platform promo code is limited to, a code without sellers applies to every seller
+*/
+func createPromoCodeSellersTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS promo_code_sellers(
+		promo_code_id uuid REFERENCES promo_codes(promo_code_id) ON DELETE CASCADE,
+		seller_id uuid REFERENCES sellers(seller_id),
+		PRIMARY KEY(promo_code_id, seller_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for the expansions a promo code is limited to, a code without expansions applies to every expansion
+*/
+func createPromoCodeExpansionsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS promo_code_expansions(
+		promo_code_id uuid REFERENCES promo_codes(promo_code_id) ON DELETE CASCADE,
+		expansion VARCHAR NOT NULL,
+		PRIMARY KEY(promo_code_id, expansion));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Promo Code Redemptions, one for each order or guest order that used a promo code. A
+redemption is reserved when the order is created and is either redeemed or released once payment completes or fails.
+*/
+func createPromoCodeRedemptionsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS promo_code_redemptions(
+		redemption_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		promo_code_id uuid REFERENCES promo_codes(promo_code_id) NOT NULL,
+		buyer_id uuid REFERENCES buyers(buyer_id),
+		email VARCHAR,
+		order_id uuid REFERENCES orders(order_id),
+		guest_order_id uuid REFERENCES guest_orders(guest_order_id),
+		discount INT NOT NULL,
+		status VARCHAR NOT NULL DEFAULT 'reserved',
+		created_date TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(redemption_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE INDEX IF NOT EXISTS promo_code_redemptions_code_idx ON promo_code_redemptions(promo_code_id)
+		WHERE status <> 'released';`
+
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Add the promo code discount line to Orders and Guest Orders
+*/
+func addOrderDiscountColumns(db *sql.DB) error {
+	query := `ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount INT NOT NULL DEFAULT 0;`
+
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `ALTER TABLE guest_orders ADD COLUMN IF NOT EXISTS discount INT NOT NULL DEFAULT 0;`
+
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Carts. A buyer has a single cart that follows them across devices, while a guest cart
+is identified by a random token kept by the client.
+*/
+func createCartsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS carts(
+		cart_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		buyer_id uuid REFERENCES buyers(buyer_id) UNIQUE,
+		guest_token VARCHAR UNIQUE,
+		created_date TIMESTAMPTZ NOT NULL,
+		updated_date TIMESTAMPTZ NOT NULL,
+		CONSTRAINT hasCartOwner CHECK (buyer_id IS NOT NULL OR guest_token IS NOT NULL),
+		PRIMARY KEY(cart_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for the products in a cart. The price of the product when it was added is kept so that
+price changes can be shown to the buyer before checkout.
+*/
+func createCartProductsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS cart_products(
+		cart_id uuid REFERENCES carts(cart_id) ON DELETE CASCADE,
+		product_id uuid REFERENCES products(product_id),
+		quantity INT NOT NULL CONSTRAINT isPositiveCartQuantity CHECK (quantity > 0),
+		added_price INT NOT NULL,
+		added_date TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(cart_id, product_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Sub Orders, one for each seller in an order or guest order. Each seller ships their own
+products, so the delivery fee, status and refunds are kept per sub-order while the order keeps the one payment.
+*/
+func createSubOrdersTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS sub_orders(
+		sub_order_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		order_id uuid REFERENCES orders(order_id),
+		guest_order_id uuid REFERENCES guest_orders(guest_order_id),
+		seller_id uuid REFERENCES sellers(seller_id) NOT NULL,
+		products_cost INT NOT NULL,
+		discount INT NOT NULL DEFAULT 0,
+		delivery_fee INT NOT NULL DEFAULT 0,
+		refunded_amount INT NOT NULL DEFAULT 0,
+		status VARCHAR NOT NULL DEFAULT 'pending',
+		created_date TIMESTAMPTZ NOT NULL,
+		updated_date TIMESTAMPTZ NOT NULL,
+		CONSTRAINT hasOneParentOrder CHECK ((order_id IS NULL) <> (guest_order_id IS NULL)),
+		PRIMARY KEY(sub_order_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE INDEX IF NOT EXISTS sub_orders_seller_id_idx ON sub_orders(seller_id, created_date);`
+
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Add the sub-order each product of an Order and Guest Order is shipped in
+*/
+func addOrderProductSubOrderColumns(db *sql.DB) error {
+	query := `ALTER TABLE order_products ADD COLUMN IF NOT EXISTS sub_order_id uuid REFERENCES sub_orders(sub_order_id);`
+
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `ALTER TABLE guest_order_products ADD COLUMN IF NOT EXISTS sub_order_id uuid REFERENCES sub_orders(sub_order_id);`
+
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for the refunds of a sub-order, a sub-order can be refunded in parts up to its total
+*/
+func createSubOrderRefundsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS sub_order_refunds(
+		refund_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		sub_order_id uuid REFERENCES sub_orders(sub_order_id) NOT NULL,
+		amount INT NOT NULL CONSTRAINT isPositiveRefund CHECK (amount > 0),
+		reason VARCHAR,
+		created_date TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(refund_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Add the date a sub-order was delivered, which starts the dispute window before the seller is paid out
+*/
+func addSubOrderDeliveredDateColumn(db *sql.DB) error {
+	query := `ALTER TABLE sub_orders ADD COLUMN IF NOT EXISTS delivered_date TIMESTAMPTZ;`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Payout Batches, each batch pays out the available balance of every seller that is owed money
+*/
+func createPayoutBatchesTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS payout_batches(
+		batch_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		total INT NOT NULL,
+		created_date TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(batch_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Payouts, the amount paid out to a seller in a payout batch
+*/
+func createPayoutsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS payouts(
+		payout_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		batch_id uuid REFERENCES payout_batches(batch_id) NOT NULL,
+		seller_id uuid REFERENCES sellers(seller_id) NOT NULL,
+		amount INT NOT NULL CONSTRAINT isPositivePayout CHECK (amount > 0),
+		status VARCHAR NOT NULL DEFAULT 'pending',
+		created_date TIMESTAMPTZ NOT NULL,
+		paid_date TIMESTAMPTZ,
+		PRIMARY KEY(payout_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Ledger Transactions. Each transaction is a balanced set of ledger entries for a payment, refund,
+release of held funds or payout. A sub-order is only paid and released once.
+*/
+func createLedgerTransactionsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS ledger_transactions(
+		transaction_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		transaction_type VARCHAR NOT NULL,
+		sub_order_id uuid REFERENCES sub_orders(sub_order_id),
+		refund_id uuid REFERENCES sub_order_refunds(refund_id),
+		payout_id uuid REFERENCES payouts(payout_id),
+		created_date TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(transaction_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE UNIQUE INDEX IF NOT EXISTS ledger_transactions_sub_order_idx ON ledger_transactions(sub_order_id, transaction_type)
+		WHERE transaction_type IN ('payment', 'release');`
 
-	if err != nil {
-		return err
-	}
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
 
-	err = createProductDiscountsTable(db)
+/*
+Create the table for Ledger Entries. Amounts are in cents, debits are positive and credits are negative, so the
+entries of a transaction add up to zero. Seller accounts have the id of the seller.
+*/
+func createLedgerEntriesTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS ledger_entries(
+		entry_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		transaction_id uuid REFERENCES ledger_transactions(transaction_id) NOT NULL,
+		account VARCHAR NOT NULL,
+		seller_id uuid REFERENCES sellers(seller_id),
+		amount INT NOT NULL,
+		PRIMARY KEY(entry_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
 
 	if err != nil {
 		return err
 	}
 
-	err = createProductImagesTable(db)
+	query = `CREATE INDEX IF NOT EXISTS ledger_entries_seller_id_idx ON ledger_entries(seller_id, account);`
 
-	if err != nil {
-		return err
-	}
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
 
-	err = createOrdersTable(db)
+/*
+Create the table for Reviews. A buyer can review each seller of a completed order once and the seller can reply once.
+Hidden reviews are removed by a moderator and do not count towards the rating of the seller.
+*/
+func createReviewsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS reviews(
+		review_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		order_id uuid REFERENCES orders(order_id) NOT NULL,
+		sub_order_id uuid REFERENCES sub_orders(sub_order_id) NOT NULL,
+		seller_id uuid REFERENCES sellers(seller_id) NOT NULL,
+		buyer_id uuid REFERENCES buyers(buyer_id) NOT NULL,
+		rating INT NOT NULL CONSTRAINT isValidRating CHECK (rating BETWEEN 1 AND 5),
+		review_text VARCHAR NOT NULL DEFAULT '',
+		reply VARCHAR,
+		replied_date TIMESTAMPTZ,
+		status VARCHAR NOT NULL DEFAULT 'visible',
+		moderation_reason VARCHAR,
+		created_date TIMESTAMPTZ NOT NULL,
+		updated_date TIMESTAMPTZ NOT NULL,
+		UNIQUE(order_id, seller_id),
+		PRIMARY KEY(review_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
 
 	if err != nil {
 		return err
 	}
 
-	err = createOrderProductsTable(db)
+	query = `CREATE INDEX IF NOT EXISTS reviews_seller_id_idx ON reviews(seller_id, created_date);`
 
-	if err != nil {
-		return err
-	}
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
 
-	err = createGuestOrdersTable(db)
+/*
+Create the table for Review Image Uploads, the upload slots for the photos a buyer attaches to a review
+*/
+func createReviewImageUploadsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS review_image_uploads(
+		upload_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		buyer_id uuid REFERENCES buyers(buyer_id) NOT NULL,
+		image_no INT NOT NULL,
+		content_type VARCHAR NOT NULL,
+		size BIGINT NOT NULL,
+		status VARCHAR DEFAULT 'pending' NOT NULL,
+		expires_at TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(upload_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Review Images, the image id is the id of its completed upload
+*/
+func createReviewImagesTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS review_images(
+		review_image_id uuid NOT NULL,
+		review_id uuid REFERENCES reviews(review_id) NOT NULL,
+		image_no INT NOT NULL,
+		PRIMARY KEY(review_image_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the view of the rating of each seller, the average of their visible reviews rounded to one decimal place
+*/
+func createSellerRatingsView(db *sql.DB) error {
+	query := `CREATE OR REPLACE VIEW seller_ratings AS
+		SELECT seller_id, ROUND(AVG(rating), 1)::FLOAT AS rating, COUNT(*)::INT AS review_count
+		FROM reviews
+		WHERE status = 'visible'
+		GROUP BY seller_id;`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Seller Follows, the followers column of a seller is the number of buyers following them
+*/
+func createSellerFollowsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS seller_follows(
+		buyer_id uuid REFERENCES buyers(buyer_id) NOT NULL,
+		seller_id uuid REFERENCES sellers(seller_id) NOT NULL,
+		created_date TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(buyer_id, seller_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
 
 	if err != nil {
 		return err
 	}
 
-	err = createGuestOrderProductsTable(db)
+	query = `CREATE INDEX IF NOT EXISTS seller_follows_seller_id_idx ON seller_follows(seller_id);`
+
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Add whether a buyer gets a daily mail of the new listings of the sellers they follow and when the last one was sent
+*/
+func addBuyerFollowDigestColumns(db *sql.DB) error {
+	query := `ALTER TABLE buyers
+		ADD COLUMN IF NOT EXISTS follow_digest BOOLEAN DEFAULT FALSE NOT NULL,
+		ADD COLUMN IF NOT EXISTS follow_digest_sent_date TIMESTAMPTZ;`
+
+	_, err := db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the table for Notifications, the alerts shown to a buyer in the app. Each notification is also mailed to the buyer.
+*/
+func createNotificationsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS notifications(
+		notification_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		buyer_id uuid REFERENCES buyers(buyer_id) NOT NULL,
+		notification_type VARCHAR NOT NULL,
+		title VARCHAR NOT NULL,
+		message VARCHAR NOT NULL,
+		product_id uuid REFERENCES products(product_id),
+		is_read BOOLEAN DEFAULT FALSE NOT NULL,
+		created_date TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(notification_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
 
 	if err != nil {
 		return err
 	}
 
-	return nil
+	query = `CREATE INDEX IF NOT EXISTS notifications_buyer_id_idx ON notifications(buyer_id, created_date);`
+
+	_, err = db.ExecContext(context.Background(), query)
+	return err
 }
 
 /*
-Install and Postgres Extensions
+Create the table for Notification Preferences. Buyers get every type of notification unless they have turned it off.
 */
-func installExtensions(db *sql.DB) error {
-	uuidExtensionQuery := `CREATE EXTENSION IF NOT EXISTS "uuid-ossp";`
-	_, err := db.ExecContext(context.Background(), uuidExtensionQuery)
+func createNotificationPreferencesTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS notification_preferences(
+		buyer_id uuid REFERENCES buyers(buyer_id) NOT NULL,
+		notification_type VARCHAR NOT NULL,
+		enabled BOOLEAN NOT NULL,
+		PRIMARY KEY(buyer_id, notification_type));`
+
+	_, err := db.ExecContext(context.Background(), query)
 	return err
 }
 
 /*
-Create the table for Buyers
+Create the table for Watchlist Products. The effective price, whether the product was sold out and whether the
+buyer was told the auction is ending are kept from the last check so that each change is only alerted once.
 */
-func createBuyersTable(db *sql.DB) error {
-	query := `CREATE TABLE IF NOT EXISTS buyers(
-		buyer_id uuid DEFAULT uuid_generate_v1() NOT NULL,
-		email VARCHAR NOT NULL UNIQUE, 
-		password VARCHAR NOT NULL,
-		verification VARCHAR NOT NULL DEFAULT 'pending',
-		PRIMARY KEY(buyer_id));`
+func createWatchlistProductsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS watchlist_products(
+		buyer_id uuid REFERENCES buyers(buyer_id) NOT NULL,
+		product_id uuid REFERENCES products(product_id) NOT NULL,
+		alert_price INT NOT NULL,
+		sold_out BOOLEAN NOT NULL,
+		ending_alerted BOOLEAN DEFAULT FALSE NOT NULL,
+		created_date TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(buyer_id, product_id));`
 
 	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE INDEX IF NOT EXISTS watchlist_products_product_id_idx ON watchlist_products(product_id);`
+
+	_, err = db.ExecContext(context.Background(), query)
 	return err
 }
 
 /*
-Create the table for Buyer Otps
+Create the table for Saved Searches, a named product list query of a buyer. Matched listings are batched into
+one notification and notified_date keeps buyers from being notified more than once per interval.
 */
-func createBuyerOtpsTable(db *sql.DB) error {
-	query := `CREATE TABLE IF NOT EXISTS buyer_otps(
+func createSavedSearchesTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS saved_searches(
+		search_id uuid DEFAULT uuid_generate_v1() NOT NULL,
 		buyer_id uuid REFERENCES buyers(buyer_id) NOT NULL,
-		email_otp VARCHAR NOT NULL,
-		PRIMARY KEY(buyer_id));`
+		search_name VARCHAR NOT NULL,
+		search_query JSONB NOT NULL,
+		notified_date TIMESTAMPTZ,
+		created_date TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(search_id));`
 
 	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE INDEX IF NOT EXISTS saved_searches_buyer_id_idx ON saved_searches(buyer_id, created_date);`
+
+	_, err = db.ExecContext(context.Background(), query)
 	return err
 }
 
 /*
-Create the table for Sellers
+Create the table for Saved Search Matches, the new listings that matched a saved search that the buyer has not
+been notified of yet
 */
-func createSellersTable(db *sql.DB) error {
-	query := `CREATE TABLE IF NOT EXISTS sellers(
-		seller_id uuid DEFAULT uuid_generate_v1() NOT NULL,
-		email VARCHAR NOT NULL UNIQUE, 
-		password VARCHAR NOT NULL,
-		seller_name VARCHAR NOT NULL UNIQUE,
-		followers INT DEFAULT 0 NOT NULL,
-		PRIMARY KEY(seller_id));`
+func createSavedSearchMatchesTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS saved_search_matches(
+		search_id uuid REFERENCES saved_searches(search_id) ON DELETE CASCADE NOT NULL,
+		product_id uuid REFERENCES products(product_id) NOT NULL,
+		created_date TIMESTAMPTZ NOT NULL,
+		PRIMARY KEY(search_id, product_id));`
 
 	_, err := db.ExecContext(context.Background(), query)
 	return err
 }
 
 /*
-Create the table for Products
+Create the table for New Listings, the products created since the saved searches were last matched
 */
-func createProductsTable(db *sql.DB) error {
-	query := `CREATE TABLE IF NOT EXISTS products(
-		product_id uuid DEFAULT uuid_generate_v1() NOT NULL,
-		seller_id uuid REFERENCES sellers(seller_id), 
-		title TEXT NOT NULL,
-		description TEXT NOT NULL,
-		image_count INT NOT NULL DEFAULT 0,
-		condition INT NOT NULL CONSTRAINT isOutOfFive CHECK (condition >= 0 AND condition <= 5),
-		price INT NOT NULL CONSTRAINT isPositive CHECK (price >= 0),
-		product_type VARCHAR NOT NULL,
-		posted_date TIMESTAMPTZ NOT NULL,
-		product_quantity INT NOT NULL,
-		sold_quantity INT DEFAULT 0 NOT NULL,
-		language VARCHAR DEFAULT 'ENG' NOT NULL,
-		expansion VARCHAR NOT NULL,
+func createNewListingsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS new_listings(
+		product_id uuid REFERENCES products(product_id) NOT NULL,
+		created_date TIMESTAMPTZ NOT NULL,
 		PRIMARY KEY(product_id));`
 
 	_, err := db.ExecContext(context.Background(), query)
@@ -162,124 +1508,209 @@ func createProductsTable(db *sql.DB) error {
 }
 
 /*
-Create Pre Order Information table
+Add the locale of a buyer, mails to the buyer are written in it
 */
-func createPreorderInformationTable(db *sql.DB) error {
-	query := `CREATE TABLE IF NOT EXISTS preorder_information(
-		product_id uuid REFERENCES products(product_id) NOT NULL,
-		order_by TIMESTAMPTZ NOT NULL,
-		releases_on TIMESTAMPTZ NOT NULL,
-		PRIMARY KEY(product_id));`
+func addBuyerLocaleColumn(db *sql.DB) error {
+	query := `ALTER TABLE buyers ADD COLUMN IF NOT EXISTS locale VARCHAR DEFAULT 'en' NOT NULL;`
 
 	_, err := db.ExecContext(context.Background(), query)
 	return err
 }
 
 /*
-Create Product Discounts table
+Create the outbox of mails and events that have to be delivered once the change that caused them is committed.
+Messages are written in the same transaction as the change and delivered by a worker, messages that keep failing
+are marked as 'dead' for an admin to look into.
 */
-func createProductDiscountsTable(db *sql.DB) error {
-	query := `CREATE TABLE IF NOT EXISTS product_discounts(
-		product_id uuid REFERENCES products(product_id) NOT NULL,
-		discount INT NOT NULL,
-		PRIMARY KEY(product_id));`
+func createOutboxMessagesTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS outbox_messages(
+		message_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		topic VARCHAR NOT NULL,
+		payload JSONB NOT NULL,
+		status VARCHAR DEFAULT 'pending' NOT NULL CHECK (status IN ('pending', 'delivered', 'dead')),
+		attempts INT DEFAULT 0 NOT NULL,
+		last_error TEXT DEFAULT '' NOT NULL,
+		next_attempt_date TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+		created_date TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+		delivered_date TIMESTAMPTZ,
+		PRIMARY KEY(message_id));`
 
 	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE INDEX IF NOT EXISTS outbox_messages_pending_index ON outbox_messages(next_attempt_date)
+		WHERE status = 'pending';`
+
+	_, err = db.ExecContext(context.Background(), query)
 	return err
 }
 
 /*
-Create the table for Product Images
+Create the queue of background jobs. Running jobs are locked until their visibility timeout runs out, after which
+another worker can claim them. Recurring jobs get a unique key for the time they are scheduled for, so that they are
+only scheduled once however many workers are running.
 */
-func createProductImagesTable(db *sql.DB) error {
-	query := `CREATE TABLE IF NOT EXISTS product_images(
-		product_image_id uuid DEFAULT uuid_generate_v1() NOT NULL,
-		product_id uuid REFERENCES products(product_id),
-		image_no INT NOT NULL,
-		PRIMARY KEY(product_image_id));`
+func createJobsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS jobs(
+		job_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		job_type VARCHAR NOT NULL,
+		payload JSONB DEFAULT '{}' NOT NULL,
+		status VARCHAR DEFAULT 'pending' NOT NULL CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+		attempts INT DEFAULT 0 NOT NULL,
+		max_attempts INT DEFAULT 5 NOT NULL CHECK (max_attempts > 0),
+		last_error TEXT DEFAULT '' NOT NULL,
+		unique_key VARCHAR UNIQUE,
+		run_date TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+		locked_until TIMESTAMPTZ,
+		created_date TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+		completed_date TIMESTAMPTZ,
+		PRIMARY KEY(job_id));`
 
 	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE INDEX IF NOT EXISTS jobs_due_index ON jobs(run_date) WHERE status IN ('pending', 'running');`
+
+	_, err = db.ExecContext(context.Background(), query)
 	return err
 }
 
 /*
-Create the table for Orders
+Create the Telegram chats that buyer and seller accounts are linked to, notifications for an account are sent
+to its chat
 */
-func createOrdersTable(db *sql.DB) error {
-	query := `CREATE TABLE IF NOT EXISTS orders(
-		order_id uuid DEFAULT uuid_generate_v1() NOT NULL,
-		buyer_id uuid REFERENCES buyers(buyer_id) NOT NULL,
-		delivery_type VARCHAR NOT NULL,
-		payment_type VARCHAR NOT NULL,
-		payment_status VARCHAR DEFAULT 'pending' NOT NULL,
-		phone_number VARCHAR NOT NULL,
-		order_date TIMESTAMPTZ NOT NULL,
-		address_line_1 VARCHAR NOT NULL,
-		address_line_2 VARCHAR,
-		postal_code VARCHAR NOT NULL,
-		telegram_handle VARCHAR,
-		delivery_fee INT NOT NULL,
-		payment_fee INT NOT NULL,
-		small_order_fee INT NOT NULL,
-		total_paid INT NOT NULL,
-		PRIMARY KEY(order_id));`
+func createTelegramChatsTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS telegram_chats(
+		account_type VARCHAR NOT NULL CHECK (account_type IN ('buyer', 'seller')),
+		account_id uuid NOT NULL,
+		chat_id BIGINT NOT NULL,
+		username VARCHAR DEFAULT '' NOT NULL,
+		linked_date TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+		PRIMARY KEY(account_type, account_id));`
+
+	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE INDEX IF NOT EXISTS telegram_chats_chat_index ON telegram_chats(chat_id);`
+
+	_, err = db.ExecContext(context.Background(), query)
+	return err
+}
+
+/*
+Create the codes that link an account to a Telegram chat. A code is sent to the bot through a deep link and is
+only valid for a short time, every account has at most one code.
+*/
+func createTelegramLinkCodesTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS telegram_link_codes(
+		code VARCHAR NOT NULL,
+		account_type VARCHAR NOT NULL CHECK (account_type IN ('buyer', 'seller')),
+		account_id uuid NOT NULL,
+		expiry_date TIMESTAMPTZ NOT NULL,
+		created_date TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+		PRIMARY KEY(code),
+		UNIQUE(account_type, account_id));`
 
 	_, err := db.ExecContext(context.Background(), query)
 	return err
 }
 
 /*
-Create table for products in a order
+Create the webhook endpoints that sellers register for their own tooling. Each endpoint is subscribed to some
+event types and has its own secret that deliveries are signed with.
 */
-func createOrderProductsTable(db *sql.DB) error {
-	query := `CREATE TABLE IF NOT EXISTS order_products(
-			order_id uuid REFERENCES orders(order_id),
-			product_id uuid REFERENCES products(product_id),
-			quantity INT NOT NULL,
-			PRIMARY KEY(order_id, product_id)
-		);`
+func createWebhooksTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS webhooks(
+		webhook_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		seller_id uuid REFERENCES sellers(seller_id) NOT NULL,
+		url VARCHAR NOT NULL,
+		secret VARCHAR NOT NULL,
+		event_types VARCHAR[] NOT NULL,
+		created_date TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+		PRIMARY KEY(webhook_id));`
 
 	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE INDEX IF NOT EXISTS webhooks_seller_index ON webhooks(seller_id);`
+
+	_, err = db.ExecContext(context.Background(), query)
 	return err
 }
 
 /*
-Create the table for Guest Orders
+Create the log of the events delivered to webhook endpoints. The payload is kept so that a delivery can be
+replayed with the same body, and the response of the latest attempt is kept for the seller to see.
 */
-func createGuestOrdersTable(db *sql.DB) error {
-	query := `CREATE TABLE IF NOT EXISTS guest_orders(
-		guest_order_id uuid DEFAULT uuid_generate_v1() NOT NULL,
-		delivery_type VARCHAR NOT NULL,
-		payment_type VARCHAR NOT NULL,
-		payment_status VARCHAR DEFAULT 'pending' NOT NULL,
-		phone_number VARCHAR NOT NULL,
-		email VARCHAR NOT NULL,
-		order_date TIMESTAMPTZ NOT NULL,
-		address_line_1 VARCHAR NOT NULL,
-		address_line_2 VARCHAR,
-		postal_code VARCHAR NOT NULL,
-		telegram_handle VARCHAR,
-		delivery_fee INT NOT NULL,
-		payment_fee INT NOT NULL,
-		small_order_fee INT NOT NULL,
-		total_paid INT NOT NULL,
-		PRIMARY KEY(guest_order_id));`
+func createWebhookDeliveriesTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS webhook_deliveries(
+		delivery_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		webhook_id uuid REFERENCES webhooks(webhook_id) ON DELETE CASCADE NOT NULL,
+		event_id VARCHAR NOT NULL,
+		event_type VARCHAR NOT NULL,
+		payload JSONB NOT NULL,
+		status VARCHAR DEFAULT 'pending' NOT NULL CHECK (status IN ('pending', 'delivered', 'failed')),
+		attempts INT DEFAULT 0 NOT NULL,
+		response_code INT DEFAULT 0 NOT NULL,
+		last_error VARCHAR DEFAULT '' NOT NULL,
+		last_attempt_date TIMESTAMPTZ,
+		delivered_date TIMESTAMPTZ,
+		created_date TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+		PRIMARY KEY(delivery_id));`
 
 	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_index ON webhook_deliveries(webhook_id, created_date);`
+
+	_, err = db.ExecContext(context.Background(), query)
 	return err
 }
 
 /*
-Create table for products in a guest order
+Create the API keys that sellers use to manage their listings and orders from their own tools. Only a hash of the
+secret is kept, the prefix identifies the key. Requests are counted in a window of a minute for the rate limit.
 */
-func createGuestOrderProductsTable(db *sql.DB) error {
-	query := `CREATE TABLE IF NOT EXISTS guest_order_products(
-			guest_order_id uuid REFERENCES guest_orders(guest_order_id),
-			product_id uuid REFERENCES products(product_id),
-			quantity INT NOT NULL,
-			PRIMARY KEY(guest_order_id, product_id)
-		);`
+func createSellerApiKeysTable(db *sql.DB) error {
+	query := `CREATE TABLE IF NOT EXISTS seller_api_keys(
+		key_id uuid DEFAULT uuid_generate_v1() NOT NULL,
+		seller_id uuid REFERENCES sellers(seller_id) NOT NULL,
+		name VARCHAR NOT NULL,
+		key_prefix VARCHAR NOT NULL UNIQUE,
+		secret_hash VARCHAR NOT NULL,
+		scopes VARCHAR[] NOT NULL,
+		rate_limit INT NOT NULL CONSTRAINT isPositiveRateLimit CHECK (rate_limit > 0),
+		window_start TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+		window_count INT DEFAULT 0 NOT NULL,
+		last_used_date TIMESTAMPTZ,
+		rotated_date TIMESTAMPTZ,
+		revoked_date TIMESTAMPTZ,
+		created_date TIMESTAMPTZ DEFAULT NOW() NOT NULL,
+		PRIMARY KEY(key_id));`
 
 	_, err := db.ExecContext(context.Background(), query)
+
+	if err != nil {
+		return err
+	}
+
+	query = `CREATE INDEX IF NOT EXISTS seller_api_keys_seller_index ON seller_api_keys(seller_id);`
+
+	_, err = db.ExecContext(context.Background(), query)
 	return err
 }
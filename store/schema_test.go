@@ -33,6 +33,38 @@ const (
 		  table_name = 'sellers'
 	);`
 
+	queryCheckTableGames = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'games'
+	);`
+
+	queryCheckTableLanguages = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'languages'
+	);`
+
+	queryCheckTableExpansions = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'expansions'
+	);`
+
+	queryCheckTableExpansionLanguages = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'expansion_languages'
+	);`
+
 	queryCheckTableProducts = `SELECT EXISTS(
 		SELECT * 
 		FROM information_schema.tables 
@@ -41,6 +73,14 @@ const (
 		  table_name = 'products'
 	);`
 
+	queryCheckIndexProductsSearch = `SELECT EXISTS(
+		SELECT * 
+		FROM pg_indexes 
+		WHERE 
+		  schemaname = 'public' AND 
+		  indexname = 'products_search_vector_idx'
+	);`
+
 	queryCheckTableProductImages = `SELECT EXISTS(
 		SELECT * 
 		FROM information_schema.tables 
@@ -49,6 +89,14 @@ const (
 		  table_name = 'product_images'
 	);`
 
+	queryCheckTableProductImageUploads = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'product_image_uploads'
+	);`
+
 	queryCheckTablePreorderInformation = `SELECT EXISTS(
 		SELECT * 
 		FROM information_schema.tables 
@@ -65,6 +113,22 @@ const (
 		  table_name = 'product_discounts'
 	);`
 
+	queryCheckTableProductCardAttributes = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'product_card_attributes'
+	);`
+
+	queryCheckTableProductSealedAttributes = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'product_sealed_attributes'
+	);`
+
 	queryCheckTableOrders = `SELECT EXISTS(
 		SELECT * 
 		FROM information_schema.tables 
@@ -95,9 +159,903 @@ const (
 		  table_schema = 'public' AND 
 		  table_name = 'guest_order_products'
 	);`
-)
+	queryCheckTableAuctionInformation = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'auction_information'
+	);`
 
-func TestCreateTables(t *testing.T) {
+	queryCheckTableAuctionBidders = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'auction_bidders'
+	);`
+
+	queryCheckTableAuctionBids = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'auction_bids'
+	);`
+
+	queryCheckTableOffers = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'offers'
+	);`
+
+	queryCheckTableDiscounts = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'discounts'
+	);`
+
+	queryCheckViewProductEffectiveDiscounts = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.views 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'product_effective_discounts'
+	);`
+
+	queryCheckTableProductPriceHistory = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'product_price_history'
+	);`
+
+	queryCheckTablePromoCodes = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'promo_codes'
+	);`
+
+	queryCheckTablePromoCodeSellers = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'promo_code_sellers'
+	);`
+
+	queryCheckTablePromoCodeExpansions = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'promo_code_expansions'
+	);`
+
+	queryCheckTablePromoCodeRedemptions = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'promo_code_redemptions'
+	);`
+
+	queryCheckTableCarts = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'carts'
+	);`
+
+	queryCheckTableCartProducts = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'cart_products'
+	);`
+
+	queryCheckTableSubOrders = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'sub_orders'
+	);`
+
+	queryCheckTableSubOrderRefunds = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'sub_order_refunds'
+	);`
+
+	queryCheckColumnOrderProductsSubOrder = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.columns 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'order_products' AND 
+		  column_name = 'sub_order_id'
+	);`
+
+	queryCheckTablePayoutBatches = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'payout_batches'
+	);`
+
+	queryCheckTablePayouts = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'payouts'
+	);`
+
+	queryCheckTableLedgerTransactions = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'ledger_transactions'
+	);`
+
+	queryCheckTableLedgerEntries = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'ledger_entries'
+	);`
+
+	queryCheckTableReviews = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'reviews'
+	);`
+
+	queryCheckTableReviewImageUploads = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'review_image_uploads'
+	);`
+
+	queryCheckTableReviewImages = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'review_images'
+	);`
+
+	queryCheckViewSellerRatings = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.views 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'seller_ratings'
+	);`
+
+	queryCheckTableSellerFollows = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'seller_follows'
+	);`
+
+	queryCheckColumnBuyersFollowDigest = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.columns 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'buyers' AND 
+		  column_name = 'follow_digest'
+	);`
+
+	queryCheckTableNotifications = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'notifications'
+	);`
+
+	queryCheckTableNotificationPreferences = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'notification_preferences'
+	);`
+
+	queryCheckTableWatchlistProducts = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'watchlist_products'
+	);`
+
+	queryCheckColumnBuyersLocale = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.columns 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'buyers' AND 
+		  column_name = 'locale'
+	);`
+
+	queryCheckTableOutboxMessages = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'outbox_messages'
+	);`
+
+	queryCheckTableJobs = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'jobs'
+	);`
+
+	queryCheckTableTelegramChats = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'telegram_chats'
+	);`
+
+	queryCheckTableTelegramLinkCodes = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'telegram_link_codes'
+	);`
+
+	queryCheckTableWebhooks = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'webhooks'
+	);`
+
+	queryCheckTableWebhookDeliveries = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'webhook_deliveries'
+	);`
+
+	queryCheckTableSellerApiKeys = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'seller_api_keys'
+	);`
+
+	queryCheckTableSavedSearches = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'saved_searches'
+	);`
+
+	queryCheckTableSavedSearchMatches = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'saved_search_matches'
+	);`
+
+	queryCheckTableNewListings = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.tables 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'new_listings'
+	);`
+
+	queryCheckColumnOrdersDiscount = `SELECT EXISTS(
+		SELECT * 
+		FROM information_schema.columns 
+		WHERE 
+		  table_schema = 'public' AND 
+		  table_name = 'orders' AND 
+		  column_name = 'discount'
+	);`
+)
+
+func TestCreateTables(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No errors in creating tables
+	err = createTables(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary buyers tables exists
+	var buyersExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableBuyers).Scan(&buyersExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, buyersExist)
+
+	//Test 3: Check if neccessary sellers tables exists
+	var sellersExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableSellers).Scan(&sellersExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, sellersExist)
+
+	//Test 4: Check if neccessary sellers tables exists
+	var productsExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableProducts).Scan(&productsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, productsExist)
+
+	CloseDB(db)
+}
+
+func TestCreateBuyersTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+	//Test 1: No errors in creating buyers table
+	var buyersExist bool
+	err = createBuyersTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary buyers tables exists
+	err = db.QueryRowContext(context.Background(), queryCheckTableBuyers).Scan(&buyersExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, buyersExist)
+
+	CloseDB(db)
+
+}
+
+func TestCreateBuyerOtpsTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+	//Test 1: No errors in creating buyers table
+	var buyerOtpsExist bool
+	err = createBuyerOtpsTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary buyers tables exists
+	err = db.QueryRowContext(context.Background(), queryCheckTableBuyerOtps).Scan(&buyerOtpsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, buyerOtpsExist)
+
+	CloseDB(db)
+}
+
+func TestCreateSellersTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	//Test 1: No errors in creating sellers table
+	err = createSellersTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary buyers tables exists
+	var sellersExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableSellers).Scan(&sellersExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, sellersExist)
+
+	CloseDB(db)
+}
+
+func TestCreateGamesTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in creating games table
+	err = createGamesTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary games tables exists
+	var gamesExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableGames).Scan(&gamesExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, gamesExist)
+
+	CloseDB(db)
+}
+
+func TestCreateLanguagesTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in creating languages table
+	err = createLanguagesTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary languages tables exists
+	var languagesExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableLanguages).Scan(&languagesExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, languagesExist)
+
+	//Test 3: Creating the languages table again does not error
+	err = createLanguagesTable(db)
+	assert.NoError(t, err)
+
+	//Test 4: Existing languages are added
+	var languageCount int
+	err = db.QueryRowContext(context.Background(), `SELECT COUNT(*) FROM languages WHERE language_code IN ('Eng', 'Jap');`).Scan(&languageCount)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, languageCount)
+
+	CloseDB(db)
+}
+
+func TestCreateExpansionsTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: Error in creating expansions table without games table
+	err = createExpansionsTable(db)
+	assert.Error(t, err)
+
+	//Test 2: No Error in creating expansions table
+	createGamesTable(db)
+	err = createExpansionsTable(db)
+	assert.NoError(t, err)
+
+	//Test 3: Check if neccessary expansions tables exists
+	var expansionsExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableExpansions).Scan(&expansionsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, expansionsExist)
+
+	CloseDB(db)
+}
+
+func TestCreateExpansionLanguagesTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in creating expansion languages table
+	createGamesTable(db)
+	createLanguagesTable(db)
+	createExpansionsTable(db)
+	err = createExpansionLanguagesTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary expansion languages tables exists
+	var expansionLanguagesExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableExpansionLanguages).Scan(&expansionLanguagesExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, expansionLanguagesExist)
+
+	CloseDB(db)
+}
+
+func TestCreateProductsTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: Error in creating products table
+	err = createProductsTable(db)
+	assert.Error(t, err)
+
+	//Test 2: No Error in creating products table
+	createSellersTable(db)
+	err = createProductsTable(db)
+	assert.NoError(t, err)
+
+	//Test 3: Check if neccessary products tables exists
+	var productsExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableProducts).Scan(&productsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, productsExist)
+
+	CloseDB(db)
+}
+
+func TestCreateProductsSearchIndex(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: Error in creating search index without products table
+	err = createProductsSearchIndex(db)
+	assert.Error(t, err)
+
+	//Test 2: No Error in creating search index
+	createSellersTable(db)
+	createProductsTable(db)
+	err = createProductsSearchIndex(db)
+	assert.NoError(t, err)
+
+	//Test 3: Creating the search index again does not error
+	err = createProductsSearchIndex(db)
+	assert.NoError(t, err)
+
+	//Test 4: Check if neccessary search index exists
+	var searchIndexExists bool
+	err = db.QueryRowContext(context.Background(), queryCheckIndexProductsSearch).Scan(&searchIndexExists)
+	assert.NoError(t, err)
+	assert.Equal(t, true, searchIndexExists)
+
+	CloseDB(db)
+}
+
+func TestCreateProductImagesTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in creating products table
+	createSellersTable(db)
+	createProductsTable(db)
+	err = createProductImagesTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary products tables exists
+	var productImagesExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableProductImages).Scan(&productImagesExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, productImagesExist)
+
+	CloseDB(db)
+}
+
+func TestCreateProductImageUploadsTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in creating product image uploads table
+	createSellersTable(db)
+	createProductsTable(db)
+	err = createProductImageUploadsTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary product image uploads tables exists
+	var productImageUploadsExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableProductImageUploads).Scan(&productImageUploadsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, productImageUploadsExist)
+
+	CloseDB(db)
+}
+
+func TestCreatePreorderInformationTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in creating products table
+	createSellersTable(db)
+	createProductsTable(db)
+	err = createPreorderInformationTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary products tables exists
+	var preorderInformationExists bool
+	err = db.QueryRowContext(context.Background(), queryCheckTablePreorderInformation).Scan(&preorderInformationExists)
+	assert.NoError(t, err)
+	assert.Equal(t, true, preorderInformationExists)
+
+	CloseDB(db)
+}
+
+func TestCreateProductDiscountsTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in creating products table
+	createSellersTable(db)
+	createProductsTable(db)
+	err = createProductDiscountsTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary products tables exists
+	var productDiscountsExists bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableProductDiscounts).Scan(&productDiscountsExists)
+	assert.NoError(t, err)
+	assert.Equal(t, true, productDiscountsExists)
+
+	CloseDB(db)
+}
+
+func TestCreateProductCardAttributesTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in creating product card attributes table
+	createSellersTable(db)
+	createProductsTable(db)
+	err = createProductCardAttributesTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary product card attributes tables exists
+	var productCardAttributesExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableProductCardAttributes).Scan(&productCardAttributesExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, productCardAttributesExist)
+
+	CloseDB(db)
+}
+
+func TestCreateProductSealedAttributesTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in creating product sealed attributes table
+	createSellersTable(db)
+	createProductsTable(db)
+	err = createProductSealedAttributesTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary product sealed attributes tables exists
+	var productSealedAttributesExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableProductSealedAttributes).Scan(&productSealedAttributesExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, productSealedAttributesExist)
+
+	CloseDB(db)
+}
+
+func TestCreateOrdersTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in creating orders table
+	createSellersTable(db)
+	createProductsTable(db)
+	createBuyersTable(db)
+	err = createOrdersTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary orders tables exists
+	var ordersExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableOrders).Scan(&ordersExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, ordersExist)
+
+	CloseDB(db)
+}
+
+func TestCreateGuestOrdersTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in creating guest orders table
+	createSellersTable(db)
+	createProductsTable(db)
+	err = createGuestOrdersTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary guest orders tables exists
+	var guestOrdersExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableGuestOrders).Scan(&guestOrdersExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, guestOrdersExist)
+
+	CloseDB(db)
+}
+
+func TestCreateOrderProductsTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in creating order products table
+	createSellersTable(db)
+	createProductsTable(db)
+	createOrdersTable(db)
+	err = createOrderProductsTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary order products tables exists
+	var orderProductsExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableOrderProducts).Scan(&orderProductsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, orderProductsExist)
+
+	CloseDB(db)
+}
+
+func TestCreateGuestOrderProductsTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in creating guest order products table
+	createSellersTable(db)
+	createProductsTable(db)
+	createOrdersTable(db)
+	err = createGuestOrderProductsTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary guest order products tables exists
+	var guestOrderProductsExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableGuestOrderProducts).Scan(&guestOrderProductsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, guestOrderProductsExist)
+
+	CloseDB(db)
+}
+
+/*
+Function to reset all the tables in the DB, used mainly during testing
+*/
+func TestCreateAuctionInformationTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in creating auction information table
+	createBuyersTable(db)
+	createSellersTable(db)
+	createProductsTable(db)
+	createOrdersTable(db)
+	err = createAuctionInformationTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary auction information tables exists
+	var auctionInformationExists bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableAuctionInformation).Scan(&auctionInformationExists)
+	assert.NoError(t, err)
+	assert.Equal(t, true, auctionInformationExists)
+
+	CloseDB(db)
+}
+
+func TestCreateAuctionBiddersTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in creating auction bidders table
+	createBuyersTable(db)
+	createSellersTable(db)
+	createProductsTable(db)
+	createOrdersTable(db)
+	err = createAuctionBiddersTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary auction bidders tables exists
+	var auctionBiddersExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableAuctionBidders).Scan(&auctionBiddersExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, auctionBiddersExist)
+
+	CloseDB(db)
+}
+
+func TestCreateAuctionBidsTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in creating auction bids table
+	createBuyersTable(db)
+	createSellersTable(db)
+	createProductsTable(db)
+	createOrdersTable(db)
+	err = createAuctionBidsTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary auction bids tables exists
+	var auctionBidsExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableAuctionBids).Scan(&auctionBidsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, auctionBidsExist)
+
+	CloseDB(db)
+}
+
+func TestCreateOffersTable(t *testing.T) {
 	err := utils.LoadDotEnv("../.env")
 	assert.NoError(t, err)
 	db, err := initTestDB()
@@ -105,92 +1063,127 @@ func TestCreateTables(t *testing.T) {
 
 	dropDB(db)
 
-	//Test 1: No errors in creating tables
-	err = createTables(db)
+	//Test 1: No Error in creating offers table
+	createBuyersTable(db)
+	createSellersTable(db)
+	createProductsTable(db)
+	createOrdersTable(db)
+	err = createOffersTable(db)
 	assert.NoError(t, err)
 
-	//Test 2: Check if neccessary buyers tables exists
-	var buyersExist bool
-	err = db.QueryRowContext(context.Background(), queryCheckTableBuyers).Scan(&buyersExist)
+	//Test 2: Check if neccessary offers tables exists
+	var offersExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableOffers).Scan(&offersExist)
 	assert.NoError(t, err)
-	assert.Equal(t, true, buyersExist)
+	assert.Equal(t, true, offersExist)
 
-	//Test 3: Check if neccessary sellers tables exists
-	var sellersExist bool
-	err = db.QueryRowContext(context.Background(), queryCheckTableSellers).Scan(&sellersExist)
+	CloseDB(db)
+}
+
+func TestCreateDiscountsTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
 	assert.NoError(t, err)
-	assert.Equal(t, true, sellersExist)
 
-	//Test 4: Check if neccessary sellers tables exists
-	var productsExist bool
-	err = db.QueryRowContext(context.Background(), queryCheckTableProducts).Scan(&productsExist)
+	dropDB(db)
+
+	//Test 1: No Error in creating discounts table
+	createSellersTable(db)
+	createProductsTable(db)
+	err = createDiscountsTable(db)
 	assert.NoError(t, err)
-	assert.Equal(t, true, productsExist)
+
+	//Test 2: Check if neccessary discounts tables exists
+	var discountsExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableDiscounts).Scan(&discountsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, discountsExist)
 
 	CloseDB(db)
 }
 
-func TestCreateBuyersTable(t *testing.T) {
+func TestCreateProductEffectiveDiscountsView(t *testing.T) {
 	err := utils.LoadDotEnv("../.env")
 	assert.NoError(t, err)
 	db, err := initTestDB()
 	assert.NoError(t, err)
 
 	dropDB(db)
-	//Test 1: No errors in creating buyers table
-	var buyersExist bool
-	err = createBuyersTable(db)
+
+	//Test 1: No Error in creating product effective discounts view
+	createSellersTable(db)
+	createProductsTable(db)
+	createProductDiscountsTable(db)
+	createDiscountsTable(db)
+	err = createProductEffectiveDiscountsView(db)
 	assert.NoError(t, err)
 
-	//Test 2: Check if neccessary buyers tables exists
-	err = db.QueryRowContext(context.Background(), queryCheckTableBuyers).Scan(&buyersExist)
+	//Test 2: Check if neccessary product effective discounts view exists
+	var viewExists bool
+	err = db.QueryRowContext(context.Background(), queryCheckViewProductEffectiveDiscounts).Scan(&viewExists)
 	assert.NoError(t, err)
-	assert.Equal(t, true, buyersExist)
+	assert.Equal(t, true, viewExists)
 
 	CloseDB(db)
-
 }
 
-func TestCreateBuyerOtpsTable(t *testing.T) {
+func TestCreateProductPriceHistoryTable(t *testing.T) {
 	err := utils.LoadDotEnv("../.env")
 	assert.NoError(t, err)
 	db, err := initTestDB()
 	assert.NoError(t, err)
 
 	dropDB(db)
-	//Test 1: No errors in creating buyers table
-	var buyerOtpsExist bool
-	err = createBuyerOtpsTable(db)
+
+	//Test 1: No Error in creating product price history table
+	createSellersTable(db)
+	createProductsTable(db)
+	err = createProductPriceHistoryTable(db)
 	assert.NoError(t, err)
 
-	//Test 2: Check if neccessary buyers tables exists
-	err = db.QueryRowContext(context.Background(), queryCheckTableBuyerOtps).Scan(&buyerOtpsExist)
+	//Test 2: Check if neccessary product price history tables exists
+	var priceHistoryExists bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableProductPriceHistory).Scan(&priceHistoryExists)
 	assert.NoError(t, err)
-	assert.Equal(t, true, buyerOtpsExist)
+	assert.Equal(t, true, priceHistoryExists)
 
 	CloseDB(db)
 }
 
-func TestCreateSellersTable(t *testing.T) {
+func TestCreatePromoCodesTable(t *testing.T) {
 	err := utils.LoadDotEnv("../.env")
 	assert.NoError(t, err)
 	db, err := initTestDB()
 	assert.NoError(t, err)
 
-	//Test 1: No errors in creating sellers table
-	err = createSellersTable(db)
+	dropDB(db)
+
+	//Test 1: No Error in creating promo code tables
+	createSellersTable(db)
+	err = createPromoCodesTable(db)
+	assert.NoError(t, err)
+	err = createPromoCodeSellersTable(db)
+	assert.NoError(t, err)
+	err = createPromoCodeExpansionsTable(db)
 	assert.NoError(t, err)
 
-	//Test 2: Check if neccessary buyers tables exists
-	var sellersExist bool
-	err = db.QueryRowContext(context.Background(), queryCheckTableSellers).Scan(&sellersExist)
+	//Test 2: Check if neccessary promo code tables exists
+	var promoCodesExist, promoCodeSellersExist, promoCodeExpansionsExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTablePromoCodes).Scan(&promoCodesExist)
 	assert.NoError(t, err)
-	assert.Equal(t, true, sellersExist)
+	assert.Equal(t, true, promoCodesExist)
+	err = db.QueryRowContext(context.Background(), queryCheckTablePromoCodeSellers).Scan(&promoCodeSellersExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, promoCodeSellersExist)
+	err = db.QueryRowContext(context.Background(), queryCheckTablePromoCodeExpansions).Scan(&promoCodeExpansionsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, promoCodeExpansionsExist)
 
 	CloseDB(db)
 }
 
-func TestCreateProductsTable(t *testing.T) {
+func TestCreatePromoCodeRedemptionsTable(t *testing.T) {
 	err := utils.LoadDotEnv("../.env")
 	assert.NoError(t, err)
 	db, err := initTestDB()
@@ -198,25 +1191,30 @@ func TestCreateProductsTable(t *testing.T) {
 
 	dropDB(db)
 
-	//Test 1: Error in creating products table
-	err = createProductsTable(db)
-	assert.Error(t, err)
-
-	//Test 2: No Error in creating products table
+	//Test 1: No Error in creating promo code redemptions table and order discount columns
+	createBuyersTable(db)
 	createSellersTable(db)
-	err = createProductsTable(db)
+	createOrdersTable(db)
+	createGuestOrdersTable(db)
+	createPromoCodesTable(db)
+	err = createPromoCodeRedemptionsTable(db)
+	assert.NoError(t, err)
+	err = addOrderDiscountColumns(db)
 	assert.NoError(t, err)
 
-	//Test 3: Check if neccessary products tables exists
-	var productsExist bool
-	err = db.QueryRowContext(context.Background(), queryCheckTableProducts).Scan(&productsExist)
+	//Test 2: Check if neccessary promo code redemptions table and order discount column exists
+	var redemptionsExist, discountExists bool
+	err = db.QueryRowContext(context.Background(), queryCheckTablePromoCodeRedemptions).Scan(&redemptionsExist)
 	assert.NoError(t, err)
-	assert.Equal(t, true, productsExist)
+	assert.Equal(t, true, redemptionsExist)
+	err = db.QueryRowContext(context.Background(), queryCheckColumnOrdersDiscount).Scan(&discountExists)
+	assert.NoError(t, err)
+	assert.Equal(t, true, discountExists)
 
 	CloseDB(db)
 }
 
-func TestCreateProductImagesTable(t *testing.T) {
+func TestCreateCartsTable(t *testing.T) {
 	err := utils.LoadDotEnv("../.env")
 	assert.NoError(t, err)
 	db, err := initTestDB()
@@ -224,22 +1222,28 @@ func TestCreateProductImagesTable(t *testing.T) {
 
 	dropDB(db)
 
-	//Test 1: No Error in creating products table
+	//Test 1: No Error in creating cart tables
+	createBuyersTable(db)
 	createSellersTable(db)
 	createProductsTable(db)
-	err = createProductImagesTable(db)
+	err = createCartsTable(db)
+	assert.NoError(t, err)
+	err = createCartProductsTable(db)
 	assert.NoError(t, err)
 
-	//Test 2: Check if neccessary products tables exists
-	var productImagesExist bool
-	err = db.QueryRowContext(context.Background(), queryCheckTableProductImages).Scan(&productImagesExist)
+	//Test 2: Check if neccessary cart tables exists
+	var cartsExist, cartProductsExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableCarts).Scan(&cartsExist)
 	assert.NoError(t, err)
-	assert.Equal(t, true, productImagesExist)
+	assert.Equal(t, true, cartsExist)
+	err = db.QueryRowContext(context.Background(), queryCheckTableCartProducts).Scan(&cartProductsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, cartProductsExist)
 
 	CloseDB(db)
 }
 
-func TestCreatePreorderInformationTable(t *testing.T) {
+func TestCreateSubOrdersTable(t *testing.T) {
 	err := utils.LoadDotEnv("../.env")
 	assert.NoError(t, err)
 	db, err := initTestDB()
@@ -247,22 +1251,37 @@ func TestCreatePreorderInformationTable(t *testing.T) {
 
 	dropDB(db)
 
-	//Test 1: No Error in creating products table
+	//Test 1: No Error in creating sub-order tables and order product sub-order columns
+	createBuyersTable(db)
 	createSellersTable(db)
 	createProductsTable(db)
-	err = createPreorderInformationTable(db)
+	createOrdersTable(db)
+	createOrderProductsTable(db)
+	createGuestOrdersTable(db)
+	createGuestOrderProductsTable(db)
+	err = createSubOrdersTable(db)
+	assert.NoError(t, err)
+	err = addOrderProductSubOrderColumns(db)
+	assert.NoError(t, err)
+	err = createSubOrderRefundsTable(db)
 	assert.NoError(t, err)
 
-	//Test 2: Check if neccessary products tables exists
-	var preorderInformationExists bool
-	err = db.QueryRowContext(context.Background(), queryCheckTablePreorderInformation).Scan(&preorderInformationExists)
+	//Test 2: Check if neccessary sub-order tables and order product sub-order column exists
+	var subOrdersExist, refundsExist, subOrderColumnExists bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableSubOrders).Scan(&subOrdersExist)
 	assert.NoError(t, err)
-	assert.Equal(t, true, preorderInformationExists)
+	assert.Equal(t, true, subOrdersExist)
+	err = db.QueryRowContext(context.Background(), queryCheckTableSubOrderRefunds).Scan(&refundsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, refundsExist)
+	err = db.QueryRowContext(context.Background(), queryCheckColumnOrderProductsSubOrder).Scan(&subOrderColumnExists)
+	assert.NoError(t, err)
+	assert.Equal(t, true, subOrderColumnExists)
 
 	CloseDB(db)
 }
 
-func TestCreateProductDiscountsTable(t *testing.T) {
+func TestCreateLedgerTables(t *testing.T) {
 	err := utils.LoadDotEnv("../.env")
 	assert.NoError(t, err)
 	db, err := initTestDB()
@@ -270,22 +1289,43 @@ func TestCreateProductDiscountsTable(t *testing.T) {
 
 	dropDB(db)
 
-	//Test 1: No Error in creating products table
+	//Test 1: No Error in creating payout and ledger tables
+	createBuyersTable(db)
 	createSellersTable(db)
-	createProductsTable(db)
-	err = createProductDiscountsTable(db)
+	createOrdersTable(db)
+	createGuestOrdersTable(db)
+	createSubOrdersTable(db)
+	createSubOrderRefundsTable(db)
+	err = addSubOrderDeliveredDateColumn(db)
+	assert.NoError(t, err)
+	err = createPayoutBatchesTable(db)
+	assert.NoError(t, err)
+	err = createPayoutsTable(db)
+	assert.NoError(t, err)
+	err = createLedgerTransactionsTable(db)
+	assert.NoError(t, err)
+	err = createLedgerEntriesTable(db)
 	assert.NoError(t, err)
 
-	//Test 2: Check if neccessary products tables exists
-	var productDiscountsExists bool
-	err = db.QueryRowContext(context.Background(), queryCheckTableProductDiscounts).Scan(&productDiscountsExists)
+	//Test 2: Check if neccessary payout and ledger tables exists
+	var batchesExist, payoutsExist, transactionsExist, entriesExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTablePayoutBatches).Scan(&batchesExist)
 	assert.NoError(t, err)
-	assert.Equal(t, true, productDiscountsExists)
+	assert.Equal(t, true, batchesExist)
+	err = db.QueryRowContext(context.Background(), queryCheckTablePayouts).Scan(&payoutsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, payoutsExist)
+	err = db.QueryRowContext(context.Background(), queryCheckTableLedgerTransactions).Scan(&transactionsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, transactionsExist)
+	err = db.QueryRowContext(context.Background(), queryCheckTableLedgerEntries).Scan(&entriesExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, entriesExist)
 
 	CloseDB(db)
 }
 
-func TestCreateOrdersTable(t *testing.T) {
+func TestCreateReviewsTable(t *testing.T) {
 	err := utils.LoadDotEnv("../.env")
 	assert.NoError(t, err)
 	db, err := initTestDB()
@@ -293,23 +1333,40 @@ func TestCreateOrdersTable(t *testing.T) {
 
 	dropDB(db)
 
-	//Test 1: No Error in creating orders table
-	createSellersTable(db)
-	createProductsTable(db)
+	//Test 1: No Error in creating review tables and view
 	createBuyersTable(db)
-	err = createOrdersTable(db)
+	createSellersTable(db)
+	createOrdersTable(db)
+	createGuestOrdersTable(db)
+	createSubOrdersTable(db)
+	err = createReviewsTable(db)
+	assert.NoError(t, err)
+	err = createReviewImageUploadsTable(db)
+	assert.NoError(t, err)
+	err = createReviewImagesTable(db)
+	assert.NoError(t, err)
+	err = createSellerRatingsView(db)
 	assert.NoError(t, err)
 
-	//Test 2: Check if neccessary orders tables exists
-	var ordersExist bool
-	err = db.QueryRowContext(context.Background(), queryCheckTableOrders).Scan(&ordersExist)
+	//Test 2: Check if neccessary review tables and view exists
+	var reviewsExist, uploadsExist, imagesExist, viewExists bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableReviews).Scan(&reviewsExist)
 	assert.NoError(t, err)
-	assert.Equal(t, true, ordersExist)
+	assert.Equal(t, true, reviewsExist)
+	err = db.QueryRowContext(context.Background(), queryCheckTableReviewImageUploads).Scan(&uploadsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, uploadsExist)
+	err = db.QueryRowContext(context.Background(), queryCheckTableReviewImages).Scan(&imagesExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, imagesExist)
+	err = db.QueryRowContext(context.Background(), queryCheckViewSellerRatings).Scan(&viewExists)
+	assert.NoError(t, err)
+	assert.Equal(t, true, viewExists)
 
 	CloseDB(db)
 }
 
-func TestCreateGuestOrdersTable(t *testing.T) {
+func TestCreateSellerFollowsTable(t *testing.T) {
 	err := utils.LoadDotEnv("../.env")
 	assert.NoError(t, err)
 	db, err := initTestDB()
@@ -317,22 +1374,27 @@ func TestCreateGuestOrdersTable(t *testing.T) {
 
 	dropDB(db)
 
-	//Test 1: No Error in creating guest orders table
+	//Test 1: No Error in creating seller follows table and digest columns
+	createBuyersTable(db)
 	createSellersTable(db)
-	createProductsTable(db)
-	err = createGuestOrdersTable(db)
+	err = createSellerFollowsTable(db)
+	assert.NoError(t, err)
+	err = addBuyerFollowDigestColumns(db)
 	assert.NoError(t, err)
 
-	//Test 2: Check if neccessary guest orders tables exists
-	var guestOrdersExist bool
-	err = db.QueryRowContext(context.Background(), queryCheckTableGuestOrders).Scan(&guestOrdersExist)
+	//Test 2: Check if neccessary seller follows table and digest columns exists
+	var followsExist, digestExists bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableSellerFollows).Scan(&followsExist)
 	assert.NoError(t, err)
-	assert.Equal(t, true, guestOrdersExist)
+	assert.Equal(t, true, followsExist)
+	err = db.QueryRowContext(context.Background(), queryCheckColumnBuyersFollowDigest).Scan(&digestExists)
+	assert.NoError(t, err)
+	assert.Equal(t, true, digestExists)
 
 	CloseDB(db)
 }
 
-func TestCreateOrderProductsTable(t *testing.T) {
+func TestCreateWatchlistTables(t *testing.T) {
 	err := utils.LoadDotEnv("../.env")
 	assert.NoError(t, err)
 	db, err := initTestDB()
@@ -340,23 +1402,33 @@ func TestCreateOrderProductsTable(t *testing.T) {
 
 	dropDB(db)
 
-	//Test 1: No Error in creating order products table
+	//Test 1: No Error in creating notification and watchlist tables
+	createBuyersTable(db)
 	createSellersTable(db)
 	createProductsTable(db)
-	createOrdersTable(db)
-	err = createOrderProductsTable(db)
+	err = createNotificationsTable(db)
+	assert.NoError(t, err)
+	err = createNotificationPreferencesTable(db)
+	assert.NoError(t, err)
+	err = createWatchlistProductsTable(db)
 	assert.NoError(t, err)
 
-	//Test 2: Check if neccessary order products tables exists
-	var orderProductsExist bool
-	err = db.QueryRowContext(context.Background(), queryCheckTableOrderProducts).Scan(&orderProductsExist)
+	//Test 2: Check if neccessary notification and watchlist tables exists
+	var notificationsExist, preferencesExist, watchlistExists bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableNotifications).Scan(&notificationsExist)
 	assert.NoError(t, err)
-	assert.Equal(t, true, orderProductsExist)
+	assert.Equal(t, true, notificationsExist)
+	err = db.QueryRowContext(context.Background(), queryCheckTableNotificationPreferences).Scan(&preferencesExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, preferencesExist)
+	err = db.QueryRowContext(context.Background(), queryCheckTableWatchlistProducts).Scan(&watchlistExists)
+	assert.NoError(t, err)
+	assert.Equal(t, true, watchlistExists)
 
 	CloseDB(db)
 }
 
-func TestCreateGuestOrderProductsTable(t *testing.T) {
+func TestCreateSavedSearchTables(t *testing.T) {
 	err := utils.LoadDotEnv("../.env")
 	assert.NoError(t, err)
 	db, err := initTestDB()
@@ -364,31 +1436,173 @@ func TestCreateGuestOrderProductsTable(t *testing.T) {
 
 	dropDB(db)
 
-	//Test 1: No Error in creating guest order products table
+	//Test 1: No Error in creating saved search tables
+	createBuyersTable(db)
 	createSellersTable(db)
 	createProductsTable(db)
-	createOrdersTable(db)
-	err = createGuestOrderProductsTable(db)
+	err = createSavedSearchesTable(db)
+	assert.NoError(t, err)
+	err = createSavedSearchMatchesTable(db)
+	assert.NoError(t, err)
+	err = createNewListingsTable(db)
 	assert.NoError(t, err)
 
-	//Test 2: Check if neccessary guest order products tables exists
-	var guestOrderProductsExist bool
-	err = db.QueryRowContext(context.Background(), queryCheckTableGuestOrderProducts).Scan(&guestOrderProductsExist)
+	//Test 2: Check if neccessary saved search tables exists
+	var searchesExist, matchesExist, listingsExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableSavedSearches).Scan(&searchesExist)
 	assert.NoError(t, err)
-	assert.Equal(t, true, guestOrderProductsExist)
+	assert.Equal(t, true, searchesExist)
+	err = db.QueryRowContext(context.Background(), queryCheckTableSavedSearchMatches).Scan(&matchesExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, matchesExist)
+	err = db.QueryRowContext(context.Background(), queryCheckTableNewListings).Scan(&listingsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, listingsExist)
+
+	CloseDB(db)
+}
+
+func TestAddBuyerLocaleColumn(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	dropDB(db)
+
+	//Test 1: No Error in adding the buyer locale column
+	createBuyersTable(db)
+	err = addBuyerLocaleColumn(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if the buyer locale column exists
+	var localeExists bool
+	err = db.QueryRowContext(context.Background(), queryCheckColumnBuyersLocale).Scan(&localeExists)
+	assert.NoError(t, err)
+	assert.Equal(t, true, localeExists)
+
+	CloseDB(db)
+}
+
+func TestCreateOutboxMessagesTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	//Test 1: No Error in creating the outbox messages table
+	err = createOutboxMessagesTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if the outbox messages table exists
+	var outboxExists bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableOutboxMessages).Scan(&outboxExists)
+	assert.NoError(t, err)
+	assert.Equal(t, true, outboxExists)
+
+	CloseDB(db)
+}
+
+func TestCreateJobsTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	//Test 1: No Error in creating the jobs table
+	err = createJobsTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if the jobs table exists
+	var jobsExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableJobs).Scan(&jobsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, jobsExist)
+
+	CloseDB(db)
+}
+
+func TestCreateTelegramTables(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	//Test 1: No Error in creating telegram tables
+	err = createTelegramChatsTable(db)
+	assert.NoError(t, err)
+	err = createTelegramLinkCodesTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary telegram tables exists
+	var chatsExist, codesExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableTelegramChats).Scan(&chatsExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, chatsExist)
+	err = db.QueryRowContext(context.Background(), queryCheckTableTelegramLinkCodes).Scan(&codesExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, codesExist)
 
 	CloseDB(db)
 }
 
-/*
-Function to reset all the tables in the DB, used mainly during testing
-*/
 func dropDB(db *sql.DB) {
 	queryDropBuyers := `DROP TABLE buyers CASCADE;`
 	queryDropSellers := `DROP TABLE sellers CASCADE;`
 	queryDropProducts := `DROP TABLE products CASCADE;`
+	queryDropExpansionLanguages := `DROP TABLE expansion_languages CASCADE;`
+	queryDropExpansions := `DROP TABLE expansions CASCADE;`
+	queryDropGames := `DROP TABLE games CASCADE;`
+	queryDropLanguages := `DROP TABLE languages CASCADE;`
 
 	db.Exec(queryDropBuyers)
 	db.Exec(queryDropSellers)
 	db.Exec(queryDropProducts)
+	db.Exec(queryDropExpansionLanguages)
+	db.Exec(queryDropExpansions)
+	db.Exec(queryDropGames)
+	db.Exec(queryDropLanguages)
+}
+
+func TestCreateWebhookTables(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	//Test 1: No Error in creating webhook tables
+	err = createWebhooksTable(db)
+	assert.NoError(t, err)
+	err = createWebhookDeliveriesTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary webhook tables exists
+	var webhooksExist, deliveriesExist bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableWebhooks).Scan(&webhooksExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, webhooksExist)
+	err = db.QueryRowContext(context.Background(), queryCheckTableWebhookDeliveries).Scan(&deliveriesExist)
+	assert.NoError(t, err)
+	assert.Equal(t, true, deliveriesExist)
+
+	CloseDB(db)
+}
+
+func TestCreateSellerApiKeysTable(t *testing.T) {
+	err := utils.LoadDotEnv("../.env")
+	assert.NoError(t, err)
+	db, err := initTestDB()
+	assert.NoError(t, err)
+
+	//Test 1: No Error in creating seller api keys table
+	err = createSellerApiKeysTable(db)
+	assert.NoError(t, err)
+
+	//Test 2: Check if neccessary seller api keys table exists
+	var exists bool
+	err = db.QueryRowContext(context.Background(), queryCheckTableSellerApiKeys).Scan(&exists)
+	assert.NoError(t, err)
+	assert.Equal(t, true, exists)
+
+	CloseDB(db)
 }
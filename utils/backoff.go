@@ -0,0 +1,21 @@
+package utils
+
+import "time"
+
+/*
+Gets how long to wait before trying again after the given number of failures. The wait starts at base and doubles
+after every failure up to max.
+*/
+func GetBackoff(attempts int, base time.Duration, max time.Duration) time.Duration {
+	backoff := base
+
+	for i := 1; i < attempts && backoff < max; i++ {
+		backoff *= 2
+	}
+
+	if backoff > max {
+		return max
+	}
+
+	return backoff
+}
@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBackoff(t *testing.T) {
+	//Test 1: Backoff doubles after every failure
+	assert.Equal(t, 30*time.Second, GetBackoff(1, 30*time.Second, time.Hour))
+	assert.Equal(t, time.Minute, GetBackoff(2, 30*time.Second, time.Hour))
+	assert.Equal(t, 8*time.Minute, GetBackoff(5, 30*time.Second, time.Hour))
+
+	//Test 2: Backoff does not go over the maximum
+	assert.Equal(t, time.Hour, GetBackoff(20, 30*time.Second, time.Hour))
+	assert.Equal(t, time.Hour, GetBackoff(1, 2*time.Hour, time.Hour))
+}
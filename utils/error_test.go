@@ -54,3 +54,27 @@ func TestNotFoundError(t *testing.T) {
 	assert.Equal(t, "Resource not found", testNotFoundError2.Error())
 	assert.Equal(t, 404, testNotFoundError2.Code)
 }
+
+func TestForbiddenError(t *testing.T) {
+	//Test 1: Forbidden Error created with error code 403 and message
+	testForbiddenError1 := ForbiddenError("Test Error 1")
+	assert.Equal(t, "Test Error 1", testForbiddenError1.Error())
+	assert.Equal(t, 403, testForbiddenError1.Code)
+
+	//Test 2: Forbidden Error created with error code 403 and standard message
+	testForbiddenError2 := ForbiddenError("")
+	assert.Equal(t, "Forbidden", testForbiddenError2.Error())
+	assert.Equal(t, 403, testForbiddenError2.Code)
+}
+
+func TestTooManyRequestsError(t *testing.T) {
+	//Test 1: Too Many Requests Error created with error code 429 and message
+	testTooManyRequestsError1 := TooManyRequestsError("Test Error 1")
+	assert.Equal(t, "Test Error 1", testTooManyRequestsError1.Error())
+	assert.Equal(t, 429, testTooManyRequestsError1.Code)
+
+	//Test 2: Too Many Requests Error created with error code 429 and standard message
+	testTooManyRequestsError2 := TooManyRequestsError("")
+	assert.Equal(t, "Too many requests", testTooManyRequestsError2.Error())
+	assert.Equal(t, 429, testTooManyRequestsError2.Code)
+}
@@ -59,3 +59,23 @@ func NotFoundError(msg string) *ErrorHandler {
 	}
 	return &ErrorHandler{Message: msg, Code: 404}
 }
+
+/*
+Creates 403 Forbidden Error
+*/
+func ForbiddenError(msg string) *ErrorHandler {
+	if msg == "" {
+		return &ErrorHandler{Message: "Forbidden", Code: 403}
+	}
+	return &ErrorHandler{Message: msg, Code: 403}
+}
+
+/*
+Creates 429 Too Many Requests Error
+*/
+func TooManyRequestsError(msg string) *ErrorHandler {
+	if msg == "" {
+		return &ErrorHandler{Message: "Too many requests", Code: 429}
+	}
+	return &ErrorHandler{Message: msg, Code: 429}
+}